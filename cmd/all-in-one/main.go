@@ -36,7 +36,9 @@ import (
 	agentRep "github.com/jaegertracing/jaeger/cmd/agent/app/reporter"
 	agentGrpcRep "github.com/jaegertracing/jaeger/cmd/agent/app/reporter/grpc"
 	"github.com/jaegertracing/jaeger/cmd/all-in-one/setupcontext"
+	"github.com/jaegertracing/jaeger/cmd/badger"
 	collectorApp "github.com/jaegertracing/jaeger/cmd/collector/app"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/servicegraph"
 	"github.com/jaegertracing/jaeger/cmd/docs"
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
@@ -93,6 +95,7 @@ by default uses only in-memory database.`,
 			if err := storageFactory.Initialize(metricsFactory, logger); err != nil {
 				logger.Fatal("Failed to init storage factory", zap.Error(err))
 			}
+			storageFactory.RegisterAdminHandlers(svc.Admin.Handle)
 
 			spanReader, err := storageFactory.CreateSpanReader()
 			if err != nil {
@@ -123,13 +126,21 @@ by default uses only in-memory database.`,
 			qOpts := new(queryApp.QueryOptions).InitFromViper(v, logger)
 
 			// collector
+			var dependencyWriter dependencystore.Writer
+			if cOpts.ServiceGraph.Enabled {
+				dependencyWriter, err = servicegraph.CreateDependencyWriter(storageFactory, logger)
+				if err != nil {
+					logger.Fatal("Failed to create dependency writer", zap.Error(err))
+				}
+			}
 			c := collectorApp.New(&collectorApp.CollectorParams{
-				ServiceName:    "jaeger-collector",
-				Logger:         logger,
-				MetricsFactory: metricsFactory,
-				SpanWriter:     spanWriter,
-				StrategyStore:  strategyStore,
-				HealthCheck:    svc.HC(),
+				ServiceName:      "jaeger-collector",
+				Logger:           logger,
+				MetricsFactory:   metricsFactory,
+				SpanWriter:       spanWriter,
+				DependencyWriter: dependencyWriter,
+				StrategyStore:    strategyStore,
+				HealthCheck:      svc.HC(),
 			})
 			c.Start(cOpts)
 
@@ -181,6 +192,7 @@ by default uses only in-memory database.`,
 
 	command.AddCommand(version.Command())
 	command.AddCommand(env.Command())
+	command.AddCommand(badger.Command())
 	command.AddCommand(docs.Command(v))
 
 	config.AddFlags(
@@ -0,0 +1,283 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/plugin/storage/badger"
+)
+
+const (
+	adminURLFlag     = "admin-url"
+	outputFlag       = "output"
+	inputFlag        = "input"
+	sinceFlag        = "since"
+	discardRatioFlag = "discard-ratio"
+
+	defaultDiscardRatio = 0.5
+)
+
+var errRestoreNotEmpty = errors.New("restore requires empty key and value directories")
+
+// Command creates the `badger` command with maintenance subcommands for the Badger storage.
+// Each subcommand works either against a running binary through its admin server
+// when --admin-url is set, or offline against the key and value directories.
+func Command() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "badger",
+		Short: "Maintenance of the Badger storage",
+		Long: `Takes backups, restores them, runs value log garbage collection and prints statistics of the Badger storage.
+Set --admin-url to run against a running binary, otherwise the key and value directories are opened directly
+and must not be in use by another process.`,
+	}
+	c.AddCommand(backupCommand())
+	c.AddCommand(restoreCommand())
+	c.AddCommand(gcCommand())
+	c.AddCommand(statsCommand())
+	return c
+}
+
+func backupCommand() *cobra.Command {
+	v := viper.New()
+	c := &cobra.Command{
+		Use:   "backup",
+		Short: "Takes a backup of the Badger storage",
+		Long:  `Writes all the entries newer than --since to --output and prints the version to pass as --since to the next incremental backup.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := os.Create(v.GetString(outputFlag))
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+			var version uint64
+			since := uint64(v.GetInt64(sinceFlag))
+			if adminURL := v.GetString(adminURLFlag); adminURL != "" {
+				version, err = backupOnline(adminURL, out, since)
+			} else {
+				err = withFactory(v, func(f *badger.Factory) error {
+					version, err = f.Backup(out, since)
+					return err
+				})
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), version)
+			return nil
+		},
+	}
+	config.AddFlags(v, c, addAdminURLFlag, addBackupFlags, badger.NewOptions("badger").AddFlags)
+	return c
+}
+
+func restoreCommand() *cobra.Command {
+	v := viper.New()
+	c := &cobra.Command{
+		Use:   "restore",
+		Short: "Restores a backup into empty Badger directories",
+		Long:  `Loads a backup taken with the backup command into the empty key and value directories. Restore always runs offline.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := os.Open(v.GetString(inputFlag))
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+			opts := badger.NewOptions("badger")
+			opts.InitFromViper(v)
+			for _, dir := range []string{opts.Primary.KeyDirectory, opts.Primary.ValueDirectory} {
+				if empty, err := isEmptyDir(dir); err != nil {
+					return err
+				} else if !empty {
+					return fmt.Errorf("%w: %s", errRestoreNotEmpty, dir)
+				}
+			}
+			return withFactory(v, func(f *badger.Factory) error {
+				return f.Restore(in)
+			})
+		},
+	}
+	config.AddFlags(v, c, addRestoreFlags, badger.NewOptions("badger").AddFlags)
+	return c
+}
+
+func gcCommand() *cobra.Command {
+	v := viper.New()
+	c := &cobra.Command{
+		Use:   "gc",
+		Short: "Runs the value log garbage collection",
+		Long:  `Rewrites the value log files that have at least --discard-ratio of discardable data and prints the number of files rewritten.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			discardRatio := v.GetFloat64(discardRatioFlag)
+			var result badger.GCResult
+			var err error
+			if adminURL := v.GetString(adminURLFlag); adminURL != "" {
+				query := url.Values{}
+				query.Set(discardRatioFlag, strconv.FormatFloat(discardRatio, 'f', -1, 64))
+				err = callAdmin(http.MethodPost, adminURL+badger.AdminGCPath+"?"+query.Encode(), &result)
+			} else {
+				err = withFactory(v, func(f *badger.Factory) error {
+					result.Rewrites, err = f.RunValueLogGC(discardRatio)
+					return err
+				})
+			}
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), result)
+		},
+	}
+	config.AddFlags(v, c, addAdminURLFlag, addGCFlags, badger.NewOptions("badger").AddFlags)
+	return c
+}
+
+func statsCommand() *cobra.Command {
+	v := viper.New()
+	c := &cobra.Command{
+		Use:   "stats",
+		Short: "Prints the Badger storage statistics",
+		Long:  `Prints the sizes of the LSM tree and value log and the disk space available to the key and value directories.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var stats badger.Stats
+			var err error
+			if adminURL := v.GetString(adminURLFlag); adminURL != "" {
+				err = callAdmin(http.MethodGet, adminURL+badger.AdminStatsPath, &stats)
+			} else {
+				err = withFactory(v, func(f *badger.Factory) error {
+					stats = f.Stats()
+					return nil
+				})
+			}
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), stats)
+		},
+	}
+	config.AddFlags(v, c, addAdminURLFlag, badger.NewOptions("badger").AddFlags)
+	return c
+}
+
+func addAdminURLFlag(flagSet *flag.FlagSet) {
+	flagSet.String(
+		adminURLFlag,
+		"",
+		"The URL of the admin server of a running binary using Badger storage, e.g. http://localhost:14269. If empty, the Badger directories are opened directly.")
+}
+
+func addBackupFlags(flagSet *flag.FlagSet) {
+	flagSet.String(outputFlag, "badger.bak", "The file to write the backup to.")
+	flagSet.Int64(sinceFlag, 0, "Only back up the entries newer than this version, as printed by a previous backup.")
+}
+
+func addRestoreFlags(flagSet *flag.FlagSet) {
+	flagSet.String(inputFlag, "badger.bak", "The backup file to restore.")
+}
+
+func addGCFlags(flagSet *flag.FlagSet) {
+	flagSet.Float64(discardRatioFlag, defaultDiscardRatio, "Rewrite a value log file if at least this ratio of it can be discarded.")
+}
+
+// withFactory opens the Badger directories configured in v, runs fn and closes them.
+func withFactory(v *viper.Viper, fn func(f *badger.Factory) error) error {
+	f := badger.NewFactory()
+	f.InitFromViper(v)
+	// Maintenance only makes sense against persisted data
+	f.Options.Primary.Ephemeral = false
+	if err := f.Initialize(metrics.NullFactory, zap.NewNop()); err != nil {
+		return err
+	}
+	err := fn(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func backupOnline(adminURL string, w io.Writer, since uint64) (uint64, error) {
+	resp, err := http.Get(adminURL + badger.AdminBackupPath + "?" + sinceFlag + "=" + strconv.FormatUint(since, 10))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, errors.New("online backups are disabled, start the binary with --badger.admin-backup=true")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, responseError(resp)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return 0, err
+	}
+	// The trailer is only available once the body has been read
+	version := resp.Trailer.Get(badger.BackupVersionHeader)
+	if version == "" {
+		return 0, errors.New("backup was interrupted, see the admin server logs")
+	}
+	return strconv.ParseUint(version, 10, 64)
+}
+
+func callAdmin(method, url string, result interface{}) error {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("admin server returned %s: %s", resp.Status, body)
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func isEmptyDir(dir string) (bool, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
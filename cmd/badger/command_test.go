@@ -0,0 +1,159 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/plugin/storage/badger"
+)
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	cmd := Command()
+	buf := new(bytes.Buffer)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func dirFlags(dir string) []string {
+	return []string{
+		"--badger.directory-key=" + filepath.Join(dir, "keys"),
+		"--badger.directory-value=" + filepath.Join(dir, "values"),
+	}
+}
+
+func writeSpan(t *testing.T, dir string) {
+	f := badger.NewFactory()
+	f.Options.Primary.Ephemeral = false
+	f.Options.Primary.KeyDirectory = filepath.Join(dir, "keys")
+	f.Options.Primary.ValueDirectory = filepath.Join(dir, "values")
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	w, err := f.CreateSpanWriter()
+	require.NoError(t, err)
+	require.NoError(t, w.WriteSpan(context.Background(), &model.Span{
+		TraceID:       model.NewTraceID(0, 1),
+		SpanID:        model.NewSpanID(1),
+		OperationName: "op",
+		Process:       model.NewProcess("svc", nil),
+		StartTime:     time.Now(),
+	}))
+	require.NoError(t, f.Close())
+}
+
+func TestOfflineBackupRestore(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "badger-cmd")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+	backup := filepath.Join(tmp, "badger.bak")
+	writeSpan(t, src)
+
+	out, err := runCommand(t, append([]string{"backup", "--output=" + backup}, dirFlags(src)...)...)
+	require.NoError(t, err)
+	assert.NotEqual(t, "0", strings.TrimSpace(out))
+
+	_, err = runCommand(t, append([]string{"restore", "--input=" + backup}, dirFlags(dst)...)...)
+	require.NoError(t, err)
+
+	// Restoring into a non-empty directory is refused
+	_, err = runCommand(t, append([]string{"restore", "--input=" + backup}, dirFlags(dst)...)...)
+	assert.True(t, errors.Is(err, errRestoreNotEmpty))
+
+	out, err = runCommand(t, append([]string{"stats"}, dirFlags(dst)...)...)
+	require.NoError(t, err)
+	var stats badger.Stats
+	require.NoError(t, json.Unmarshal([]byte(out), &stats))
+	assert.Equal(t, filepath.Join(dst, "keys"), stats.KeyDirectory)
+
+	out, err = runCommand(t, append([]string{"gc", "--discard-ratio=0.7"}, dirFlags(dst)...)...)
+	require.NoError(t, err)
+	var result badger.GCResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+}
+
+func TestOnline(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "badger-cmd")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	f := badger.NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--badger.admin-backup=true"})
+	f.InitFromViper(v)
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	defer f.Close()
+	mux := http.NewServeMux()
+	f.RegisterAdminHandlers(mux.Handle)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	adminURL := "--admin-url=" + server.URL
+
+	out, err := runCommand(t, "backup", adminURL, "--output="+filepath.Join(tmp, "badger.bak"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, strings.TrimSpace(out))
+
+	out, err = runCommand(t, "stats", adminURL)
+	require.NoError(t, err)
+	assert.Contains(t, out, "lsmSizeBytes")
+
+	out, err = runCommand(t, "gc", adminURL)
+	require.NoError(t, err)
+	assert.Contains(t, out, "rewrites")
+
+	_, err = runCommand(t, "gc", adminURL, "--discard-ratio=2")
+	assert.Error(t, err)
+
+	_, err = runCommand(t, "stats", "--admin-url="+server.URL+"/missing")
+	assert.Error(t, err)
+}
+
+func TestOnlineBackupDisabled(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "badger-cmd")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	f := badger.NewFactory()
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	defer f.Close()
+	mux := http.NewServeMux()
+	f.RegisterAdminHandlers(mux.Handle)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	_, err = runCommand(t, "backup", "--admin-url="+server.URL, "--output="+filepath.Join(tmp, "badger.bak"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--badger.admin-backup")
+}
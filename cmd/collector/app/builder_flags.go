@@ -17,9 +17,14 @@ package app
 
 import (
 	"flag"
+	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/jaegertracing/jaeger/cmd/collector/app/retry"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/servicegraph"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/spanmetrics"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
 	"github.com/jaegertracing/jaeger/cmd/flags"
 	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
 	"github.com/jaegertracing/jaeger/ports"
@@ -41,6 +46,18 @@ const (
 	collectorTags                 = "collector.tags"
 	collectorZipkinAllowedOrigins = "collector.zipkin.allowed-origins"
 	collectorZipkinAllowedHeaders = "collector.zipkin.allowed-headers"
+	collectorSanitizerRulesFile   = "collector.sanitizer.rules-file"
+	collectorSanitizerReload      = "collector.sanitizer.rules-reload-interval"
+	collectorRateLimitsFile       = "collector.rate-limits.file"
+	collectorRateLimitsReload     = "collector.rate-limits.reload-interval"
+	collectorPersistentQueueDir   = "collector.persistent-queue.directory"
+	collectorPersistentQueueMax   = "collector.persistent-queue.max-size"
+	collectorPersistentQueueSeg   = "collector.persistent-queue.segment-size"
+	collectorPersistentQueueSync  = "collector.persistent-queue.sync-interval"
+	// CollectorOTLPGRPCHostPort is the flag for the OTLP gRPC port
+	CollectorOTLPGRPCHostPort = "collector.otlp.grpc.host-port"
+	// CollectorOTLPHTTPHostPort is the flag for the OTLP HTTP port
+	CollectorOTLPHTTPHostPort = "collector.otlp.http.host-port"
 
 	collectorHTTPPortWarning       = "(deprecated, will be removed after 2020-06-30 or in release v1.20.0, whichever is later)"
 	collectorGRPCPortWarning       = "(deprecated, will be removed after 2020-06-30 or in release v1.20.0, whichever is later)"
@@ -75,6 +92,34 @@ type CollectorOptions struct {
 	CollectorZipkinAllowedOrigins string
 	// CollectorZipkinAllowedHeaders is a list of headers that the Zipkin collector service allowes the client to use with cross-domain requests
 	CollectorZipkinAllowedHeaders string
+	// CollectorOTLPGRPCHostPort is the host:port address that the collector service listens in on for OTLP gRPC requests
+	CollectorOTLPGRPCHostPort string
+	// CollectorOTLPHTTPHostPort is the host:port address that the collector service listens in on for OTLP HTTP requests
+	CollectorOTLPHTTPHostPort string
+	// SanitizerRulesFile is the path of the YAML file with the rules redacting the spans before they are saved
+	SanitizerRulesFile string
+	// SanitizerRulesReloadInterval is the interval at which the sanitizer rules file is reloaded, zero disables reloading
+	SanitizerRulesReloadInterval time.Duration
+	// PersistentQueueDirectory is the directory of the queue persisting the spans on disk, the queue is in memory if empty
+	PersistentQueueDirectory string
+	// PersistentQueueMaxSize is the maximum size in bytes of the persistent queue on disk
+	PersistentQueueMaxSize uint
+	// PersistentQueueSegmentSize is the size in bytes of the segment files of the persistent queue
+	PersistentQueueSegmentSize uint
+	// PersistentQueueSyncInterval is the interval at which the persistent queue is synced to disk, zero syncs only the full segments
+	PersistentQueueSyncInterval time.Duration
+	// RateLimitsFile is the path of the YAML file with the rate limits of the spans per service and per tenant
+	RateLimitsFile string
+	// RateLimitsReloadInterval is the interval at which the rate limits file is reloaded, zero disables reloading
+	RateLimitsReloadInterval time.Duration
+	// TailSampling is the configuration of the tail-based sampling of the spans written to the storage
+	TailSampling tailsampling.Options
+	// StorageRetry is the configuration of the retries and the circuit breaker of the failed storage writes
+	StorageRetry retry.Options
+	// SpanMetrics is the configuration of the request, error and latency metrics generated from the spans
+	SpanMetrics spanmetrics.Options
+	// ServiceGraph is the configuration of the service graph edges paired from the client and server spans
+	ServiceGraph servicegraph.Options
 }
 
 // AddFlags adds flags for CollectorOptions
@@ -88,6 +133,26 @@ func AddFlags(flags *flag.FlagSet) {
 	flags.String(collectorTags, "", "One or more tags to be added to the Process tags of all spans passing through this collector. Ex: key1=value1,key2=${envVar:defaultValue}")
 	flags.String(collectorZipkinAllowedOrigins, "*", "Comma separated list of allowed origins for the Zipkin collector service, default accepts all")
 	flags.String(collectorZipkinAllowedHeaders, "content-type", "Comma separated list of allowed headers for the Zipkin collector service, default content-type")
+	flags.String(CollectorOTLPGRPCHostPort, "", "The host:port (e.g. 127.0.0.1:55680 or :55680) of the collector's OTLP gRPC server (disabled by default), secured by the --collector.grpc.tls.* settings")
+	flags.String(CollectorOTLPHTTPHostPort, "", "The host:port (e.g. 127.0.0.1:55681 or :55681) of the collector's OTLP HTTP server accepting protobuf and JSON on "+
+		"/v1/traces (disabled by default)")
+	flags.String(collectorPersistentQueueDir, "", "The directory of a queue persisting the spans on disk until they are saved, "+
+		"so that they survive restarts and storage outages (by default the queue is in memory). The spans saved since the last sync "+
+		"may be saved again after a crash. It cannot be combined with tail-based sampling")
+	flags.Uint(collectorPersistentQueueMax, 1024, "The max size in MiB of the persistent queue on disk")
+	flags.Uint(collectorPersistentQueueSeg, 64, "The size in MiB of the segment files of the persistent queue, it must be lower than the max size")
+	flags.Duration(collectorPersistentQueueSync, time.Second, "The interval at which the spans of the persistent queue are synced to disk and the saved spans are checkpointed, "+
+		"the segment files are always synced when they are full. Zero value means no periodic sync")
+	flags.String(collectorSanitizerRulesFile, "", "The path of a YAML file with rules deleting, hashing, replacing or truncating "+
+		"the span tags, logs and operation names before the spans are saved")
+	flags.Duration(collectorSanitizerReload, 0, "Reload interval of the sanitizer rules file. Zero value means no reloading")
+	flags.String(collectorRateLimitsFile, "", "The path of a YAML file with the maximum rates of spans accepted per service and per tenant, "+
+		"the spans above the limits are dropped and the batches with no span within the limits are rejected (disabled by default)")
+	flags.Duration(collectorRateLimitsReload, 0, "Reload interval of the rate limits file. Zero value means no reloading")
+	tailsampling.AddFlags(flags)
+	retry.AddFlags(flags)
+	spanmetrics.AddFlags(flags)
+	servicegraph.AddFlags(flags)
 	AddOTELJaegerFlags(flags)
 	AddOTELZipkinFlags(flags)
 }
@@ -115,6 +180,20 @@ func (cOpts *CollectorOptions) InitFromViper(v *viper.Viper) *CollectorOptions {
 	cOpts.CollectorTags = flags.ParseJaegerTags(v.GetString(collectorTags))
 	cOpts.CollectorZipkinAllowedOrigins = v.GetString(collectorZipkinAllowedOrigins)
 	cOpts.CollectorZipkinAllowedHeaders = v.GetString(collectorZipkinAllowedHeaders)
+	cOpts.CollectorOTLPGRPCHostPort = ports.GetAddressFromCLIOptions(0, v.GetString(CollectorOTLPGRPCHostPort))
+	cOpts.CollectorOTLPHTTPHostPort = ports.GetAddressFromCLIOptions(0, v.GetString(CollectorOTLPHTTPHostPort))
+	cOpts.PersistentQueueDirectory = v.GetString(collectorPersistentQueueDir)
+	cOpts.PersistentQueueMaxSize = v.GetUint(collectorPersistentQueueMax) * 1024 * 1024 // we receive in MiB and store in bytes
+	cOpts.PersistentQueueSegmentSize = v.GetUint(collectorPersistentQueueSeg) * 1024 * 1024
+	cOpts.PersistentQueueSyncInterval = v.GetDuration(collectorPersistentQueueSync)
+	cOpts.SanitizerRulesFile = v.GetString(collectorSanitizerRulesFile)
+	cOpts.SanitizerRulesReloadInterval = v.GetDuration(collectorSanitizerReload)
+	cOpts.RateLimitsFile = v.GetString(collectorRateLimitsFile)
+	cOpts.RateLimitsReloadInterval = v.GetDuration(collectorRateLimitsReload)
+	cOpts.TailSampling.InitFromViper(v)
+	cOpts.StorageRetry.InitFromViper(v)
+	cOpts.SpanMetrics.InitFromViper(v)
+	cOpts.ServiceGraph.InitFromViper(v)
 	cOpts.TLS = tlsFlagsConfig.InitFromViper(v)
 
 	return cOpts
@@ -16,9 +16,12 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/jaegertracing/jaeger/cmd/collector/app/servicegraph"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/spanmetrics"
 	"github.com/jaegertracing/jaeger/pkg/config"
 )
 
@@ -51,3 +54,106 @@ func TestCollectorOptionsWithFlags_CheckFullHostPort(t *testing.T) {
 	assert.Equal(t, "127.0.0.1:1234", c.CollectorGRPCHostPort)
 	assert.Equal(t, "0.0.0.0:3456", c.CollectorZipkinHTTPHostPort)
 }
+
+func TestCollectorOptionsWithFlags_CheckOTLPHostPort(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{})
+	c.InitFromViper(v)
+
+	assert.Empty(t, c.CollectorOTLPGRPCHostPort)
+	assert.Empty(t, c.CollectorOTLPHTTPHostPort)
+
+	command.ParseFlags([]string{
+		"--collector.otlp.grpc.host-port=55680",
+		"--collector.otlp.http.host-port=127.0.0.1:55681",
+	})
+	c.InitFromViper(v)
+
+	assert.Equal(t, ":55680", c.CollectorOTLPGRPCHostPort)
+	assert.Equal(t, "127.0.0.1:55681", c.CollectorOTLPHTTPHostPort)
+}
+
+func TestCollectorOptionsWithFlags_CheckSanitizerRules(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.sanitizer.rules-file=rules.yaml",
+		"--collector.sanitizer.rules-reload-interval=1m",
+	})
+	c.InitFromViper(v)
+
+	assert.Equal(t, "rules.yaml", c.SanitizerRulesFile)
+	assert.Equal(t, time.Minute, c.SanitizerRulesReloadInterval)
+}
+
+func TestCollectorOptionsWithFlags_CheckRateLimits(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.rate-limits.file=limits.yaml",
+		"--collector.rate-limits.reload-interval=30s",
+	})
+	c.InitFromViper(v)
+
+	assert.Equal(t, "limits.yaml", c.RateLimitsFile)
+	assert.Equal(t, 30*time.Second, c.RateLimitsReloadInterval)
+}
+
+func TestCollectorOptionsWithFlags_CheckStorageRetry(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.storage-retry.max-retries=3",
+		"--collector.storage-retry.circuit-breaker-threshold=50",
+	})
+	c.InitFromViper(v)
+
+	assert.Equal(t, uint(3), c.StorageRetry.MaxRetries)
+	assert.Equal(t, 100*time.Millisecond, c.StorageRetry.MinBackoff)
+	assert.Equal(t, uint(50), c.StorageRetry.BreakerThreshold)
+}
+
+func TestCollectorOptionsWithFlags_CheckSpanMetrics(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.span-metrics.enabled=true",
+		"--collector.span-metrics.max-services=100",
+	})
+	c.InitFromViper(v)
+
+	assert.True(t, c.SpanMetrics.Enabled)
+	assert.Equal(t, 100, c.SpanMetrics.MaxServices)
+	assert.Equal(t, spanmetrics.DefaultMaxOperations, c.SpanMetrics.MaxOperations)
+}
+
+func TestCollectorOptionsWithFlags_CheckServiceGraph(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.service-graph.enabled=true",
+		"--collector.service-graph.max-edges=100",
+	})
+	c.InitFromViper(v)
+
+	assert.True(t, c.ServiceGraph.Enabled)
+	assert.Equal(t, 100, c.ServiceGraph.MaxEdges)
+	assert.Equal(t, servicegraph.DefaultWait, c.ServiceGraph.Wait)
+}
+
+func TestCollectorOptionsWithFlags_CheckPersistentQueue(t *testing.T) {
+	c := &CollectorOptions{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.persistent-queue.directory=/var/lib/jaeger/queue",
+		"--collector.persistent-queue.segment-size=16",
+		"--collector.persistent-queue.sync-interval=5s",
+	})
+	c.InitFromViper(v)
+
+	assert.Equal(t, "/var/lib/jaeger/queue", c.PersistentQueueDirectory)
+	assert.Equal(t, uint(1024*1024*1024), c.PersistentQueueMaxSize)
+	assert.Equal(t, uint(16*1024*1024), c.PersistentQueueSegmentSize)
+	assert.Equal(t, 5*time.Second, c.PersistentQueueSyncInterval)
+}
@@ -25,29 +25,44 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/ratelimit"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/retry"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/server"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/servicegraph"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/spanmetrics"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 // Collector returns the collector as a manageable unit of work
 type Collector struct {
 	// required to start a new collector
-	serviceName    string
-	logger         *zap.Logger
-	metricsFactory metrics.Factory
-	spanWriter     spanstore.Writer
-	strategyStore  strategystore.StrategyStore
-	hCheck         *healthcheck.HealthCheck
-	spanProcessor  processor.SpanProcessor
-	spanHandlers   *SpanHandlers
+	serviceName      string
+	logger           *zap.Logger
+	metricsFactory   metrics.Factory
+	spanWriter       spanstore.Writer
+	dependencyWriter dependencystore.Writer
+	strategyStore    strategystore.StrategyStore
+	hCheck           *healthcheck.HealthCheck
+	spanProcessor    processor.SpanProcessor
+	spanHandlers     *SpanHandlers
 
 	// state, read only
 	hServer    *http.Server
 	zkServer   *http.Server
 	grpcServer *grpc.Server
+	otlpGRPC   *grpc.Server
+	otlpHTTP   *http.Server
 	tlsCloser  io.Closer
+
+	rulesSanitizer *sanitizer.RulesSanitizer
+	rateLimiter    *ratelimit.Limiter
+	tailSampler    *tailsampling.Sampler
+	serviceGraph   *servicegraph.Graph
 }
 
 // CollectorParams to construct a new Jaeger Collector.
@@ -56,30 +71,99 @@ type CollectorParams struct {
 	Logger         *zap.Logger
 	MetricsFactory metrics.Factory
 	SpanWriter     spanstore.Writer
-	StrategyStore  strategystore.StrategyStore
-	HealthCheck    *healthcheck.HealthCheck
+	// DependencyWriter stores the dependency links of the service graph, it is nil if the storage cannot store them
+	DependencyWriter dependencystore.Writer
+	StrategyStore    strategystore.StrategyStore
+	HealthCheck      *healthcheck.HealthCheck
 }
 
 // New constructs a new collector component, ready to be started
 func New(params *CollectorParams) *Collector {
 	return &Collector{
-		serviceName:    params.ServiceName,
-		logger:         params.Logger,
-		metricsFactory: params.MetricsFactory,
-		spanWriter:     params.SpanWriter,
-		strategyStore:  params.StrategyStore,
-		hCheck:         params.HealthCheck,
+		serviceName:      params.ServiceName,
+		logger:           params.Logger,
+		metricsFactory:   params.MetricsFactory,
+		spanWriter:       params.SpanWriter,
+		dependencyWriter: params.DependencyWriter,
+		strategyStore:    params.StrategyStore,
+		hCheck:           params.HealthCheck,
 	}
 }
 
 // Start the component and underlying dependencies
 func (c *Collector) Start(builderOpts *CollectorOptions) error {
+	if builderOpts.TailSampling.DecisionWait > 0 && builderOpts.PersistentQueueDirectory != "" {
+		// the tail sampler acknowledges the spans once buffered, the persistent queue would then
+		// delete the spans waiting for a decision, which are lost on restart
+		c.logger.Fatal("tail-based sampling cannot be combined with the persistent queue")
+	}
+	spanWriter := c.spanWriter
+	if builderOpts.StorageRetry.Enabled() {
+		retryWriter, err := retry.NewWriter(spanWriter, builderOpts.StorageRetry, c.hCheck, c.metricsFactory, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not create the storage retries", zap.Error(err))
+		}
+		spanWriter = retryWriter
+	}
+	if builderOpts.TailSampling.DecisionWait > 0 {
+		tailSampler, err := tailsampling.NewSampler(spanWriter, builderOpts.TailSampling, c.metricsFactory, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not create the tail sampler", zap.Error(err))
+		}
+		c.tailSampler = tailSampler
+		spanWriter = tailSampler
+	}
+
 	handlerBuilder := &SpanHandlerBuilder{
-		SpanWriter:     c.spanWriter,
+		SpanWriter:     spanWriter,
 		CollectorOpts:  *builderOpts,
 		Logger:         c.logger,
 		MetricsFactory: c.metricsFactory,
 	}
+	if builderOpts.SanitizerRulesFile != "" {
+		rulesSanitizer, err := sanitizer.NewRulesSanitizer(builderOpts.SanitizerRulesFile, builderOpts.SanitizerRulesReloadInterval, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not create the sanitizer", zap.Error(err))
+		}
+		c.rulesSanitizer = rulesSanitizer
+		handlerBuilder.Sanitizer = rulesSanitizer.Sanitize
+	}
+	if builderOpts.RateLimitsFile != "" {
+		rateLimiter, err := ratelimit.NewLimiter(builderOpts.RateLimitsFile, builderOpts.RateLimitsReloadInterval, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not create the rate limiter", zap.Error(err))
+		}
+		c.rateLimiter = rateLimiter
+		handlerBuilder.RateLimiter = rateLimiter.Allow
+	}
+
+	var preSave []ProcessSpan
+	if builderOpts.SpanMetrics.Enabled {
+		spanMetrics, err := spanmetrics.NewGenerator(builderOpts.SpanMetrics, c.metricsFactory, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not create the span metrics", zap.Error(err))
+		}
+		preSave = append(preSave, spanMetrics.ProcessSpan)
+	}
+	if builderOpts.ServiceGraph.Enabled {
+		serviceGraph, err := servicegraph.NewGraph(builderOpts.ServiceGraph, c.dependencyWriter, c.metricsFactory, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not create the service graph", zap.Error(err))
+		}
+		c.serviceGraph = serviceGraph
+		preSave = append(preSave, serviceGraph.ProcessSpan)
+	}
+	if len(preSave) > 0 {
+		handlerBuilder.PreSave = ChainedProcessSpan(preSave...)
+	}
+
+	if builderOpts.PersistentQueueDirectory != "" {
+		persistentQueue, err := NewPersistentQueue(builderOpts, c.metricsFactory, c.logger)
+		if err != nil {
+			c.logger.Fatal("could not open the persistent queue", zap.Error(err))
+		}
+		handlerBuilder.Queue = persistentQueue
+	}
 
 	c.spanProcessor = handlerBuilder.BuildSpanProcessor()
 	c.spanHandlers = handlerBuilder.BuildHandlers(c.spanProcessor)
@@ -122,6 +206,25 @@ func (c *Collector) Start(builderOpts *CollectorOptions) error {
 	} else {
 		c.zkServer = zkServer
 	}
+
+	otlpParams := &server.OTLPServerParams{
+		GRPCTLSConfig: &builderOpts.TLS,
+		GRPCHostPort:  builderOpts.CollectorOTLPGRPCHostPort,
+		HTTPHostPort:  builderOpts.CollectorOTLPHTTPHostPort,
+		Handler:       c.spanHandlers.OTLPHandler,
+		HealthCheck:   c.hCheck,
+		Logger:        c.logger,
+	}
+	if otlpGRPC, err := server.StartOTLPGRPCServer(otlpParams); err != nil {
+		c.logger.Fatal("could not start the OTLP gRPC server", zap.Error(err))
+	} else {
+		c.otlpGRPC = otlpGRPC
+	}
+	if otlpHTTP, err := server.StartOTLPHTTPServer(otlpParams); err != nil {
+		c.logger.Fatal("could not start the OTLP HTTP server", zap.Error(err))
+	} else {
+		c.otlpHTTP = otlpHTTP
+	}
 	c.publishOpts(builderOpts)
 
 	return nil
@@ -160,10 +263,49 @@ func (c *Collector) Close() error {
 		defer cancel()
 	}
 
+	// OTLP servers
+	if c.otlpGRPC != nil {
+		c.otlpGRPC.GracefulStop()
+	}
+	if c.otlpHTTP != nil {
+		timeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := c.otlpHTTP.Shutdown(timeout)
+		if err != nil {
+			c.logger.Error("failed to stop the OTLP HTTP server", zap.Error(err))
+		}
+		defer cancel()
+	}
+
 	if err := c.spanProcessor.Close(); err != nil {
 		c.logger.Error("failed to close span processor.", zap.Error(err))
 	}
 
+	if c.rulesSanitizer != nil {
+		if err := c.rulesSanitizer.Close(); err != nil {
+			c.logger.Error("failed to close the sanitizer", zap.Error(err))
+		}
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Close(); err != nil {
+			c.logger.Error("failed to close the rate limiter", zap.Error(err))
+		}
+	}
+
+	// decide the traces still buffered once no more spans are processed
+	if c.tailSampler != nil {
+		if err := c.tailSampler.Close(); err != nil {
+			c.logger.Error("failed to close the tail sampler", zap.Error(err))
+		}
+	}
+
+	// write the last dependency links once no more spans are processed
+	if c.serviceGraph != nil {
+		if err := c.serviceGraph.Close(); err != nil {
+			c.logger.Error("failed to close the service graph", zap.Error(err))
+		}
+	}
+
 	if err := c.tlsCloser.Close(); err != nil {
 		c.logger.Error("failed to close TLS certificate watcher", zap.Error(err))
 	}
@@ -16,16 +16,29 @@ package app
 
 import (
 	"context"
+	"errors"
 	"io"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/uber/jaeger-lib/metrics/fork"
 	"github.com/uber/jaeger-lib/metrics/metricstest"
 	"go.uber.org/zap"
 
+	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/retry"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/servicegraph"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/spanmetrics"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/tailsampling"
+	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	"github.com/jaegertracing/jaeger/pkg/queue"
+	depsmocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
 	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 )
 
@@ -56,6 +69,223 @@ func TestNewCollector(t *testing.T) {
 	assert.NoError(t, c.Close())
 }
 
+func TestCollectorTailSampling(t *testing.T) {
+	baseMetrics := metricstest.NewFactory(time.Hour)
+	c := New(&CollectorParams{
+		ServiceName:    "collector",
+		Logger:         zap.NewNop(),
+		MetricsFactory: baseMetrics,
+		SpanWriter:     &fakeSpanWriter{},
+		StrategyStore:  &mockStrategyStore{},
+		HealthCheck:    healthcheck.New(),
+	})
+	collectorOpts := &CollectorOptions{
+		QueueSize:    10,
+		TailSampling: tailsampling.Options{DecisionWait: time.Hour, MaxTraces: 10, MaxSpans: 100, Errors: true},
+	}
+
+	c.Start(collectorOpts)
+	require.NotNil(t, c.tailSampler)
+	_, err := c.spanProcessor.ProcessSpans([]*model.Span{
+		{TraceID: model.NewTraceID(0, 1), Process: model.NewProcess("svc", nil), Tags: model.KeyValues{model.Bool("error", true)}},
+		{TraceID: model.NewTraceID(0, 2), Process: model.NewProcess("svc", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	require.NoError(t, err)
+	for i := 0; i < 100 && bufferedTraces(c.tailSampler) < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, 2, bufferedTraces(c.tailSampler))
+
+	// the spans are buffered until the traces are decided on close
+	assert.NoError(t, c.Close())
+	baseMetrics.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-kept", Tags: map[string]string{"policy": "error"}, Value: 1},
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-dropped", Value: 1},
+	)
+}
+
+func TestCollectorSanitizerRules(t *testing.T) {
+	rulesFile, err := ioutil.TempFile("", "sanitizer-rules-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(rulesFile.Name())
+	_, err = rulesFile.WriteString("rules: [{key: password, action: delete}]")
+	require.NoError(t, err)
+	require.NoError(t, rulesFile.Close())
+
+	c := New(&CollectorParams{
+		ServiceName:    "collector",
+		Logger:         zap.NewNop(),
+		MetricsFactory: metricstest.NewFactory(time.Hour),
+		SpanWriter:     &fakeSpanWriter{},
+		StrategyStore:  &mockStrategyStore{},
+		HealthCheck:    healthcheck.New(),
+	})
+	c.Start(&CollectorOptions{SanitizerRulesFile: rulesFile.Name()})
+	require.NotNil(t, c.rulesSanitizer)
+	span := c.rulesSanitizer.Sanitize(&model.Span{Tags: model.KeyValues{model.String("password", "secret")}})
+	assert.Empty(t, span.Tags)
+	assert.NoError(t, c.Close())
+}
+
+func TestCollectorRateLimits(t *testing.T) {
+	limitsFile, err := ioutil.TempFile("", "rate-limits-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(limitsFile.Name())
+	_, err = limitsFile.WriteString("services: {noisy: {spans-per-second: 0.001, burst: 1}}")
+	require.NoError(t, err)
+	require.NoError(t, limitsFile.Close())
+
+	c := New(&CollectorParams{
+		ServiceName:    "collector",
+		Logger:         zap.NewNop(),
+		MetricsFactory: metricstest.NewFactory(time.Hour),
+		SpanWriter:     &fakeSpanWriter{},
+		StrategyStore:  &mockStrategyStore{},
+		HealthCheck:    healthcheck.New(),
+	})
+	c.Start(&CollectorOptions{QueueSize: 10, RateLimitsFile: limitsFile.Name()})
+	require.NotNil(t, c.rateLimiter)
+	res, err := c.spanProcessor.ProcessSpans([]*model.Span{
+		{Process: model.NewProcess("noisy", nil)},
+		{Process: model.NewProcess("noisy", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, res)
+	res, err = c.spanProcessor.ProcessSpans([]*model.Span{
+		{Process: model.NewProcess("noisy", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	assert.Equal(t, processor.ErrRateLimited, err)
+	assert.Equal(t, []bool{false}, res)
+	assert.NoError(t, c.Close())
+}
+
+func TestCollectorPersistentQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collector-queue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	baseMetrics := metricstest.NewFactory(time.Hour)
+	c := New(&CollectorParams{
+		ServiceName:    "collector",
+		Logger:         zap.NewNop(),
+		MetricsFactory: baseMetrics,
+		SpanWriter:     &fakeSpanWriter{},
+		StrategyStore:  &mockStrategyStore{},
+		HealthCheck:    healthcheck.New(),
+	})
+	c.Start(&CollectorOptions{
+		QueueSize:                  10,
+		PersistentQueueDirectory:   dir,
+		PersistentQueueMaxSize:     1024 * 1024,
+		PersistentQueueSegmentSize: 1024,
+	})
+	_, ok := c.spanProcessor.(*spanProcessor).queue.(*queue.PersistentQueue)
+	assert.True(t, ok)
+	assert.NoError(t, c.Close())
+	baseMetrics.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "persistent-queue.segments", Value: 1})
+}
+
+func TestCollectorStorageRetry(t *testing.T) {
+	hc := healthcheck.New()
+	hc.Ready()
+	c := New(&CollectorParams{
+		ServiceName:    "collector",
+		Logger:         zap.NewNop(),
+		MetricsFactory: metricstest.NewFactory(time.Hour),
+		SpanWriter:     &fakeSpanWriter{err: errors.New("storage unavailable")},
+		StrategyStore:  &mockStrategyStore{},
+		HealthCheck:    hc,
+	})
+	c.Start(&CollectorOptions{
+		QueueSize:    10,
+		StorageRetry: retry.Options{BreakerThreshold: 1, BreakerOpenDuration: time.Hour},
+	})
+	_, err := c.spanProcessor.ProcessSpans([]*model.Span{
+		{Process: model.NewProcess("svc", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	require.NoError(t, err)
+	for i := 0; i < 100 && hc.Get() != healthcheck.Degraded; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, healthcheck.Degraded, hc.Get())
+	assert.NoError(t, c.Close())
+}
+
+func TestCollectorSpanMetrics(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(time.Hour)
+	c := New(&CollectorParams{
+		ServiceName:    "collector",
+		Logger:         zap.NewNop(),
+		MetricsFactory: metricsFactory,
+		SpanWriter:     &fakeSpanWriter{},
+		StrategyStore:  &mockStrategyStore{},
+		HealthCheck:    healthcheck.New(),
+	})
+	c.Start(&CollectorOptions{
+		QueueSize:   10,
+		SpanMetrics: spanmetrics.Options{Enabled: true, MaxServices: 10, MaxOperations: 10, MaxSeries: 100},
+	})
+	_, err := c.spanProcessor.ProcessSpans([]*model.Span{
+		{OperationName: "op", Process: model.NewProcess("svc", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	require.NoError(t, err)
+	assert.NoError(t, c.Close())
+
+	metricsFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
+		Name: "span-metrics.calls|operation=op|service=svc|span_kind=unspecified", Value: 1,
+	})
+}
+
+func TestCollectorServiceGraph(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(time.Hour)
+	dependencyWriter := &depsmocks.Writer{}
+	dependencyWriter.On("WriteDependencies", mock.Anything, []model.DependencyLink{
+		{Parent: "frontend", Child: "backend", CallCount: 1},
+	}).Return(nil)
+	c := New(&CollectorParams{
+		ServiceName:      "collector",
+		Logger:           zap.NewNop(),
+		MetricsFactory:   metricsFactory,
+		SpanWriter:       &fakeSpanWriter{},
+		DependencyWriter: dependencyWriter,
+		StrategyStore:    &mockStrategyStore{},
+		HealthCheck:      healthcheck.New(),
+	})
+	c.Start(&CollectorOptions{
+		QueueSize: 10,
+		ServiceGraph: servicegraph.Options{
+			Enabled:              true,
+			Wait:                 time.Minute,
+			MaxItems:             10,
+			MaxEdges:             10,
+			DependenciesInterval: time.Hour,
+		},
+	})
+	traceID := model.NewTraceID(0, 1)
+	_, err := c.spanProcessor.ProcessSpans([]*model.Span{
+		{
+			TraceID: traceID,
+			SpanID:  1,
+			Tags:    []model.KeyValue{model.String("span.kind", "client")},
+			Process: model.NewProcess("frontend", nil),
+		},
+		{
+			TraceID:    traceID,
+			SpanID:     2,
+			References: []model.SpanRef{model.NewChildOfRef(traceID, 1)},
+			Tags:       []model.KeyValue{model.String("span.kind", "server")},
+			Process:    model.NewProcess("backend", nil),
+		},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	require.NoError(t, err)
+	assert.NoError(t, c.Close())
+
+	metricsFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
+		Name: "service-graph.calls|client=frontend|server=backend", Value: 1,
+	})
+	dependencyWriter.AssertNumberOfCalls(t, "WriteDependencies", 1)
+}
+
 type mockStrategyStore struct {
 }
 
@@ -98,3 +328,8 @@ func TestCollector_PublishOpts(t *testing.T) {
 		Value: 42,
 	})
 }
+
+func bufferedTraces(s *tailsampling.Sampler) int {
+	n, _ := s.Buffered()
+	return n
+}
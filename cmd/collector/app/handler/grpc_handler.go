@@ -51,7 +51,7 @@ func (g *GRPCHandler) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest)
 		SpanFormat:       processor.ProtoSpanFormat,
 	})
 	if err != nil {
-		if err == processor.ErrBusy {
+		if err == processor.ErrBusy || err == processor.ErrRateLimited {
 			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
 		}
 		g.logger.Error("cannot process spans", zap.Error(err))
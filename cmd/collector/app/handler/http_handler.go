@@ -89,9 +89,18 @@ func (aH *APIHandler) SaveSpan(w http.ResponseWriter, r *http.Request) {
 	batches := []*tJaeger.Batch{batch}
 	opts := SubmitBatchOptions{InboundTransport: processor.HTTPTransport}
 	if _, err = aH.jaegerBatchesHandler.SubmitBatches(batches, opts); err != nil {
-		http.Error(w, fmt.Sprintf("Cannot submit Jaeger batch: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Cannot submit Jaeger batch: %v", err), SubmitErrorStatusCode(err))
 		return
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 }
+
+// SubmitErrorStatusCode returns the HTTP status code of an error returned when submitting spans,
+// so that the clients can tell the spans dropped by the rate limits from the server errors.
+func SubmitErrorStatusCode(err error) int {
+	if err == processor.ErrRateLimited {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
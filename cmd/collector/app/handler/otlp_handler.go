@@ -0,0 +1,161 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/model/converter/otlp"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+)
+
+const (
+	// OTLPTracesPath is the path of the OTLP/HTTP traces endpoint
+	OTLPTracesPath = "/v1/traces"
+
+	otlpProtobufContentType = "application/x-protobuf"
+	otlpJSONContentType     = "application/json"
+)
+
+// OTLPHandler implements the OTLP TraceService over gRPC and the OTLP/HTTP traces endpoint,
+// the spans are converted to the domain model and passed to the span processor.
+type OTLPHandler struct {
+	logger        *zap.Logger
+	spanProcessor processor.SpanProcessor
+}
+
+// NewOTLPHandler returns a new OTLPHandler
+func NewOTLPHandler(logger *zap.Logger, spanProcessor processor.SpanProcessor) *OTLPHandler {
+	return &OTLPHandler{
+		logger:        logger,
+		spanProcessor: spanProcessor,
+	}
+}
+
+// Export implements OTLP TraceService.
+func (h *OTLPHandler) Export(ctx context.Context, r *otlpcollector.ExportTraceServiceRequest) (*otlpcollector.ExportTraceServiceResponse, error) {
+	spans, err := otlp.ToDomain(r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	_, err = h.spanProcessor.ProcessSpans(spans, processor.SpansOptions{
+		InboundTransport: processor.GRPCTransport,
+		SpanFormat:       processor.OTLPSpanFormat,
+	})
+	if err != nil {
+		if err == processor.ErrBusy || err == processor.ErrRateLimited {
+			return nil, status.Errorf(codes.ResourceExhausted, err.Error())
+		}
+		h.logger.Error("cannot process spans", zap.Error(err))
+		return nil, err
+	}
+	return &otlpcollector.ExportTraceServiceResponse{}, nil
+}
+
+// RegisterRoutes registers the OTLP/HTTP routes on the given router
+func (h *OTLPHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(OTLPTracesPath, h.ExportHTTP).Methods(http.MethodPost)
+}
+
+// ExportHTTP handles the OTLP/HTTP requests encoded as protobuf or JSON
+func (h *OTLPHandler) ExportHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	defer r.Body.Close()
+	if strings.Contains(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(UnableToReadBodyErrFormat, err), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	bodyBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(UnableToReadBodyErrFormat, err), http.StatusInternalServerError)
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Cannot parse content type: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	request := &otlpcollector.ExportTraceServiceRequest{}
+	switch contentType {
+	case otlpProtobufContentType:
+		err = proto.Unmarshal(bodyBytes, request)
+	case otlpJSONContentType:
+		// OTLP/JSON encodes the trace and span IDs as hex
+		err = otlp.UnmarshalJSON(bodyBytes, request)
+	default:
+		http.Error(w, fmt.Sprintf("Unsupported content type: %v", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf(UnableToReadBodyErrFormat, err), http.StatusBadRequest)
+		return
+	}
+
+	spans, err := otlp.ToDomain(request)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(UnableToReadBodyErrFormat, err), http.StatusBadRequest)
+		return
+	}
+	if _, err = h.spanProcessor.ProcessSpans(spans, processor.SpansOptions{
+		InboundTransport: processor.HTTPTransport,
+		SpanFormat:       processor.OTLPSpanFormat,
+	}); err != nil {
+		if err == processor.ErrBusy {
+			// OTLP clients retry the requests rejected with 503
+			http.Error(w, fmt.Sprintf("Cannot submit OTLP spans: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Cannot submit OTLP spans: %v", err), SubmitErrorStatusCode(err))
+		return
+	}
+
+	// the response is encoded like the request
+	response := &otlpcollector.ExportTraceServiceResponse{}
+	w.Header().Set("Content-Type", contentType)
+	if contentType == otlpJSONContentType {
+		err = (&jsonpb.Marshaler{}).Marshal(w, response)
+	} else {
+		var data []byte
+		if data, err = proto.Marshal(response); err == nil {
+			_, err = w.Write(data)
+		}
+	}
+	if err != nil {
+		h.logger.Error("cannot write OTLP response", zap.Error(err))
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/converter/otlp"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+	otlpcommon "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	otlpresource "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	otlptrace "github.com/jaegertracing/jaeger/proto-gen/otlp/trace/v1"
+)
+
+func otlpRequest(spanIDs ...byte) *otlpcollector.ExportTraceServiceRequest {
+	spans := make([]*otlptrace.Span, len(spanIDs))
+	for i, id := range spanIDs {
+		spans[i] = &otlptrace.Span{
+			TraceId: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			SpanId:  []byte{0, 0, 0, 0, 0, 0, 0, id},
+			Name:    "test-op",
+		}
+	}
+	return &otlpcollector.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			Resource: &otlpresource.Resource{Attributes: []*otlpcommon.KeyValue{{
+				Key:   otlp.ServiceNameAttribute,
+				Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "frontend"}},
+			}}},
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{Spans: spans}},
+		}},
+	}
+}
+
+type formatRecordingProcessor struct {
+	mockSpanProcessor
+	options []processor.SpansOptions
+}
+
+func (p *formatRecordingProcessor) ProcessSpans(spans []*model.Span, opts processor.SpansOptions) ([]bool, error) {
+	p.options = append(p.options, opts)
+	return p.mockSpanProcessor.ProcessSpans(spans, opts)
+}
+
+func TestOTLPExport(t *testing.T) {
+	spanProcessor := &formatRecordingProcessor{}
+	server, addr := initializeGRPCTestServer(t, func(s *grpc.Server) {
+		otlpcollector.RegisterTraceServiceServer(s, NewOTLPHandler(zap.NewNop(), spanProcessor))
+	})
+	defer server.Stop()
+	conn, err := grpc.Dial(addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := otlpcollector.NewTraceServiceClient(conn)
+
+	response, err := client.Export(context.Background(), otlpRequest(1, 2))
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+
+	spans := spanProcessor.getSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "test-op", spans[0].OperationName)
+	assert.Equal(t, "frontend", spans[0].Process.ServiceName)
+	assert.Equal(t, []processor.SpansOptions{{
+		SpanFormat:       processor.OTLPSpanFormat,
+		InboundTransport: processor.GRPCTransport,
+	}}, spanProcessor.options)
+}
+
+func TestOTLPExportErrors(t *testing.T) {
+	spanProcessor := &mockSpanProcessor{}
+	handler := NewOTLPHandler(zap.NewNop(), spanProcessor)
+
+	request := otlpRequest(1)
+	request.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0].SpanId = []byte{1}
+	_, err := handler.Export(context.Background(), request)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, spanProcessor.getSpans())
+
+	spanProcessor.expectedError = processor.ErrBusy
+	_, err = handler.Export(context.Background(), otlpRequest(1))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	spanProcessor.expectedError = processor.ErrRateLimited
+	_, err = handler.Export(context.Background(), otlpRequest(1))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	spanProcessor.expectedError = errors.New("test-error")
+	_, err = handler.Export(context.Background(), otlpRequest(1))
+	assert.EqualError(t, err, "test-error")
+}
+
+func initializeOTLPTestServer(spanProcessor processor.SpanProcessor) *httptest.Server {
+	r := mux.NewRouter()
+	NewOTLPHandler(zap.NewNop(), spanProcessor).RegisterRoutes(r)
+	return httptest.NewServer(r)
+}
+
+func TestOTLPExportHTTP(t *testing.T) {
+	spanProcessor := &mockSpanProcessor{}
+	server := initializeOTLPTestServer(spanProcessor)
+	defer server.Close()
+
+	data, err := proto.Marshal(otlpRequest(1, 2))
+	require.NoError(t, err)
+	statusCode, body, err := postBytes("application/x-protobuf", server.URL+OTLPTracesPath, data)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.NoError(t, proto.Unmarshal([]byte(body), &otlpcollector.ExportTraceServiceResponse{}))
+	assert.Len(t, spanProcessor.getSpans(), 2)
+
+	json, err := otlp.MarshalJSON(otlpRequest(3))
+	require.NoError(t, err)
+	assert.Contains(t, string(json), `"spanId":"0000000000000003"`)
+	statusCode, body, err = postBytes("application/json; charset=utf-8", server.URL+OTLPTracesPath, json)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "{}", body)
+	spans := spanProcessor.getSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "frontend", spans[2].Process.ServiceName)
+	assert.Equal(t, model.NewSpanID(3), spans[2].SpanID)
+}
+
+func TestOTLPExportHTTPGzip(t *testing.T) {
+	spanProcessor := &mockSpanProcessor{}
+	server := initializeOTLPTestServer(spanProcessor)
+	defer server.Close()
+
+	data, err := proto.Marshal(otlpRequest(1))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+OTLPTracesPath, &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	res, err := httpClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Len(t, spanProcessor.getSpans(), 1)
+
+	req, err = http.NewRequest(http.MethodPost, server.URL+OTLPTracesPath, bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	res, err = httpClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestOTLPExportHTTPErrors(t *testing.T) {
+	invalidID := otlpRequest(1)
+	invalidID.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0].SpanId = []byte{1}
+	invalidIDData, err := proto.Marshal(invalidID)
+	require.NoError(t, err)
+	data, err := proto.Marshal(otlpRequest(1))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           []byte
+		processorError error
+		statusCode     int
+		response       string
+	}{
+		{
+			name:        "unsupported content type",
+			contentType: "application/x-thrift",
+			statusCode:  http.StatusUnsupportedMediaType,
+			response:    "Unsupported content type: application/x-thrift\n",
+		},
+		{
+			name:        "malformed content type",
+			contentType: "application/json; =iammalformed",
+			statusCode:  http.StatusBadRequest,
+			response:    "Cannot parse content type: mime: invalid media parameter\n",
+		},
+		{
+			name:        "malformed protobuf",
+			contentType: "application/x-protobuf",
+			body:        []byte("not good"),
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "malformed JSON",
+			contentType: "application/json",
+			body:        []byte("not good"),
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid span",
+			contentType: "application/x-protobuf",
+			body:        invalidIDData,
+			statusCode:  http.StatusBadRequest,
+			response:    "Unable to process request body: invalid OTLP span ID length 1, expected 8 bytes\n",
+		},
+		{
+			name:           "busy",
+			contentType:    "application/x-protobuf",
+			body:           data,
+			processorError: processor.ErrBusy,
+			statusCode:     http.StatusServiceUnavailable,
+			response:       "Cannot submit OTLP spans: server busy\n",
+		},
+		{
+			name:           "rate limited",
+			contentType:    "application/x-protobuf",
+			body:           data,
+			processorError: processor.ErrRateLimited,
+			statusCode:     http.StatusTooManyRequests,
+			response:       "Cannot submit OTLP spans: rate limit exceeded\n",
+		},
+		{
+			name:           "processor error",
+			contentType:    "application/x-protobuf",
+			body:           data,
+			processorError: errors.New("Bad times ahead"),
+			statusCode:     http.StatusInternalServerError,
+			response:       "Cannot submit OTLP spans: Bad times ahead\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := initializeOTLPTestServer(&mockSpanProcessor{expectedError: test.processorError})
+			defer server.Close()
+			statusCode, body, err := postBytes(test.contentType, server.URL+OTLPTracesPath, test.body)
+			require.NoError(t, err)
+			assert.Equal(t, test.statusCode, statusCode)
+			if test.response != "" {
+				assert.Equal(t, test.response, body)
+			}
+		})
+	}
+}
+
+func TestOTLPExportHTTPCannotReadBody(t *testing.T) {
+	handler := NewOTLPHandler(zap.NewNop(), &mockSpanProcessor{})
+	req, err := http.NewRequest(http.MethodPost, "whatever", ioutil.NopCloser(&errReader{}))
+	require.NoError(t, err)
+	rw := dummyResponseWriter{}
+	handler.ExportHTTP(&rw, req)
+	assert.EqualValues(t, http.StatusInternalServerError, rw.myStatusCode)
+	assert.EqualValues(t, "Unable to process request body: Simulated error reading body\n", rw.myBody)
+}
@@ -110,6 +110,8 @@ type SpanCounts struct {
 	ReceivedBySvc metricsBySvc
 	// RejectedBySvc is the number of spans we rejected (usually due to blacklisting) by-service.
 	RejectedBySvc metricsBySvc
+	// RateLimitedBySvc is the number of spans we dropped because they exceeded the rate limits by-service.
+	RateLimitedBySvc metricsBySvc
 }
 
 // NewSpanProcessorMetrics returns a SpanProcessorMetrics
@@ -118,6 +120,7 @@ func NewSpanProcessorMetrics(serviceMetrics metrics.Factory, hostMetrics metrics
 		processor.ZipkinSpanFormat:  newCountsByTransport(serviceMetrics, processor.ZipkinSpanFormat),
 		processor.JaegerSpanFormat:  newCountsByTransport(serviceMetrics, processor.JaegerSpanFormat),
 		processor.ProtoSpanFormat:   newCountsByTransport(serviceMetrics, processor.ProtoSpanFormat),
+		processor.OTLPSpanFormat:    newCountsByTransport(serviceMetrics, processor.OTLPSpanFormat),
 		processor.UnknownSpanFormat: newCountsByTransport(serviceMetrics, processor.UnknownSpanFormat),
 	}
 	for _, otherFormatType := range otherFormatTypes {
@@ -203,8 +206,9 @@ func newCountsByTransport(factory metrics.Factory, format processor.SpanFormat)
 func newCounts(factory metrics.Factory, transport processor.InboundTransport) SpanCounts {
 	factory = factory.Namespace(metrics.NSOptions{Tags: map[string]string{"transport": string(transport)}})
 	return SpanCounts{
-		RejectedBySvc: newMetricsBySvc(factory, "rejected"),
-		ReceivedBySvc: newMetricsBySvc(factory, "received"),
+		RejectedBySvc:    newMetricsBySvc(factory, "rejected"),
+		ReceivedBySvc:    newMetricsBySvc(factory, "received"),
+		RateLimitedBySvc: newMetricsBySvc(factory, "rate-limited"),
 	}
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer"
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/queue"
 )
 
 const (
@@ -39,9 +40,11 @@ type options struct {
 	sanitizer          sanitizer.SanitizeSpan
 	preSave            ProcessSpan
 	spanFilter         FilterSpan
+	rateLimiter        FilterSpan
 	numWorkers         int
 	blockingSubmit     bool
 	queueSize          int
+	queue              queue.Queue
 	dynQueueSizeWarmup uint
 	dynQueueSizeMemory uint
 	reportBusy         bool
@@ -104,6 +107,13 @@ func (options) SpanFilter(spanFilter FilterSpan) Option {
 	}
 }
 
+// RateLimiter creates an Option that initializes the rateLimiter function, which drops the spans it disallows
+func (options) RateLimiter(rateLimiter FilterSpan) Option {
+	return func(b *options) {
+		b.rateLimiter = rateLimiter
+	}
+}
+
 // NumWorkers creates an Option that initializes the number of queue consumers AKA workers
 func (options) NumWorkers(numWorkers int) Option {
 	return func(b *options) {
@@ -125,6 +135,13 @@ func (options) QueueSize(queueSize int) Option {
 	}
 }
 
+// Queue creates an Option that initializes the queue of the spans, replacing the in-memory queue of queueSize
+func (options) Queue(queue queue.Queue) Option {
+	return func(b *options) {
+		b.queue = queue
+	}
+}
+
 // DynQueueSize creates an Option that initializes the queue size
 func (options) DynQueueSizeWarmup(dynQueueSizeWarmup uint) Option {
 	return func(b *options) {
@@ -186,6 +203,9 @@ func (o options) apply(opts ...Option) options {
 	if ret.spanFilter == nil {
 		ret.spanFilter = func(span *model.Span) bool { return true }
 	}
+	if ret.rateLimiter == nil {
+		ret.rateLimiter = func(span *model.Span) bool { return true }
+	}
 	if ret.numWorkers == 0 {
 		ret.numWorkers = DefaultNumWorkers
 	}
@@ -0,0 +1,74 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/queue"
+)
+
+// queuedTimeSize is the size of the queued time prefixed to the spans in the persistent queue
+const queuedTimeSize = 8
+
+// NewPersistentQueue opens the persistent queue of the spans configured by the options
+func NewPersistentQueue(cOpts *CollectorOptions, metricsFactory metrics.Factory, logger *zap.Logger) (*queue.PersistentQueue, error) {
+	return queue.NewPersistentQueue(queue.PersistentQueueOptions{
+		Directory:      cOpts.PersistentQueueDirectory,
+		Capacity:       cOpts.QueueSize,
+		MaxBytes:       int64(cOpts.PersistentQueueMaxSize),
+		SegmentBytes:   int64(cOpts.PersistentQueueSegmentSize),
+		SyncInterval:   cOpts.PersistentQueueSyncInterval,
+		Marshal:        marshalQueueItem,
+		Unmarshal:      unmarshalQueueItem,
+		MetricsFactory: metricsFactory,
+		Logger:         logger,
+	}, nil)
+}
+
+// marshalQueueItem encodes the queued time followed by the protobuf span
+func marshalQueueItem(item interface{}) ([]byte, error) {
+	value, ok := item.(*queueItem)
+	if !ok {
+		return nil, fmt.Errorf("unexpected queue item %T", item)
+	}
+	data := make([]byte, queuedTimeSize+value.span.Size())
+	binary.BigEndian.PutUint64(data, uint64(value.queuedTime.UnixNano()))
+	if _, err := value.span.MarshalTo(data[queuedTimeSize:]); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func unmarshalQueueItem(data []byte) (interface{}, error) {
+	if len(data) < queuedTimeSize {
+		return nil, errors.New("queue item is too short")
+	}
+	span := &model.Span{}
+	if err := span.Unmarshal(data[queuedTimeSize:]); err != nil {
+		return nil, err
+	}
+	return &queueItem{
+		queuedTime: time.Unix(0, int64(binary.BigEndian.Uint64(data))),
+		span:       span,
+	}, nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/queue"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+type recordingSpanWriter struct {
+	mu    sync.Mutex
+	spans []*model.Span
+	// failures is the number of writes failing before the spans are recorded
+	failures int
+}
+
+func (w *recordingSpanWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("storage unavailable")
+	}
+	w.spans = append(w.spans, span)
+	return nil
+}
+
+func (w *recordingSpanWriter) getSpans() []*model.Span {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.spans
+}
+
+func TestQueueItemMarshalling(t *testing.T) {
+	item := &queueItem{
+		queuedTime: time.Unix(0, 1234567890),
+		span: &model.Span{
+			TraceID:       model.NewTraceID(1, 2),
+			SpanID:        model.NewSpanID(3),
+			OperationName: "op",
+			Process:       model.NewProcess("svc", []model.KeyValue{model.String("k", "v")}),
+		},
+	}
+	data, err := marshalQueueItem(item)
+	require.NoError(t, err)
+	decoded, err := unmarshalQueueItem(data)
+	require.NoError(t, err)
+	assert.True(t, item.queuedTime.Equal(decoded.(*queueItem).queuedTime))
+	assert.Equal(t, item.span, decoded.(*queueItem).span)
+
+	_, err = marshalQueueItem("span")
+	assert.EqualError(t, err, "unexpected queue item string")
+	_, err = unmarshalQueueItem([]byte{1})
+	assert.EqualError(t, err, "queue item is too short")
+	_, err = unmarshalQueueItem(append(data[:queuedTimeSize:queuedTimeSize], 0xff))
+	assert.Error(t, err)
+}
+
+func TestSpanProcessorPersistentQueueReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collector-queue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cOpts := &CollectorOptions{
+		QueueSize:                  10,
+		PersistentQueueDirectory:   dir,
+		PersistentQueueMaxSize:     1024 * 1024,
+		PersistentQueueSegmentSize: 1024,
+	}
+
+	// the consumers are not started, as if the collector stopped before saving the span
+	q, err := NewPersistentQueue(cOpts, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	sp := newSpanProcessor(&fakeSpanWriter{}, Options.Queue(q))
+	res, err := sp.ProcessSpans([]*model.Span{
+		{OperationName: "op", Process: model.NewProcess("svc", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.ProtoSpanFormat})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true}, res)
+	require.NoError(t, sp.Close())
+
+	q, err = NewPersistentQueue(cOpts, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	w := &recordingSpanWriter{}
+	p := NewSpanProcessor(w, Options.Queue(q))
+	for i := 0; i < 100 && len(w.getSpans()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, p.Close())
+	require.Len(t, w.getSpans(), 1)
+	assert.Equal(t, "op", w.getSpans()[0].OperationName)
+	assert.Equal(t, model.String("internal.span.format", "proto"), w.getSpans()[0].Tags[0])
+}
+
+func TestSpanProcessorPersistentQueueSanitizesBeforeWriting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collector-queue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cOpts := &CollectorOptions{
+		QueueSize:                  10,
+		PersistentQueueDirectory:   dir,
+		PersistentQueueMaxSize:     1024 * 1024,
+		PersistentQueueSegmentSize: 1024,
+	}
+	sanitized := 0
+	redact := func(span *model.Span) *model.Span {
+		sanitized++
+		span.Tags = model.KeyValues{model.String("password", "REDACTED")}
+		return span
+	}
+
+	// the consumers are not started, the span stays on disk
+	q, err := NewPersistentQueue(cOpts, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	sp := newSpanProcessor(&fakeSpanWriter{}, Options.Queue(q), Options.Sanitizer(redact))
+	_, err = sp.ProcessSpans([]*model.Span{
+		{OperationName: "op", Process: model.NewProcess("svc", nil), Tags: model.KeyValues{model.String("password", "secret")}},
+	}, processor.SpansOptions{SpanFormat: processor.ProtoSpanFormat})
+	require.NoError(t, err)
+	require.NoError(t, sp.Close())
+	assert.Equal(t, 1, sanitized)
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	for _, segment := range segments {
+		data, err := ioutil.ReadFile(segment)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret", "the span is sanitized before being written to disk")
+	}
+
+	q, err = NewPersistentQueue(cOpts, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	w := &recordingSpanWriter{}
+	p := NewSpanProcessor(w, Options.Queue(q), Options.Sanitizer(redact))
+	for i := 0; i < 100 && len(w.getSpans()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, p.Close())
+	require.Len(t, w.getSpans(), 1)
+	assert.Equal(t, []model.KeyValue{model.String("password", "REDACTED")}, w.getSpans()[0].Tags)
+	assert.Equal(t, 1, sanitized, "the replayed span is not sanitized again")
+}
+
+func TestSpanProcessorPersistentQueueRetriesFailedSaves(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collector-queue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	q, err := queue.NewPersistentQueue(queue.PersistentQueueOptions{
+		Directory:     dir,
+		Capacity:      10,
+		MaxBytes:      1024 * 1024,
+		SegmentBytes:  1024,
+		RetryInterval: time.Millisecond,
+		Marshal:       marshalQueueItem,
+		Unmarshal:     unmarshalQueueItem,
+	}, nil)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	preSaved := 0
+	w := &recordingSpanWriter{failures: 3}
+	p := NewSpanProcessor(w, Options.Queue(q), Options.PreSave(func(span *model.Span) {
+		mu.Lock()
+		defer mu.Unlock()
+		preSaved++
+	}))
+	_, err = p.ProcessSpans([]*model.Span{
+		{OperationName: "op", Process: model.NewProcess("svc", nil)},
+	}, processor.SpansOptions{SpanFormat: processor.ProtoSpanFormat})
+	require.NoError(t, err)
+	for i := 0; i < 100 && len(w.getSpans()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, p.Close())
+	require.Len(t, w.getSpans(), 1, "the span is saved once the storage is available")
+	assert.Equal(t, 1, preSaved, "the span is processed once")
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestSpanProcessorSaveSpanRetryable(t *testing.T) {
+	sp := newSpanProcessor(&fakeSpanWriter{err: errors.New("storage unavailable")})
+	assert.False(t, sp.saveSpan(&model.Span{Process: model.NewProcess("svc", nil)}), "the span can be saved again")
+	sp = newSpanProcessor(&fakeSpanWriter{err: spanstore.PermanentError{Err: errors.New("invalid span")}})
+	assert.True(t, sp.saveSpan(&model.Span{Process: model.NewProcess("svc", nil)}), "the span is dropped")
+	assert.True(t, sp.saveSpan(&model.Span{}), "the span without process is dropped")
+}
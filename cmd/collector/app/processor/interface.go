@@ -24,6 +24,11 @@ import (
 // ErrBusy signalizes that processor cannot process incoming data
 var ErrBusy = errors.New("server busy")
 
+// ErrRateLimited signalizes that all the spans of a batch exceeded the rate limits of their service or tenant
+// and were dropped, so the batch can be retried. The rate limited spans of a partially processed batch are only
+// reported in the metrics, retrying the batch would process its other spans twice.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
 // SpansOptions additional options passed to processor along with the spans.
 type SpansOptions struct {
 	SpanFormat       SpanFormat
@@ -59,6 +64,8 @@ const (
 	ZipkinSpanFormat SpanFormat = "zipkin"
 	// ProtoSpanFormat is for Jaeger protobuf Spans.
 	ProtoSpanFormat SpanFormat = "proto"
+	// OTLPSpanFormat is for OpenTelemetry OTLP spans.
+	OTLPSpanFormat SpanFormat = "otlp"
 	// UnknownSpanFormat is the fallback/catch-all category.
 	UnknownSpanFormat SpanFormat = "unknown"
 )
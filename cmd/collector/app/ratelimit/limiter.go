@@ -0,0 +1,294 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/config/filereloader"
+)
+
+const (
+	// maxBuckets caps the number of token buckets kept for the services and tenants, the idle buckets are evicted
+	// when the cap is reached and the spans of the services and tenants above it share an overflow bucket.
+	maxBuckets = 4000
+	// evictionInterval is the minimum time between two sweeps of the idle buckets
+	evictionInterval = time.Second
+
+	servicePrefix = "service:"
+	tenantPrefix  = "tenant:"
+	overflowKey   = "overflow"
+)
+
+// limitsConfig is the format of the limits file, e.g.
+//
+//	default:
+//	  spans-per-second: 1000
+//	services:
+//	  frontend:
+//	    spans-per-second: 5000
+//	    burst: 10000
+//	tenant-tag: tenant
+//	tenants:
+//	  acme:
+//	    spans-per-second: 20000
+//
+// Each service gets its own token bucket with its limit, or with the default limit if it is not listed.
+// The services are not limited if there is no default and they are not listed. If the tenant tag is set,
+// the spans carrying it in their tags or process tags are also limited by the bucket of their tenant.
+// The burst is the maximum number of spans accepted at once, it defaults to the spans per second
+// or to 1 if lower.
+type limitsConfig struct {
+	Default   *limitConfig           `yaml:"default"`
+	Services  map[string]limitConfig `yaml:"services"`
+	TenantTag string                 `yaml:"tenant-tag"`
+	Tenants   map[string]limitConfig `yaml:"tenants"`
+}
+
+type limitConfig struct {
+	SpansPerSecond float64 `yaml:"spans-per-second"`
+	Burst          float64 `yaml:"burst"`
+}
+
+// bucket is a token bucket holding up to limit.Burst credits and refilled at limit.SpansPerSecond
+type bucket struct {
+	limit    limitConfig
+	balance  float64
+	lastTick time.Time
+}
+
+func newBucket(limit limitConfig, now time.Time) *bucket {
+	return &bucket{limit: limit, balance: limit.Burst, lastTick: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastTick).Seconds()
+	b.lastTick = now
+	b.balance = math.Min(b.balance+elapsed*b.limit.SpansPerSecond, b.limit.Burst)
+}
+
+// update changes the limit of the bucket, pro-rating its balance to the new burst
+func (b *bucket) update(limit limitConfig) {
+	b.balance = b.balance * limit.Burst / b.limit.Burst
+	b.limit = limit
+}
+
+// idle returns true if the bucket is full, in which case it behaves as a new bucket and can be evicted
+func (b *bucket) idle() bool {
+	return b.balance >= b.limit.Burst
+}
+
+// Limiter limits the rate of spans accepted per service and per tenant with token buckets
+// configured by a YAML file, which can be reloaded periodically.
+type Limiter struct {
+	logger *zap.Logger
+	limits atomic.Value // holds *limitsConfig
+	now    func() time.Time
+
+	bucketsMu sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+
+	reloader *filereloader.Reloader
+}
+
+// NewLimiter creates a Limiter with the limits of the file, reloaded at the given interval if positive.
+func NewLimiter(limitsFile string, reloadInterval time.Duration, logger *zap.Logger) (*Limiter, error) {
+	l := &Limiter{
+		logger:  logger,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+	reloader, err := filereloader.New(limitsFile, "rate limits", reloadInterval, l.updateLimits, logger)
+	if err != nil {
+		return nil, err
+	}
+	l.reloader = reloader
+	return l, nil
+}
+
+func (l *Limiter) updateLimits(content []byte) error {
+	limits, err := parseLimits(content)
+	if err != nil {
+		return err
+	}
+	l.limits.Store(limits)
+	return nil
+}
+
+func parseLimits(content []byte) (*limitsConfig, error) {
+	var limits limitsConfig
+	if err := yaml.UnmarshalStrict(content, &limits); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rate limits: %w", err)
+	}
+	if limits.Default != nil {
+		limit, err := validateLimit(*limits.Default)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default rate limit: %w", err)
+		}
+		limits.Default = &limit
+	}
+	for service, limit := range limits.Services {
+		limit, err := validateLimit(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit of service %s: %w", service, err)
+		}
+		limits.Services[service] = limit
+	}
+	if len(limits.Tenants) > 0 && limits.TenantTag == "" {
+		return nil, fmt.Errorf("the tenant rate limits require a tenant-tag")
+	}
+	for tenant, limit := range limits.Tenants {
+		limit, err := validateLimit(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit of tenant %s: %w", tenant, err)
+		}
+		limits.Tenants[tenant] = limit
+	}
+	return &limits, nil
+}
+
+func validateLimit(limit limitConfig) (limitConfig, error) {
+	if limit.SpansPerSecond <= 0 {
+		return limit, fmt.Errorf("spans-per-second must be positive")
+	}
+	if limit.Burst == 0 {
+		limit.Burst = math.Max(limit.SpansPerSecond, 1)
+	}
+	if limit.Burst < 1 {
+		return limit, fmt.Errorf("burst must be at least 1")
+	}
+	return limit, nil
+}
+
+// Close stops reloading the limits
+func (l *Limiter) Close() error {
+	return l.reloader.Close()
+}
+
+// Allow returns false if the span exceeds the rate limit of its service or of its tenant.
+// The span is charged to the buckets of its service and tenant only if both have credit.
+func (l *Limiter) Allow(span *model.Span) bool {
+	limits := l.limits.Load().(*limitsConfig)
+
+	keys := make([]string, 0, 2)
+	keyLimits := make([]limitConfig, 0, 2)
+	if limits.TenantTag != "" {
+		if tenant, ok := findTag(span, limits.TenantTag); ok {
+			if limit, ok := limits.Tenants[tenant]; ok {
+				keys = append(keys, tenantPrefix+tenant)
+				keyLimits = append(keyLimits, limit)
+			}
+		}
+	}
+
+	var serviceName string
+	if span.Process != nil {
+		serviceName = span.Process.ServiceName
+	}
+	if limit, ok := limits.Services[serviceName]; ok {
+		keys = append(keys, servicePrefix+serviceName)
+		keyLimits = append(keyLimits, limit)
+	} else if limits.Default != nil {
+		keys = append(keys, servicePrefix+serviceName)
+		keyLimits = append(keyLimits, *limits.Default)
+	}
+	if len(keys) == 0 {
+		return true
+	}
+
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+	now := l.now()
+	buckets := make([]*bucket, 0, len(keys))
+	for i, key := range keys {
+		b := l.getBucket(key, keyLimits[i], now)
+		if len(buckets) > 0 && buckets[0] == b {
+			// the service and the tenant share the overflow bucket
+			continue
+		}
+		b.refill(now)
+		if b.balance < 1 {
+			return false
+		}
+		buckets = append(buckets, b)
+	}
+	for _, b := range buckets {
+		b.balance--
+	}
+	return true
+}
+
+// getBucket returns the token bucket of the key, updated to the current limit, must be called under bucketsMu.
+// The buckets are updated in place to retain their balance when the limits are reloaded.
+func (l *Limiter) getBucket(key string, limit limitConfig, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok && len(l.buckets) >= maxBuckets {
+		l.evictIdleBuckets(now)
+	}
+	if !ok && len(l.buckets) >= maxBuckets {
+		// the overflow bucket keeps the first limit it was created with,
+		// instead of flapping between the limits of the services and tenants sharing it
+		if b, ok = l.buckets[overflowKey]; ok {
+			return b
+		}
+		key = overflowKey
+	}
+	if !ok {
+		b = newBucket(limit, now)
+		l.buckets[key] = b
+	}
+	if b.limit != limit {
+		b.refill(now)
+		b.update(limit)
+	}
+	return b
+}
+
+// evictIdleBuckets removes the buckets refilled to their burst, at most once per eviction interval since
+// sweeping all the buckets is expensive, must be called under bucketsMu.
+func (l *Limiter) evictIdleBuckets(now time.Time) {
+	if now.Sub(l.lastSweep) < evictionInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.idle() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// findTag returns the value of the string tag of the span, or of its process.
+func findTag(span *model.Span, key string) (string, bool) {
+	if kv, ok := model.KeyValues(span.Tags).FindByKey(key); ok && kv.VType == model.StringType {
+		return kv.VStr, true
+	}
+	if span.Process != nil {
+		if kv, ok := model.KeyValues(span.Process.Tags).FindByKey(key); ok && kv.VType == model.StringType {
+			return kv.VStr, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+// the limits are low enough for the buckets not to be refilled during the tests
+const testLimits = `
+default:
+  spans-per-second: 0.001
+  burst: 2
+services:
+  frontend:
+    spans-per-second: 0.001
+    burst: 3
+tenant-tag: tenant
+tenants:
+  acme:
+    spans-per-second: 0.001
+`
+
+func writeLimitsFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "rate-limits-*.yaml")
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
+
+func newSpan(service string, tags ...model.KeyValue) *model.Span {
+	return &model.Span{Process: model.NewProcess(service, nil), Tags: tags}
+}
+
+func countAllowed(l *Limiter, span *model.Span, n int) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		if l.Allow(span) {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestLimiter(t *testing.T) {
+	limitsFile := writeLimitsFile(t, testLimits)
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, 3, countAllowed(l, newSpan("frontend"), 10))
+	assert.Equal(t, 2, countAllowed(l, newSpan("backend"), 10))
+	assert.Equal(t, 2, countAllowed(l, newSpan("database"), 10), "each service has its own bucket")
+	assert.Equal(t, 2, countAllowed(l, &model.Span{}, 10), "spans without process are limited by the default")
+
+	assert.Equal(t, 1, countAllowed(l, newSpan("billing", model.String("tenant", "acme")), 10))
+	acme := &model.Span{Process: model.NewProcess("payments", []model.KeyValue{model.String("tenant", "acme")})}
+	assert.Equal(t, 0, countAllowed(l, acme, 10), "the tenant is found in the process tags")
+	assert.Equal(t, 2, countAllowed(l, newSpan("payments"), 10), "the rejected spans do not use the service credits")
+	assert.Equal(t, 2, countAllowed(l, newSpan("shipping", model.String("tenant", "other")), 10))
+	assert.Equal(t, 2, countAllowed(l, newSpan("mail", model.Int64("tenant", 1)), 10))
+}
+
+func TestLimiterChargesBothBucketsOrNone(t *testing.T) {
+	limitsFile := writeLimitsFile(t, testLimits)
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, 2, countAllowed(l, newSpan("backend"), 10))
+	assert.Equal(t, 0, countAllowed(l, newSpan("backend", model.String("tenant", "acme")), 10))
+	assert.Equal(t, 1, countAllowed(l, newSpan("billing", model.String("tenant", "acme")), 10),
+		"the spans rejected by their service do not use the tenant credits")
+}
+
+func TestLimiterWithoutDefault(t *testing.T) {
+	limitsFile := writeLimitsFile(t, "services: {frontend: {spans-per-second: 0.001, burst: 1}}")
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, 1, countAllowed(l, newSpan("frontend"), 10))
+	assert.Equal(t, 10, countAllowed(l, newSpan("backend"), 10))
+	assert.Equal(t, 10, countAllowed(l, newSpan("backend", model.String("tenant", "acme")), 10))
+}
+
+func TestLimiterOverflowBucket(t *testing.T) {
+	limitsFile := writeLimitsFile(t, "default: {spans-per-second: 0.001, burst: 1}")
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	for i := 0; i < maxBuckets; i++ {
+		require.True(t, l.Allow(newSpan(fmt.Sprintf("svc-%d", i))))
+	}
+	assert.True(t, l.Allow(newSpan("one-too-many")))
+	assert.False(t, l.Allow(newSpan("two-too-many")), "the services above the cap share a bucket")
+	assert.Len(t, l.buckets, maxBuckets+1)
+}
+
+func TestLimiterEvictsIdleBuckets(t *testing.T) {
+	limitsFile := writeLimitsFile(t, "default: {spans-per-second: 1, burst: 1}")
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < maxBuckets; i++ {
+		require.True(t, l.Allow(newSpan(fmt.Sprintf("svc-%d", i))))
+	}
+	assert.True(t, l.Allow(newSpan("one-too-many")))
+	assert.False(t, l.Allow(newSpan("two-too-many")))
+	assert.Len(t, l.buckets, maxBuckets+1)
+
+	// the buckets refilled to their burst are evicted, the new services get their own bucket
+	now = now.Add(time.Second)
+	assert.True(t, l.Allow(newSpan("new-service")))
+	assert.False(t, l.Allow(newSpan("new-service")))
+	assert.True(t, l.Allow(newSpan("another-service")))
+	assert.Len(t, l.buckets, 2)
+}
+
+func TestLimiterErrors(t *testing.T) {
+	_, err := NewLimiter("does-not-exist.yaml", 0, zap.NewNop())
+	assert.EqualError(t, err, "failed to read rate limits file does-not-exist.yaml: open does-not-exist.yaml: no such file or directory")
+
+	tests := []struct {
+		limits string
+		err    string
+	}{
+		{
+			limits: "services: []",
+			err:    "failed to unmarshal rate limits: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into map[string]ratelimit.limitConfig",
+		},
+		{
+			limits: "default: {spans-per-second: 1, unknown: true}",
+			err:    "failed to unmarshal rate limits: yaml: unmarshal errors:\n  line 1: field unknown not found in type ratelimit.limitConfig",
+		},
+		{
+			limits: "default: {burst: 1}",
+			err:    "invalid default rate limit: spans-per-second must be positive",
+		},
+		{
+			limits: "services: {frontend: {spans-per-second: 1, burst: 0.5}}",
+			err:    "invalid rate limit of service frontend: burst must be at least 1",
+		},
+		{
+			limits: "tenants: {acme: {spans-per-second: 1}}",
+			err:    "the tenant rate limits require a tenant-tag",
+		},
+		{
+			limits: "tenant-tag: tenant\ntenants: {acme: {spans-per-second: -1}}",
+			err:    "invalid rate limit of tenant acme: spans-per-second must be positive",
+		},
+	}
+	for _, test := range tests {
+		_, err := parseLimits([]byte(test.limits))
+		assert.EqualError(t, err, test.err, test.limits)
+	}
+}
+
+func TestLimiterReload(t *testing.T) {
+	limitsFile := writeLimitsFile(t, "default: {spans-per-second: 0.001, burst: 1}")
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 10*time.Millisecond, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.True(t, l.Allow(newSpan("frontend")))
+	assert.False(t, l.Allow(newSpan("frontend")))
+
+	require.NoError(t, ioutil.WriteFile(limitsFile, []byte("services: {}"), 0600))
+	for i := 0; i < 100 && l.limits.Load().(*limitsConfig).Default != nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, l.Allow(newSpan("frontend")))
+}
+
+func TestLimiterReloadUpdatesBuckets(t *testing.T) {
+	limitsFile := writeLimitsFile(t, "default: {spans-per-second: 0.001, burst: 2}")
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	assert.True(t, l.Allow(newSpan("frontend")))
+
+	require.NoError(t, ioutil.WriteFile(limitsFile, []byte("default: {spans-per-second: 0.001, burst: 4}"), 0600))
+	l.reloader.Reload()
+	// the remaining balance of 1 out of 2 is pro-rated to 2 out of 4
+	assert.Equal(t, 2, countAllowed(l, newSpan("frontend"), 10))
+	assert.Equal(t, limitConfig{SpansPerSecond: 0.001, Burst: 4}, l.buckets[servicePrefix+"frontend"].limit)
+}
+
+func TestLimiterReloadErrors(t *testing.T) {
+	limitsFile := writeLimitsFile(t, "default: {spans-per-second: 0.001, burst: 1}")
+	defer os.Remove(limitsFile)
+	l, err := NewLimiter(limitsFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer l.Close()
+
+	require.NoError(t, ioutil.WriteFile(limitsFile, []byte("default: {burst: 1}"), 0600))
+	l.reloader.Reload()
+	assert.NotNil(t, l.limits.Load().(*limitsConfig).Default)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker counts the consecutive failed writes, and opens once they reach the threshold.
+// While open, the writes are rejected for the open duration, then a single write is let through
+// to probe the storage: its success closes the circuit breaker, and its failure opens it again.
+type circuitBreaker struct {
+	threshold     uint
+	openDuration  time.Duration
+	onStateChange func(open bool)
+	now           func() time.Time
+
+	lock      sync.Mutex
+	failures  uint
+	open      bool
+	openUntil time.Time
+	// probing is set while the write probing the storage after the open duration is in flight
+	probing bool
+}
+
+func newCircuitBreaker(threshold uint, openDuration time.Duration, onStateChange func(open bool)) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:     threshold,
+		openDuration:  openDuration,
+		onStateChange: onStateChange,
+		now:           time.Now,
+	}
+}
+
+// allow returns false if the writes are rejected
+func (b *circuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.open {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures = 0
+	b.probing = false
+	if b.open {
+		b.open = false
+		b.onStateChange(false)
+	}
+}
+
+func (b *circuitBreaker) failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures++
+	b.probing = false
+	if b.open {
+		b.openUntil = b.now().Add(b.openDuration)
+	} else if b.threshold > 0 && b.failures >= b.threshold {
+		b.open = true
+		b.openUntil = b.now().Add(b.openDuration)
+		b.onStateChange(true)
+	}
+}
+
+// release lets another write probe the storage, after a write that did not reach it, e.g. because of its context
+func (b *circuitBreaker) release() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.probing = false
+}
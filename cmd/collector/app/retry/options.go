@@ -0,0 +1,82 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	storageRetryMaxRetries       = "collector.storage-retry.max-retries"
+	storageRetryMinBackoff       = "collector.storage-retry.min-backoff"
+	storageRetryMaxBackoff       = "collector.storage-retry.max-backoff"
+	storageRetryBreakerThreshold = "collector.storage-retry.circuit-breaker-threshold"
+	storageRetryBreakerOpen      = "collector.storage-retry.circuit-breaker-open-duration"
+)
+
+// Options holds configuration for the retries of the failed storage writes of the collector.
+type Options struct {
+	// MaxRetries is the maximum number of times a failed write is retried, zero disables retrying
+	MaxRetries uint
+	// MinBackoff is the upper bound of the randomized backoff before the first retry, it doubles with each retry
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum backoff between retries
+	MaxBackoff time.Duration
+	// BreakerThreshold is the number of consecutive failed writes opening the circuit breaker,
+	// zero disables the circuit breaker
+	BreakerThreshold uint
+	// BreakerOpenDuration is the time the writes are rejected once the circuit breaker is open
+	BreakerOpenDuration time.Duration
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Uint(storageRetryMaxRetries, 0, "The maximum number of times a span failing to be written to the storage is retried. "+
+		"Zero value disables retrying")
+	flagSet.Duration(storageRetryMinBackoff, 100*time.Millisecond, "The upper bound of the randomized backoff before the first retry, it doubles with each retry")
+	flagSet.Duration(storageRetryMaxBackoff, 5*time.Second, "The maximum backoff between retries")
+	flagSet.Uint(storageRetryBreakerThreshold, 0, "The number of consecutive failed storage writes after which the writes are rejected "+
+		"and the collector health is degraded. Zero value disables the circuit breaker")
+	flagSet.Duration(storageRetryBreakerOpen, 30*time.Second, "The time the storage writes are rejected before trying the storage again")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opts *Options) InitFromViper(v *viper.Viper) *Options {
+	opts.MaxRetries = v.GetUint(storageRetryMaxRetries)
+	opts.MinBackoff = v.GetDuration(storageRetryMinBackoff)
+	opts.MaxBackoff = v.GetDuration(storageRetryMaxBackoff)
+	opts.BreakerThreshold = v.GetUint(storageRetryBreakerThreshold)
+	opts.BreakerOpenDuration = v.GetDuration(storageRetryBreakerOpen)
+	return opts
+}
+
+// Enabled returns true if the failed writes are retried or the circuit breaker is enabled
+func (opts Options) Enabled() bool {
+	return opts.MaxRetries > 0 || opts.BreakerThreshold > 0
+}
+
+func (opts Options) validate() error {
+	if opts.MaxRetries > 0 && (opts.MinBackoff <= 0 || opts.MaxBackoff < opts.MinBackoff) {
+		return errors.New("the storage retry backoffs must be positive, and the max backoff at least the min backoff")
+	}
+	if opts.BreakerThreshold > 0 && opts.BreakerOpenDuration <= 0 {
+		return errors.New("the storage circuit breaker open duration must be positive")
+	}
+	return nil
+}
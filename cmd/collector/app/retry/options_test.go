@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.storage-retry.max-retries=5",
+		"--collector.storage-retry.min-backoff=10ms",
+		"--collector.storage-retry.circuit-breaker-threshold=100",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{
+		MaxRetries:          5,
+		MinBackoff:          10 * time.Millisecond,
+		MaxBackoff:          5 * time.Second,
+		BreakerThreshold:    100,
+		BreakerOpenDuration: 30 * time.Second,
+	}, *opts)
+	assert.True(t, opts.Enabled())
+	assert.False(t, Options{}.Enabled())
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// ErrCircuitOpen is returned for the writes rejected while the storage circuit breaker is open
+var ErrCircuitOpen = errors.New("storage circuit breaker is open")
+
+// Writer is a spanstore.Writer retrying the failed writes of the underlying writer with an exponential
+// backoff with jitter. The errors which are not retryable according to spanstore.IsRetryable fail immediately.
+//
+// Its circuit breaker rejects the writes once the storage keeps failing, and degrades the health of the collector
+// until the storage recovers.
+type Writer struct {
+	writer  spanstore.Writer
+	options Options
+	breaker *circuitBreaker
+	hCheck  *healthcheck.HealthCheck
+	logger  *zap.Logger
+	metrics *writerMetrics
+}
+
+type writerMetrics struct {
+	// Number of retried writes
+	Retries metrics.Counter `metric:"retries"`
+	// Number of writes which still failed after all the retries
+	RetriesExhausted metrics.Counter `metric:"retries-exhausted"`
+	// Number of writes which failed with an error which is not retryable
+	PermanentErrors metrics.Counter `metric:"permanent-errors"`
+	// Number of writes rejected by the open circuit breaker
+	RejectedWrites metrics.Counter `metric:"rejected-writes"`
+	// Number of times the circuit breaker opened
+	BreakerOpened metrics.Counter `metric:"circuit-breaker-opened"`
+	// 1 while the circuit breaker is open, 0 otherwise
+	BreakerOpen metrics.Gauge `metric:"circuit-breaker-open"`
+}
+
+// batchWriter is the Writer of the spanstore.BatchWriters, it retries the failed batches as a whole
+type batchWriter struct {
+	*Writer
+	batchWriter spanstore.BatchWriter
+}
+
+// NewWriter creates a Writer retrying the failed writes of writer, which is also a spanstore.BatchWriter
+// if writer is one. The health check is degraded while the circuit breaker is open, it can be nil.
+func NewWriter(writer spanstore.Writer, opts Options, hCheck *healthcheck.HealthCheck, metricsFactory metrics.Factory, logger *zap.Logger) (spanstore.Writer, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	wm := &writerMetrics{}
+	metrics.MustInit(wm, metricsFactory.Namespace(metrics.NSOptions{Name: "storage-retry", Tags: nil}), nil)
+	w := &Writer{
+		writer:  writer,
+		options: opts,
+		hCheck:  hCheck,
+		logger:  logger,
+		metrics: wm,
+	}
+	w.breaker = newCircuitBreaker(opts.BreakerThreshold, opts.BreakerOpenDuration, w.onBreakerStateChange)
+	if bw, ok := writer.(spanstore.BatchWriter); ok {
+		return &batchWriter{Writer: w, batchWriter: bw}, nil
+	}
+	return w, nil
+}
+
+// WriteSpan writes the span to the underlying writer, retrying the failures
+func (w *Writer) WriteSpan(ctx context.Context, span *model.Span) error {
+	return w.write(ctx, func() error {
+		return w.writer.WriteSpan(ctx, span)
+	})
+}
+
+// WriteSpans writes the spans to the underlying writer, retrying the failures of the whole batch
+func (w *batchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	return w.write(ctx, func() error {
+		return w.batchWriter.WriteSpans(ctx, spans)
+	})
+}
+
+func (w *Writer) write(ctx context.Context, write func() error) error {
+	for attempt := uint(0); ; attempt++ {
+		if !w.breaker.allow() {
+			w.metrics.RejectedWrites.Inc(1)
+			return ErrCircuitOpen
+		}
+		err := write()
+		if err == nil {
+			w.breaker.success()
+			return nil
+		}
+		if !spanstore.IsRetryable(err) {
+			// the storage is reachable, only the write is rejected
+			w.metrics.PermanentErrors.Inc(1)
+			w.breaker.release()
+			return err
+		}
+		w.breaker.failure()
+		if attempt >= w.options.MaxRetries {
+			if w.options.MaxRetries > 0 {
+				w.metrics.RetriesExhausted.Inc(1)
+			}
+			return err
+		}
+		select {
+		case <-time.After(w.backoff(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+		w.metrics.Retries.Inc(1)
+	}
+}
+
+func (w *Writer) backoff(attempt uint) time.Duration {
+	dur := (1 << attempt) * w.options.MinBackoff.Nanoseconds()
+	if dur <= 0 || dur > w.options.MaxBackoff.Nanoseconds() {
+		dur = w.options.MaxBackoff.Nanoseconds()
+	}
+	return time.Duration(rand.Int63n(dur))
+}
+
+func (w *Writer) onBreakerStateChange(open bool) {
+	if open {
+		w.logger.Warn("Storage writes keep failing, rejecting the writes", zap.Duration("open-duration", w.options.BreakerOpenDuration))
+		w.metrics.BreakerOpened.Inc(1)
+		w.metrics.BreakerOpen.Update(1)
+	} else {
+		w.logger.Info("Storage writes succeed again")
+		w.metrics.BreakerOpen.Update(0)
+	}
+	if w.hCheck == nil {
+		return
+	}
+	// the health is only degraded while the collector is otherwise ready
+	if status := w.hCheck.Get(); open && status == healthcheck.Ready {
+		w.hCheck.Set(healthcheck.Degraded)
+	} else if !open && status == healthcheck.Degraded {
+		w.hCheck.Set(healthcheck.Ready)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+	"github.com/jaegertracing/jaeger/storage/spanstore/mocks"
+)
+
+var (
+	errUnavailable = errors.New("storage unavailable")
+	testSpan       = &model.Span{OperationName: "op"}
+)
+
+func testOptions() Options {
+	return Options{
+		MaxRetries:          3,
+		MinBackoff:          time.Millisecond,
+		MaxBackoff:          2 * time.Millisecond,
+		BreakerThreshold:    8,
+		BreakerOpenDuration: time.Hour,
+	}
+}
+
+func newTestWriter(t *testing.T, opts Options, hCheck *healthcheck.HealthCheck) (*Writer, *mocks.Writer, *metricstest.Factory) {
+	spanWriter := &mocks.Writer{}
+	mFact := metricstest.NewFactory(0)
+	w, err := NewWriter(spanWriter, opts, hCheck, mFact, zap.NewNop())
+	require.NoError(t, err)
+	return w.(*Writer), spanWriter, mFact
+}
+
+func TestWriterRetries(t *testing.T) {
+	w, spanWriter, mFact := newTestWriter(t, testOptions(), nil)
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(errUnavailable).Twice()
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(nil).Once()
+
+	assert.NoError(t, w.WriteSpan(context.Background(), testSpan))
+	spanWriter.AssertNumberOfCalls(t, "WriteSpan", 3)
+	mFact.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "storage-retry.retries", Value: 2})
+}
+
+type fakeBatchWriter struct {
+	*mocks.Writer
+	batches int
+	err     error
+}
+
+func (w *fakeBatchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	w.batches++
+	if w.batches == 1 {
+		return w.err
+	}
+	return nil
+}
+
+func TestWriterRetriesBatches(t *testing.T) {
+	fake := &fakeBatchWriter{Writer: &mocks.Writer{}, err: errUnavailable}
+	w, err := NewWriter(fake, testOptions(), nil, metricstest.NewFactory(0), zap.NewNop())
+	require.NoError(t, err)
+	batchWriter, ok := w.(spanstore.BatchWriter)
+	require.True(t, ok)
+
+	assert.NoError(t, batchWriter.WriteSpans(context.Background(), []*model.Span{testSpan, testSpan}))
+	assert.Equal(t, 2, fake.batches, "the whole batch is retried")
+
+	w, err = NewWriter(&mocks.Writer{}, testOptions(), nil, metricstest.NewFactory(0), zap.NewNop())
+	require.NoError(t, err)
+	_, ok = w.(spanstore.BatchWriter)
+	assert.False(t, ok)
+}
+
+func TestWriterRetriesExhausted(t *testing.T) {
+	w, spanWriter, mFact := newTestWriter(t, testOptions(), nil)
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(errUnavailable)
+
+	assert.Equal(t, errUnavailable, w.WriteSpan(context.Background(), testSpan))
+	spanWriter.AssertNumberOfCalls(t, "WriteSpan", 4)
+	mFact.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "storage-retry.retries", Value: 3},
+		metricstest.ExpectedMetric{Name: "storage-retry.retries-exhausted", Value: 1})
+}
+
+func TestWriterPermanentError(t *testing.T) {
+	w, spanWriter, mFact := newTestWriter(t, testOptions(), nil)
+	permanent := spanstore.PermanentError{Err: errors.New("invalid span")}
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(permanent)
+
+	assert.Equal(t, permanent, w.WriteSpan(context.Background(), testSpan))
+	spanWriter.AssertNumberOfCalls(t, "WriteSpan", 1)
+	mFact.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "storage-retry.permanent-errors", Value: 1},
+		metricstest.ExpectedMetric{Name: "storage-retry.retries", Value: 0})
+}
+
+func TestWriterContextDone(t *testing.T) {
+	opts := testOptions()
+	opts.MinBackoff = time.Hour
+	opts.MaxBackoff = time.Hour
+	w, spanWriter, _ := newTestWriter(t, opts, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(errUnavailable).Run(func(mock.Arguments) {
+		cancel()
+	})
+
+	assert.Equal(t, errUnavailable, w.WriteSpan(ctx, testSpan), "the backoff is interrupted")
+	spanWriter.AssertNumberOfCalls(t, "WriteSpan", 1)
+}
+
+func TestWriterCircuitBreaker(t *testing.T) {
+	hCheck := healthcheck.New()
+	hCheck.Ready()
+	opts := testOptions()
+	opts.MaxRetries = 1
+	opts.BreakerThreshold = 3
+	w, spanWriter, mFact := newTestWriter(t, opts, hCheck)
+	now := time.Now()
+	w.breaker.now = func() time.Time { return now }
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(errUnavailable).Times(4)
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(nil)
+
+	assert.Equal(t, errUnavailable, w.WriteSpan(context.Background(), testSpan))
+	assert.Equal(t, healthcheck.Ready, hCheck.Get())
+	// the third consecutive failure opens the circuit breaker, the retry is rejected
+	assert.Equal(t, ErrCircuitOpen, w.WriteSpan(context.Background(), testSpan))
+	assert.Equal(t, healthcheck.Degraded, hCheck.Get())
+	assert.Equal(t, ErrCircuitOpen, w.WriteSpan(context.Background(), testSpan))
+	spanWriter.AssertNumberOfCalls(t, "WriteSpan", 3)
+
+	// a failure after the open duration opens the circuit breaker again
+	now = now.Add(opts.BreakerOpenDuration)
+	assert.Equal(t, ErrCircuitOpen, w.WriteSpan(context.Background(), testSpan))
+	spanWriter.AssertNumberOfCalls(t, "WriteSpan", 4)
+	assert.Equal(t, healthcheck.Degraded, hCheck.Get())
+
+	now = now.Add(opts.BreakerOpenDuration)
+	assert.NoError(t, w.WriteSpan(context.Background(), testSpan))
+	assert.Equal(t, healthcheck.Ready, hCheck.Get())
+
+	mFact.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "storage-retry.circuit-breaker-opened", Value: 1},
+		metricstest.ExpectedMetric{Name: "storage-retry.rejected-writes", Value: 3})
+	mFact.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "storage-retry.circuit-breaker-open", Value: 0})
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	b := newCircuitBreaker(1, time.Minute, func(open bool) {})
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	b.failure()
+	assert.False(t, b.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow(), "a write probes the storage after the open duration")
+	assert.False(t, b.allow(), "the other writes are rejected during the probe")
+	b.failure()
+	assert.False(t, b.allow(), "the failed probe opens the circuit breaker again")
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	b.release()
+	assert.True(t, b.allow(), "another write probes the storage after a write that did not reach it")
+	b.success()
+	assert.True(t, b.allow())
+	assert.True(t, b.allow(), "the writes are let through once the probe succeeded")
+}
+
+func TestWriterCircuitBreakerKeepsUnavailableHealth(t *testing.T) {
+	hCheck := healthcheck.New()
+	opts := testOptions()
+	opts.MaxRetries = 0
+	opts.BreakerThreshold = 1
+	w, spanWriter, _ := newTestWriter(t, opts, hCheck)
+	spanWriter.On("WriteSpan", mock.Anything, testSpan).Return(errUnavailable)
+
+	assert.Equal(t, errUnavailable, w.WriteSpan(context.Background(), testSpan))
+	assert.Equal(t, ErrCircuitOpen, w.WriteSpan(context.Background(), testSpan))
+	assert.Equal(t, healthcheck.Unavailable, hCheck.Get())
+}
+
+func TestWriterBackoff(t *testing.T) {
+	w, _, _ := newTestWriter(t, Options{MaxRetries: 100, MinBackoff: time.Second, MaxBackoff: time.Minute}, nil)
+	for attempt := uint(0); attempt < 100; attempt++ {
+		backoff := w.backoff(attempt)
+		assert.True(t, backoff >= 0 && backoff < time.Minute)
+		if attempt == 0 {
+			assert.True(t, backoff < time.Second)
+		}
+	}
+}
+
+func TestNewWriterErrors(t *testing.T) {
+	tests := []struct {
+		opts Options
+		err  string
+	}{
+		{
+			opts: Options{MaxRetries: 1, MinBackoff: time.Second},
+			err:  "the storage retry backoffs must be positive, and the max backoff at least the min backoff",
+		},
+		{
+			opts: Options{BreakerThreshold: 1},
+			err:  "the storage circuit breaker open duration must be positive",
+		},
+	}
+	for _, test := range tests {
+		_, err := NewWriter(&mocks.Writer{}, test.opts, nil, metricstest.NewFactory(0), zap.NewNop())
+		assert.EqualError(t, err, test.err)
+	}
+}
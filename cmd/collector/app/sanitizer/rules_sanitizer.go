@@ -0,0 +1,307 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sanitizer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/config/filereloader"
+)
+
+const (
+	deleteAction   = "delete"
+	hashAction     = "hash"
+	replaceAction  = "replace"
+	truncateAction = "truncate"
+
+	tagsField        = "tags"
+	processTagsField = "process-tags"
+	logsField        = "logs"
+	operationField   = "operation"
+)
+
+// rulesConfig is the format of the rules file, e.g.
+//
+//	hash-key: "a long random secret"
+//	rules:
+//	  - service: frontend
+//	    key: http.url
+//	    action: replace
+//	    pattern: "token=[^&]*"
+//	    replacement: "token=REDACTED"
+//	  - key: user.email
+//	    fields: [tags, process-tags, logs]
+//	    action: hash
+//
+// The service and key are regular expressions matching the whole service name and tag key,
+// a rule without service applies to all the services and a rule without key to all the tags.
+// The rules apply to the span tags and logs unless fields says otherwise, in the order of the file.
+// The hash action replaces the values with their HMAC-SHA256 keyed by hash-key, so that the values
+// cannot be recovered by hashing guesses without the key, which is required by the hash rules.
+type rulesConfig struct {
+	HashKey string       `yaml:"hash-key"`
+	Rules   []ruleConfig `yaml:"rules"`
+}
+
+type ruleConfig struct {
+	Service     string   `yaml:"service"`
+	Key         string   `yaml:"key"`
+	Fields      []string `yaml:"fields"`
+	Action      string   `yaml:"action"`
+	Pattern     string   `yaml:"pattern"`
+	Replacement string   `yaml:"replacement"`
+	MaxLength   int      `yaml:"max-length"`
+}
+
+type rule struct {
+	service     *regexp.Regexp // nil matches all the services
+	key         *regexp.Regexp // nil matches all the keys
+	tags        bool
+	processTags bool
+	logs        bool
+	operation   bool
+	action      string
+	pattern     *regexp.Regexp
+	replacement string
+	maxLength   int
+	hashKey     []byte
+}
+
+// RulesSanitizer deletes, hashes, replaces or truncates the tags, logs and operation names of the spans
+// according to the rules of a YAML file, which can be reloaded periodically.
+type RulesSanitizer struct {
+	logger *zap.Logger
+	rules  atomic.Value // holds []*rule
+
+	reloader *filereloader.Reloader
+}
+
+// NewRulesSanitizer creates a RulesSanitizer with the rules of the file, reloaded at the given interval if positive.
+func NewRulesSanitizer(rulesFile string, reloadInterval time.Duration, logger *zap.Logger) (*RulesSanitizer, error) {
+	s := &RulesSanitizer{logger: logger}
+	reloader, err := filereloader.New(rulesFile, "sanitizer rules", reloadInterval, s.updateRules, logger)
+	if err != nil {
+		return nil, err
+	}
+	s.reloader = reloader
+	return s, nil
+}
+
+func (s *RulesSanitizer) updateRules(content []byte) error {
+	rules, err := parseRules(content)
+	if err != nil {
+		return err
+	}
+	s.rules.Store(rules)
+	return nil
+}
+
+func parseRules(content []byte) ([]*rule, error) {
+	var config rulesConfig
+	if err := yaml.UnmarshalStrict(content, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sanitizer rules: %w", err)
+	}
+	rules := make([]*rule, len(config.Rules))
+	for i, c := range config.Rules {
+		r, err := newRule(c, []byte(config.HashKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sanitizer rule #%d: %w", i+1, err)
+		}
+		rules[i] = r
+	}
+	return rules, nil
+}
+
+func newRule(c ruleConfig, hashKey []byte) (*rule, error) {
+	r := &rule{
+		action:      c.Action,
+		replacement: c.Replacement,
+		maxLength:   c.MaxLength,
+	}
+	var err error
+	if r.service, err = compileAnchored(c.Service); err != nil {
+		return nil, fmt.Errorf("invalid service: %w", err)
+	}
+	if r.key, err = compileAnchored(c.Key); err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	fields := c.Fields
+	if len(fields) == 0 {
+		fields = []string{tagsField, logsField}
+	}
+	for _, field := range fields {
+		switch field {
+		case tagsField:
+			r.tags = true
+		case processTagsField:
+			r.processTags = true
+		case logsField:
+			r.logs = true
+		case operationField:
+			r.operation = true
+		default:
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+	}
+
+	switch c.Action {
+	case deleteAction:
+		if r.operation {
+			return nil, fmt.Errorf("the operation name cannot be deleted")
+		}
+	case hashAction:
+		if len(hashKey) == 0 {
+			return nil, fmt.Errorf("the hash action requires the hash-key of the rules file")
+		}
+		r.hashKey = hashKey
+	case replaceAction:
+		if c.Pattern == "" {
+			return nil, fmt.Errorf("the replace action requires a pattern")
+		}
+		if r.pattern, err = regexp.Compile(c.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	case truncateAction:
+		if c.MaxLength <= 0 {
+			return nil, fmt.Errorf("the truncate action requires a positive max-length")
+		}
+	default:
+		return nil, fmt.Errorf("unknown action %q", c.Action)
+	}
+	return r, nil
+}
+
+func compileAnchored(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + expr + ")$")
+}
+
+// Close stops reloading the rules
+func (s *RulesSanitizer) Close() error {
+	return s.reloader.Close()
+}
+
+// Sanitize applies the rules matching the service of the span.
+func (s *RulesSanitizer) Sanitize(span *model.Span) *model.Span {
+	var serviceName string
+	if span.Process != nil {
+		serviceName = span.Process.ServiceName
+	}
+	for _, r := range s.rules.Load().([]*rule) {
+		if r.service != nil && !r.service.MatchString(serviceName) {
+			continue
+		}
+		if r.operation {
+			span.OperationName = r.apply(span.OperationName)
+		}
+		if r.tags {
+			span.Tags = r.sanitizeKeyValues(span.Tags)
+		}
+		if r.logs {
+			for i := range span.Logs {
+				span.Logs[i].Fields = r.sanitizeKeyValues(span.Logs[i].Fields)
+			}
+		}
+		if r.processTags && span.Process != nil {
+			if tags := r.sanitizeKeyValues(span.Process.Tags); !sameKeyValues(tags, span.Process.Tags) {
+				// the process can be shared by the spans of a batch, it is copied to be sanitized once per span
+				process := *span.Process
+				process.Tags = tags
+				span.Process = &process
+			}
+		}
+	}
+	return span
+}
+
+// sanitizeKeyValues returns the sanitized key values, the slice is only copied if a key value is changed.
+func (r *rule) sanitizeKeyValues(kvs model.KeyValues) model.KeyValues {
+	var result model.KeyValues
+	for i, kv := range kvs {
+		sanitized, keep := kv, true
+		if r.key == nil || r.key.MatchString(kv.Key) {
+			sanitized, keep = r.sanitizeKeyValue(kv)
+		}
+		if result == nil {
+			if keep && sanitized.Equal(&kv) {
+				continue
+			}
+			result = make(model.KeyValues, i, len(kvs))
+			copy(result, kvs[:i])
+		}
+		if keep {
+			result = append(result, sanitized)
+		}
+	}
+	if result == nil {
+		return kvs
+	}
+	return result
+}
+
+// sanitizeKeyValue returns the sanitized key value, or false if it is deleted
+func (r *rule) sanitizeKeyValue(kv model.KeyValue) (model.KeyValue, bool) {
+	switch r.action {
+	case deleteAction:
+		return kv, false
+	case hashAction:
+		return model.String(kv.Key, r.apply(kv.AsString())), true
+	}
+	if kv.VType != model.StringType {
+		return kv, true
+	}
+	return model.String(kv.Key, r.apply(kv.VStr)), true
+}
+
+// apply applies the hash, replace or truncate action to a string
+func (r *rule) apply(value string) string {
+	switch r.action {
+	case hashAction:
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))
+	case replaceAction:
+		return r.pattern.ReplaceAllString(value, r.replacement)
+	case truncateAction:
+		if len(value) <= r.maxLength {
+			return value
+		}
+		end := r.maxLength
+		// do not cut a multi-byte character
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		return value[:end]
+	}
+	return value
+}
+
+func sameKeyValues(a, b model.KeyValues) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
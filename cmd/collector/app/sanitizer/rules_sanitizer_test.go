@@ -0,0 +1,236 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sanitizer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+const testHashKey = "test-key"
+
+const testRules = `
+hash-key: test-key
+rules:
+  - service: frontend
+    key: http.url
+    action: replace
+    pattern: "token=[^&]*"
+    replacement: "token=REDACTED"
+  - key: user\.email
+    fields: [tags, process-tags, logs]
+    action: hash
+  - key: db.statement
+    action: truncate
+    max-length: 8
+  - service: "back.*"
+    key: password|secret
+    action: delete
+  - service: frontend
+    fields: [operation]
+    action: replace
+    pattern: "/users/[0-9]+"
+    replacement: "/users/{id}"
+`
+
+func writeRulesFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "sanitizer-rules-*.yaml")
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
+
+func hash(value string) string {
+	return (&rule{action: hashAction, hashKey: []byte(testHashKey)}).apply(value)
+}
+
+func TestRulesSanitizer(t *testing.T) {
+	rulesFile := writeRulesFile(t, testRules)
+	defer os.Remove(rulesFile)
+	s, err := NewRulesSanitizer(rulesFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer s.Close()
+
+	process := model.NewProcess("frontend", []model.KeyValue{model.String("hostname", "h"), model.String("user.email", "a@b.c")})
+	span := s.Sanitize(&model.Span{
+		OperationName: "GET /users/42",
+		Process:       process,
+		Tags: model.KeyValues{
+			model.String("http.url", "/api?token=secret&page=1"),
+			model.String("db.statement", "SELECT * FROM users WHERE email = 'a@b.c'"),
+			model.String("password", "kept for frontend"),
+			model.Int64("user.email", 42),
+		},
+		Logs: []model.Log{{Fields: model.KeyValues{model.String("event", "login"), model.String("user.email", "a@b.c")}}},
+	})
+
+	assert.Equal(t, "GET /users/{id}", span.OperationName)
+	assert.Equal(t, []model.KeyValue{
+		model.String("http.url", "/api?token=REDACTED&page=1"),
+		model.String("db.statement", "SELECT *"),
+		model.String("password", "kept for frontend"),
+		model.String("user.email", hash("42")),
+	}, span.Tags)
+	assert.Equal(t, []model.KeyValue{model.String("event", "login"), model.String("user.email", hash("a@b.c"))}, span.Logs[0].Fields)
+	assert.Equal(t, []model.KeyValue{model.String("hostname", "h"), model.String("user.email", hash("a@b.c"))}, span.Process.Tags)
+	assert.Equal(t, model.String("user.email", "a@b.c"), process.Tags[1], "the shared process is not modified")
+
+	span = s.Sanitize(&model.Span{
+		OperationName: "GET /users/42",
+		Process:       model.NewProcess("backend", nil),
+		Tags: model.KeyValues{
+			model.String("password", "p"),
+			model.String("http.url", "/api?token=secret"),
+			model.String("secret", "s"),
+		},
+	})
+	assert.Equal(t, "GET /users/42", span.OperationName)
+	assert.Equal(t, []model.KeyValue{model.String("http.url", "/api?token=secret")}, span.Tags)
+}
+
+func TestRulesSanitizerKeepsUnchangedSlices(t *testing.T) {
+	r, err := newRule(ruleConfig{Key: "password", Action: deleteAction}, nil)
+	require.NoError(t, err)
+	tags := model.KeyValues{model.String("a", "b"), model.String("c", "d")}
+	assert.True(t, sameKeyValues(tags, r.sanitizeKeyValues(tags)))
+	assert.Empty(t, r.sanitizeKeyValues(model.KeyValues{model.String("password", "p")}))
+}
+
+func TestRulesSanitizerHash(t *testing.T) {
+	r, err := newRule(ruleConfig{Action: hashAction}, []byte("key"))
+	require.NoError(t, err)
+	// HMAC-SHA256 of "value" keyed by "key"
+	assert.Equal(t, "90fbfcf15e74a36b89dbdb2a721d9aecffdfdddc5c83e27f7592594f71932481", r.apply("value"))
+
+	other, err := newRule(ruleConfig{Action: hashAction}, []byte("other key"))
+	require.NoError(t, err)
+	assert.NotEqual(t, r.apply("value"), other.apply("value"))
+}
+
+func TestRulesSanitizerTruncate(t *testing.T) {
+	r, err := newRule(ruleConfig{Action: truncateAction, MaxLength: 4}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", r.apply("abc"))
+	assert.Equal(t, "abcd", r.apply("abcde"))
+	assert.Equal(t, "abé", r.apply("abé"), "the max length is in bytes")
+	assert.Equal(t, "abé", r.apply("abéé"))
+
+	r, err = newRule(ruleConfig{Action: truncateAction, MaxLength: 3}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ab", r.apply("abé"), "a multi-byte character is not cut")
+}
+
+func TestRulesSanitizerErrors(t *testing.T) {
+	_, err := NewRulesSanitizer("does-not-exist.yaml", 0, zap.NewNop())
+	assert.EqualError(t, err, "failed to read sanitizer rules file does-not-exist.yaml: open does-not-exist.yaml: no such file or directory")
+
+	tests := []struct {
+		rules string
+		err   string
+	}{
+		{
+			rules: "rules: {}",
+			err:   "failed to unmarshal sanitizer rules: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!map into []sanitizer.ruleConfig",
+		},
+		{
+			rules: "rules: [{action: hash, unknown: true}]",
+			err:   "failed to unmarshal sanitizer rules: yaml: unmarshal errors:\n  line 1: field unknown not found in type sanitizer.ruleConfig",
+		},
+		{
+			rules: "rules: [{action: scramble}]",
+			err:   `invalid sanitizer rule #1: unknown action "scramble"`,
+		},
+		{
+			rules: "{hash-key: k, rules: [{action: hash}, {action: hash, fields: [baggage]}]}",
+			err:   `invalid sanitizer rule #2: unknown field "baggage"`,
+		},
+		{
+			rules: "rules: [{action: hash, service: '('}]",
+			err:   "invalid sanitizer rule #1: invalid service: error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			rules: "rules: [{action: hash, key: '('}]",
+			err:   "invalid sanitizer rule #1: invalid key: error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			rules: "rules: [{action: hash}]",
+			err:   "invalid sanitizer rule #1: the hash action requires the hash-key of the rules file",
+		},
+		{
+			rules: "rules: [{action: delete, fields: [operation]}]",
+			err:   "invalid sanitizer rule #1: the operation name cannot be deleted",
+		},
+		{
+			rules: "rules: [{action: replace}]",
+			err:   "invalid sanitizer rule #1: the replace action requires a pattern",
+		},
+		{
+			rules: "rules: [{action: replace, pattern: '('}]",
+			err:   "invalid sanitizer rule #1: invalid pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			rules: "rules: [{action: truncate}]",
+			err:   "invalid sanitizer rule #1: the truncate action requires a positive max-length",
+		},
+	}
+	for _, test := range tests {
+		_, err := parseRules([]byte(test.rules))
+		assert.EqualError(t, err, test.err, test.rules)
+	}
+}
+
+func TestRulesSanitizerReload(t *testing.T) {
+	rulesFile := writeRulesFile(t, "rules: [{key: password, action: delete}]")
+	defer os.Remove(rulesFile)
+	s, err := NewRulesSanitizer(rulesFile, 10*time.Millisecond, zap.NewNop())
+	require.NoError(t, err)
+	defer s.Close()
+
+	newSpan := func() *model.Span {
+		return &model.Span{Tags: model.KeyValues{model.String("password", "p"), model.String("token", "t")}}
+	}
+	assert.Equal(t, []model.KeyValue{model.String("token", "t")}, s.Sanitize(newSpan()).Tags)
+
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte("rules: [{key: token, action: delete}]"), 0600))
+	for i := 0; i < 100; i++ {
+		if len(s.Sanitize(newSpan()).Tags) == 1 && s.Sanitize(newSpan()).Tags[0].Key == "password" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, []model.KeyValue{model.String("password", "p")}, s.Sanitize(newSpan()).Tags)
+}
+
+func TestRulesSanitizerReloadErrors(t *testing.T) {
+	rulesFile := writeRulesFile(t, "rules: [{key: password, action: delete}]")
+	defer os.Remove(rulesFile)
+	s, err := NewRulesSanitizer(rulesFile, 0, zap.NewNop())
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte("rules: [{action: scramble}]"), 0600))
+	s.reloader.Reload()
+	assert.Len(t, s.rules.Load().([]*rule), 1)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	"github.com/jaegertracing/jaeger/pkg/recoveryhandler"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+)
+
+// OTLPServerParams to construct the OTLP gRPC and HTTP servers of the Jaeger Collector
+type OTLPServerParams struct {
+	// GRPCTLSConfig secures the OTLP gRPC server, the same way as the api_v2 gRPC server. It is shared rather than
+	// copied, so that closing the options of the collector also stops watching the certificates of the server
+	GRPCTLSConfig *tlscfg.Options
+	GRPCHostPort  string
+	HTTPHostPort  string
+	Handler       *handler.OTLPHandler
+	HealthCheck   *healthcheck.HealthCheck
+	Logger        *zap.Logger
+	OnError       func(error)
+}
+
+// StartOTLPGRPCServer starts the OTLP gRPC server, unless its host:port is not configured
+func StartOTLPGRPCServer(params *OTLPServerParams) (*grpc.Server, error) {
+	if params.GRPCHostPort == "" {
+		params.Logger.Info("Not listening for OTLP gRPC traffic, port not configured")
+		return nil, nil
+	}
+
+	var server *grpc.Server
+	if params.GRPCTLSConfig != nil && params.GRPCTLSConfig.Enabled {
+		tlsCfg, err := params.GRPCTLSConfig.Config(params.Logger)
+		if err != nil {
+			return nil, err
+		}
+		server = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsCfg)))
+	} else {
+		server = grpc.NewServer()
+	}
+
+	listener, err := net.Listen("tcp", params.GRPCHostPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on OTLP gRPC port: %w", err)
+	}
+
+	serveOTLPGRPC(server, listener, params)
+	return server, nil
+}
+
+func serveOTLPGRPC(server *grpc.Server, listener net.Listener, params *OTLPServerParams) {
+	otlpcollector.RegisterTraceServiceServer(server, params.Handler)
+
+	params.Logger.Info("Listening for OTLP gRPC traffic", zap.String("otlp.grpc host-port", params.GRPCHostPort))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			params.Logger.Error("Could not launch OTLP gRPC service", zap.Error(err))
+			if params.OnError != nil {
+				params.OnError(err)
+			}
+		}
+	}()
+}
+
+// StartOTLPHTTPServer starts the OTLP HTTP server, unless its host:port is not configured
+func StartOTLPHTTPServer(params *OTLPServerParams) (*http.Server, error) {
+	if params.HTTPHostPort == "" {
+		params.Logger.Info("Not listening for OTLP HTTP traffic, port not configured")
+		return nil, nil
+	}
+
+	params.Logger.Info("Listening for OTLP HTTP traffic", zap.String("otlp.http host-port", params.HTTPHostPort))
+
+	listener, err := net.Listen("tcp", params.HTTPHostPort)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{Addr: params.HTTPHostPort}
+	serveOTLPHTTP(server, listener, params)
+
+	return server, nil
+}
+
+func serveOTLPHTTP(server *http.Server, listener net.Listener, params *OTLPServerParams) {
+	r := mux.NewRouter()
+	params.Handler.RegisterRoutes(r)
+
+	recoveryHandler := recoveryhandler.NewRecoveryHandler(params.Logger, true)
+	server.Handler = recoveryHandler(r)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			if err != http.ErrServerClosed {
+				params.Logger.Fatal("Could not launch OTLP HTTP server", zap.Error(err))
+			}
+		}
+		params.HealthCheck.Set(healthcheck.Unavailable)
+	}()
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+)
+
+func TestOTLPServersDisabled(t *testing.T) {
+	params := &OTLPServerParams{Logger: zap.NewNop()}
+	grpcServer, err := StartOTLPGRPCServer(params)
+	assert.NoError(t, err)
+	assert.Nil(t, grpcServer)
+	httpServer, err := StartOTLPHTTPServer(params)
+	assert.NoError(t, err)
+	assert.Nil(t, httpServer)
+}
+
+func TestOTLPServersFailToListen(t *testing.T) {
+	params := &OTLPServerParams{
+		GRPCHostPort: ":-1",
+		HTTPHostPort: ":-1",
+		Logger:       zap.NewNop(),
+	}
+	grpcServer, err := StartOTLPGRPCServer(params)
+	assert.Nil(t, grpcServer)
+	assert.EqualError(t, err, "failed to listen on OTLP gRPC port: listen tcp: address -1: invalid port")
+	httpServer, err := StartOTLPHTTPServer(params)
+	assert.Nil(t, httpServer)
+	assert.EqualError(t, err, "listen tcp: address -1: invalid port")
+}
+
+func TestOTLPGRPCServerTLS(t *testing.T) {
+	params := &OTLPServerParams{
+		GRPCTLSConfig: &tlscfg.Options{
+			Enabled:  true,
+			CertPath: "invalid/path",
+		},
+		GRPCHostPort: ":0",
+		Logger:       zap.NewNop(),
+	}
+	// the server fails to start rather than serving plaintext
+	grpcServer, err := StartOTLPGRPCServer(params)
+	assert.Nil(t, grpcServer)
+	assert.Error(t, err)
+}
+
+func TestOTLPGRPCFailServe(t *testing.T) {
+	lis := bufconn.Listen(0)
+	lis.Close()
+	core, logs := observer.New(zap.NewAtomicLevelAt(zapcore.ErrorLevel))
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	logger := zap.New(core)
+	serveOTLPGRPC(grpc.NewServer(), lis, &OTLPServerParams{
+		Handler: handler.NewOTLPHandler(logger, &mockSpanProcessor{}),
+		Logger:  logger,
+		OnError: func(e error) {
+			assert.Equal(t, 1, len(logs.All()))
+			assert.Equal(t, "Could not launch OTLP gRPC service", logs.All()[0].Message)
+			wg.Done()
+		},
+	})
+	wg.Wait()
+}
+
+func TestOTLPServers(t *testing.T) {
+	logger := zap.NewNop()
+	params := &OTLPServerParams{
+		GRPCHostPort: ":0",
+		HTTPHostPort: ":0",
+		Handler:      handler.NewOTLPHandler(logger, &mockSpanProcessor{}),
+		HealthCheck:  healthcheck.New(),
+		Logger:       logger,
+	}
+
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	grpcListener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	serveOTLPGRPC(grpcServer, grpcListener, params)
+
+	conn, err := grpc.Dial(grpcListener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	response, err := otlpcollector.NewTraceServiceClient(conn).Export(context.Background(), &otlpcollector.ExportTraceServiceRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, response)
+
+	httpServer := &http.Server{}
+	httpListener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	serveOTLPHTTP(httpServer, httpListener, params)
+	defer httpServer.Close()
+
+	res, err := http.Post("http://"+httpListener.Addr().String()+handler.OTLPTracesPath, "application/x-protobuf", nil)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/storage"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+)
+
+// CreateDependencyWriter creates the writer of the dependency links with the storage factory,
+// it returns nil if the storage cannot store them
+func CreateDependencyWriter(factory storage.Factory, logger *zap.Logger) (dependencystore.Writer, error) {
+	if writerFactory, ok := factory.(storage.DependencyWriterFactory); ok {
+		writer, err := writerFactory.CreateDependencyWriter()
+		if err != storage.ErrDependencyWriterNotSupported {
+			return writer, err
+		}
+	}
+	logger.Info("The storage cannot store the dependency links, the service graph only generates metrics")
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/storage"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	smocks "github.com/jaegertracing/jaeger/storage/mocks"
+)
+
+type dependencyWriterFactory struct {
+	smocks.Factory
+	writer dependencystore.Writer
+	err    error
+}
+
+func (f *dependencyWriterFactory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	return f.writer, f.err
+}
+
+func TestCreateDependencyWriter(t *testing.T) {
+	writer, err := CreateDependencyWriter(&smocks.Factory{}, zap.NewNop())
+	assert.NoError(t, err)
+	assert.Nil(t, writer)
+
+	writer, err = CreateDependencyWriter(&dependencyWriterFactory{err: storage.ErrDependencyWriterNotSupported}, zap.NewNop())
+	assert.NoError(t, err)
+	assert.Nil(t, writer)
+
+	_, err = CreateDependencyWriter(&dependencyWriterFactory{err: errors.New("made-up error")}, zap.NewNop())
+	assert.EqualError(t, err, "made-up error")
+
+	mockWriter := &mocks.Writer{}
+	writer, err = CreateDependencyWriter(&dependencyWriterFactory{writer: mockWriter}, zap.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, mockWriter, writer)
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/normalizer"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+)
+
+const (
+	// otherServices is the client and server label of the edges above the edges limit
+	otherServices = "other-services"
+
+	// minWait bounds how often the expired spans are discarded, ten times per wait
+	minWait = time.Millisecond
+)
+
+type graphMetrics struct {
+	// Number of client and server spans waiting for their peer
+	PendingEdges metrics.Gauge `metric:"pending-edges"`
+	// Number of client and server spans discarded because their peer was not received in time
+	ExpiredEdges metrics.Counter `metric:"expired-edges"`
+	// Number of client and server spans discarded because too many spans were waiting for their peer
+	DroppedSpans metrics.Counter `metric:"dropped-spans"`
+	// Number of calls reported between other-services
+	OverflowEdges metrics.Counter `metric:"overflow-edges"`
+	// Number of failed writes of the dependency links
+	DependencyWriteErrors metrics.Counter `metric:"dependency-write-errors"`
+}
+
+// Graph pairs the client spans with the server spans of their callees, as they are received by the collector,
+// into edges between services. It generates the call count, error count and latency metrics per edge, and
+// periodically writes the calls between services as dependency links.
+type Graph struct {
+	writer               dependencystore.Writer
+	factory              metrics.Factory
+	metrics              *graphMetrics
+	logger               *zap.Logger
+	wait                 time.Duration
+	maxItems             int
+	maxEdges             int
+	dependenciesInterval time.Duration
+	now                  func() time.Time
+
+	lock         sync.Mutex
+	pending      map[edgeKey]*pendingEdge
+	pendingOrder *list.List
+	edges        map[edgeServices]*edgeMetrics
+	links        map[edgeServices]uint64 // the calls since the dependency links were last written
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// edgeKey identifies an edge by the span ID of the client span, which is the parent of the server span
+type edgeKey struct {
+	traceID model.TraceID
+	spanID  model.SpanID
+}
+
+type edgeServices struct {
+	client string
+	server string
+}
+
+type pendingEdge struct {
+	key       edgeKey
+	services  edgeServices
+	hasClient bool
+	hasServer bool
+	latency   time.Duration
+	failed    bool
+	expiresAt time.Time
+	element   *list.Element
+}
+
+type edgeMetrics struct {
+	calls   metrics.Counter
+	errors  metrics.Counter
+	latency metrics.Timer
+}
+
+// NewGraph creates a Graph writing the dependency links to writer, which can be nil if the storage
+// cannot store them, and starts discarding the spans whose peer is not received in time
+func NewGraph(opts Options, writer dependencystore.Writer, metricsFactory metrics.Factory, logger *zap.Logger) (*Graph, error) {
+	if opts.Wait < minWait {
+		return nil, fmt.Errorf("service graph wait must be at least %v", minWait)
+	}
+	if opts.MaxItems <= 0 || opts.MaxEdges <= 0 {
+		return nil, errors.New("service graph max items and max edges must be positive")
+	}
+	if writer == nil {
+		opts.DependenciesInterval = 0
+	}
+	g := newGraph(opts, writer, metricsFactory, logger)
+	g.start(opts.Wait / 10)
+	return g, nil
+}
+
+func newGraph(opts Options, writer dependencystore.Writer, metricsFactory metrics.Factory, logger *zap.Logger) *Graph {
+	f := metricsFactory.Namespace(metrics.NSOptions{Name: "service-graph", Tags: nil})
+	gm := &graphMetrics{}
+	metrics.MustInit(gm, f, nil)
+	return &Graph{
+		writer:               writer,
+		factory:              f,
+		metrics:              gm,
+		logger:               logger,
+		wait:                 opts.Wait,
+		maxItems:             opts.MaxItems,
+		maxEdges:             opts.MaxEdges,
+		dependenciesInterval: opts.DependenciesInterval,
+		now:                  time.Now,
+		pending:              make(map[edgeKey]*pendingEdge),
+		pendingOrder:         list.New(),
+		edges:                make(map[edgeServices]*edgeMetrics),
+		links:                make(map[edgeServices]uint64),
+		done:                 make(chan struct{}),
+	}
+}
+
+func (g *Graph) start(tickInterval time.Duration) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		var dependencies <-chan time.Time
+		if g.dependenciesInterval > 0 {
+			dependenciesTicker := time.NewTicker(g.dependenciesInterval)
+			defer dependenciesTicker.Stop()
+			dependencies = dependenciesTicker.C
+		}
+		for {
+			select {
+			case <-ticker.C:
+				g.expireEdges(g.now())
+			case <-dependencies:
+				g.writeDependencies(g.now())
+			case <-g.done:
+				return
+			}
+		}
+	}()
+}
+
+// ProcessSpan pairs the client or server span with its peer, and records the edge once both are received
+func (g *Graph) ProcessSpan(span *model.Span) {
+	if span.Process == nil {
+		return
+	}
+	kind, _ := span.GetSpanKind()
+	var key edgeKey
+	var isClient bool
+	switch kind {
+	case "client", "producer":
+		key, isClient = edgeKey{traceID: span.TraceID, spanID: span.SpanID}, true
+	case "server", "consumer":
+		key = edgeKey{traceID: span.TraceID, spanID: span.ParentSpanID()}
+		if key.spanID == 0 {
+			return
+		}
+	default:
+		return
+	}
+	service := normalizer.ServiceName(span.Process.ServiceName)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	edge, ok := g.pending[key]
+	if !ok {
+		if len(g.pending) >= g.maxItems {
+			g.metrics.DroppedSpans.Inc(1)
+			return
+		}
+		edge = &pendingEdge{key: key, expiresAt: g.now().Add(g.wait)}
+		edge.element = g.pendingOrder.PushBack(edge)
+		g.pending[key] = edge
+	}
+	if isClient {
+		edge.services.client = service
+		edge.hasClient = true
+		// the latency of the call is measured by the client
+		edge.latency = span.Duration
+	} else {
+		edge.services.server = service
+		edge.hasServer = true
+		if !edge.hasClient {
+			edge.latency = span.Duration
+		}
+	}
+	edge.failed = edge.failed || isError(span)
+	if edge.hasClient && edge.hasServer {
+		g.removePending(edge)
+		g.recordEdge(edge)
+	}
+	g.metrics.PendingEdges.Update(int64(len(g.pending)))
+}
+
+// removePending removes the edge from the edges waiting for their peer. Must be called under lock.
+func (g *Graph) removePending(edge *pendingEdge) {
+	g.pendingOrder.Remove(edge.element)
+	delete(g.pending, edge.key)
+}
+
+// recordEdge records the metrics of the completed edge and its call for the dependency links. Must be called under lock.
+func (g *Graph) recordEdge(edge *pendingEdge) {
+	services := edge.services
+	em, ok := g.edges[services]
+	if !ok && len(g.edges) >= g.maxEdges {
+		g.metrics.OverflowEdges.Inc(1)
+		// the dependency links are capped together with the metrics, otherwise they would keep every pair of services
+		services = edgeServices{client: otherServices, server: otherServices}
+		em, ok = g.edges[services]
+	}
+	if !ok {
+		tags := map[string]string{"client": services.client, "server": services.server}
+		em = &edgeMetrics{
+			calls:   g.factory.Counter(metrics.Options{Name: "calls", Tags: tags}),
+			errors:  g.factory.Counter(metrics.Options{Name: "errors", Tags: tags}),
+			latency: g.factory.Timer(metrics.TimerOptions{Name: "latency", Tags: tags}),
+		}
+		g.edges[services] = em
+	}
+	if g.dependenciesInterval > 0 {
+		g.links[services]++
+	}
+	em.calls.Inc(1)
+	if edge.failed {
+		em.errors.Inc(1)
+	}
+	em.latency.Record(edge.latency)
+}
+
+// expireEdges discards the client and server spans whose peer was not received before their expiration
+func (g *Graph) expireEdges(now time.Time) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for e := g.pendingOrder.Front(); e != nil; e = g.pendingOrder.Front() {
+		edge := e.Value.(*pendingEdge)
+		if edge.expiresAt.After(now) {
+			break
+		}
+		g.removePending(edge)
+		g.metrics.ExpiredEdges.Inc(1)
+	}
+	g.metrics.PendingEdges.Update(int64(len(g.pending)))
+}
+
+// writeDependencies writes the calls between services since the last write as dependency links
+func (g *Graph) writeDependencies(ts time.Time) {
+	g.lock.Lock()
+	links := g.links
+	g.links = make(map[edgeServices]uint64)
+	g.lock.Unlock()
+	if len(links) == 0 {
+		return
+	}
+
+	dependencies := make([]model.DependencyLink, 0, len(links))
+	for services, calls := range links {
+		dependencies = append(dependencies, model.DependencyLink{
+			Parent:    services.client,
+			Child:     services.server,
+			CallCount: calls,
+		})
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].Parent != dependencies[j].Parent {
+			return dependencies[i].Parent < dependencies[j].Parent
+		}
+		return dependencies[i].Child < dependencies[j].Child
+	})
+	if err := g.writer.WriteDependencies(ts, dependencies); err != nil {
+		g.logger.Error("Failed to write the dependency links of the service graph", zap.Error(err))
+		g.metrics.DependencyWriteErrors.Inc(1)
+	}
+}
+
+// Close stops discarding the expired spans and writes the last dependency links
+func (g *Graph) Close() error {
+	close(g.done)
+	g.wg.Wait()
+	if g.dependenciesInterval > 0 {
+		g.writeDependencies(g.now())
+	}
+	return nil
+}
+
+func isError(span *model.Span) bool {
+	tag, ok := model.KeyValues(span.Tags).FindByKey("error")
+	return ok && tag.AsString() == "true"
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+)
+
+var testOptions = Options{Wait: time.Second, MaxItems: 10, MaxEdges: 10, DependenciesInterval: time.Minute}
+
+func newTestGraph(opts Options, writer *mocks.Writer, metricsFactory metrics.Factory) (*Graph, *time.Time) {
+	g := newGraph(opts, writer, metricsFactory, zap.NewNop())
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+	return g, &now
+}
+
+func newSpan(traceID uint64, spanID uint64, parentID uint64, service string, kind string, tags ...model.KeyValue) *model.Span {
+	span := &model.Span{
+		TraceID:  model.NewTraceID(0, traceID),
+		SpanID:   model.NewSpanID(spanID),
+		Process:  model.NewProcess(service, nil),
+		Tags:     append(tags, model.String("span.kind", kind)),
+		Duration: 10 * time.Millisecond,
+	}
+	if parentID != 0 {
+		span.References = []model.SpanRef{model.NewChildOfRef(span.TraceID, model.NewSpanID(parentID))}
+	}
+	return span
+}
+
+func TestGraphPairsSpans(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	g, _ := newTestGraph(testOptions, nil, metricsFactory)
+
+	g.ProcessSpan(newSpan(1, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(1, 2, 1, "backend", "server"))
+	// the server span can be received first
+	g.ProcessSpan(newSpan(2, 2, 1, "backend", "server", model.Bool("error", true)))
+	g.ProcessSpan(newSpan(2, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(3, 1, 0, "backend", "producer"))
+	g.ProcessSpan(newSpan(3, 2, 1, "worker", "consumer"))
+
+	// the spans without kind, without parent or without process are ignored
+	g.ProcessSpan(newSpan(4, 2, 1, "backend", "internal"))
+	g.ProcessSpan(newSpan(4, 3, 0, "backend", "server"))
+	g.ProcessSpan(&model.Span{SpanID: 4, Tags: []model.KeyValue{model.String("span.kind", "client")}})
+
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "service-graph.calls|client=frontend|server=backend", Value: 2},
+		metricstest.ExpectedMetric{Name: "service-graph.errors|client=frontend|server=backend", Value: 1},
+		metricstest.ExpectedMetric{Name: "service-graph.calls|client=backend|server=worker", Value: 1},
+		metricstest.ExpectedMetric{Name: "service-graph.errors|client=backend|server=worker", Value: 0},
+	)
+	metricsFactory.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "service-graph.pending-edges", Value: 0})
+	assert.Empty(t, g.pending)
+	assert.Equal(t, map[edgeServices]uint64{
+		{client: "frontend", server: "backend"}: 2,
+		{client: "backend", server: "worker"}:   1,
+	}, g.links)
+}
+
+func TestGraphExpiresEdges(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	g, now := newTestGraph(testOptions, nil, metricsFactory)
+
+	g.ProcessSpan(newSpan(1, 1, 0, "frontend", "client"))
+	*now = now.Add(500 * time.Millisecond)
+	g.ProcessSpan(newSpan(2, 2, 1, "backend", "server"))
+	g.expireEdges(now.Add(600 * time.Millisecond))
+	assert.Len(t, g.pending, 1)
+
+	// the server span arrives too late for its client span
+	g.ProcessSpan(newSpan(1, 2, 1, "backend", "server"))
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "service-graph.expired-edges", Value: 1},
+		metricstest.ExpectedMetric{Name: "service-graph.calls|client=frontend|server=backend", Value: 0},
+	)
+	metricsFactory.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "service-graph.pending-edges", Value: 2})
+}
+
+func TestGraphLimits(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	g, _ := newTestGraph(Options{Wait: time.Second, MaxItems: 2, MaxEdges: 1}, nil, metricsFactory)
+
+	for i := uint64(1); i <= 3; i++ {
+		g.ProcessSpan(newSpan(i, 1, 0, "frontend", "client"))
+	}
+	assert.Len(t, g.pending, 2)
+
+	g.ProcessSpan(newSpan(1, 2, 1, "backend", "server"))
+	g.ProcessSpan(newSpan(2, 2, 1, "database", "server"))
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "service-graph.dropped-spans", Value: 1},
+		metricstest.ExpectedMetric{Name: "service-graph.overflow-edges", Value: 1},
+		metricstest.ExpectedMetric{Name: "service-graph.calls|client=frontend|server=backend", Value: 1},
+		metricstest.ExpectedMetric{Name: "service-graph.calls|client=other-services|server=other-services", Value: 1},
+	)
+	assert.Empty(t, g.links, "the dependency links are not collected when they are not written")
+}
+
+func TestGraphLimitsDependencies(t *testing.T) {
+	g, _ := newTestGraph(Options{Wait: time.Second, MaxItems: 10, MaxEdges: 1, DependenciesInterval: time.Minute}, nil, metrics.NullFactory)
+
+	g.ProcessSpan(newSpan(1, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(1, 2, 1, "backend", "server"))
+	g.ProcessSpan(newSpan(2, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(2, 2, 1, "database", "server"))
+	g.ProcessSpan(newSpan(3, 1, 0, "backend", "client"))
+	g.ProcessSpan(newSpan(3, 2, 1, "cache", "server"))
+	assert.Equal(t, map[edgeServices]uint64{
+		{client: "frontend", server: "backend"}:        1,
+		{client: otherServices, server: otherServices}: 2,
+	}, g.links, "the calls above the edges limit are linked between other-services")
+}
+
+func TestGraphWritesDependencies(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &mocks.Writer{}
+	g, now := newTestGraph(testOptions, writer, metricsFactory)
+
+	// nothing is written without calls
+	g.writeDependencies(*now)
+	writer.AssertNotCalled(t, "WriteDependencies", mock.Anything, mock.Anything)
+
+	g.ProcessSpan(newSpan(1, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(1, 2, 1, "backend", "server"))
+	g.ProcessSpan(newSpan(1, 3, 2, "backend", "client"))
+	g.ProcessSpan(newSpan(1, 4, 3, "database", "server"))
+	g.ProcessSpan(newSpan(2, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(2, 2, 1, "backend", "server"))
+
+	writer.On("WriteDependencies", *now, []model.DependencyLink{
+		{Parent: "backend", Child: "database", CallCount: 1},
+		{Parent: "frontend", Child: "backend", CallCount: 2},
+	}).Return(nil).Once()
+	g.writeDependencies(*now)
+	writer.AssertExpectations(t)
+	assert.Empty(t, g.links, "the calls are only written once")
+
+	writer.On("WriteDependencies", *now, mock.Anything).Return(errors.New("storage down"))
+	g.ProcessSpan(newSpan(3, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(3, 2, 1, "backend", "server"))
+	require.NoError(t, g.Close())
+	metricsFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "service-graph.dependency-write-errors", Value: 1})
+}
+
+func TestGraphWritesDependenciesPeriodically(t *testing.T) {
+	writer := &mocks.Writer{}
+	written := make(chan []model.DependencyLink, 1)
+	writer.On("WriteDependencies", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		written <- args.Get(1).([]model.DependencyLink)
+	})
+	opts := Options{Wait: time.Second, MaxItems: 10, MaxEdges: 10, DependenciesInterval: 10 * time.Millisecond}
+	g, err := NewGraph(opts, writer, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	defer g.Close()
+
+	g.ProcessSpan(newSpan(1, 1, 0, "frontend", "client"))
+	g.ProcessSpan(newSpan(1, 2, 1, "backend", "server"))
+	select {
+	case links := <-written:
+		assert.Equal(t, []model.DependencyLink{{Parent: "frontend", Child: "backend", CallCount: 1}}, links)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the dependency links were not written")
+	}
+}
+
+func TestNewGraphErrors(t *testing.T) {
+	tests := []struct {
+		opts Options
+		err  string
+	}{
+		{
+			opts: Options{MaxItems: 1, MaxEdges: 1},
+			err:  "service graph wait must be at least 1ms",
+		},
+		{
+			opts: Options{Wait: 5 * time.Nanosecond, MaxItems: 1, MaxEdges: 1},
+			err:  "service graph wait must be at least 1ms",
+		},
+		{
+			opts: Options{Wait: time.Second, MaxEdges: 1},
+			err:  "service graph max items and max edges must be positive",
+		},
+	}
+	for _, test := range tests {
+		_, err := NewGraph(test.opts, nil, metrics.NullFactory, zap.NewNop())
+		assert.EqualError(t, err, test.err, fmt.Sprint(test.opts))
+	}
+
+	g, err := NewGraph(testOptions, nil, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	assert.Zero(t, g.dependenciesInterval, "the dependency links are not collected without writer")
+	assert.NoError(t, g.Close())
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	serviceGraphEnabled              = "collector.service-graph.enabled"
+	serviceGraphWait                 = "collector.service-graph.wait"
+	serviceGraphMaxItems             = "collector.service-graph.max-items"
+	serviceGraphMaxEdges             = "collector.service-graph.max-edges"
+	serviceGraphDependenciesInterval = "collector.service-graph.dependencies-interval"
+
+	// DefaultWait is the default time a client or server span waits for its peer
+	DefaultWait = 10 * time.Second
+	// DefaultMaxItems is the default number of client and server spans waiting for their peer
+	DefaultMaxItems = 10000
+	// DefaultMaxEdges is the default number of edges with their own metrics
+	DefaultMaxEdges = 1000
+	// DefaultDependenciesInterval is the default interval at which the dependency links are written,
+	// long enough for the documents of a week of several collectors to stay below the storage read limits
+	DefaultDependenciesInterval = time.Hour
+)
+
+// Options holds configuration for the service graph computed by the collector.
+type Options struct {
+	// Enabled pairs the client and server spans received by the collector into service graph edges
+	Enabled bool
+	// Wait is the time a client or server span waits for its peer before it is discarded
+	Wait time.Duration
+	// MaxItems is the maximum number of client and server spans waiting for their peer,
+	// the new spans are discarded when exceeded
+	MaxItems int
+	// MaxEdges is the maximum number of edges with their own metrics, the other edges are counted together
+	MaxEdges int
+	// DependenciesInterval is the interval at which the dependency links are written to the storage,
+	// each collector writes one document per interval, zero disables writing them
+	DependenciesInterval time.Duration
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Bool(serviceGraphEnabled, false, "(experimental) Pair the client and server spans into service graph edges, "+
+		"generating the call count, error count and latency metrics per edge and writing the dependency links to the storage")
+	flagSet.Duration(serviceGraphWait, DefaultWait, "The time a client or server span waits for its peer before it is discarded, at least 1ms")
+	flagSet.Int(serviceGraphMaxItems, DefaultMaxItems, "The maximum number of client and server spans waiting for their peer")
+	flagSet.Int(serviceGraphMaxEdges, DefaultMaxEdges, "The maximum number of edges with their own metrics, "+
+		"the calls of the other edges are reported between other-services")
+	flagSet.Duration(serviceGraphDependenciesInterval, DefaultDependenciesInterval, "The interval at which the dependency links "+
+		"are written to the storage, if the storage supports it. Each collector writes one document per interval: "+
+		"with Elasticsearch, the documents read for a lookback are limited by es.max-doc-count, "+
+		"which must be above the number of collectors times the lookback divided by this interval. Zero value disables writing them")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opts *Options) InitFromViper(v *viper.Viper) *Options {
+	opts.Enabled = v.GetBool(serviceGraphEnabled)
+	opts.Wait = v.GetDuration(serviceGraphWait)
+	opts.MaxItems = v.GetInt(serviceGraphMaxItems)
+	opts.MaxEdges = v.GetInt(serviceGraphMaxEdges)
+	opts.DependenciesInterval = v.GetDuration(serviceGraphDependenciesInterval)
+	return opts
+}
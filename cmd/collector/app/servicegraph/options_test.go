@@ -0,0 +1,41 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.service-graph.enabled=true",
+		"--collector.service-graph.wait=5s",
+		"--collector.service-graph.dependencies-interval=0",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{
+		Enabled:              true,
+		Wait:                 5 * time.Second,
+		MaxItems:             DefaultMaxItems,
+		MaxEdges:             DefaultMaxEdges,
+		DependenciesInterval: 0,
+	}, *opts)
+}
@@ -23,8 +23,10 @@ import (
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer"
 	zs "github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer/zipkin"
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/queue"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -34,6 +36,10 @@ type SpanHandlerBuilder struct {
 	CollectorOpts  CollectorOptions
 	Logger         *zap.Logger
 	MetricsFactory metrics.Factory
+	Sanitizer      sanitizer.SanitizeSpan
+	RateLimiter    FilterSpan
+	PreSave        ProcessSpan
+	Queue          queue.Queue
 }
 
 // SpanHandlers holds instances to the span handlers built by the SpanHandlerBuilder
@@ -41,6 +47,7 @@ type SpanHandlers struct {
 	ZipkinSpansHandler   handler.ZipkinSpansHandler
 	JaegerBatchesHandler handler.JaegerBatchesHandler
 	GRPCHandler          *handler.GRPCHandler
+	OTLPHandler          *handler.OTLPHandler
 }
 
 // BuildSpanProcessor builds the span processor to be used with the handlers
@@ -55,8 +62,12 @@ func (b *SpanHandlerBuilder) BuildSpanProcessor() processor.SpanProcessor {
 		Options.HostMetrics(hostMetrics),
 		Options.Logger(b.logger()),
 		Options.SpanFilter(defaultSpanFilter),
+		Options.Sanitizer(b.Sanitizer),
+		Options.RateLimiter(b.RateLimiter),
+		Options.PreSave(b.PreSave),
 		Options.NumWorkers(b.CollectorOpts.NumWorkers),
 		Options.QueueSize(b.CollectorOpts.QueueSize),
+		Options.Queue(b.Queue),
 		Options.CollectorTags(b.CollectorOpts.CollectorTags),
 		Options.DynQueueSizeWarmup(uint(b.CollectorOpts.QueueSize)), // same as queue size for now
 		Options.DynQueueSizeMemory(b.CollectorOpts.DynQueueSizeMemory),
@@ -64,12 +75,13 @@ func (b *SpanHandlerBuilder) BuildSpanProcessor() processor.SpanProcessor {
 
 }
 
-// BuildHandlers builds span handlers (Zipkin, Jaeger)
+// BuildHandlers builds span handlers (Zipkin, Jaeger, OTLP)
 func (b *SpanHandlerBuilder) BuildHandlers(spanProcessor processor.SpanProcessor) *SpanHandlers {
 	return &SpanHandlers{
 		handler.NewZipkinSpanHandler(b.Logger, spanProcessor, zs.NewChainedSanitizer(zs.StandardSanitizers...)),
 		handler.NewJaegerSpanHandler(b.Logger, spanProcessor),
 		handler.NewGRPCHandler(b.Logger, spanProcessor),
+		handler.NewOTLPHandler(b.Logger, spanProcessor),
 	}
 }
 
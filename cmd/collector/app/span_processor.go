@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/retry"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer"
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/queue"
@@ -36,16 +37,21 @@ const (
 
 	// if the new queue size isn't 20% bigger than the previous one, don't change
 	minRequiredChange = 1.2
+
+	// the maximum time a span saved by a queue worker waits for the other workers to fill up its batch
+	batchFlushInterval = 10 * time.Millisecond
 )
 
 type spanProcessor struct {
-	queue              *queue.BoundedQueue
+	queue              queue.Queue
 	queueResizeMu      sync.Mutex
 	metrics            *SpanProcessorMetrics
 	preProcessSpans    ProcessSpans
 	filterSpan         FilterSpan             // filter is called before the sanitizer but after preProcessSpans
-	sanitizer          sanitizer.SanitizeSpan // sanitizer is called before processSpan
-	processSpan        ProcessSpan
+	rateLimiter        FilterSpan             // rateLimiter is called after the filter, before enqueueing
+	sanitizer          sanitizer.SanitizeSpan // sanitizer is called before processSpan, or before enqueueing if sanitizeOnEnqueue
+	sanitizeOnEnqueue  bool                   // set when the queue keeps the spans on disk, which must not hold unsanitized data
+	processSpan        ProcessSpan            // processSpan is called once per span before saveSpan
 	logger             *zap.Logger
 	spanWriter         spanstore.Writer
 	reportBusy         bool
@@ -61,6 +67,9 @@ type spanProcessor struct {
 type queueItem struct {
 	queuedTime time.Time
 	span       *model.Span
+	// processed is set once the span went through the processors preceding the save,
+	// so that they do not process it again when the save is retried
+	processed bool
 }
 
 // NewSpanProcessor returns a SpanProcessor that preProcesses, filters, queues, sanitizes, and processes spans
@@ -70,10 +79,18 @@ func NewSpanProcessor(
 ) processor.SpanProcessor {
 	sp := newSpanProcessor(spanWriter, opts...)
 
-	sp.queue.StartConsumers(sp.numWorkers, func(item interface{}) {
-		value := item.(*queueItem)
-		sp.processItemFromQueue(value)
-	})
+	if ackQueue, ok := sp.queue.(queue.AckQueue); ok {
+		// the spans are kept in the queue until they are saved, e.g. during a storage outage
+		ackQueue.StartAckConsumers(sp.numWorkers, func(item interface{}) bool {
+			value := item.(*queueItem)
+			return sp.processItemFromQueue(value)
+		})
+	} else {
+		sp.queue.StartConsumers(sp.numWorkers, func(item interface{}) {
+			value := item.(*queueItem)
+			sp.processItemFromQueue(value)
+		})
+	}
 
 	sp.background(1*time.Second, sp.updateGauges)
 
@@ -90,18 +107,29 @@ func newSpanProcessor(spanWriter spanstore.Writer, opts ...Option) *spanProcesso
 		options.serviceMetrics,
 		options.hostMetrics,
 		options.extraFormatTypes)
-	droppedItemHandler := func(item interface{}) {
-		handlerMetrics.SpansDropped.Inc(1)
+	spanQueue := options.queue
+	if spanQueue == nil {
+		// the dropped spans are counted when enqueued, for all the queue implementations
+		spanQueue = queue.NewBoundedQueue(options.queueSize, func(item interface{}) {})
 	}
-	boundedQueue := queue.NewBoundedQueue(options.queueSize, droppedItemHandler)
 
+	if batchWriter, ok := spanWriter.(spanstore.BatchWriter); ok {
+		if _, batching := spanWriter.(*spanstore.BatchingWriter); !batching {
+			// the spans saved concurrently by the queue workers are written together
+			spanWriter = spanstore.NewBatchingWriter(batchWriter, options.numWorkers, batchFlushInterval)
+		}
+	}
+
+	_, persistent := spanQueue.(queue.AckQueue)
 	sp := spanProcessor{
-		queue:              boundedQueue,
+		queue:              spanQueue,
 		metrics:            handlerMetrics,
 		logger:             options.logger,
 		preProcessSpans:    options.preProcessSpans,
 		filterSpan:         options.spanFilter,
+		rateLimiter:        options.rateLimiter,
 		sanitizer:          options.sanitizer,
+		sanitizeOnEnqueue:  persistent,
 		reportBusy:         options.reportBusy,
 		numWorkers:         options.numWorkers,
 		spanWriter:         spanWriter,
@@ -113,7 +141,7 @@ func newSpanProcessor(spanWriter spanstore.Writer, opts ...Option) *spanProcesso
 		spansProcessed:     atomic.NewUint64(0),
 	}
 
-	processSpanFuncs := []ProcessSpan{options.preSave, sp.saveSpan}
+	processSpanFuncs := []ProcessSpan{options.preSave}
 	if options.dynQueueSizeMemory > 0 {
 		// add to processSpanFuncs
 		options.logger.Info("Dynamically adjusting the queue size at runtime.",
@@ -133,24 +161,29 @@ func (sp *spanProcessor) Close() error {
 	return nil
 }
 
-func (sp *spanProcessor) saveSpan(span *model.Span) {
+// saveSpan writes the span to the storage, it returns false if the span was not saved but writing it again may succeed
+func (sp *spanProcessor) saveSpan(span *model.Span) bool {
 	if nil == span.Process {
 		sp.logger.Error("process is empty for the span")
 		sp.metrics.SavedErrBySvc.ReportServiceNameForSpan(span)
-		return
+		return true
 	}
 
 	startTime := time.Now()
+	defer func() { sp.metrics.SaveLatency.Record(time.Since(startTime)) }()
 	// TODO context should be propagated from upstream components
 	if err := sp.spanWriter.WriteSpan(context.TODO(), span); err != nil {
-		sp.logger.Error("Failed to save span", zap.Error(err))
+		// the rejections of the open circuit breaker are logged once by the retrying writer
+		if err != retry.ErrCircuitOpen {
+			sp.logger.Error("Failed to save span", zap.Error(err))
+		}
 		sp.metrics.SavedErrBySvc.ReportServiceNameForSpan(span)
-	} else {
-		sp.logger.Debug("Span written to the storage by the collector",
-			zap.Stringer("trace-id", span.TraceID), zap.Stringer("span-id", span.SpanID))
-		sp.metrics.SavedOkBySvc.ReportServiceNameForSpan(span)
+		return !spanstore.IsRetryable(err)
 	}
-	sp.metrics.SaveLatency.Record(time.Since(startTime))
+	sp.logger.Debug("Span written to the storage by the collector",
+		zap.Stringer("trace-id", span.TraceID), zap.Stringer("span-id", span.SpanID))
+	sp.metrics.SavedOkBySvc.ReportServiceNameForSpan(span)
+	return true
 }
 
 func (sp *spanProcessor) countSpan(span *model.Span) {
@@ -162,19 +195,37 @@ func (sp *spanProcessor) ProcessSpans(mSpans []*model.Span, options processor.Sp
 	sp.preProcessSpans(mSpans)
 	sp.metrics.BatchSize.Update(int64(len(mSpans)))
 	retMe := make([]bool, len(mSpans))
+	rateLimited := 0
 	for i, mSpan := range mSpans {
-		ok := sp.enqueueSpan(mSpan, options.SpanFormat, options.InboundTransport)
-		if !ok && sp.reportBusy {
+		ok, err := sp.enqueueSpan(mSpan, options.SpanFormat, options.InboundTransport)
+		if err == processor.ErrRateLimited {
+			rateLimited++
+		} else if !ok && sp.reportBusy {
 			return nil, processor.ErrBusy
 		}
 		retMe[i] = ok
 	}
+	// the batch is only rejected when none of its spans was queued, so that its retry is not written twice
+	if rateLimited > 0 && rateLimited == len(mSpans) {
+		return retMe, processor.ErrRateLimited
+	}
 	return retMe, nil
 }
 
-func (sp *spanProcessor) processItemFromQueue(item *queueItem) {
-	sp.processSpan(sp.sanitizer(item.span))
+// processItemFromQueue processes and saves the span, it returns false if the span must be saved again
+func (sp *spanProcessor) processItemFromQueue(item *queueItem) bool {
+	if !item.processed {
+		if !sp.sanitizeOnEnqueue {
+			item.span = sp.sanitizer(item.span)
+		}
+		sp.processSpan(item.span)
+		item.processed = true
+	}
+	if !sp.saveSpan(item.span) {
+		return false
+	}
 	sp.metrics.InQueueLatency.Record(time.Since(item.queuedTime))
+	return true
 }
 
 func (sp *spanProcessor) addCollectorTags(span *model.Span) {
@@ -184,13 +235,18 @@ func (sp *spanProcessor) addCollectorTags(span *model.Span) {
 	}
 }
 
-func (sp *spanProcessor) enqueueSpan(span *model.Span, originalFormat processor.SpanFormat, transport processor.InboundTransport) bool {
+func (sp *spanProcessor) enqueueSpan(span *model.Span, originalFormat processor.SpanFormat, transport processor.InboundTransport) (bool, error) {
 	spanCounts := sp.metrics.GetCountsForFormat(originalFormat, transport)
 	spanCounts.ReceivedBySvc.ReportServiceNameForSpan(span)
 
 	if !sp.filterSpan(span) {
 		spanCounts.RejectedBySvc.ReportServiceNameForSpan(span)
-		return true // as in "not dropped", because it's actively rejected
+		return true, nil // as in "not dropped", because it's actively rejected
+	}
+
+	if !sp.rateLimiter(span) {
+		spanCounts.RateLimitedBySvc.ReportServiceNameForSpan(span)
+		return false, processor.ErrRateLimited
 	}
 
 	//add format tag
@@ -199,11 +255,19 @@ func (sp *spanProcessor) enqueueSpan(span *model.Span, originalFormat processor.
 	// append the collector tags
 	sp.addCollectorTags(span)
 
+	if sp.sanitizeOnEnqueue {
+		span = sp.sanitizer(span)
+	}
+
 	item := &queueItem{
 		queuedTime: time.Now(),
 		span:       span,
 	}
-	return sp.queue.Produce(item)
+	if !sp.queue.Produce(item) {
+		sp.metrics.SpansDropped.Inc(1)
+		return false, nil
+	}
+	return true, nil
 }
 
 func (sp *spanProcessor) background(reportPeriod time.Duration, callback func()) {
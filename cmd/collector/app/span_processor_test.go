@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/uber/jaeger-lib/metrics"
 	"github.com/uber/jaeger-lib/metrics/metricstest"
 	"go.uber.org/atomic"
@@ -34,6 +35,7 @@ import (
 	zipkinSanitizer "github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer/zipkin"
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/testutils"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
 	zc "github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
 )
@@ -270,6 +272,37 @@ func TestSpanProcessorErrors(t *testing.T) {
 	mb.AssertCounterMetrics(t, expected...)
 }
 
+type fakeBatchWriter struct {
+	fakeSpanWriter
+	mu      sync.Mutex
+	batches [][]*model.Span
+}
+
+func (w *fakeBatchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.batches = append(w.batches, spans)
+	return nil
+}
+
+func TestSpanProcessorBatchWriter(t *testing.T) {
+	w := &fakeBatchWriter{}
+	p := newSpanProcessor(w, Options.NumWorkers(4))
+	batchingWriter, ok := p.spanWriter.(*spanstore.BatchingWriter)
+	require.True(t, ok, "the batch writers are batching the spans of the queue workers")
+	require.NoError(t, batchingWriter.WriteSpan(context.Background(), &model.Span{}))
+	assert.Len(t, w.batches, 1)
+	assert.NoError(t, p.Close())
+
+	p = newSpanProcessor(batchingWriter)
+	assert.Same(t, batchingWriter, p.spanWriter, "the batching writers are not wrapped twice")
+	assert.NoError(t, p.Close())
+
+	p = newSpanProcessor(&fakeSpanWriter{})
+	assert.IsType(t, &fakeSpanWriter{}, p.spanWriter)
+	assert.NoError(t, p.Close())
+}
+
 type blockingWriter struct {
 	sync.Mutex
 }
@@ -316,6 +349,38 @@ func TestSpanProcessorBusy(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestSpanProcessorRateLimited(t *testing.T) {
+	mb := metricstest.NewFactory(time.Hour)
+	serviceMetrics := mb.Namespace(metrics.NSOptions{Name: "service", Tags: nil})
+	p := NewSpanProcessor(&fakeSpanWriter{},
+		Options.ServiceMetrics(serviceMetrics),
+		Options.QueueSize(10),
+		Options.ReportBusy(true),
+		Options.RateLimiter(func(span *model.Span) bool {
+			return span.Process.ServiceName != "noisy"
+		}),
+	).(*spanProcessor)
+
+	res, err := p.ProcessSpans([]*model.Span{
+		{Process: &model.Process{ServiceName: "x"}},
+		{Process: &model.Process{ServiceName: "noisy"}},
+		{Process: &model.Process{ServiceName: "x"}},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+
+	assert.NoError(t, err, "the batch is partially processed, it must not be retried")
+	assert.Equal(t, []bool{true, false, true}, res, "the spans within the limits are processed")
+
+	res, err = p.ProcessSpans([]*model.Span{
+		{Process: &model.Process{ServiceName: "noisy"}},
+	}, processor.SpansOptions{SpanFormat: processor.JaegerSpanFormat, InboundTransport: processor.GRPCTransport})
+	assert.Equal(t, processor.ErrRateLimited, err, "no span of the batch is processed")
+	assert.Equal(t, []bool{false}, res)
+	assert.NoError(t, p.Close())
+	mb.AssertCounterMetrics(t, metricstest.ExpectedMetric{
+		Name: "service.spans.rate-limited|debug=false|format=jaeger|svc=noisy|transport=grpc", Value: 2,
+	})
+}
+
 func TestSpanProcessorWithNilProcess(t *testing.T) {
 	mb := metricstest.NewFactory(time.Hour)
 	serviceMetrics := mb.Namespace(metrics.NSOptions{Name: "service", Tags: nil})
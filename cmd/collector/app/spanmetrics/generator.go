@@ -0,0 +1,231 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/normalizer"
+)
+
+const (
+	// otherServices is the service label of the spans above the services limit
+	otherServices = "other-services"
+	// otherOperations is the operation label of the spans above the operations limit of their service
+	otherOperations = "other-operations"
+	// unspecifiedKind is the span kind label of the spans without a known span.kind tag
+	unspecifiedKind = "unspecified"
+)
+
+// knownKinds bounds the values of the span kind label
+var knownKinds = map[string]bool{
+	"client":   true,
+	"server":   true,
+	"producer": true,
+	"consumer": true,
+	"internal": true,
+}
+
+type generatorMetrics struct {
+	// Number of label sets of the span metrics
+	Series metrics.Gauge `metric:"series"`
+	// Number of spans reported under other-services or other-operations
+	OverflowSpans metrics.Counter `metric:"overflow-spans"`
+}
+
+// Generator derives the request, error and latency (RED) metrics per service, operation and span kind
+// from the spans received by the collector.
+type Generator struct {
+	factory       metrics.Factory
+	buckets       []time.Duration
+	maxServices   int
+	maxOperations int
+	maxSeries     int
+	metrics       generatorMetrics
+	logger        *zap.Logger
+
+	lock       sync.Mutex
+	operations map[string]map[string]bool // the operations with their own metrics per service
+	series     map[seriesKey]*seriesMetrics
+}
+
+type seriesKey struct {
+	service   string
+	operation string
+	spanKind  string
+}
+
+type seriesMetrics struct {
+	calls   metrics.Counter
+	errors  metrics.Counter
+	latency metrics.Timer
+}
+
+// NewGenerator creates a Generator reporting the span metrics to the metrics factory
+func NewGenerator(opts Options, metricsFactory metrics.Factory, logger *zap.Logger) (*Generator, error) {
+	if opts.MaxServices <= 0 || opts.MaxOperations <= 0 || opts.MaxSeries <= 0 {
+		return nil, errors.New("the span metrics max services, max operations and max series must be positive")
+	}
+	buckets, err := parseBuckets(opts.LatencyBuckets)
+	if err != nil {
+		return nil, err
+	}
+	factory := metricsFactory.Namespace(metrics.NSOptions{Name: "span-metrics"})
+	g := &Generator{
+		factory:       factory,
+		buckets:       buckets,
+		maxServices:   opts.MaxServices,
+		maxOperations: opts.MaxOperations,
+		maxSeries:     opts.MaxSeries,
+		logger:        logger,
+		operations:    make(map[string]map[string]bool),
+		series:        make(map[seriesKey]*seriesMetrics),
+	}
+	metrics.MustInit(&g.metrics, factory, nil)
+	return g, nil
+}
+
+func parseBuckets(value string) ([]time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var buckets []time.Duration
+	for _, bucket := range strings.Split(value, ",") {
+		duration, err := time.ParseDuration(strings.TrimSpace(bucket))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse span metrics latency bucket: %w", err)
+		}
+		buckets = append(buckets, duration)
+	}
+	if !sort.SliceIsSorted(buckets, func(i, j int) bool { return buckets[i] < buckets[j] }) {
+		return nil, errors.New("the span metrics latency buckets must be in increasing order")
+	}
+	return buckets, nil
+}
+
+// ProcessSpan records the call, the error if any and the latency of the span
+func (g *Generator) ProcessSpan(span *model.Span) {
+	if span.Process == nil {
+		return
+	}
+	series := g.getSeries(span)
+	series.calls.Inc(1)
+	if isError(span) {
+		series.errors.Inc(1)
+	}
+	series.latency.Record(span.Duration)
+}
+
+func (g *Generator) getSeries(span *model.Span) *seriesMetrics {
+	key := seriesKey{
+		service:   normalizer.ServiceName(span.Process.ServiceName),
+		operation: span.OperationName,
+		spanKind:  spanKind(span),
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if series, ok := g.series[key]; ok {
+		return series
+	}
+	if g.limitLabels(&key) {
+		g.metrics.OverflowSpans.Inc(1)
+		if series, ok := g.series[key]; ok {
+			return series
+		}
+	}
+	if len(g.series) >= g.maxSeries {
+		// the catch-all series of each span kind may exceed the limit
+		if len(g.series) == g.maxSeries {
+			g.logger.Warn("Too many series for the span metrics, the new series are reported as "+otherServices,
+				zap.Int("max-series", g.maxSeries))
+		}
+		if key.service != otherServices || key.operation != otherOperations {
+			g.metrics.OverflowSpans.Inc(1)
+		}
+		key.service = otherServices
+		key.operation = otherOperations
+		if series, ok := g.series[key]; ok {
+			return series
+		}
+	}
+	tags := map[string]string{
+		"service":   key.service,
+		"operation": key.operation,
+		"span_kind": key.spanKind,
+	}
+	series := &seriesMetrics{
+		calls:  g.factory.Counter(metrics.Options{Name: "calls", Tags: tags}),
+		errors: g.factory.Counter(metrics.Options{Name: "errors", Tags: tags}),
+		latency: g.factory.Timer(metrics.TimerOptions{
+			Name:    "latency",
+			Tags:    tags,
+			Buckets: g.buckets,
+		}),
+	}
+	g.series[key] = series
+	g.metrics.Series.Update(int64(len(g.series)))
+	return series
+}
+
+// limitLabels replaces the service or the operation of the key above the limits by the catch-all labels,
+// and returns whether the labels were replaced. Must be called under lock.
+func (g *Generator) limitLabels(key *seriesKey) bool {
+	operations, ok := g.operations[key.service]
+	if !ok {
+		if len(g.operations) >= g.maxServices {
+			if len(g.operations) == g.maxServices {
+				g.logger.Warn("Too many services for the span metrics, the new services are reported as "+otherServices,
+					zap.Int("max-services", g.maxServices))
+				g.operations[otherServices] = map[string]bool{otherOperations: true}
+			}
+			key.service = otherServices
+			key.operation = otherOperations
+			return true
+		}
+		operations = make(map[string]bool)
+		g.operations[key.service] = operations
+	}
+	if operations[key.operation] {
+		return false
+	}
+	if len(operations) >= g.maxOperations {
+		key.operation = otherOperations
+		return true
+	}
+	operations[key.operation] = true
+	return false
+}
+
+func spanKind(span *model.Span) string {
+	if kind, ok := span.GetSpanKind(); ok && knownKinds[kind] {
+		return kind
+	}
+	return unspecifiedKind
+}
+
+func isError(span *model.Span) bool {
+	tag, ok := model.KeyValues(span.Tags).FindByKey("error")
+	return ok && tag.AsString() == "true"
+}
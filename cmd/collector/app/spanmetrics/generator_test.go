@@ -0,0 +1,132 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+func newSpan(service, operation string, tags ...model.KeyValue) *model.Span {
+	return &model.Span{
+		OperationName: operation,
+		Process:       model.NewProcess(service, nil),
+		Tags:          tags,
+		Duration:      10 * time.Millisecond,
+	}
+}
+
+func newTestGenerator(t *testing.T, opts Options) (*Generator, *metricstest.Factory) {
+	mFact := metricstest.NewFactory(0)
+	g, err := NewGenerator(opts, mFact, zap.NewNop())
+	require.NoError(t, err)
+	return g, mFact
+}
+
+func TestGenerator(t *testing.T) {
+	g, mFact := newTestGenerator(t, Options{MaxServices: 10, MaxOperations: 10, MaxSeries: 10, LatencyBuckets: "10ms, 1s"})
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, time.Second}, g.buckets)
+
+	g.ProcessSpan(newSpan("frontend", "GET /", model.String("span.kind", "server")))
+	g.ProcessSpan(newSpan("frontend", "GET /", model.String("span.kind", "server"), model.Bool("error", true)))
+	g.ProcessSpan(newSpan("frontend", "GET /", model.String("span.kind", "client")))
+	g.ProcessSpan(newSpan("frontend", "render", model.String("span.kind", "unknown")))
+	g.ProcessSpan(&model.Span{OperationName: "no-process"})
+
+	mFact.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=GET /|service=frontend|span_kind=server", Value: 2},
+		metricstest.ExpectedMetric{Name: "span-metrics.errors|operation=GET /|service=frontend|span_kind=server", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=GET /|service=frontend|span_kind=client", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.errors|operation=GET /|service=frontend|span_kind=client", Value: 0},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=render|service=frontend|span_kind=unspecified", Value: 1},
+	)
+	mFact.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "span-metrics.series", Value: 3})
+}
+
+func TestGeneratorLimits(t *testing.T) {
+	g, mFact := newTestGenerator(t, Options{MaxServices: 2, MaxOperations: 2, MaxSeries: 10})
+
+	for i := 0; i < 4; i++ {
+		g.ProcessSpan(newSpan("frontend", fmt.Sprintf("op-%d", i)))
+	}
+	g.ProcessSpan(newSpan("backend", "op"))
+	g.ProcessSpan(newSpan("database", "query"))
+	g.ProcessSpan(newSpan("cache", "get"))
+
+	mFact.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=op-0|service=frontend|span_kind=unspecified", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=op-1|service=frontend|span_kind=unspecified", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=other-operations|service=frontend|span_kind=unspecified", Value: 2},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=op|service=backend|span_kind=unspecified", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=other-operations|service=other-services|span_kind=unspecified", Value: 2},
+		metricstest.ExpectedMetric{Name: "span-metrics.overflow-spans", Value: 4},
+	)
+	mFact.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "span-metrics.series", Value: 5})
+}
+
+func TestGeneratorMaxSeries(t *testing.T) {
+	g, mFact := newTestGenerator(t, Options{MaxServices: 10, MaxOperations: 10, MaxSeries: 2})
+
+	g.ProcessSpan(newSpan("frontend", "GET /"))
+	g.ProcessSpan(newSpan("backend", "query"))
+	g.ProcessSpan(newSpan("backend", "query"))
+	g.ProcessSpan(newSpan("database", "select"))
+	g.ProcessSpan(newSpan("frontend", "render", model.String("span.kind", "client")))
+
+	mFact.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=GET /|service=frontend|span_kind=unspecified", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=query|service=backend|span_kind=unspecified", Value: 2},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=other-operations|service=other-services|span_kind=unspecified", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.calls|operation=other-operations|service=other-services|span_kind=client", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-metrics.overflow-spans", Value: 2},
+	)
+	mFact.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "span-metrics.series", Value: 4})
+}
+
+func TestNewGeneratorErrors(t *testing.T) {
+	tests := []struct {
+		opts Options
+		err  string
+	}{
+		{
+			opts: Options{MaxServices: 0, MaxOperations: 1, MaxSeries: 1},
+			err:  "the span metrics max services, max operations and max series must be positive",
+		},
+		{
+			opts: Options{MaxServices: 1, MaxOperations: 1},
+			err:  "the span metrics max services, max operations and max series must be positive",
+		},
+		{
+			opts: Options{MaxServices: 1, MaxOperations: 1, MaxSeries: 1, LatencyBuckets: "10ms,fast"},
+			err:  `cannot parse span metrics latency bucket: time: invalid duration "fast"`,
+		},
+		{
+			opts: Options{MaxServices: 1, MaxOperations: 1, MaxSeries: 1, LatencyBuckets: "1s,10ms"},
+			err:  "the span metrics latency buckets must be in increasing order",
+		},
+	}
+	for _, test := range tests {
+		_, err := NewGenerator(test.opts, metricstest.NewFactory(0), zap.NewNop())
+		assert.EqualError(t, err, test.err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"flag"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	spanMetricsEnabled        = "collector.span-metrics.enabled"
+	spanMetricsMaxServices    = "collector.span-metrics.max-services"
+	spanMetricsMaxOperations  = "collector.span-metrics.max-operations"
+	spanMetricsMaxSeries      = "collector.span-metrics.max-series"
+	spanMetricsLatencyBuckets = "collector.span-metrics.latency-buckets"
+
+	// DefaultMaxServices is the default number of services with their own span metrics
+	DefaultMaxServices = 1000
+	// DefaultMaxOperations is the default number of operations per service with their own span metrics
+	DefaultMaxOperations = 200
+	// DefaultMaxSeries is the default number of label sets of the span metrics
+	DefaultMaxSeries = 10000
+)
+
+// Options holds configuration for the span metrics generated by the collector.
+type Options struct {
+	// Enabled generates the request, error and latency metrics of the spans received by the collector
+	Enabled bool
+	// MaxServices is the maximum number of services with their own metrics, the spans of the
+	// other services are counted together
+	MaxServices int
+	// MaxOperations is the maximum number of operations per service with their own metrics, the spans
+	// of the other operations of the service are counted together
+	MaxOperations int
+	// MaxSeries is the maximum number of service, operation and span kind label sets, which bounds the number of
+	// series whatever the number of services and operations, the spans of the new label sets above it are counted
+	// under other-services and other-operations
+	MaxSeries int
+	// LatencyBuckets are the upper bounds of the latency histogram buckets, as a comma-separated list
+	// of durations, the default buckets are used if empty
+	LatencyBuckets string
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Bool(spanMetricsEnabled, false, "(experimental) Generate the request count, error count and latency histogram "+
+		"metrics per service, operation and span kind from the received spans")
+	flagSet.Int(spanMetricsMaxServices, DefaultMaxServices, "The maximum number of services with their own span metrics, "+
+		"the spans of the other services are reported under the service other-services")
+	flagSet.Int(spanMetricsMaxOperations, DefaultMaxOperations, "The maximum number of operations per service with their own span metrics, "+
+		"the spans of the other operations are reported under the operation other-operations")
+	flagSet.Int(spanMetricsMaxSeries, DefaultMaxSeries, "The maximum number of service, operation and span kind label sets of the span metrics, "+
+		"each of them creates 2 counters and a latency histogram, the spans of the new label sets are reported under the service other-services")
+	flagSet.String(spanMetricsLatencyBuckets, "", "The upper bounds of the latency histogram buckets of the span metrics. "+
+		"Ex: 10ms,100ms,1s,10s")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opts *Options) InitFromViper(v *viper.Viper) *Options {
+	opts.Enabled = v.GetBool(spanMetricsEnabled)
+	opts.MaxServices = v.GetInt(spanMetricsMaxServices)
+	opts.MaxOperations = v.GetInt(spanMetricsMaxOperations)
+	opts.MaxSeries = v.GetInt(spanMetricsMaxSeries)
+	opts.LatencyBuckets = v.GetString(spanMetricsLatencyBuckets)
+	return opts
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.span-metrics.enabled=true",
+		"--collector.span-metrics.max-operations=50",
+		"--collector.span-metrics.max-series=500",
+		"--collector.span-metrics.latency-buckets=10ms,1s",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, Options{
+		Enabled:        true,
+		MaxServices:    DefaultMaxServices,
+		MaxOperations:  50,
+		MaxSeries:      500,
+		LatencyBuckets: "10ms,1s",
+	}, *opts)
+}
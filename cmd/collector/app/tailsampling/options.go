@@ -0,0 +1,85 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"flag"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	tailSamplingDecisionWait      = "collector.tail-sampling.decision-wait"
+	tailSamplingMaxTraces         = "collector.tail-sampling.max-traces"
+	tailSamplingMaxSpans          = "collector.tail-sampling.max-spans"
+	tailSamplingErrors            = "collector.tail-sampling.errors"
+	tailSamplingLatencyThresholds = "collector.tail-sampling.latency-thresholds"
+	tailSamplingTags              = "collector.tail-sampling.tags"
+	tailSamplingProbability       = "collector.tail-sampling.probability"
+
+	// DefaultMaxTraces is the default number of traces buffered while waiting for a decision
+	DefaultMaxTraces = 50000
+	// DefaultMaxSpans is the default number of spans buffered while waiting for a decision
+	DefaultMaxSpans = 1000000
+)
+
+// Options holds configuration for the tail-based sampling stage of the collector.
+type Options struct {
+	// DecisionWait is the time the spans of a trace are buffered before the policies are evaluated,
+	// zero disables tail-based sampling
+	DecisionWait time.Duration
+	// MaxTraces is the maximum number of traces buffered, the oldest trace is decided early when exceeded
+	MaxTraces int
+	// MaxSpans is the maximum number of spans buffered across all the traces, the oldest traces are decided
+	// early when exceeded, so that a single huge trace cannot use unbounded memory
+	MaxSpans int
+	// Errors keeps the traces with at least one span tagged with error=true
+	Errors bool
+	// LatencyThresholds keeps the traces with at least one span longer than the threshold of its service,
+	// as a comma-separated list of service=duration, the service * applies to all the other services
+	LatencyThresholds string
+	// Tags keeps the traces with at least one span with the given tag value,
+	// as a comma-separated list of key=value
+	Tags string
+	// Probability of keeping the traces not kept by any other policy, between 0 and 1
+	Probability float64
+}
+
+// AddFlags adds flags for Options
+func AddFlags(flagSet *flag.FlagSet) {
+	flagSet.Duration(tailSamplingDecisionWait, 0, "(experimental) The time the spans of a trace are buffered before deciding whether the trace is kept. "+
+		"The buffered spans are lost if the collector stops unexpectedly, so it cannot be combined with the persistent queue. "+
+		"Zero value disables tail-based sampling")
+	flagSet.Int(tailSamplingMaxTraces, DefaultMaxTraces, "The maximum number of traces buffered by tail-based sampling, the oldest trace is decided early when exceeded")
+	flagSet.Int(tailSamplingMaxSpans, DefaultMaxSpans, "The maximum number of spans buffered by tail-based sampling, the oldest traces are decided early when exceeded")
+	flagSet.Bool(tailSamplingErrors, true, "Keep the traces with at least one error span")
+	flagSet.String(tailSamplingLatencyThresholds, "", "Keep the traces with at least one span longer than the threshold of its service. "+
+		"Ex: frontend=500ms,*=2s, where * applies to all the other services")
+	flagSet.String(tailSamplingTags, "", "Keep the traces with at least one span with one of the tag values. Ex: http.status_code=500,sampling.priority=1")
+	flagSet.Float64(tailSamplingProbability, 0, "The probability, between 0 and 1, of keeping the traces not kept by any other policy")
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opts *Options) InitFromViper(v *viper.Viper) *Options {
+	opts.DecisionWait = v.GetDuration(tailSamplingDecisionWait)
+	opts.MaxTraces = v.GetInt(tailSamplingMaxTraces)
+	opts.MaxSpans = v.GetInt(tailSamplingMaxSpans)
+	opts.Errors = v.GetBool(tailSamplingErrors)
+	opts.LatencyThresholds = v.GetString(tailSamplingLatencyThresholds)
+	opts.Tags = v.GetString(tailSamplingTags)
+	opts.Probability = v.GetFloat64(tailSamplingProbability)
+	return opts
+}
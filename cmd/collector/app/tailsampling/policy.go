@@ -0,0 +1,190 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+const (
+	allServices = "*"
+
+	// maxRandomNumber is the mask of the trace ID bits compared to the probabilistic boundary,
+	// like the probabilistic samplers of the Jaeger clients
+	maxRandomNumber = ^(uint64(1) << 63)
+)
+
+// Policy decides whether a trace is kept from all the spans received for it during the decision window
+type Policy interface {
+	// Name identifies the policy in the metrics
+	Name() string
+	// Keep returns true if the trace must be written to the storage
+	Keep(traceID model.TraceID, spans []*model.Span) bool
+}
+
+// NewPolicies creates the policies configured by the options, in the order they are evaluated
+func NewPolicies(opts Options) ([]Policy, error) {
+	var policies []Policy
+	if opts.Errors {
+		policies = append(policies, errorPolicy{})
+	}
+	if opts.LatencyThresholds != "" {
+		thresholds, err := parseLatencyThresholds(opts.LatencyThresholds)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, latencyPolicy{thresholds: thresholds})
+	}
+	if opts.Tags != "" {
+		tags, err := parseTags(opts.Tags)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, tagPolicy{tags: tags})
+	}
+	if opts.Probability < 0 || opts.Probability > 1 {
+		return nil, fmt.Errorf("tail sampling probability must be between 0 and 1, got %v", opts.Probability)
+	}
+	if opts.Probability > 0 {
+		policies = append(policies, probabilisticPolicy{boundary: uint64(float64(maxRandomNumber) * opts.Probability)})
+	}
+	return policies, nil
+}
+
+func parseKeyValues(value string) ([][2]string, error) {
+	var kvs [][2]string
+	for _, kv := range strings.Split(value, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid key=value pair %q", kv)
+		}
+		kvs = append(kvs, [2]string{parts[0], parts[1]})
+	}
+	return kvs, nil
+}
+
+func parseLatencyThresholds(value string) (map[string]time.Duration, error) {
+	kvs, err := parseKeyValues(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse tail sampling latency thresholds: %w", err)
+	}
+	thresholds := make(map[string]time.Duration, len(kvs))
+	for _, kv := range kvs {
+		threshold, err := time.ParseDuration(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse tail sampling latency threshold of service %q: %w", kv[0], err)
+		}
+		thresholds[kv[0]] = threshold
+	}
+	return thresholds, nil
+}
+
+func parseTags(value string) (map[string][]string, error) {
+	kvs, err := parseKeyValues(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse tail sampling tags: %w", err)
+	}
+	tags := make(map[string][]string, len(kvs))
+	for _, kv := range kvs {
+		tags[kv[0]] = append(tags[kv[0]], kv[1])
+	}
+	return tags, nil
+}
+
+// errorPolicy keeps the traces with at least one span tagged with error=true
+type errorPolicy struct{}
+
+func (errorPolicy) Name() string {
+	return "error"
+}
+
+func (errorPolicy) Keep(_ model.TraceID, spans []*model.Span) bool {
+	for _, span := range spans {
+		if tag, ok := model.KeyValues(span.Tags).FindByKey("error"); ok && tag.AsString() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// latencyPolicy keeps the traces with at least one span longer than the threshold of its service
+type latencyPolicy struct {
+	thresholds map[string]time.Duration
+}
+
+func (latencyPolicy) Name() string {
+	return "latency"
+}
+
+func (p latencyPolicy) Keep(_ model.TraceID, spans []*model.Span) bool {
+	for _, span := range spans {
+		var serviceName string
+		if span.Process != nil {
+			serviceName = span.Process.ServiceName
+		}
+		threshold, ok := p.thresholds[serviceName]
+		if !ok {
+			threshold, ok = p.thresholds[allServices]
+		}
+		if ok && span.Duration > threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// tagPolicy keeps the traces with at least one span with one of the tag values
+type tagPolicy struct {
+	tags map[string][]string
+}
+
+func (tagPolicy) Name() string {
+	return "tag"
+}
+
+func (p tagPolicy) Keep(_ model.TraceID, spans []*model.Span) bool {
+	for _, span := range spans {
+		for _, tag := range span.Tags {
+			for _, value := range p.tags[tag.Key] {
+				if tag.AsString() == value {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// probabilisticPolicy keeps a fraction of the traces, the decision only depends on the trace ID
+// so that all the collectors make the same decision for a given trace
+type probabilisticPolicy struct {
+	boundary uint64
+}
+
+func (probabilisticPolicy) Name() string {
+	return "probabilistic"
+}
+
+func (p probabilisticPolicy) Keep(traceID model.TraceID, _ []*model.Span) bool {
+	return traceID.Low&maxRandomNumber < p.boundary
+}
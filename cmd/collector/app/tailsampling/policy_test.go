@@ -0,0 +1,152 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--collector.tail-sampling.decision-wait=10s",
+		"--collector.tail-sampling.max-traces=100",
+		"--collector.tail-sampling.max-spans=1000",
+		"--collector.tail-sampling.errors=false",
+		"--collector.tail-sampling.latency-thresholds=frontend=500ms,*=2s",
+		"--collector.tail-sampling.tags=http.status_code=500",
+		"--collector.tail-sampling.probability=0.1",
+	})
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, &Options{
+		DecisionWait:      10 * time.Second,
+		MaxTraces:         100,
+		MaxSpans:          1000,
+		Errors:            false,
+		LatencyThresholds: "frontend=500ms,*=2s",
+		Tags:              "http.status_code=500",
+		Probability:       0.1,
+	}, opts)
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	v, _ := config.Viperize(AddFlags)
+	opts := new(Options).InitFromViper(v)
+	assert.Equal(t, time.Duration(0), opts.DecisionWait)
+	assert.Equal(t, DefaultMaxTraces, opts.MaxTraces)
+	assert.True(t, opts.Errors)
+}
+
+func TestNewPolicies(t *testing.T) {
+	policies, err := NewPolicies(Options{
+		Errors:            true,
+		LatencyThresholds: "frontend=500ms, *=2s",
+		Tags:              "http.status_code=500,http.status_code=503",
+		Probability:       0.5,
+	})
+	require.NoError(t, err)
+	require.Len(t, policies, 4)
+	assert.Equal(t, errorPolicy{}, policies[0])
+	assert.Equal(t, latencyPolicy{thresholds: map[string]time.Duration{
+		"frontend": 500 * time.Millisecond,
+		"*":        2 * time.Second,
+	}}, policies[1])
+	assert.Equal(t, tagPolicy{tags: map[string][]string{"http.status_code": {"500", "503"}}}, policies[2])
+	assert.Equal(t, "probabilistic", policies[3].Name())
+
+	policies, err = NewPolicies(Options{})
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+}
+
+func TestNewPoliciesErrors(t *testing.T) {
+	tests := []struct {
+		opts Options
+		err  string
+	}{
+		{
+			opts: Options{LatencyThresholds: "frontend"},
+			err:  `cannot parse tail sampling latency thresholds: invalid key=value pair "frontend"`,
+		},
+		{
+			opts: Options{LatencyThresholds: "frontend=fast"},
+			err:  `cannot parse tail sampling latency threshold of service "frontend": time: invalid duration "fast"`,
+		},
+		{
+			opts: Options{Tags: "=500"},
+			err:  `cannot parse tail sampling tags: invalid key=value pair "=500"`,
+		},
+		{
+			opts: Options{Probability: 1.5},
+			err:  "tail sampling probability must be between 0 and 1, got 1.5",
+		},
+	}
+	for _, test := range tests {
+		_, err := NewPolicies(test.opts)
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	policy := errorPolicy{}
+	assert.False(t, policy.Keep(model.TraceID{}, []*model.Span{{}, {Tags: model.KeyValues{model.Bool("error", false)}}}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{{}, {Tags: model.KeyValues{model.Bool("error", true)}}}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{{Tags: model.KeyValues{model.String("error", "true")}}}))
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	policy := latencyPolicy{thresholds: map[string]time.Duration{
+		"frontend": 500 * time.Millisecond,
+		"*":        2 * time.Second,
+	}}
+	span := func(service string, duration time.Duration) *model.Span {
+		return &model.Span{Process: model.NewProcess(service, nil), Duration: duration}
+	}
+	assert.False(t, policy.Keep(model.TraceID{}, []*model.Span{span("frontend", 500*time.Millisecond), span("backend", time.Second)}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{span("frontend", 501*time.Millisecond)}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{span("backend", 3*time.Second)}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{{Duration: 3 * time.Second}}))
+
+	policy = latencyPolicy{thresholds: map[string]time.Duration{"frontend": time.Second}}
+	assert.False(t, policy.Keep(model.TraceID{}, []*model.Span{span("backend", time.Hour)}))
+}
+
+func TestTagPolicy(t *testing.T) {
+	policy := tagPolicy{tags: map[string][]string{"http.status_code": {"500", "503"}}}
+	assert.False(t, policy.Keep(model.TraceID{}, []*model.Span{{Tags: model.KeyValues{model.Int64("http.status_code", 200)}}}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{{}, {Tags: model.KeyValues{model.Int64("http.status_code", 503)}}}))
+	assert.True(t, policy.Keep(model.TraceID{}, []*model.Span{{Tags: model.KeyValues{model.String("http.status_code", "500")}}}))
+}
+
+func TestProbabilisticPolicy(t *testing.T) {
+	policies, err := NewPolicies(Options{Probability: 0.5})
+	require.NoError(t, err)
+	policy := policies[0]
+	assert.True(t, policy.Keep(model.NewTraceID(0, 1), nil))
+	assert.False(t, policy.Keep(model.NewTraceID(0, maxRandomNumber), nil))
+	assert.False(t, policy.Keep(model.NewTraceID(1, maxRandomNumber), nil), "only the low bits are used")
+	assert.True(t, policy.Keep(model.NewTraceID(0, 1<<63|1), nil), "the highest bit is ignored")
+
+	policies, err = NewPolicies(Options{Probability: 1})
+	require.NoError(t, err)
+	assert.True(t, policies[0].Keep(model.NewTraceID(0, maxRandomNumber-1), nil))
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// minTickInterval bounds how often the buffered traces are checked for a short decision wait
+const minTickInterval = time.Millisecond
+
+// Sampler is a spanstore.Writer that buffers the spans of each trace for a decision window,
+// then evaluates the policies and only forwards the kept traces to the underlying writer.
+//
+// The spans arriving after the decision follow it, as long as the trace is among
+// the last MaxTraces decided traces, and are dropped otherwise.
+//
+// WriteSpan returns as soon as the span is buffered, so the spans waiting for a decision
+// are lost if the collector stops unexpectedly.
+type Sampler struct {
+	writer       spanstore.Writer
+	logger       *zap.Logger
+	policies     []Policy
+	decisionWait time.Duration
+	maxTraces    int
+	maxSpans     int
+	metrics      *samplerMetrics
+	tracesKept   map[string]metrics.Counter // by policy
+	now          func() time.Time
+
+	lock          sync.Mutex
+	traces        map[model.TraceID]*pendingTrace
+	pending       *list.List // of *pendingTrace, in the order of their first span
+	decided       map[model.TraceID]bool
+	decidedOrder  *list.List // of model.TraceID
+	bufferedSpans int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+type pendingTrace struct {
+	traceID   model.TraceID
+	firstSeen time.Time
+	spans     []*model.Span
+	element   *list.Element
+}
+
+type samplerMetrics struct {
+	// Number of traces dropped because no policy kept them
+	TracesDropped metrics.Counter `metric:"traces-dropped"`
+	// Number of traces decided before the end of the decision window because of the max-traces or max-spans limit
+	TracesEvicted metrics.Counter `metric:"traces-evicted"`
+	// Number of spans written to the storage
+	SpansKept metrics.Counter `metric:"spans-kept"`
+	// Number of spans dropped
+	SpansDropped metrics.Counter `metric:"spans-dropped"`
+	// Number of spans received after the decision of their trace
+	LateSpans metrics.Counter `metric:"late-spans"`
+	// Number of kept spans that failed to be written to the storage
+	WriteErrors metrics.Counter `metric:"write-errors"`
+	// Number of traces buffered waiting for a decision
+	BufferedTraces metrics.Gauge `metric:"buffered-traces"`
+	// Number of spans buffered waiting for a decision
+	BufferedSpans metrics.Gauge `metric:"buffered-spans"`
+}
+
+// NewSampler creates a Sampler forwarding the kept traces to writer, and starts deciding the buffered traces
+func NewSampler(writer spanstore.Writer, opts Options, metricsFactory metrics.Factory, logger *zap.Logger) (*Sampler, error) {
+	if opts.DecisionWait <= 0 {
+		return nil, errors.New("tail sampling decision wait must be positive")
+	}
+	if opts.MaxTraces <= 0 {
+		return nil, errors.New("tail sampling max traces must be positive")
+	}
+	if opts.MaxSpans <= 0 {
+		return nil, errors.New("tail sampling max spans must be positive")
+	}
+	policies, err := NewPolicies(opts)
+	if err != nil {
+		return nil, err
+	}
+	s := newSampler(writer, policies, opts, metricsFactory, logger)
+	s.start(tickInterval(opts.DecisionWait))
+	return s, nil
+}
+
+// tickInterval checks the buffered traces ten times per decision wait, but not more often than minTickInterval
+func tickInterval(decisionWait time.Duration) time.Duration {
+	if tick := decisionWait / 10; tick > minTickInterval {
+		return tick
+	}
+	return minTickInterval
+}
+
+func newSampler(writer spanstore.Writer, policies []Policy, opts Options, metricsFactory metrics.Factory, logger *zap.Logger) *Sampler {
+	f := metricsFactory.Namespace(metrics.NSOptions{Name: "tail-sampling", Tags: nil})
+	sm := &samplerMetrics{}
+	metrics.MustInit(sm, f, nil)
+	tracesKept := make(map[string]metrics.Counter, len(policies))
+	for _, policy := range policies {
+		tracesKept[policy.Name()] = f.Counter(metrics.Options{Name: "traces-kept", Tags: map[string]string{"policy": policy.Name()}})
+	}
+	return &Sampler{
+		writer:       writer,
+		logger:       logger,
+		policies:     policies,
+		decisionWait: opts.DecisionWait,
+		maxTraces:    opts.MaxTraces,
+		maxSpans:     opts.MaxSpans,
+		metrics:      sm,
+		tracesKept:   tracesKept,
+		now:          time.Now,
+		traces:       make(map[model.TraceID]*pendingTrace),
+		pending:      list.New(),
+		decided:      make(map[model.TraceID]bool),
+		decidedOrder: list.New(),
+		done:         make(chan struct{}),
+	}
+}
+
+func (s *Sampler) start(tickInterval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.decideTraces(s.now().Add(-s.decisionWait))
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+// WriteSpan buffers the span until the decision of its trace, or writes it if its trace was already kept
+func (s *Sampler) WriteSpan(ctx context.Context, span *model.Span) error {
+	s.lock.Lock()
+	if keep, ok := s.decided[span.TraceID]; ok {
+		s.lock.Unlock()
+		s.metrics.LateSpans.Inc(1)
+		if !keep {
+			s.metrics.SpansDropped.Inc(1)
+			return nil
+		}
+		s.metrics.SpansKept.Inc(1)
+		return s.writer.WriteSpan(ctx, span)
+	}
+
+	var evicted []*model.Span
+	trace, ok := s.traces[span.TraceID]
+	if !ok {
+		if len(s.traces) >= s.maxTraces {
+			s.metrics.TracesEvicted.Inc(1)
+			evicted = append(evicted, s.evictOldest()...)
+		}
+		trace = &pendingTrace{traceID: span.TraceID, firstSeen: s.now()}
+		trace.element = s.pending.PushBack(trace)
+		s.traces[span.TraceID] = trace
+	}
+	trace.spans = append(trace.spans, span)
+	s.bufferedSpans++
+	// the trace of the span itself is decided if it is the only one left
+	for s.bufferedSpans > s.maxSpans {
+		s.metrics.TracesEvicted.Inc(1)
+		evicted = append(evicted, s.evictOldest()...)
+	}
+	s.lock.Unlock()
+
+	s.writeSpans(ctx, evicted)
+	return nil
+}
+
+// evictOldest decides the oldest buffered trace early and returns its spans if it is kept, must be called under lock
+func (s *Sampler) evictOldest() []*model.Span {
+	oldest := s.pending.Front().Value.(*pendingTrace)
+	if s.decide(oldest) {
+		return oldest.spans
+	}
+	return nil
+}
+
+// Buffered returns the number of traces and spans waiting for a decision
+func (s *Sampler) Buffered() (traces int, spans int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.traces), s.bufferedSpans
+}
+
+// decideTraces decides the traces whose first span was received before the deadline
+func (s *Sampler) decideTraces(deadline time.Time) {
+	var kept [][]*model.Span
+	s.lock.Lock()
+	for e := s.pending.Front(); e != nil; e = s.pending.Front() {
+		trace := e.Value.(*pendingTrace)
+		if trace.firstSeen.After(deadline) {
+			break
+		}
+		if s.decide(trace) {
+			kept = append(kept, trace.spans)
+		}
+	}
+	s.metrics.BufferedTraces.Update(int64(len(s.traces)))
+	s.metrics.BufferedSpans.Update(int64(s.bufferedSpans))
+	s.lock.Unlock()
+
+	for _, spans := range kept {
+		s.writeSpans(context.Background(), spans)
+	}
+}
+
+// decide removes the trace from the buffer and records the decision of the policies, must be called under lock
+func (s *Sampler) decide(trace *pendingTrace) bool {
+	s.pending.Remove(trace.element)
+	delete(s.traces, trace.traceID)
+	s.bufferedSpans -= len(trace.spans)
+
+	keep := false
+	for _, policy := range s.policies {
+		if policy.Keep(trace.traceID, trace.spans) {
+			s.tracesKept[policy.Name()].Inc(1)
+			keep = true
+			break
+		}
+	}
+	if keep {
+		s.metrics.SpansKept.Inc(int64(len(trace.spans)))
+	} else {
+		s.metrics.TracesDropped.Inc(1)
+		s.metrics.SpansDropped.Inc(int64(len(trace.spans)))
+	}
+
+	s.decided[trace.traceID] = keep
+	s.decidedOrder.PushBack(trace.traceID)
+	if s.decidedOrder.Len() > s.maxTraces {
+		delete(s.decided, s.decidedOrder.Remove(s.decidedOrder.Front()).(model.TraceID))
+	}
+	return keep
+}
+
+func (s *Sampler) writeSpans(ctx context.Context, spans []*model.Span) {
+	if batchWriter, ok := s.writer.(spanstore.BatchWriter); ok && len(spans) > 0 {
+		// the spans of a kept trace are written together
+		if err := batchWriter.WriteSpans(ctx, spans); err != nil {
+			s.logger.Error("Failed to save spans of a kept trace", zap.Error(err))
+			s.metrics.WriteErrors.Inc(int64(len(spans)))
+		}
+		return
+	}
+	for _, span := range spans {
+		if err := s.writer.WriteSpan(ctx, span); err != nil {
+			s.logger.Error("Failed to save span of a kept trace", zap.Error(err))
+			s.metrics.WriteErrors.Inc(1)
+		}
+	}
+}
+
+// Close stops deciding periodically and decides all the buffered traces
+func (s *Sampler) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	s.decideTraces(s.now())
+	return nil
+}
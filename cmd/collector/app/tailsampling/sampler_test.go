@@ -0,0 +1,267 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsampling
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+type fakeWriter struct {
+	lock  sync.Mutex
+	spans []*model.Span
+	err   error
+}
+
+func (w *fakeWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	w.spans = append(w.spans, span)
+	return nil
+}
+
+func (w *fakeWriter) getSpans() []*model.Span {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.spans
+}
+
+func newSpan(traceID uint64, spanID uint64, tags ...model.KeyValue) *model.Span {
+	return &model.Span{
+		TraceID: model.NewTraceID(0, traceID),
+		SpanID:  model.NewSpanID(spanID),
+		Tags:    tags,
+	}
+}
+
+func newTestSampler(writer *fakeWriter, maxTraces int, metricsFactory metrics.Factory) (*Sampler, *time.Time) {
+	policies, _ := NewPolicies(Options{Errors: true})
+	s := newSampler(writer, policies, Options{DecisionWait: time.Second, MaxTraces: maxTraces, MaxSpans: 1000}, metricsFactory, zap.NewNop())
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestNewSamplerErrors(t *testing.T) {
+	_, err := NewSampler(&fakeWriter{}, Options{MaxTraces: 1}, metrics.NullFactory, zap.NewNop())
+	assert.EqualError(t, err, "tail sampling decision wait must be positive")
+	_, err = NewSampler(&fakeWriter{}, Options{DecisionWait: time.Second}, metrics.NullFactory, zap.NewNop())
+	assert.EqualError(t, err, "tail sampling max traces must be positive")
+	_, err = NewSampler(&fakeWriter{}, Options{DecisionWait: time.Second, MaxTraces: 1}, metrics.NullFactory, zap.NewNop())
+	assert.EqualError(t, err, "tail sampling max spans must be positive")
+	_, err = NewSampler(&fakeWriter{}, Options{DecisionWait: time.Second, MaxTraces: 1, MaxSpans: 1, Probability: 2}, metrics.NullFactory, zap.NewNop())
+	assert.EqualError(t, err, "tail sampling probability must be between 0 and 1, got 2")
+
+	s, err := NewSampler(&fakeWriter{}, Options{DecisionWait: 5 * time.Nanosecond, MaxTraces: 1, MaxSpans: 1}, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, s.Close())
+}
+
+func TestTickInterval(t *testing.T) {
+	assert.Equal(t, time.Second, tickInterval(10*time.Second))
+	assert.Equal(t, minTickInterval, tickInterval(5*time.Millisecond))
+	assert.Equal(t, minTickInterval, tickInterval(5*time.Nanosecond))
+}
+
+func TestSamplerDecidesAfterDecisionWait(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &fakeWriter{}
+	s, now := newTestSampler(writer, 10, metricsFactory)
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1)))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 1)))
+	*now = now.Add(500 * time.Millisecond)
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 2, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(3, 1, model.Bool("error", true))))
+
+	s.decideTraces(now.Add(-time.Second))
+	assert.Empty(t, writer.getSpans(), "no trace is decided before the end of its decision window")
+	traces, spans := s.Buffered()
+	assert.Equal(t, 3, traces)
+	assert.Equal(t, 4, spans)
+
+	*now = now.Add(500 * time.Millisecond)
+	s.decideTraces(now.Add(-time.Second))
+	assert.Equal(t, []*model.Span{newSpan(1, 1), newSpan(1, 2, model.Bool("error", true))}, writer.getSpans())
+
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-kept", Tags: map[string]string{"policy": "error"}, Value: 1},
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-dropped", Value: 1},
+		metricstest.ExpectedMetric{Name: "tail-sampling.spans-kept", Value: 2},
+		metricstest.ExpectedMetric{Name: "tail-sampling.spans-dropped", Value: 1},
+	)
+	metricsFactory.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "tail-sampling.buffered-traces", Value: 1},
+		metricstest.ExpectedMetric{Name: "tail-sampling.buffered-spans", Value: 1},
+	)
+
+	require.NoError(t, s.Close())
+	assert.Len(t, writer.getSpans(), 3, "the buffered traces are decided on close")
+}
+
+func TestSamplerLateSpans(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &fakeWriter{}
+	s, now := newTestSampler(writer, 10, metricsFactory)
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 1)))
+	s.decideTraces(*now)
+	require.Len(t, writer.getSpans(), 1)
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 2)))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 2, model.Bool("error", true))))
+	assert.Equal(t, []*model.Span{newSpan(1, 1, model.Bool("error", true)), newSpan(1, 2)}, writer.getSpans())
+
+	writer.err = errors.New("write error")
+	assert.EqualError(t, s.WriteSpan(context.Background(), newSpan(1, 3)), "write error")
+
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "tail-sampling.late-spans", Value: 3},
+		metricstest.ExpectedMetric{Name: "tail-sampling.spans-kept", Value: 3},
+		metricstest.ExpectedMetric{Name: "tail-sampling.spans-dropped", Value: 2},
+	)
+}
+
+func TestSamplerMaxTraces(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &fakeWriter{}
+	s, now := newTestSampler(writer, 2, metricsFactory)
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 1)))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 2)))
+	assert.Empty(t, writer.getSpans())
+
+	// the oldest trace is decided early to make room for the new one
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(3, 1)))
+	assert.Equal(t, []*model.Span{newSpan(1, 1, model.Bool("error", true))}, writer.getSpans())
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(4, 1)))
+	assert.Len(t, writer.getSpans(), 1)
+
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-evicted", Value: 2},
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-dropped", Value: 1},
+	)
+
+	// only the last decisions are remembered, the spans of older traces are buffered again
+	s.decideTraces(*now)
+	assert.Len(t, s.decided, 2)
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 2)))
+	assert.Len(t, s.traces, 1)
+}
+
+func TestSamplerMaxSpans(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &fakeWriter{}
+	s, _ := newTestSampler(writer, 10, metricsFactory)
+	s.maxSpans = 3
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 1, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 2)))
+	assert.Empty(t, writer.getSpans())
+
+	// the oldest trace is decided early to make room for the span
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 3)))
+	assert.Equal(t, []*model.Span{newSpan(1, 1, model.Bool("error", true))}, writer.getSpans())
+
+	// a single trace exceeding the limit is decided on its own
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 4)))
+	assert.Len(t, writer.getSpans(), 5)
+	traces, spans := s.Buffered()
+	assert.Equal(t, 0, traces)
+	assert.Equal(t, 0, spans)
+
+	// its later spans follow the decision
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 5)))
+	assert.Len(t, writer.getSpans(), 6)
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "tail-sampling.traces-evicted", Value: 2},
+		metricstest.ExpectedMetric{Name: "tail-sampling.late-spans", Value: 1},
+	)
+}
+
+func TestSamplerWriteErrors(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &fakeWriter{err: errors.New("write error")}
+	s, now := newTestSampler(writer, 10, metricsFactory)
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1, model.Bool("error", true))))
+	s.decideTraces(*now)
+	metricsFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "tail-sampling.write-errors", Value: 1})
+}
+
+type fakeBatchWriter struct {
+	fakeWriter
+	batches [][]*model.Span
+}
+
+func (w *fakeBatchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.batches = append(w.batches, spans)
+	return nil
+}
+
+func TestSamplerWritesBatches(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	writer := &fakeBatchWriter{}
+	policies, _ := NewPolicies(Options{Errors: true})
+	s := newSampler(writer, policies, Options{DecisionWait: time.Second, MaxTraces: 10, MaxSpans: 1000}, metricsFactory, zap.NewNop())
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 2)))
+	s.decideTraces(now.Add(time.Second))
+	require.Len(t, writer.batches, 1)
+	assert.Len(t, writer.batches[0], 2)
+	assert.Empty(t, writer.getSpans())
+
+	writer.err = errors.New("write error")
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 1, model.Bool("error", true))))
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(2, 2)))
+	s.decideTraces(now.Add(time.Second))
+	metricsFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "tail-sampling.write-errors", Value: 2})
+}
+
+func TestSamplerDecidesPeriodically(t *testing.T) {
+	writer := &fakeWriter{}
+	s, err := NewSampler(writer, Options{DecisionWait: 10 * time.Millisecond, MaxTraces: 10, MaxSpans: 1000, Errors: true}, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.WriteSpan(context.Background(), newSpan(1, 1, model.Bool("error", true))))
+	for i := 0; i < 100 && len(writer.getSpans()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Len(t, writer.getSpans(), 1)
+}
@@ -35,6 +35,7 @@ import (
 	zipkinTransport "github.com/uber/jaeger-client-go/transport/zipkin"
 
 	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/processor"
 	zipkinTrift "github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
 	zipkinProto "github.com/jaegertracing/jaeger/proto-gen/zipkin"
 	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
@@ -281,6 +282,12 @@ func TestSaveSpansV2(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, http.StatusInternalServerError, statusCode)
 	assert.EqualValues(t, "Cannot submit Zipkin batch: Bad times ahead\n", resBody)
+
+	handler.zipkinSpansHandler.(*mockZipkinHandler).err = processor.ErrRateLimited
+	statusCode, resBody, err = postBytes(server.URL+`/api/v2/spans`, []byte(`[{"id":"1111111111111111", "traceId":"1111111111111111"}]`), createHeader("application/json"))
+	require.NoError(t, err)
+	assert.EqualValues(t, http.StatusTooManyRequests, statusCode)
+	assert.EqualValues(t, "Cannot submit Zipkin batch: rate limit exceeded\n", resBody)
 }
 
 func TestSaveProtoSpansV2(t *testing.T) {
@@ -29,7 +29,9 @@ import (
 	_ "go.uber.org/automaxprocs"
 	"go.uber.org/zap"
 
+	"github.com/jaegertracing/jaeger/cmd/badger"
 	"github.com/jaegertracing/jaeger/cmd/collector/app"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/servicegraph"
 	"github.com/jaegertracing/jaeger/cmd/docs"
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
@@ -38,6 +40,7 @@ import (
 	ss "github.com/jaegertracing/jaeger/plugin/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/plugin/storage"
 	"github.com/jaegertracing/jaeger/ports"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
 )
 
 const serviceName = "jaeger-collector"
@@ -73,6 +76,7 @@ func main() {
 			if err := storageFactory.Initialize(baseFactory, logger); err != nil {
 				logger.Fatal("Failed to init storage factory", zap.Error(err))
 			}
+			storageFactory.RegisterAdminHandlers(svc.Admin.Handle)
 			spanWriter, err := storageFactory.CreateSpanWriter()
 			if err != nil {
 				logger.Fatal("Failed to create span writer", zap.Error(err))
@@ -87,15 +91,24 @@ func main() {
 				logger.Fatal("Failed to create sampling strategy store", zap.Error(err))
 			}
 
+			collectorOpts := new(app.CollectorOptions).InitFromViper(v)
+			var dependencyWriter dependencystore.Writer
+			if collectorOpts.ServiceGraph.Enabled {
+				dependencyWriter, err = servicegraph.CreateDependencyWriter(storageFactory, logger)
+				if err != nil {
+					logger.Fatal("Failed to create dependency writer", zap.Error(err))
+				}
+			}
+
 			c := app.New(&app.CollectorParams{
-				ServiceName:    serviceName,
-				Logger:         logger,
-				MetricsFactory: metricsFactory,
-				SpanWriter:     spanWriter,
-				StrategyStore:  strategyStore,
-				HealthCheck:    svc.HC(),
+				ServiceName:      serviceName,
+				Logger:           logger,
+				MetricsFactory:   metricsFactory,
+				SpanWriter:       spanWriter,
+				DependencyWriter: dependencyWriter,
+				StrategyStore:    strategyStore,
+				HealthCheck:      svc.HC(),
 			})
-			collectorOpts := new(app.CollectorOptions).InitFromViper(v)
 			c.Start(collectorOpts)
 
 			svc.RunAndThen(func() {
@@ -119,6 +132,7 @@ func main() {
 
 	command.AddCommand(version.Command())
 	command.AddCommand(env.Command())
+	command.AddCommand(badger.Command())
 	command.AddCommand(docs.Command(v))
 
 	config.AddFlags(
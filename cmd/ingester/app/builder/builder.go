@@ -15,61 +15,78 @@
 package builder
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
 	"strings"
 
+	"github.com/Shopify/sarama"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/ingester/app"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/consumer"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/deadletter"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/decorator"
 	kafkaConsumer "github.com/jaegertracing/jaeger/pkg/kafka/consumer"
+	kafkaProducer "github.com/jaegertracing/jaeger/pkg/kafka/producer"
 	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 // CreateConsumer creates a new span consumer for the ingester
 func CreateConsumer(logger *zap.Logger, metricsFactory metrics.Factory, spanWriter spanstore.Writer, options app.Options) (*consumer.Consumer, error) {
-	var unmarshaller kafka.Unmarshaller
-	switch options.Encoding {
-	case kafka.EncodingJSON:
-		unmarshaller = kafka.NewJSONUnmarshaller()
-	case kafka.EncodingProto:
-		unmarshaller = kafka.NewProtobufUnmarshaller()
-	case kafka.EncodingZipkinThrift:
-		unmarshaller = kafka.NewZipkinThriftUnmarshaller()
-	default:
-		return nil, fmt.Errorf(`encoding '%s' not recognised, use one of ("%s")`,
-			options.Encoding, strings.Join(kafka.AllEncodings, "\", \""))
+	spanProcessor, err := createSpanProcessor(logger, metricsFactory, spanWriter, options, options.DedupTTL > 0)
+	if err != nil {
+		return nil, err
 	}
 
-	spParams := processor.SpanProcessorParams{
-		Writer:       spanWriter,
-		Unmarshaller: unmarshaller,
+	var deadLetterPublisher decorator.DeadLetterPublisher
+	if options.DeadLetterTopic != "" {
+		if err := deadletter.ValidateProtocolVersion(options.ProtocolVersion); err != nil {
+			return nil, err
+		}
+		producerConfig := kafkaProducer.Configuration{
+			Brokers:              options.Brokers,
+			RequiredAcks:         sarama.WaitForAll,
+			ProtocolVersion:      options.ProtocolVersion,
+			AuthenticationConfig: options.AuthenticationConfig,
+		}
+		producer, err := producerConfig.NewProducer(logger)
+		if err != nil {
+			return nil, err
+		}
+		deadLetterPublisher = deadletter.NewPublisher(producer, options.DeadLetterTopic)
 	}
-	spanProcessor := processor.NewSpanProcessor(spParams)
 
 	consumerConfig := kafkaConsumer.Configuration{
-		Brokers:              options.Brokers,
-		Topic:                options.Topic,
-		GroupID:              options.GroupID,
-		ClientID:             options.ClientID,
-		ProtocolVersion:      options.ProtocolVersion,
-		AuthenticationConfig: options.AuthenticationConfig,
+		Brokers:               options.Brokers,
+		Topic:                 options.Topic,
+		Topics:                options.Topics,
+		GroupID:               options.GroupID,
+		ClientID:              options.ClientID,
+		ProtocolVersion:       options.ProtocolVersion,
+		AuthenticationConfig:  options.AuthenticationConfig,
+		RebalanceFlushTimeout: options.RebalanceFlushTimeout,
 	}
 	saramaConsumer, err := consumerConfig.NewConsumer(logger)
 	if err != nil {
+		if closer, ok := deadLetterPublisher.(io.Closer); ok {
+			closer.Close()
+		}
 		return nil, err
 	}
 
 	factoryParams := consumer.ProcessorFactoryParams{
-		Topic:          options.Topic,
-		Parallelism:    options.Parallelism,
-		SaramaConsumer: saramaConsumer,
-		BaseProcessor:  spanProcessor,
-		Logger:         logger,
-		Factory:        metricsFactory,
+		Parallelism:         options.Parallelism,
+		SaramaConsumer:      saramaConsumer,
+		BaseProcessor:       spanProcessor,
+		Logger:              logger,
+		Factory:             metricsFactory,
+		RetryOptions:        retryOptions(options, deadLetterPublisher != nil),
+		DeadLetterPublisher: deadLetterPublisher,
 	}
 	processorFactory, err := consumer.NewProcessorFactory(factoryParams)
 	if err != nil {
@@ -82,6 +99,125 @@ func CreateConsumer(logger *zap.Logger, metricsFactory metrics.Factory, spanWrit
 		MetricsFactory:        metricsFactory,
 		Logger:                logger,
 		DeadlockCheckInterval: options.DeadlockInterval,
+		LagCheckInterval:      options.LagCheckInterval,
+		MaxLag:                options.LagMaxMessages,
 	}
 	return consumer.New(consumerParams)
 }
+
+// CreateDeadLetterReplayer creates a replayer which processes the messages of the dead-letter topic
+// with the same unmarshaller, retries and span writer as the consumer.
+func CreateDeadLetterReplayer(logger *zap.Logger, metricsFactory metrics.Factory, spanWriter spanstore.Writer, options app.Options) (*deadletter.Replayer, error) {
+	if options.DeadLetterTopic == "" {
+		return nil, errors.New("the dead-letter topic is not set")
+	}
+	// the dead letters were never written, there is nothing to deduplicate
+	spanProcessor, err := createSpanProcessor(logger, metricsFactory, spanWriter, options, false)
+	if err != nil {
+		return nil, err
+	}
+	retryProcessor := decorator.NewRetryingProcessor(metricsFactory, spanProcessor, retryOptions(options, true)...)
+
+	consumerConfig := kafkaConsumer.Configuration{
+		Brokers:              options.Brokers,
+		ClientID:             options.ClientID,
+		ProtocolVersion:      options.ProtocolVersion,
+		AuthenticationConfig: options.AuthenticationConfig,
+	}
+	client, err := consumerConfig.NewClient(logger)
+	if err != nil {
+		return nil, err
+	}
+	saramaConsumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return deadletter.NewReplayer(client, saramaConsumer, options.DeadLetterTopic, retryProcessor, logger), nil
+}
+
+// createSpanProcessor creates the span processors of the consumed topics,
+// the messages are routed to the processor of their topic when there are several
+func createSpanProcessor(logger *zap.Logger, metricsFactory metrics.Factory, spanWriter spanstore.Writer, options app.Options, deduplicate bool) (processor.SpanProcessor, error) {
+	topics := options.ConsumedTopics
+	if len(topics) == 0 {
+		topics = []app.TopicOptions{{Topic: options.Topic, Encoding: options.Encoding}}
+	}
+	processors := make(map[string]processor.SpanProcessor, len(topics))
+	for _, topic := range topics {
+		if topic.Topic == "" {
+			return nil, errors.New("the name of a consumed topic is empty")
+		}
+		if _, ok := processors[topic.Topic]; ok {
+			return nil, fmt.Errorf("the topic %s is consumed more than once", topic.Topic)
+		}
+		var deduplicator *processor.SpanDeduplicator
+		if deduplicate {
+			params := processor.DeduplicatorParams{
+				TTL:        options.DedupTTL,
+				MaxEntries: options.DedupMaxEntries,
+				Logger:     logger,
+			}
+			// the partitions of the topics are saved to their own directories
+			if options.DedupDirectory != "" {
+				params.Directory = filepath.Join(options.DedupDirectory, topic.Topic)
+			}
+			var err error
+			if deduplicator, err = processor.NewSpanDeduplicator(metricsFactory, params); err != nil {
+				return nil, err
+			}
+		}
+		spanProcessor, err := createTopicSpanProcessor(spanWriter, topic, deduplicator)
+		if err != nil {
+			return nil, err
+		}
+		processors[topic.Topic] = spanProcessor
+	}
+	if len(processors) == 1 {
+		return processors[topics[0].Topic], nil
+	}
+	return processor.NewTopicRouter(processors), nil
+}
+
+func createTopicSpanProcessor(spanWriter spanstore.Writer, topic app.TopicOptions, deduplicator *processor.SpanDeduplicator) (processor.SpanProcessor, error) {
+	var unmarshaller kafka.Unmarshaller
+	switch topic.Encoding {
+	case kafka.EncodingJSON:
+		unmarshaller = kafka.NewJSONUnmarshaller()
+	case kafka.EncodingProto:
+		unmarshaller = kafka.NewProtobufUnmarshaller()
+	case kafka.EncodingZipkinThrift:
+		unmarshaller = kafka.NewZipkinThriftUnmarshaller()
+	case kafka.EncodingOTLPProto:
+		unmarshaller = kafka.NewOTLPProtobufUnmarshaller()
+	case kafka.EncodingOTLPJSON:
+		unmarshaller = kafka.NewOTLPJSONUnmarshaller()
+	default:
+		return nil, fmt.Errorf(`encoding '%s' not recognised, use one of ("%s")`,
+			topic.Encoding, strings.Join(kafka.AllEncodings, "\", \""))
+	}
+
+	spParams := processor.SpanProcessorParams{
+		Writer:            spanWriter,
+		Unmarshaller:      unmarshaller,
+		Deduplicator:      deduplicator,
+		ServiceNamePrefix: topic.ServiceNamePrefix,
+	}
+	return processor.NewSpanProcessor(spParams), nil
+}
+
+// retryOptions returns the retry options of the ingester, the error is propagated after the retries
+// when it is handled by the dead-letter publisher
+func retryOptions(options app.Options, propagateError bool) []decorator.RetryOption {
+	opts := []decorator.RetryOption{
+		decorator.MaxAttempts(options.RetryMaxAttempts),
+		decorator.PropagateError(propagateError),
+	}
+	if options.RetryMinBackoff > 0 {
+		opts = append(opts, decorator.MinBackoffInterval(options.RetryMinBackoff))
+	}
+	if options.RetryMaxBackoff > 0 {
+		opts = append(opts, decorator.MaxBackoffInterval(options.RetryMaxBackoff))
+	}
+	return opts
+}
@@ -15,7 +15,9 @@
 package consumer
 
 import (
+	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Shopify/sarama"
@@ -24,9 +26,23 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"github.com/jaegertracing/jaeger/pkg/kafka/consumer"
 )
 
+// topicPartition identifies a partition of one of the consumed topics
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+func partitionOf(pc sc.PartitionConsumer) topicPartition {
+	return topicPartition{topic: pc.Topic(), partition: pc.Partition()}
+}
+
+// noOffset is the last offset of a partition no message was consumed from yet
+const noOffset = int64(-1)
+
 // Params are the parameters of a Consumer
 type Params struct {
 	ProcessorFactory      ProcessorFactory
@@ -34,6 +50,10 @@ type Params struct {
 	Logger                *zap.Logger
 	InternalConsumer      consumer.Consumer
 	DeadlockCheckInterval time.Duration
+	// LagCheckInterval is the interval to compute the partition lags at, 0 disables the lag monitor
+	LagCheckInterval time.Duration
+	// MaxLag is the partition lag above which the ingester is reported unavailable, 0 disables it
+	MaxLag int64
 }
 
 // Consumer uses sarama to consume and handle messages from kafka
@@ -45,14 +65,16 @@ type Consumer struct {
 	processorFactory ProcessorFactory
 
 	deadlockDetector deadlockDetector
+	lagMonitor       *lagMonitor
 
-	partitionIDToState  map[int32]*consumerState
+	partitionIDToState  map[topicPartition]*consumerState
 	partitionMapLock    sync.Mutex
 	partitionsHeld      int64
 	partitionsHeldGauge metrics.Gauge
 }
 
 type consumerState struct {
+	lastOffset        int64 // accessed atomically, kept first for 64-bit alignment
 	wg                sync.WaitGroup
 	partitionConsumer sc.PartitionConsumer
 }
@@ -60,43 +82,53 @@ type consumerState struct {
 // New is a constructor for a Consumer
 func New(params Params) (*Consumer, error) {
 	deadlockDetector := newDeadlockDetector(params.MetricsFactory, params.Logger, params.DeadlockCheckInterval)
-	return &Consumer{
+	c := &Consumer{
 		metricsFactory:      params.MetricsFactory,
 		logger:              params.Logger,
 		internalConsumer:    params.InternalConsumer,
 		processorFactory:    params.ProcessorFactory,
 		deadlockDetector:    deadlockDetector,
-		partitionIDToState:  make(map[int32]*consumerState),
+		partitionIDToState:  make(map[topicPartition]*consumerState),
 		partitionsHeldGauge: partitionsHeldGauge(params.MetricsFactory),
-	}, nil
+	}
+	c.lagMonitor = newLagMonitor(params.MetricsFactory, params.Logger, params.LagCheckInterval, params.MaxLag, c.partitionLags, c.lagGauge)
+	return c, nil
+}
+
+// HealthCheckStatus returns the channel the ingester is reported unavailable on while it lags behind
+func (c *Consumer) HealthCheckStatus() chan healthcheck.Status {
+	return c.lagMonitor.hcStatus
 }
 
 // Start begins consuming messages in a go routine
 func (c *Consumer) Start() {
 	c.deadlockDetector.start()
+	c.lagMonitor.start()
 	go func() {
 		c.logger.Info("Starting main loop")
 		for pc := range c.internalConsumer.Partitions() {
+			tp := partitionOf(pc)
 			c.partitionMapLock.Lock()
-			if p, ok := c.partitionIDToState[pc.Partition()]; ok {
+			if p, ok := c.partitionIDToState[tp]; ok {
 				// This is a guard against simultaneously draining messages
 				// from the last time the partition was assigned and
 				// processing new messages for the same partition, which may lead
 				// to the cleanup process not completing
 				p.wg.Wait()
 			}
-			c.partitionIDToState[pc.Partition()] = &consumerState{partitionConsumer: pc}
-			c.partitionIDToState[pc.Partition()].wg.Add(2)
+			c.partitionIDToState[tp] = &consumerState{lastOffset: noOffset, partitionConsumer: pc}
+			c.partitionIDToState[tp].wg.Add(2)
 			c.partitionMapLock.Unlock()
-			c.partitionMetrics(pc.Partition()).startCounter.Inc(1)
+			c.partitionMetrics(tp).startCounter.Inc(1)
 			go c.handleMessages(pc)
-			go c.handleErrors(pc.Partition(), pc.Errors())
+			go c.handleErrors(tp, pc.Errors())
 		}
 	}()
 }
 
 // Close closes the Consumer and underlying sarama consumer
 func (c *Consumer) Close() error {
+	c.lagMonitor.close()
 	c.partitionMapLock.Lock()
 	for _, p := range c.partitionIDToState {
 		c.closePartition(p.partitionConsumer)
@@ -105,17 +137,27 @@ func (c *Consumer) Close() error {
 	c.partitionMapLock.Unlock()
 	c.deadlockDetector.close()
 	c.logger.Info("Closing parent consumer")
-	return c.internalConsumer.Close()
+	err := c.internalConsumer.Close()
+	// the dead-letter publisher is closed once no partition processes messages anymore
+	if closer, ok := c.processorFactory.deadLetter.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 func (c *Consumer) handleMessages(pc sc.PartitionConsumer) {
-	c.logger.Info("Starting message handler", zap.Int32("partition", pc.Partition()))
+	tp := partitionOf(pc)
+	c.logger.Info("Starting message handler", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 	c.partitionMapLock.Lock()
 	c.partitionsHeld++
 	c.partitionsHeldGauge.Update(c.partitionsHeld)
-	wg := &c.partitionIDToState[pc.Partition()].wg
+	state := c.partitionIDToState[tp]
 	c.partitionMapLock.Unlock()
+	wg := &state.wg
 	defer func() {
+		atomic.StoreInt64(&state.lastOffset, noOffset)
 		c.closePartition(pc)
 		wg.Done()
 		c.partitionMapLock.Lock()
@@ -124,7 +166,7 @@ func (c *Consumer) handleMessages(pc sc.PartitionConsumer) {
 		c.partitionMapLock.Unlock()
 	}()
 
-	msgMetrics := c.newMsgMetrics(pc.Partition())
+	msgMetrics := c.newMsgMetrics(tp)
 
 	var msgProcessor processor.SpanProcessor
 
@@ -135,47 +177,64 @@ func (c *Consumer) handleMessages(pc sc.PartitionConsumer) {
 		select {
 		case msg, ok := <-pc.Messages():
 			if !ok {
-				c.logger.Info("Message channel closed. ", zap.Int32("partition", pc.Partition()))
+				c.logger.Info("Message channel closed. ", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 				return
 			}
 			c.logger.Debug("Got msg", zap.Any("msg", msg))
 			msgMetrics.counter.Inc(1)
 			msgMetrics.offsetGauge.Update(msg.Offset)
 			msgMetrics.lagGauge.Update(pc.HighWaterMarkOffset() - msg.Offset - 1)
+			atomic.StoreInt64(&state.lastOffset, msg.Offset)
 			deadlockDetector.incrementMsgCount()
 
 			if msgProcessor == nil {
-				msgProcessor = c.processorFactory.new(pc.Partition(), msg.Offset-1)
+				msgProcessor = c.processorFactory.new(tp.topic, tp.partition, msg.Offset-1)
+				// closing the processor waits for the in-flight messages and marks their offsets,
+				// so they are committed before the partition is handed off on rebalance
 				defer msgProcessor.Close()
 			}
 
 			msgProcessor.Process(saramaMessageWrapper{msg})
 
 		case <-deadlockDetector.closePartitionChannel():
-			c.logger.Info("Closing partition due to inactivity", zap.Int32("partition", pc.Partition()))
+			c.logger.Info("Closing partition due to inactivity", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 			return
 		}
 	}
 }
 
+// partitionLags returns the lag of the held partitions messages were consumed from
+func (c *Consumer) partitionLags() map[topicPartition]int64 {
+	c.partitionMapLock.Lock()
+	defer c.partitionMapLock.Unlock()
+	lags := make(map[topicPartition]int64, len(c.partitionIDToState))
+	for tp, state := range c.partitionIDToState {
+		if offset := atomic.LoadInt64(&state.lastOffset); offset != noOffset {
+			lags[tp] = state.partitionConsumer.HighWaterMarkOffset() - offset - 1
+		}
+	}
+	return lags
+}
+
 func (c *Consumer) closePartition(partitionConsumer sc.PartitionConsumer) {
-	c.logger.Info("Closing partition consumer", zap.Int32("partition", partitionConsumer.Partition()))
+	tp := partitionOf(partitionConsumer)
+	c.logger.Info("Closing partition consumer", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 	partitionConsumer.Close() // blocks until messages channel is drained
-	c.partitionMetrics(partitionConsumer.Partition()).closeCounter.Inc(1)
-	c.logger.Info("Closed partition consumer", zap.Int32("partition", partitionConsumer.Partition()))
+	c.partitionMetrics(tp).closeCounter.Inc(1)
+	c.logger.Info("Closed partition consumer", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 }
 
-func (c *Consumer) handleErrors(partition int32, errChan <-chan *sarama.ConsumerError) {
-	c.logger.Info("Starting error handler", zap.Int32("partition", partition))
+func (c *Consumer) handleErrors(tp topicPartition, errChan <-chan *sarama.ConsumerError) {
+	c.logger.Info("Starting error handler", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 	c.partitionMapLock.Lock()
-	wg := &c.partitionIDToState[partition].wg
+	wg := &c.partitionIDToState[tp].wg
 	c.partitionMapLock.Unlock()
 	defer wg.Done()
 
-	errMetrics := c.newErrMetrics(partition)
+	errMetrics := c.newErrMetrics(tp)
 	for err := range errChan {
 		errMetrics.errCounter.Inc(1)
 		c.logger.Error("Error consuming from Kafka", zap.Error(err))
 	}
-	c.logger.Info("Finished handling errors", zap.Int32("partition", partition))
+	c.logger.Info("Finished handling errors", zap.String("topic", tp.topic), zap.Int32("partition", tp.partition))
 }
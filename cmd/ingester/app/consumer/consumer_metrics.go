@@ -37,25 +37,29 @@ type partitionMetrics struct {
 	closeCounter metrics.Counter
 }
 
-func (c *Consumer) namespace(partition int32) metrics.Factory {
-	return c.metricsFactory.Namespace(metrics.NSOptions{Name: consumerNamespace, Tags: map[string]string{"partition": strconv.Itoa(int(partition))}})
+func (c *Consumer) namespace(tp topicPartition) metrics.Factory {
+	return c.metricsFactory.Namespace(metrics.NSOptions{Name: consumerNamespace, Tags: map[string]string{"topic": tp.topic, "partition": strconv.Itoa(int(tp.partition))}})
 }
 
-func (c *Consumer) newMsgMetrics(partition int32) msgMetrics {
-	f := c.namespace(partition)
+func (c *Consumer) newMsgMetrics(tp topicPartition) msgMetrics {
+	f := c.namespace(tp)
 	return msgMetrics{
 		counter:     f.Counter(metrics.Options{Name: "messages", Tags: nil}),
 		offsetGauge: f.Gauge(metrics.Options{Name: "current-offset", Tags: nil}),
-		lagGauge:    f.Gauge(metrics.Options{Name: "offset-lag", Tags: nil}),
+		lagGauge:    c.lagGauge(tp),
 	}
 }
 
-func (c *Consumer) newErrMetrics(partition int32) errMetrics {
-	return errMetrics{errCounter: c.namespace(partition).Counter(metrics.Options{Name: "errors", Tags: nil})}
+func (c *Consumer) lagGauge(tp topicPartition) metrics.Gauge {
+	return c.namespace(tp).Gauge(metrics.Options{Name: "offset-lag", Tags: nil})
 }
 
-func (c *Consumer) partitionMetrics(partition int32) partitionMetrics {
-	f := c.namespace(partition)
+func (c *Consumer) newErrMetrics(tp topicPartition) errMetrics {
+	return errMetrics{errCounter: c.namespace(tp).Counter(metrics.Options{Name: "errors", Tags: nil})}
+}
+
+func (c *Consumer) partitionMetrics(tp topicPartition) partitionMetrics {
+	f := c.namespace(tp)
 	return partitionMetrics{
 		closeCounter: f.Counter(metrics.Options{Name: "partition-close", Tags: nil}),
 		startCounter: f.Counter(metrics.Options{Name: "partition-start", Tags: nil})}
@@ -89,16 +89,15 @@ func newConsumer(
 	consumer consumer.Consumer) *Consumer {
 
 	logger, _ := zap.NewDevelopment()
-	return &Consumer{
+	c := &Consumer{
 		metricsFactory:      metricsFactory,
 		logger:              logger,
 		internalConsumer:    consumer,
-		partitionIDToState:  make(map[int32]*consumerState),
+		partitionIDToState:  make(map[topicPartition]*consumerState),
 		partitionsHeldGauge: partitionsHeldGauge(metricsFactory),
 		deadlockDetector:    newDeadlockDetector(metricsFactory, logger, time.Second),
 
 		processorFactory: ProcessorFactory{
-			topic:          topic,
 			consumer:       consumer,
 			metricsFactory: metricsFactory,
 			logger:         logger,
@@ -106,6 +105,8 @@ func newConsumer(
 			parallelism:    1,
 		},
 	}
+	c.lagMonitor = newLagMonitor(metricsFactory, logger, 0, 0, c.partitionLags, c.lagGauge)
+	return c
 }
 
 func TestSaramaConsumerWrapper_MarkPartitionOffset(t *testing.T) {
@@ -138,8 +139,8 @@ func TestSaramaConsumerWrapper_start_Messages(t *testing.T) {
 
 	undertest := newConsumer(localFactory, topic, mp, newSaramaClusterConsumer(saramaPartitionConsumer))
 
-	undertest.partitionIDToState = map[int32]*consumerState{
-		partition: {
+	undertest.partitionIDToState = map[topicPartition]*consumerState{
+		{topic: topic, partition: partition}: {
 			partitionConsumer: &partitionConsumerWrapper{
 				topic:             topic,
 				partition:         partition,
@@ -161,15 +162,17 @@ func TestSaramaConsumerWrapper_start_Messages(t *testing.T) {
 	mp.AssertExpectations(t)
 	// Ensure that the partition consumer was updated in the map
 	assert.Equal(t, saramaPartitionConsumer.HighWaterMarkOffset(),
-		undertest.partitionIDToState[partition].partitionConsumer.HighWaterMarkOffset())
+		undertest.partitionIDToState[topicPartition{topic: topic, partition: partition}].partitionConsumer.HighWaterMarkOffset())
+	assert.Equal(t, map[topicPartition]int64{{topic: topic, partition: partition}: 0}, undertest.partitionLags())
 	undertest.Close()
+	assert.Empty(t, undertest.partitionLags())
 
 	localFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
 		Name:  "sarama-consumer.partitions-held",
 		Value: 0,
 	})
 
-	partitionTag := map[string]string{"partition": fmt.Sprint(partition)}
+	partitionTag := map[string]string{"topic": topic, "partition": fmt.Sprint(partition)}
 	localFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
 		Name:  "sarama-consumer.messages",
 		Tags:  partitionTag,
@@ -215,7 +218,7 @@ func TestSaramaConsumerWrapper_start_Errors(t *testing.T) {
 			continue
 		}
 
-		partitionTag := map[string]string{"partition": fmt.Sprint(partition)}
+		partitionTag := map[string]string{"topic": topic, "partition": fmt.Sprint(partition)}
 		localFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
 			Name:  "sarama-consumer.errors",
 			Tags:  partitionTag,
@@ -247,7 +250,7 @@ func TestHandleClosePartition(t *testing.T) {
 		undertest.deadlockDetector.allPartitionsDeadlockDetector.incrementMsgCount() // Don't trigger panic on all partitions detector
 		time.Sleep(100 * time.Millisecond)
 		c, _ := metricsFactory.Snapshot()
-		if c["sarama-consumer.partition-close|partition=316"] == 1 {
+		if c["sarama-consumer.partition-close|partition=316|topic=morekuzambu"] == 1 {
 			return
 		}
 	}
@@ -0,0 +1,126 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+)
+
+// lagMonitor periodically computes the offset lag of the held partitions from their high-water marks.
+// The lag is reported even when no messages are consumed, which the per message lag gauge cannot do.
+//
+// When maxLag is set, the ingester is reported unavailable on the health check status channel as soon as
+// any partition lags behind by more than maxLag messages, and ready again once all partitions caught up.
+// Only the transitions are sent, so the monitor does not override the status set by others.
+type lagMonitor struct {
+	logger   *zap.Logger
+	interval time.Duration
+	maxLag   int64
+	lags     func() map[topicPartition]int64
+
+	partitionLag func(tp topicPartition) metrics.Gauge
+	totalLag     metrics.Gauge
+	maxLagGauge  metrics.Gauge
+
+	hcStatus chan healthcheck.Status
+	lagging  bool
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newLagMonitor(
+	metricsFactory metrics.Factory,
+	logger *zap.Logger,
+	interval time.Duration,
+	maxLag int64,
+	lags func() map[topicPartition]int64,
+	partitionLag func(tp topicPartition) metrics.Gauge,
+) *lagMonitor {
+	f := metricsFactory.Namespace(metrics.NSOptions{Name: consumerNamespace, Tags: nil})
+	return &lagMonitor{
+		logger:       logger,
+		interval:     interval,
+		maxLag:       maxLag,
+		lags:         lags,
+		partitionLag: partitionLag,
+		totalLag:     f.Gauge(metrics.Options{Name: "offset-lag-total", Tags: nil}),
+		maxLagGauge:  f.Gauge(metrics.Options{Name: "offset-lag-max", Tags: nil}),
+		hcStatus:     make(chan healthcheck.Status),
+		done:         make(chan struct{}),
+	}
+}
+
+func (m *lagMonitor) start() {
+	if m.interval == 0 {
+		m.logger.Debug("Lag monitor disabled")
+		return
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.check()
+			case <-m.done:
+				return
+			}
+		}
+	}()
+}
+
+func (m *lagMonitor) check() {
+	var total, max int64
+	for tp, lag := range m.lags() {
+		m.partitionLag(tp).Update(lag)
+		total += lag
+		if lag > max {
+			max = lag
+		}
+	}
+	m.totalLag.Update(total)
+	m.maxLagGauge.Update(max)
+
+	if m.maxLag == 0 {
+		return
+	}
+	if lagging := max > m.maxLag; lagging != m.lagging {
+		status := healthcheck.Ready
+		if lagging {
+			status = healthcheck.Unavailable
+			m.logger.Warn("Offset lag exceeds the threshold", zap.Int64("lag", max), zap.Int64("threshold", m.maxLag))
+		} else {
+			m.logger.Info("Offset lag is back under the threshold", zap.Int64("lag", max), zap.Int64("threshold", m.maxLag))
+		}
+		select {
+		case m.hcStatus <- status:
+			m.lagging = lagging
+		case <-m.done:
+		}
+	}
+}
+
+func (m *lagMonitor) close() {
+	close(m.done)
+	m.wg.Wait()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+)
+
+type fakeLags struct {
+	sync.Mutex
+	lags map[topicPartition]int64
+}
+
+func (f *fakeLags) set(lags map[topicPartition]int64) {
+	f.Lock()
+	defer f.Unlock()
+	f.lags = lags
+}
+
+func (f *fakeLags) get() map[topicPartition]int64 {
+	f.Lock()
+	defer f.Unlock()
+	return f.lags
+}
+
+func newTestLagMonitor(metricsFactory metrics.Factory, interval time.Duration, maxLag int64, lags *fakeLags) *lagMonitor {
+	partitionLag := func(tp topicPartition) metrics.Gauge {
+		return metricsFactory.Gauge(metrics.Options{Name: "offset-lag", Tags: map[string]string{"topic": tp.topic, "partition": strconv.Itoa(int(tp.partition))}})
+	}
+	return newLagMonitor(metricsFactory, zap.NewNop(), interval, maxLag, lags.get, partitionLag)
+}
+
+func TestLagMonitorMetrics(t *testing.T) {
+	localFactory := metricstest.NewFactory(0)
+	lags := &fakeLags{lags: map[topicPartition]int64{{"a", 1}: 5, {"b", 1}: 10}}
+	m := newTestLagMonitor(localFactory, time.Minute, 0, lags)
+
+	m.check()
+
+	localFactory.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "offset-lag", Tags: map[string]string{"topic": "a", "partition": "1"}, Value: 5},
+		metricstest.ExpectedMetric{Name: "offset-lag", Tags: map[string]string{"topic": "b", "partition": "1"}, Value: 10},
+		metricstest.ExpectedMetric{Name: "sarama-consumer.offset-lag-total", Value: 15},
+		metricstest.ExpectedMetric{Name: "sarama-consumer.offset-lag-max", Value: 10},
+	)
+	assert.False(t, m.lagging)
+}
+
+func TestLagMonitorReadiness(t *testing.T) {
+	lags := &fakeLags{lags: map[topicPartition]int64{{"a", 1}: 5, {"a", 2}: 100}}
+	m := newTestLagMonitor(metrics.NullFactory, time.Millisecond, 10, lags)
+	m.start()
+	defer m.close()
+
+	assert.Equal(t, healthcheck.Unavailable, <-m.hcStatus)
+
+	lags.set(map[topicPartition]int64{{"a", 1}: 5, {"a", 2}: 10})
+	assert.Equal(t, healthcheck.Ready, <-m.hcStatus)
+}
+
+func TestLagMonitorDisabled(t *testing.T) {
+	lags := &fakeLags{lags: map[topicPartition]int64{{"a", 1}: 100}}
+	m := newTestLagMonitor(metrics.NullFactory, 0, 10, lags)
+	m.start()
+	m.close()
+}
+
+func TestLagMonitorCloseUnblocksStatus(t *testing.T) {
+	lags := &fakeLags{lags: map[topicPartition]int64{{"a", 1}: 100}}
+	m := newTestLagMonitor(metrics.NullFactory, time.Millisecond, 10, lags)
+	m.start()
+	// nobody reads the status, closing must not block
+	time.Sleep(10 * time.Millisecond)
+	m.close()
+	assert.False(t, m.lagging)
+}
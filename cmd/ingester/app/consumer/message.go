@@ -50,3 +50,12 @@ func (m saramaMessageWrapper) Partition() int32 {
 func (m saramaMessageWrapper) Offset() int64 {
 	return m.ConsumerMessage.Offset
 }
+
+// Headers returns the record headers of the message, keyed by name.
+func (m saramaMessageWrapper) Headers() map[string]string {
+	headers := make(map[string]string, len(m.ConsumerMessage.Headers))
+	for _, h := range m.ConsumerMessage.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	return headers
+}
@@ -28,6 +28,9 @@ func TestSaramaMessageWrapper(t *testing.T) {
 		Topic:     "some topic",
 		Partition: 555,
 		Offset:    1942,
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("some header"), Value: []byte("some header value")},
+		},
 	}
 
 	wrappedMessage := saramaMessageWrapper{saramaMessage}
@@ -37,4 +40,5 @@ func TestSaramaMessageWrapper(t *testing.T) {
 	assert.Equal(t, saramaMessage.Topic, wrappedMessage.Topic())
 	assert.Equal(t, saramaMessage.Partition, wrappedMessage.Partition())
 	assert.Equal(t, saramaMessage.Offset, wrappedMessage.Offset())
+	assert.Equal(t, map[string]string{"some header": "some header value"}, wrappedMessage.Headers())
 }
@@ -75,14 +75,11 @@ func (m *Manager) Start() {
 		for {
 			select {
 			case <-time.After(resetInterval):
-				offset := m.list.setToHighestContiguous()
-				if lastCommittedOffset != offset {
-					m.offsetCommitCount.Inc(1)
-					m.lastCommittedOffset.Update(offset)
-					m.markOffsetFunction(offset)
-					lastCommittedOffset = offset
-				}
+				lastCommittedOffset = m.commit(lastCommittedOffset)
 			case <-m.close:
+				// flush the offsets of the messages processed since the last tick,
+				// so they are committed when the partition is handed off
+				m.commit(lastCommittedOffset)
 				m.isClosed.Done()
 				return
 			}
@@ -90,7 +87,17 @@ func (m *Manager) Start() {
 	}()
 }
 
-// Close closes the Manager
+func (m *Manager) commit(lastCommittedOffset int64) int64 {
+	offset := m.list.setToHighestContiguous()
+	if lastCommittedOffset != offset {
+		m.offsetCommitCount.Inc(1)
+		m.lastCommittedOffset.Update(offset)
+		m.markOffsetFunction(offset)
+	}
+	return offset
+}
+
+// Close marks the highest contiguous offset processed so far and closes the Manager
 func (m *Manager) Close() error {
 	close(m.close)
 	m.isClosed.Wait()
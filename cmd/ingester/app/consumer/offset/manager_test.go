@@ -62,3 +62,18 @@ func TestCache(t *testing.T) {
 	manager.MarkOffset(offset)
 	manager.Close()
 }
+
+func TestCloseMarksProcessedOffset(t *testing.T) {
+	offset := int64(1498)
+
+	var captureOffset int64
+	fakeMarker := func(offset int64) {
+		captureOffset = offset
+	}
+	manager := NewManager(offset-1, fakeMarker, 1, metrics.NullFactory)
+	manager.Start()
+	manager.MarkOffset(offset)
+	manager.Close()
+
+	assert.Equal(t, offset, captureOffset)
+}
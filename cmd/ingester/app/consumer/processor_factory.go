@@ -29,55 +29,77 @@ import (
 // ProcessorFactoryParams are the parameters of a ProcessorFactory
 type ProcessorFactoryParams struct {
 	Parallelism    int
-	Topic          string
 	BaseProcessor  processor.SpanProcessor
 	SaramaConsumer consumer.Consumer
 	Factory        metrics.Factory
 	Logger         *zap.Logger
 	RetryOptions   []decorator.RetryOption
+	// DeadLetterPublisher receives the messages failed after all retries, they are dropped if nil
+	DeadLetterPublisher decorator.DeadLetterPublisher
 }
 
 // ProcessorFactory is a factory for creating startedProcessors
 type ProcessorFactory struct {
-	topic          string
 	consumer       consumer.Consumer
 	metricsFactory metrics.Factory
 	logger         *zap.Logger
 	baseProcessor  processor.SpanProcessor
 	parallelism    int
 	retryOptions   []decorator.RetryOption
+	deadLetter     decorator.DeadLetterPublisher
 }
 
 // NewProcessorFactory constructs a new ProcessorFactory
 func NewProcessorFactory(params ProcessorFactoryParams) (*ProcessorFactory, error) {
 	return &ProcessorFactory{
-		topic:          params.Topic,
 		consumer:       params.SaramaConsumer,
 		metricsFactory: params.Factory,
 		logger:         params.Logger,
 		baseProcessor:  params.BaseProcessor,
 		parallelism:    params.Parallelism,
 		retryOptions:   params.RetryOptions,
+		deadLetter:     params.DeadLetterPublisher,
 	}, nil
 }
 
-func (c *ProcessorFactory) new(partition int32, minOffset int64) processor.SpanProcessor {
-	c.logger.Info("Creating new processors", zap.Int32("partition", partition))
+func (c *ProcessorFactory) new(topic string, partition int32, minOffset int64) processor.SpanProcessor {
+	c.logger.Info("Creating new processors", zap.String("topic", topic), zap.Int32("partition", partition))
 
 	markOffset := func(offset int64) {
-		c.consumer.MarkPartitionOffset(c.topic, partition, offset, "")
+		c.consumer.MarkPartitionOffset(topic, partition, offset, "")
 	}
 
-	om := offset.NewManager(minOffset, markOffset, partition, c.metricsFactory)
+	offsetMetrics := c.metricsFactory.Namespace(metrics.NSOptions{Name: "", Tags: map[string]string{"topic": topic}})
+	om := offset.NewManager(minOffset, markOffset, partition, offsetMetrics)
 
 	retryProcessor := decorator.NewRetryingProcessor(c.metricsFactory, c.baseProcessor, c.retryOptions...)
+	if c.deadLetter != nil {
+		retryProcessor = decorator.NewDeadLetterProcessor(c.metricsFactory, retryProcessor, c.deadLetter, c.logger)
+	}
 	cp := NewCommittingProcessor(retryProcessor, om)
 	spanProcessor := processor.NewDecoratedProcessor(c.metricsFactory, cp)
 	pp := processor.NewParallelProcessor(spanProcessor, c.parallelism, c.logger)
 
+	if closer, ok := c.baseProcessor.(processor.PartitionCloser); ok {
+		return newStartedProcessor(pp, om, &partitionCloser{closer: closer, topic: topic, partition: partition})
+	}
 	return newStartedProcessor(pp, om)
 }
 
+// partitionCloser notifies the base processor once the processors of a partition are closed
+type partitionCloser struct {
+	closer    processor.PartitionCloser
+	topic     string
+	partition int32
+}
+
+func (p *partitionCloser) Start() {}
+
+func (p *partitionCloser) Close() error {
+	p.closer.ClosePartition(p.topic, p.partition)
+	return nil
+}
+
 type service interface {
 	Start()
 	io.Closer
@@ -15,15 +15,20 @@
 package consumer
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	kmocks "github.com/jaegertracing/jaeger/cmd/ingester/app/consumer/mocks"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/decorator"
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/mocks"
 )
 
@@ -47,7 +52,6 @@ func Test_new(t *testing.T) {
 	sp.On("Process", mock.Anything).Return(nil)
 
 	pf := ProcessorFactory{
-		topic:          topic,
 		consumer:       mockConsumer,
 		metricsFactory: metrics.NullFactory,
 		logger:         zap.NewNop(),
@@ -55,7 +59,7 @@ func Test_new(t *testing.T) {
 		parallelism:    1,
 	}
 
-	processor := pf.new(partition, offset)
+	processor := pf.new(topic, partition, offset)
 	msg := &kmocks.Message{}
 	msg.On("Offset").Return(offset + 1)
 	processor.Process(msg)
@@ -66,6 +70,76 @@ func Test_new(t *testing.T) {
 	mockConsumer.AssertCalled(t, "MarkPartitionOffset", topic, partition, offset+1, "")
 }
 
+type partitionClosingProcessor struct {
+	mocks.SpanProcessor
+	closed []string
+}
+
+func (p *partitionClosingProcessor) ClosePartition(topic string, partition int32) {
+	p.closed = append(p.closed, fmt.Sprintf("%s:%d", topic, partition))
+}
+
+func Test_newClosesPartition(t *testing.T) {
+	mockConsumer := &kmocks.Consumer{}
+	mockConsumer.On("MarkPartitionOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sp := &partitionClosingProcessor{}
+
+	pf := ProcessorFactory{
+		consumer:       mockConsumer,
+		metricsFactory: metrics.NullFactory,
+		logger:         zap.NewNop(),
+		baseProcessor:  sp,
+		parallelism:    1,
+	}
+	p := pf.new("coelacanth", 21, 555)
+	assert.Empty(t, sp.closed)
+	require.NoError(t, p.Close())
+	assert.Equal(t, []string{"coelacanth:21"}, sp.closed)
+}
+
+type fakePublisher struct {
+	published []processor.Message
+}
+
+func (p *fakePublisher) Publish(message processor.Message, cause error) error {
+	p.published = append(p.published, message)
+	return nil
+}
+
+func Test_newWithDeadLetter(t *testing.T) {
+	mockConsumer := &kmocks.Consumer{}
+	mockConsumer.On("MarkPartitionOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	topic := "coelacanth"
+	partition := int32(21)
+	offset := int64(555)
+
+	sp := &mocks.SpanProcessor{}
+	sp.On("Process", mock.Anything).Return(errors.New("storage down"))
+	publisher := &fakePublisher{}
+
+	pf, err := NewProcessorFactory(ProcessorFactoryParams{
+		SaramaConsumer:      mockConsumer,
+		Factory:             metrics.NullFactory,
+		Logger:              zap.NewNop(),
+		BaseProcessor:       sp,
+		Parallelism:         1,
+		RetryOptions:        []decorator.RetryOption{decorator.MaxAttempts(0), decorator.PropagateError(true)},
+		DeadLetterPublisher: publisher,
+	})
+	require.NoError(t, err)
+
+	p := pf.new(topic, partition, offset)
+	msg := &kmocks.Message{}
+	msg.On("Offset").Return(offset + 1)
+	p.Process(msg)
+
+	// the failed message is published, so its offset is committed
+	time.Sleep(150 * time.Millisecond)
+	mockConsumer.AssertCalled(t, "MarkPartitionOffset", topic, partition, offset+1, "")
+	assert.Equal(t, []processor.Message{msg}, publisher.published)
+}
+
 type fakeService struct {
 	startCalled bool
 	closeCalled bool
@@ -0,0 +1,138 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/consumer"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+)
+
+const (
+	// HeaderError is the record header carrying the error which failed the message.
+	HeaderError = "jaeger-dlq-error"
+	// HeaderTopic is the record header carrying the topic the message was consumed from.
+	HeaderTopic = "jaeger-dlq-topic"
+	// HeaderPartition is the record header carrying the partition the message was consumed from.
+	HeaderPartition = "jaeger-dlq-partition"
+	// HeaderOffset is the record header carrying the offset of the message in its partition.
+	HeaderOffset = "jaeger-dlq-offset"
+)
+
+// Publisher publishes the messages which could not be processed to the dead-letter topic.
+// The value, key and headers of the message are kept as is, so that the message can be replayed,
+// and the error and origin of the message are added as record headers.
+type Publisher struct {
+	producer sarama.AsyncProducer
+	topic    string
+	wg       sync.WaitGroup
+}
+
+// NewPublisher creates a Publisher. The producer must return successes.
+func NewPublisher(producer sarama.AsyncProducer, topic string) *Publisher {
+	p := &Publisher{
+		producer: producer,
+		topic:    topic,
+	}
+	p.wg.Add(2)
+	go func() {
+		defer p.wg.Done()
+		for msg := range producer.Successes() {
+			msg.Metadata.(chan error) <- nil
+		}
+	}()
+	go func() {
+		defer p.wg.Done()
+		for e := range producer.Errors() {
+			e.Msg.Metadata.(chan error) <- e.Err
+		}
+	}()
+	return p
+}
+
+// Publish sends the message to the dead-letter topic and waits for it to be acknowledged.
+func (p *Publisher) Publish(message processor.Message, cause error) error {
+	done := make(chan error, 1)
+	msg := &sarama.ProducerMessage{
+		Topic:    p.topic,
+		Value:    sarama.ByteEncoder(message.Value()),
+		Headers:  headers(message, cause),
+		Metadata: done,
+	}
+	if m, ok := message.(consumer.Message); ok && m.Key() != nil {
+		msg.Key = sarama.ByteEncoder(m.Key())
+	}
+	p.producer.Input() <- msg
+	if err := <-done; err != nil {
+		return fmt.Errorf("cannot publish message to dead-letter topic %s: %w", p.topic, err)
+	}
+	return nil
+}
+
+func headers(message processor.Message, cause error) []sarama.RecordHeader {
+	var headers []sarama.RecordHeader
+	if m, ok := message.(processor.HeadersMessage); ok {
+		original := m.Headers()
+		keys := make([]string, 0, len(original))
+		for key := range original {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			headers = append(headers, header(key, original[key]))
+		}
+	}
+	headers = append(headers, header(HeaderError, cause.Error()))
+	if m, ok := message.(consumer.Message); ok {
+		headers = append(headers,
+			header(HeaderTopic, m.Topic()),
+			header(HeaderPartition, strconv.FormatInt(int64(m.Partition()), 10)),
+			header(HeaderOffset, strconv.FormatInt(m.Offset(), 10)),
+		)
+	}
+	return headers
+}
+
+func header(key, value string) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
+
+// Close flushes the pending messages and closes the producer.
+func (p *Publisher) Close() error {
+	err := p.producer.Close()
+	p.wg.Wait()
+	return err
+}
+
+// ValidateProtocolVersion checks that the Kafka protocol version supports the record headers used by the dead-letter topic.
+func ValidateProtocolVersion(protocolVersion string) error {
+	version := sarama.V0_8_2_0
+	if protocolVersion != "" {
+		var err error
+		if version, err = sarama.ParseKafkaVersion(protocolVersion); err != nil {
+			return err
+		}
+	}
+	if !version.IsAtLeast(sarama.V0_11_0_0) {
+		return fmt.Errorf("the dead-letter topic requires Kafka protocol version 0.11.0 or later, got %s", version)
+	}
+	return nil
+}
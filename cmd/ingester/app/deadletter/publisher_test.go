@@ -0,0 +1,140 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMessage struct {
+	value []byte
+}
+
+func (m fakeMessage) Value() []byte {
+	return m.value
+}
+
+// kafkaMessage implements consumer.Message and processor.HeadersMessage
+type kafkaMessage struct {
+	fakeMessage
+	headers map[string]string
+}
+
+func (m kafkaMessage) Key() []byte                { return []byte("key") }
+func (m kafkaMessage) Topic() string              { return "jaeger-spans" }
+func (m kafkaMessage) Partition() int32           { return 3 }
+func (m kafkaMessage) Offset() int64              { return 42 }
+func (m kafkaMessage) Headers() map[string]string { return m.headers }
+
+func newMockProducer(t *testing.T) *mocks.AsyncProducer {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	return mocks.NewAsyncProducer(t, config)
+}
+
+func headersMap(headers []sarama.RecordHeader) map[string]string {
+	m := make(map[string]string)
+	for _, h := range headers {
+		m[string(h.Key)] = string(h.Value)
+	}
+	return m
+}
+
+func TestPublisherPublish(t *testing.T) {
+	producer := newMockProducer(t)
+	var published *sarama.ProducerMessage
+	producer.ExpectInputWithCheckerFunctionAndSucceed(func(val []byte) error {
+		assert.Equal(t, "value", string(val))
+		return nil
+	})
+	publisher := NewPublisher(&capturingProducer{AsyncProducer: producer, captured: &published}, "jaeger-spans-dlq")
+
+	msg := kafkaMessage{
+		fakeMessage: fakeMessage{value: []byte("value")},
+		headers:     map[string]string{"jaeger-span-count": "2"},
+	}
+	require.NoError(t, publisher.Publish(msg, errors.New("storage down")))
+
+	require.NotNil(t, published)
+	assert.Equal(t, "jaeger-spans-dlq", published.Topic)
+	assert.Equal(t, sarama.ByteEncoder("key"), published.Key)
+	assert.Equal(t, map[string]string{
+		"jaeger-span-count": "2",
+		HeaderError:         "storage down",
+		HeaderTopic:         "jaeger-spans",
+		HeaderPartition:     "3",
+		HeaderOffset:        "42",
+	}, headersMap(published.Headers))
+	assert.NoError(t, publisher.Close())
+}
+
+func TestPublisherPublishPlainMessage(t *testing.T) {
+	producer := newMockProducer(t)
+	var published *sarama.ProducerMessage
+	producer.ExpectInputAndSucceed()
+	publisher := NewPublisher(&capturingProducer{AsyncProducer: producer, captured: &published}, "dlq")
+
+	require.NoError(t, publisher.Publish(fakeMessage{value: []byte("value")}, errors.New("storage down")))
+
+	assert.Nil(t, published.Key)
+	assert.Equal(t, map[string]string{HeaderError: "storage down"}, headersMap(published.Headers))
+	assert.NoError(t, publisher.Close())
+}
+
+func TestPublisherPublishError(t *testing.T) {
+	producer := newMockProducer(t)
+	producer.ExpectInputAndFail(errors.New("kafka down"))
+	publisher := NewPublisher(producer, "dlq")
+
+	err := publisher.Publish(fakeMessage{}, errors.New("storage down"))
+	assert.EqualError(t, err, "cannot publish message to dead-letter topic dlq: kafka down")
+	assert.NoError(t, publisher.Close())
+}
+
+func TestValidateProtocolVersion(t *testing.T) {
+	assert.NoError(t, ValidateProtocolVersion("0.11.0.0"))
+	assert.NoError(t, ValidateProtocolVersion("2.3.0"))
+	assert.EqualError(t, ValidateProtocolVersion(""),
+		"the dead-letter topic requires Kafka protocol version 0.11.0 or later, got 0.8.2.0")
+	assert.EqualError(t, ValidateProtocolVersion("0.10.2.0"),
+		"the dead-letter topic requires Kafka protocol version 0.11.0 or later, got 0.10.2.0")
+	assert.Error(t, ValidateProtocolVersion("foo"))
+}
+
+// capturingProducer records the last message sent to the producer
+type capturingProducer struct {
+	sarama.AsyncProducer
+	captured **sarama.ProducerMessage
+	input    chan *sarama.ProducerMessage
+}
+
+func (p *capturingProducer) Input() chan<- *sarama.ProducerMessage {
+	if p.input == nil {
+		p.input = make(chan *sarama.ProducerMessage)
+		go func() {
+			for msg := range p.input {
+				*p.captured = msg
+				p.AsyncProducer.Input() <- msg
+			}
+		}()
+	}
+	return p.input
+}
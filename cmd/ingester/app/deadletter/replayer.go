@@ -0,0 +1,148 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"io"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+)
+
+// OffsetGetter returns the offsets of a partition, it is implemented by sarama.Client
+type OffsetGetter interface {
+	GetOffset(topic string, partitionID int32, time int64) (int64, error)
+}
+
+// ReplayResult counts the messages of a replay
+type ReplayResult struct {
+	// Processed is the number of messages processed successfully
+	Processed int
+	// Failed is the number of messages failed again, they stay in the dead-letter topic
+	Failed int
+}
+
+// Replayer processes the messages of the dead-letter topic again, e.g. after a storage outage is resolved.
+// It reads all partitions from the oldest offset up to the offset of the last message at the start of the replay.
+// The dead-letter topic is not modified, messages failed again are logged and counted.
+type Replayer struct {
+	offsets   OffsetGetter
+	consumer  sarama.Consumer
+	topic     string
+	processor processor.SpanProcessor
+	logger    *zap.Logger
+}
+
+// NewReplayer creates a Replayer
+func NewReplayer(offsets OffsetGetter, consumer sarama.Consumer, topic string, processor processor.SpanProcessor, logger *zap.Logger) *Replayer {
+	return &Replayer{
+		offsets:   offsets,
+		consumer:  consumer,
+		topic:     topic,
+		processor: processor,
+		logger:    logger,
+	}
+}
+
+// Replay processes the messages of all partitions of the dead-letter topic
+func (r *Replayer) Replay() (ReplayResult, error) {
+	var result ReplayResult
+	partitions, err := r.consumer.Partitions(r.topic)
+	if err != nil {
+		return result, err
+	}
+	for _, partition := range partitions {
+		if err := r.replayPartition(partition, &result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (r *Replayer) replayPartition(partition int32, result *ReplayResult) error {
+	oldest, err := r.offsets.GetOffset(r.topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return err
+	}
+	// the high-water mark, i.e. the offset of the next message written to the partition
+	newest, err := r.offsets.GetOffset(r.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return err
+	}
+	if oldest >= newest {
+		return nil
+	}
+	r.logger.Info("Replaying dead-letter partition",
+		zap.Int32("partition", partition), zap.Int64("from-offset", oldest), zap.Int64("to-offset", newest-1))
+
+	pc, err := r.consumer.ConsumePartition(r.topic, partition, oldest)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+	for msg := range pc.Messages() {
+		if err := r.processor.Process(replayedMessage{msg}); err != nil {
+			result.Failed++
+			r.logger.Error("Failed to replay message",
+				zap.Int32("partition", partition), zap.Int64("offset", msg.Offset), zap.Error(err))
+		} else {
+			result.Processed++
+		}
+		if msg.Offset >= newest-1 {
+			break
+		}
+	}
+	return nil
+}
+
+// Close closes the consumer, and the offset getter if it is an io.Closer, e.g. the sarama.Client of the consumer
+func (r *Replayer) Close() error {
+	err := r.consumer.Close()
+	if closer, ok := r.offsets.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+type replayedMessage struct {
+	*sarama.ConsumerMessage
+}
+
+func (m replayedMessage) Value() []byte {
+	return m.ConsumerMessage.Value
+}
+
+func (m replayedMessage) Headers() map[string]string {
+	headers := make(map[string]string, len(m.ConsumerMessage.Headers))
+	for _, h := range m.ConsumerMessage.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	return headers
+}
+
+// Topic returns the topic the message was originally consumed from, so it is processed
+// by the processor of that topic when the ingester consumes several topics
+func (m replayedMessage) Topic() string {
+	for _, h := range m.ConsumerMessage.Headers {
+		if string(h.Key) == HeaderTopic {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
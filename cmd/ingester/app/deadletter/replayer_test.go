@@ -0,0 +1,108 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+)
+
+type fakeOffsets struct {
+	oldest, newest map[int32]int64
+	err            error
+	closed         bool
+}
+
+func (o *fakeOffsets) GetOffset(topic string, partition int32, time int64) (int64, error) {
+	if time == sarama.OffsetOldest {
+		return o.oldest[partition], o.err
+	}
+	return o.newest[partition], o.err
+}
+
+func (o *fakeOffsets) Close() error {
+	o.closed = true
+	return nil
+}
+
+type fakeProcessor struct {
+	fail     string
+	messages []processor.Message
+}
+
+func (p *fakeProcessor) Process(message processor.Message) error {
+	p.messages = append(p.messages, message)
+	if string(message.Value()) == p.fail {
+		return errors.New("storage down")
+	}
+	return nil
+}
+
+func (p *fakeProcessor) Close() error {
+	return nil
+}
+
+func TestReplayer(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"dlq": {0, 1, 2}})
+	pc := consumer.ExpectConsumePartition("dlq", 0, 1)
+	for _, value := range []string{"a", "b", "c", "not-replayed"} {
+		pc.YieldMessage(&sarama.ConsumerMessage{
+			Value:   []byte(value),
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte(HeaderError), Value: []byte("storage down")},
+				{Key: []byte(HeaderTopic), Value: []byte("spans")},
+			},
+		})
+	}
+	// the mock assigns offsets from 1, the last message is written after the replay starts,
+	// partition 1 is empty, partition 2 has been fully removed by retention
+	offsets := &fakeOffsets{
+		oldest: map[int32]int64{0: 1, 1: 0, 2: 5},
+		newest: map[int32]int64{0: 4, 1: 0, 2: 5},
+	}
+	proc := &fakeProcessor{fail: "b"}
+	replayer := NewReplayer(offsets, consumer, "dlq", proc, zap.NewNop())
+
+	result, err := replayer.Replay()
+	require.NoError(t, err)
+	assert.Equal(t, ReplayResult{Processed: 2, Failed: 1}, result)
+	require.Len(t, proc.messages, 3)
+	assert.Equal(t, map[string]string{HeaderError: "storage down", HeaderTopic: "spans"}, proc.messages[0].(processor.HeadersMessage).Headers())
+	assert.Equal(t, "spans", proc.messages[0].(processor.TopicMessage).Topic())
+
+	assert.NoError(t, replayer.Close())
+	assert.True(t, offsets.closed)
+}
+
+func TestReplayerErrors(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"dlq": {0}})
+	replayer := NewReplayer(&fakeOffsets{err: errors.New("offsets")}, consumer, "dlq", &fakeProcessor{}, zap.NewNop())
+	_, err := replayer.Replay()
+	assert.EqualError(t, err, "offsets")
+
+	replayer = NewReplayer(&fakeOffsets{}, consumer, "foo", &fakeProcessor{}, zap.NewNop())
+	_, err = replayer.Replay()
+	assert.Error(t, err)
+}
@@ -37,6 +37,8 @@ const (
 	SuffixBrokers = ".brokers"
 	// SuffixTopic is a suffix for the topic flag
 	SuffixTopic = ".topic"
+	// SuffixTopics is a suffix for the topics flag
+	SuffixTopics = ".topics"
 	// SuffixGroupID is a suffix for the group-id flag
 	SuffixGroupID = ".group-id"
 	// SuffixClientID is a suffix for the client-id flag
@@ -49,6 +51,26 @@ const (
 	SuffixDeadlockInterval = ".deadlockInterval"
 	// SuffixParallelism is a suffix for the parallelism flag
 	SuffixParallelism = ".parallelism"
+	// SuffixRetryMaxAttempts is a suffix for the retry max attempts flag
+	SuffixRetryMaxAttempts = ".retry.max-attempts"
+	// SuffixRetryMinBackoff is a suffix for the retry min backoff flag
+	SuffixRetryMinBackoff = ".retry.min-backoff"
+	// SuffixRetryMaxBackoff is a suffix for the retry max backoff flag
+	SuffixRetryMaxBackoff = ".retry.max-backoff"
+	// SuffixDeadLetterTopic is a suffix for the dead-letter topic flag
+	SuffixDeadLetterTopic = ".dead-letter-topic"
+	// SuffixLagCheckInterval is a suffix for the lag check interval flag
+	SuffixLagCheckInterval = ".lag.check-interval"
+	// SuffixLagMaxMessages is a suffix for the lag threshold flag
+	SuffixLagMaxMessages = ".lag.max-messages"
+	// SuffixRebalanceFlushTimeout is a suffix for the rebalance flush timeout flag
+	SuffixRebalanceFlushTimeout = ".rebalance-flush-timeout"
+	// SuffixDedupTTL is a suffix for the span deduplication flag
+	SuffixDedupTTL = ".dedup.ttl"
+	// SuffixDedupMaxEntries is a suffix for the maximum number of span hashes kept per partition
+	SuffixDedupMaxEntries = ".dedup.max-entries"
+	// SuffixDedupDirectory is a suffix for the directory the span hashes are saved to
+	SuffixDedupDirectory = ".dedup.directory"
 	// SuffixHTTPPort is a suffix for the HTTP port
 	SuffixHTTPPort = ".http-port"
 	// DefaultBroker is the default kafka broker
@@ -65,14 +87,47 @@ const (
 	DefaultEncoding = kafka.EncodingProto
 	// DefaultDeadlockInterval is the default deadlock interval
 	DefaultDeadlockInterval = time.Duration(0)
+	// DefaultRetryMaxAttempts is the default number of retries of a failed message
+	DefaultRetryMaxAttempts = 10
+	// DefaultRetryMinBackoff is the default backoff before the first retry
+	DefaultRetryMinBackoff = time.Second
+	// DefaultRetryMaxBackoff is the default maximum backoff between retries
+	DefaultRetryMaxBackoff = time.Minute
+	// DefaultLagCheckInterval is the default interval to compute the partition lags at
+	DefaultLagCheckInterval = 10 * time.Second
+	// DefaultLagMaxMessages is the default lag threshold, 0 does not tie readiness to the lag
+	DefaultLagMaxMessages = 0
+	// DefaultRebalanceFlushTimeout is the default time given to released partitions to flush in-flight messages
+	DefaultRebalanceFlushTimeout = time.Second
+	// DefaultDedupTTL is the default time the written spans are remembered for, 0 disables the deduplication
+	DefaultDedupTTL = time.Duration(0)
+	// DefaultDedupMaxEntries is the default number of span hashes kept per partition
+	DefaultDedupMaxEntries = 100000
 )
 
+// TopicOptions stores how the messages of one of the consumed topics are processed
+type TopicOptions struct {
+	Topic             string `mapstructure:"topic"`
+	Encoding          string `mapstructure:"encoding"`
+	ServiceNamePrefix string `mapstructure:"service_name_prefix"`
+}
+
 // Options stores the configuration options for the Ingester
 type Options struct {
 	kafkaConsumer.Configuration `mapstructure:",squash"`
-	Parallelism                 int           `mapstructure:"parallelism"`
-	Encoding                    string        `mapstructure:"encoding"`
-	DeadlockInterval            time.Duration `mapstructure:"deadlock_interval"`
+	Parallelism                 int            `mapstructure:"parallelism"`
+	Encoding                    string         `mapstructure:"encoding"`
+	DeadlockInterval            time.Duration  `mapstructure:"deadlock_interval"`
+	RetryMaxAttempts            uint           `mapstructure:"retry_max_attempts"`
+	RetryMinBackoff             time.Duration  `mapstructure:"retry_min_backoff"`
+	RetryMaxBackoff             time.Duration  `mapstructure:"retry_max_backoff"`
+	DeadLetterTopic             string         `mapstructure:"dead_letter_topic"`
+	LagCheckInterval            time.Duration  `mapstructure:"lag_check_interval"`
+	LagMaxMessages              int64          `mapstructure:"lag_max_messages"`
+	DedupTTL                    time.Duration  `mapstructure:"dedup_ttl"`
+	DedupMaxEntries             int            `mapstructure:"dedup_max_entries"`
+	DedupDirectory              string         `mapstructure:"dedup_directory"`
+	ConsumedTopics              []TopicOptions `mapstructure:"consumed_topics"`
 }
 
 // AddFlags adds flags for Builder
@@ -85,6 +140,59 @@ func AddFlags(flagSet *flag.FlagSet) {
 		ConfigPrefix+SuffixDeadlockInterval,
 		DefaultDeadlockInterval,
 		"Interval to check for deadlocks. If no messages gets processed in given time, ingester app will exit. Value of 0 disables deadlock check.")
+	flagSet.Uint(
+		ConfigPrefix+SuffixRetryMaxAttempts,
+		DefaultRetryMaxAttempts,
+		"The number of times a message that failed to be written to storage is retried")
+	flagSet.Duration(
+		ConfigPrefix+SuffixRetryMinBackoff,
+		DefaultRetryMinBackoff,
+		"The upper bound of the randomized backoff before the first retry, it doubles with each retry")
+	flagSet.Duration(
+		ConfigPrefix+SuffixRetryMaxBackoff,
+		DefaultRetryMaxBackoff,
+		"The maximum backoff between retries")
+	flagSet.String(
+		ConfigPrefix+SuffixDeadLetterTopic,
+		"",
+		"The kafka topic to publish the messages which cannot be processed after all retries, together with the error. "+
+			"The messages can be processed again with the replay-dead-letters command. "+
+			"Requires Kafka protocol version 0.11.0 or later, messages are dropped when empty")
+	flagSet.Duration(
+		ConfigPrefix+SuffixLagCheckInterval,
+		DefaultLagCheckInterval,
+		"Interval to compute the offset lag of each partition from its high-water mark at. Value of 0 disables the lag metrics and readiness check.")
+	flagSet.Int64(
+		ConfigPrefix+SuffixLagMaxMessages,
+		DefaultLagMaxMessages,
+		"The offset lag of a partition above which the ingester reports itself as not ready on the health check. Value of 0 disables the readiness check.")
+	flagSet.Duration(
+		ConfigPrefix+SuffixRebalanceFlushTimeout,
+		DefaultRebalanceFlushTimeout,
+		"The time given to the partitions released on rebalance to finish processing the in-flight messages before their offsets are committed")
+	flagSet.Duration(
+		ConfigPrefix+SuffixDedupTTL,
+		DefaultDedupTTL,
+		"The time the hashes of the written spans are kept for, separately for each partition, to skip writing the spans "+
+			"of messages consumed again before their offsets were committed. Value of 0 disables the deduplication.")
+	flagSet.Int(
+		ConfigPrefix+SuffixDedupMaxEntries,
+		DefaultDedupMaxEntries,
+		"The maximum number of hashes of the written spans kept per partition, the oldest are evicted above it")
+	flagSet.String(
+		ConfigPrefix+SuffixDedupDirectory,
+		"",
+		"The directory the hashes of the written spans are saved to when a partition is released on rebalance or shutdown, "+
+			"they are loaded again when the partition is next assigned so the messages consumed again after a restart are deduplicated. "+
+			"The hashes are not shared with the other ingesters and the ones added since the partition was assigned are lost on a crash. "+
+			"When empty, the hashes are only kept in memory while the partition is held")
+	// the topics flag is not supported by the OpenTelemetry Kafka receiver
+	flagSet.String(
+		KafkaConsumerConfigPrefix+SuffixTopics,
+		"",
+		"The comma-separated list of kafka topics to consume from, each as topic[:encoding[:service-name-prefix]], "+
+			"e.g. 'jaeger-spans,legacy-spans:json:legacy.'. The encoding defaults to the encoding flag, the service-name-prefix "+
+			"is prepended to the service name of the spans. Overrides the topic flag when set")
 	AddOTELFlags(flagSet)
 }
 
@@ -129,11 +237,50 @@ func (o *Options) InitFromViper(v *viper.Viper) {
 
 	o.Parallelism = v.GetInt(ConfigPrefix + SuffixParallelism)
 	o.DeadlockInterval = v.GetDuration(ConfigPrefix + SuffixDeadlockInterval)
+	o.RetryMaxAttempts = v.GetUint(ConfigPrefix + SuffixRetryMaxAttempts)
+	o.RetryMinBackoff = v.GetDuration(ConfigPrefix + SuffixRetryMinBackoff)
+	o.RetryMaxBackoff = v.GetDuration(ConfigPrefix + SuffixRetryMaxBackoff)
+	o.DeadLetterTopic = v.GetString(ConfigPrefix + SuffixDeadLetterTopic)
+	o.LagCheckInterval = v.GetDuration(ConfigPrefix + SuffixLagCheckInterval)
+	o.LagMaxMessages = v.GetInt64(ConfigPrefix + SuffixLagMaxMessages)
+	o.RebalanceFlushTimeout = v.GetDuration(ConfigPrefix + SuffixRebalanceFlushTimeout)
+	o.DedupTTL = v.GetDuration(ConfigPrefix + SuffixDedupTTL)
+	o.DedupMaxEntries = v.GetInt(ConfigPrefix + SuffixDedupMaxEntries)
+	o.DedupDirectory = v.GetString(ConfigPrefix + SuffixDedupDirectory)
+	o.ConsumedTopics = parseTopics(v.GetString(KafkaConsumerConfigPrefix+SuffixTopics), o.Encoding)
+	if len(o.ConsumedTopics) == 0 {
+		o.ConsumedTopics = []TopicOptions{{Topic: o.Topic, Encoding: o.Encoding}}
+	} else {
+		o.Topics = make([]string, len(o.ConsumedTopics))
+		for i, topic := range o.ConsumedTopics {
+			o.Topics[i] = topic.Topic
+		}
+	}
 	authenticationOptions := auth.AuthenticationConfig{}
 	authenticationOptions.InitFromViper(KafkaConsumerConfigPrefix, v)
 	o.AuthenticationConfig = authenticationOptions
 }
 
+// parseTopics parses the comma-separated topic[:encoding[:service-name-prefix]] list of the topics flag
+func parseTopics(value string, defaultEncoding string) []TopicOptions {
+	var topics []TopicOptions
+	for _, spec := range strings.Split(stripWhiteSpace(value), ",") {
+		if spec == "" {
+			continue
+		}
+		parts := strings.SplitN(spec, ":", 3)
+		topic := TopicOptions{Topic: parts[0], Encoding: defaultEncoding}
+		if len(parts) > 1 && parts[1] != "" {
+			topic.Encoding = parts[1]
+		}
+		if len(parts) > 2 {
+			topic.ServiceNamePrefix = parts[2]
+		}
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
 // stripWhiteSpace removes all whitespace characters from a string
 func stripWhiteSpace(str string) string {
 	return strings.Replace(str, " ", "", -1)
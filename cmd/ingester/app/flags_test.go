@@ -40,6 +40,16 @@ func TestOptionsWithFlags(t *testing.T) {
 		"--kafka.consumer.protocol-version=1.0.0",
 		"--ingester.parallelism=5",
 		"--ingester.deadlockInterval=2m",
+		"--ingester.retry.max-attempts=3",
+		"--ingester.retry.min-backoff=10ms",
+		"--ingester.retry.max-backoff=5s",
+		"--ingester.dead-letter-topic=topic1-dlq",
+		"--ingester.lag.check-interval=30s",
+		"--ingester.lag.max-messages=1000",
+		"--ingester.rebalance-flush-timeout=5s",
+		"--ingester.dedup.ttl=10m",
+		"--ingester.dedup.max-entries=500",
+		"--ingester.dedup.directory=/var/lib/jaeger/dedup",
 	})
 	o.InitFromViper(v)
 
@@ -51,6 +61,37 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, 5, o.Parallelism)
 	assert.Equal(t, 2*time.Minute, o.DeadlockInterval)
 	assert.Equal(t, kafka.EncodingJSON, o.Encoding)
+	assert.Equal(t, uint(3), o.RetryMaxAttempts)
+	assert.Equal(t, 10*time.Millisecond, o.RetryMinBackoff)
+	assert.Equal(t, 5*time.Second, o.RetryMaxBackoff)
+	assert.Equal(t, "topic1-dlq", o.DeadLetterTopic)
+	assert.Equal(t, 30*time.Second, o.LagCheckInterval)
+	assert.Equal(t, int64(1000), o.LagMaxMessages)
+	assert.Equal(t, 5*time.Second, o.RebalanceFlushTimeout)
+	assert.Equal(t, 10*time.Minute, o.DedupTTL)
+	assert.Equal(t, 500, o.DedupMaxEntries)
+	assert.Equal(t, "/var/lib/jaeger/dedup", o.DedupDirectory)
+	assert.Equal(t, []TopicOptions{{Topic: "topic1", Encoding: kafka.EncodingJSON}}, o.ConsumedTopics)
+	assert.Empty(t, o.Topics)
+}
+
+func TestTopicsFlag(t *testing.T) {
+	o := &Options{}
+	v, command := config.Viperize(AddFlags)
+	command.ParseFlags([]string{
+		"--kafka.consumer.topic=topic1",
+		"--kafka.consumer.encoding=json",
+		"--kafka.consumer.topics=spans-a, spans-b:protobuf,spans-c::cluster-c.,spans-d:otlp-proto:cluster-d.,",
+	})
+	o.InitFromViper(v)
+
+	assert.Equal(t, []TopicOptions{
+		{Topic: "spans-a", Encoding: kafka.EncodingJSON},
+		{Topic: "spans-b", Encoding: kafka.EncodingProto},
+		{Topic: "spans-c", Encoding: kafka.EncodingJSON, ServiceNamePrefix: "cluster-c."},
+		{Topic: "spans-d", Encoding: kafka.EncodingOTLPProto, ServiceNamePrefix: "cluster-d."},
+	}, o.ConsumedTopics)
+	assert.Equal(t, []string{"spans-a", "spans-b", "spans-c", "spans-d"}, o.Topics)
 }
 
 func TestTLSFlags(t *testing.T) {
@@ -106,4 +147,15 @@ func TestFlagDefaults(t *testing.T) {
 	assert.Equal(t, DefaultParallelism, o.Parallelism)
 	assert.Equal(t, DefaultEncoding, o.Encoding)
 	assert.Equal(t, DefaultDeadlockInterval, o.DeadlockInterval)
+	assert.Equal(t, uint(DefaultRetryMaxAttempts), o.RetryMaxAttempts)
+	assert.Equal(t, DefaultRetryMinBackoff, o.RetryMinBackoff)
+	assert.Equal(t, DefaultRetryMaxBackoff, o.RetryMaxBackoff)
+	assert.Empty(t, o.DeadLetterTopic)
+	assert.Equal(t, DefaultLagCheckInterval, o.LagCheckInterval)
+	assert.Equal(t, int64(DefaultLagMaxMessages), o.LagMaxMessages)
+	assert.Equal(t, DefaultRebalanceFlushTimeout, o.RebalanceFlushTimeout)
+	assert.Equal(t, DefaultDedupTTL, o.DedupTTL)
+	assert.Equal(t, DefaultDedupMaxEntries, o.DedupMaxEntries)
+	assert.Empty(t, o.DedupDirectory)
+	assert.Equal(t, []TopicOptions{{Topic: DefaultTopic, Encoding: DefaultEncoding}}, o.ConsumedTopics)
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decorator
+
+import (
+	"io"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+)
+
+// DeadLetterPublisher publishes the messages which could not be processed, together with the cause
+type DeadLetterPublisher interface {
+	Publish(message processor.Message, cause error) error
+}
+
+type deadLetterDecorator struct {
+	processor processor.SpanProcessor
+	publisher DeadLetterPublisher
+	logger    *zap.Logger
+	published metrics.Counter
+	failures  metrics.Counter
+	io.Closer
+}
+
+// NewDeadLetterProcessor returns a processor that publishes the messages failed by the wrapped processor
+// to the dead-letter publisher. The error is only returned if the message cannot be published, so that the
+// offset of the message is not committed. The wrapped processor is expected to retry the failures first.
+func NewDeadLetterProcessor(f metrics.Factory, processor processor.SpanProcessor, publisher DeadLetterPublisher, logger *zap.Logger) processor.SpanProcessor {
+	m := f.Namespace(metrics.NSOptions{Name: "span-processor", Tags: nil})
+	return &deadLetterDecorator{
+		processor: processor,
+		publisher: publisher,
+		logger:    logger,
+		published: m.Counter(metrics.Options{Name: "dead-letter-published", Tags: nil}),
+		failures:  m.Counter(metrics.Options{Name: "dead-letter-failures", Tags: nil}),
+	}
+}
+
+func (d *deadLetterDecorator) Process(message processor.Message) error {
+	err := d.processor.Process(message)
+	if err == nil {
+		return nil
+	}
+	if pubErr := d.publisher.Publish(message, err); pubErr != nil {
+		d.failures.Inc(1)
+		d.logger.Error("Failed to publish message to the dead-letter topic", zap.NamedError("cause", err), zap.Error(pubErr))
+		return pubErr
+	}
+	d.published.Inc(1)
+	d.logger.Warn("Published message to the dead-letter topic", zap.Error(err))
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decorator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/mocks"
+)
+
+type fakePublisher struct {
+	err      error
+	messages []processor.Message
+	causes   []error
+}
+
+func (p *fakePublisher) Publish(message processor.Message, cause error) error {
+	p.messages = append(p.messages, message)
+	p.causes = append(p.causes, cause)
+	return p.err
+}
+
+func TestDeadLetterProcessor(t *testing.T) {
+	mockProcessor := &mocks.SpanProcessor{}
+	msg := &fakeMsg{}
+	mockProcessor.On("Process", msg).Return(nil)
+	publisher := &fakePublisher{}
+	lf := metricstest.NewFactory(0)
+	dp := NewDeadLetterProcessor(lf, mockProcessor, publisher, zap.NewNop())
+
+	assert.NoError(t, dp.Process(msg))
+
+	assert.Empty(t, publisher.messages)
+	lf.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "span-processor.dead-letter-published", Value: 0},
+		metricstest.ExpectedMetric{Name: "span-processor.dead-letter-failures", Value: 0})
+}
+
+func TestDeadLetterProcessorPublishes(t *testing.T) {
+	mockProcessor := &mocks.SpanProcessor{}
+	msg := &fakeMsg{}
+	cause := errors.New("storage down")
+	mockProcessor.On("Process", msg).Return(cause)
+	publisher := &fakePublisher{}
+	lf := metricstest.NewFactory(0)
+	dp := NewDeadLetterProcessor(lf, mockProcessor, publisher, zap.NewNop())
+
+	assert.NoError(t, dp.Process(msg))
+
+	assert.Equal(t, []processor.Message{msg}, publisher.messages)
+	assert.Equal(t, []error{cause}, publisher.causes)
+	lf.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "span-processor.dead-letter-published", Value: 1},
+		metricstest.ExpectedMetric{Name: "span-processor.dead-letter-failures", Value: 0})
+}
+
+func TestDeadLetterProcessorPublishError(t *testing.T) {
+	mockProcessor := &mocks.SpanProcessor{}
+	msg := &fakeMsg{}
+	mockProcessor.On("Process", msg).Return(errors.New("storage down"))
+	publisher := &fakePublisher{err: errors.New("kafka down")}
+	lf := metricstest.NewFactory(0)
+	dp := NewDeadLetterProcessor(lf, mockProcessor, publisher, zap.NewNop())
+
+	assert.EqualError(t, dp.Process(msg), "kafka down")
+
+	lf.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "span-processor.dead-letter-published", Value: 0},
+		metricstest.ExpectedMetric{Name: "span-processor.dead-letter-failures", Value: 1})
+}
@@ -113,7 +113,8 @@ func (d *retryDecorator) Process(message processor.Message) error {
 		return nil
 	}
 
-	for attempts := uint(0); err != nil && d.options.maxAttempts > attempts; attempts++ {
+	// permanent errors, e.g. malformed messages, fail again on every attempt
+	for attempts := uint(0); err != nil && !processor.IsPermanent(err) && d.options.maxAttempts > attempts; attempts++ {
 		time.Sleep(d.computeInterval(attempts))
 		err = d.processor.Process(message)
 		d.retryAttempts.Inc(1)
@@ -24,6 +24,7 @@ import (
 	"github.com/uber/jaeger-lib/metrics/metricstest"
 
 	"github.com/jaegertracing/jaeger/cmd/ingester/app/processor/mocks"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 type fakeMsg struct{}
@@ -88,6 +89,26 @@ func TestNewRetryingProcessorNoErrorPropagation(t *testing.T) {
 	assert.Equal(t, int64(1), c["span-processor.retry-attempts"])
 }
 
+func TestNewRetryingProcessorPermanentError(t *testing.T) {
+	mockProcessor := &mocks.SpanProcessor{}
+	msg := &fakeMsg{}
+	mockProcessor.On("Process", msg).Return(spanstore.PermanentError{Err: errors.New("malformed")})
+	opts := []RetryOption{
+		MinBackoffInterval(0),
+		MaxAttempts(2),
+		PropagateError(true),
+		Rand(&fakeRand{})}
+	lf := metricstest.NewFactory(0)
+	rp := NewRetryingProcessor(lf, mockProcessor, opts...)
+
+	assert.EqualError(t, rp.Process(msg), "malformed")
+
+	mockProcessor.AssertNumberOfCalls(t, "Process", 1)
+	c, _ := lf.Snapshot()
+	assert.Equal(t, int64(1), c["span-processor.retry-exhausted"])
+	assert.Equal(t, int64(0), c["span-processor.retry-attempts"])
+}
+
 type fakeRand struct{}
 
 func (f *fakeRand) Int63n(v int64) int64 {
@@ -0,0 +1,246 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+)
+
+// hashesFileSuffix is the suffix of the files the hashes of the released partitions are saved to
+const hashesFileSuffix = ".hashes"
+
+// PartitionMessage is implemented by the messages which know the kafka partition they were consumed from
+type PartitionMessage interface {
+	Partition() int32
+}
+
+// PartitionCloser is implemented by the processors which keep state for the partitions of a topic,
+// ClosePartition is called once the messages of a released partition are processed
+type PartitionCloser interface {
+	ClosePartition(topic string, partition int32)
+}
+
+// DeduplicatorParams stores the parameters of a SpanDeduplicator
+type DeduplicatorParams struct {
+	// TTL is the time the hashes of the written spans are kept for
+	TTL time.Duration
+	// MaxEntries is the maximum number of hashes kept per partition, the oldest are evicted above it
+	MaxEntries int
+	// Directory is where the hashes of the released partitions are saved, empty keeps them in memory only
+	Directory string
+	Logger    *zap.Logger
+}
+
+// SpanDeduplicator remembers the hashes of the spans written in the last ttl, separately for each partition,
+// so the spans of the messages consumed again after their offsets were not committed are not written twice.
+// The spans are identified by model.HashCode, which covers all their fields.
+// The partition consumers are closed on every rebalance and on shutdown, so the hashes of a partition are saved
+// to the directory when it is released and loaded again when it is next assigned, which covers the messages
+// consumed again after a rebalance or a restart of the same ingester. The hashes added since the partition was
+// assigned are lost if the ingester crashes, and they are not shared with the other ingesters.
+type SpanDeduplicator struct {
+	ttl        time.Duration
+	maxEntries int
+	directory  string
+	logger     *zap.Logger
+	now        func() time.Time
+	skipped    metrics.Counter
+	evicted    metrics.Counter
+	lock       sync.Mutex
+	partitions map[int32]*spanHashes
+}
+
+// spanHashes is a set of span hashes which expire in the order they were added
+type spanHashes struct {
+	added map[uint64]time.Time
+	order *list.List
+}
+
+type spanHash struct {
+	hash  uint64
+	added time.Time
+}
+
+// NewSpanDeduplicator creates a SpanDeduplicator remembering the written spans for the ttl of the params
+func NewSpanDeduplicator(f metrics.Factory, params DeduplicatorParams) (*SpanDeduplicator, error) {
+	if params.TTL <= 0 || params.MaxEntries <= 0 {
+		return nil, errors.New("the span deduplication ttl and max entries must be positive")
+	}
+	if params.Directory != "" {
+		if err := os.MkdirAll(params.Directory, 0750); err != nil {
+			return nil, fmt.Errorf("failed to create the span deduplication directory: %w", err)
+		}
+	}
+	if params.Logger == nil {
+		params.Logger = zap.NewNop()
+	}
+	m := f.Namespace(metrics.NSOptions{Name: "span-processor", Tags: nil})
+	return &SpanDeduplicator{
+		ttl:        params.TTL,
+		maxEntries: params.MaxEntries,
+		directory:  params.Directory,
+		logger:     params.Logger,
+		now:        time.Now,
+		skipped:    m.Counter(metrics.Options{Name: "duplicate-spans-skipped", Tags: nil}),
+		evicted:    m.Counter(metrics.Options{Name: "duplicate-hashes-evicted", Tags: nil}),
+		partitions: make(map[int32]*spanHashes),
+	}, nil
+}
+
+// Seen returns true if the span with the given hash was written from the partition in the last ttl
+func (d *SpanDeduplicator) Seen(partition int32, hash uint64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	hashes := d.hashes(partition)
+	hashes.expire(d.now().Add(-d.ttl))
+	_, ok := hashes.added[hash]
+	if ok {
+		d.skipped.Inc(1)
+	}
+	return ok
+}
+
+// Add remembers the span with the given hash as written from the partition
+func (d *SpanDeduplicator) Add(partition int32, hash uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	hashes := d.hashes(partition)
+	now := d.now()
+	hashes.expire(now.Add(-d.ttl))
+	if _, ok := hashes.added[hash]; ok {
+		return
+	}
+	if hashes.order.Len() >= d.maxEntries {
+		hashes.removeOldest()
+		d.evicted.Inc(1)
+	}
+	hashes.push(hash, now)
+}
+
+// Release saves the hashes of a partition to the directory once it is released, and drops them from memory
+func (d *SpanDeduplicator) Release(partition int32) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	hashes, ok := d.partitions[partition]
+	if !ok {
+		return
+	}
+	delete(d.partitions, partition)
+	if d.directory == "" {
+		return
+	}
+	hashes.expire(d.now().Add(-d.ttl))
+	if err := hashes.save(d.path(partition)); err != nil {
+		d.logger.Error("Failed to save the hashes of the written spans", zap.Int32("partition", partition), zap.Error(err))
+	}
+}
+
+// hashes returns the hashes of the partition, loading them from the directory when the partition is not held yet.
+// Must be called under lock.
+func (d *SpanDeduplicator) hashes(partition int32) *spanHashes {
+	hashes, ok := d.partitions[partition]
+	if ok {
+		return hashes
+	}
+	hashes = &spanHashes{added: make(map[uint64]time.Time), order: list.New()}
+	d.partitions[partition] = hashes
+	if d.directory == "" {
+		return hashes
+	}
+	if err := hashes.load(d.path(partition), d.now().Add(-d.ttl), d.maxEntries); err != nil {
+		d.logger.Warn("Ignoring the saved hashes of the written spans", zap.Int32("partition", partition), zap.Error(err))
+	}
+	return hashes
+}
+
+func (d *SpanDeduplicator) path(partition int32) string {
+	return filepath.Join(d.directory, strconv.Itoa(int(partition))+hashesFileSuffix)
+}
+
+func (h *spanHashes) push(hash uint64, added time.Time) {
+	h.added[hash] = added
+	h.order.PushBack(spanHash{hash: hash, added: added})
+}
+
+func (h *spanHashes) removeOldest() {
+	entry := h.order.Remove(h.order.Front()).(spanHash)
+	delete(h.added, entry.hash)
+}
+
+func (h *spanHashes) expire(before time.Time) {
+	for e := h.order.Front(); e != nil; e = h.order.Front() {
+		if !e.Value.(spanHash).added.Before(before) {
+			return
+		}
+		h.removeOldest()
+	}
+}
+
+// save writes the hashes with the time they were added, oldest first, the file is replaced atomically
+func (h *spanHashes) save(path string) error {
+	data := make([]byte, 16*h.order.Len())
+	i := 0
+	for e := h.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(spanHash)
+		binary.BigEndian.PutUint64(data[i:], entry.hash)
+		binary.BigEndian.PutUint64(data[i+8:], uint64(entry.added.UnixNano()))
+		i += 16
+	}
+	if err := ioutil.WriteFile(path+".tmp", data, 0640); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// load adds the saved hashes which were added after the given time, keeping the newest maxEntries
+func (h *spanHashes) load(path string, after time.Time, maxEntries int) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data)%16 != 0 {
+		return fmt.Errorf("the file %s is truncated", path)
+	}
+	for ; len(data) > 0; data = data[16:] {
+		hash := binary.BigEndian.Uint64(data)
+		added := time.Unix(0, int64(binary.BigEndian.Uint64(data[8:])))
+		if added.Before(after) {
+			continue
+		}
+		if _, ok := h.added[hash]; ok {
+			continue
+		}
+		if h.order.Len() >= maxEntries {
+			h.removeOldest()
+		}
+		h.push(hash, added)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func newTestDeduplicator(t *testing.T, directory string) *SpanDeduplicator {
+	d, err := NewSpanDeduplicator(metrics.NullFactory, DeduplicatorParams{TTL: time.Minute, MaxEntries: 10, Directory: directory})
+	require.NoError(t, err)
+	return d
+}
+
+func TestSpanDeduplicator(t *testing.T) {
+	localFactory := metricstest.NewFactory(0)
+	d, err := NewSpanDeduplicator(localFactory, DeduplicatorParams{TTL: time.Minute, MaxEntries: 10})
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+
+	assert.False(t, d.Seen(1, 42))
+	d.Add(1, 42)
+	assert.True(t, d.Seen(1, 42))
+	assert.False(t, d.Seen(2, 42))
+	assert.False(t, d.Seen(1, 43))
+
+	now = now.Add(30 * time.Second)
+	d.Add(1, 43)
+	// adding a known hash again does not extend its lifetime
+	d.Add(1, 42)
+
+	now = now.Add(31 * time.Second)
+	assert.False(t, d.Seen(1, 42))
+	assert.True(t, d.Seen(1, 43))
+	assert.Len(t, d.partitions[1].added, 1)
+	assert.Equal(t, 1, d.partitions[1].order.Len())
+
+	now = now.Add(time.Minute)
+	assert.False(t, d.Seen(1, 43))
+	assert.Empty(t, d.partitions[1].added)
+
+	localFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
+		Name:  "span-processor.duplicate-spans-skipped",
+		Value: 2,
+	})
+}
+
+func TestSpanDeduplicatorMaxEntries(t *testing.T) {
+	localFactory := metricstest.NewFactory(0)
+	d, err := NewSpanDeduplicator(localFactory, DeduplicatorParams{TTL: time.Minute, MaxEntries: 2})
+	require.NoError(t, err)
+
+	d.Add(1, 41)
+	d.Add(1, 42)
+	d.Add(1, 43)
+	d.Add(2, 41)
+	assert.False(t, d.Seen(1, 41), "the oldest hash is evicted")
+	assert.True(t, d.Seen(1, 42))
+	assert.True(t, d.Seen(1, 43))
+	assert.True(t, d.Seen(2, 41), "the limit applies per partition")
+	localFactory.AssertCounterMetrics(t, metricstest.ExpectedMetric{
+		Name:  "span-processor.duplicate-hashes-evicted",
+		Value: 1,
+	})
+}
+
+func TestSpanDeduplicatorRelease(t *testing.T) {
+	d := newTestDeduplicator(t, "")
+	d.Add(1, 42)
+	d.Add(2, 42)
+	d.Release(1)
+	assert.NotContains(t, d.partitions, int32(1))
+	assert.False(t, d.Seen(1, 42))
+	assert.True(t, d.Seen(2, 42))
+
+	p := NewSpanProcessor(SpanProcessorParams{Deduplicator: d})
+	p.ClosePartition("spans", 2)
+	assert.False(t, d.Seen(2, 42))
+	NewSpanProcessor(SpanProcessorParams{}).ClosePartition("spans", 2)
+}
+
+func TestSpanDeduplicatorSavesReleasedPartitions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dedup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	directory := filepath.Join(dir, "spans")
+
+	d := newTestDeduplicator(t, directory)
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+	d.Add(1, 41)
+	now = now.Add(30 * time.Second)
+	d.Add(1, 42)
+	d.Add(2, 43)
+	d.Release(1)
+	d.Release(2)
+	// a partition which was never held is not saved
+	d.Release(3)
+	assert.Empty(t, d.partitions)
+
+	// the partitions assigned again after a restart are loaded with the time their hashes were added
+	restarted := newTestDeduplicator(t, directory)
+	restarted.now = func() time.Time { return now.Add(31 * time.Second) }
+	assert.False(t, restarted.Seen(1, 41))
+	assert.True(t, restarted.Seen(1, 42))
+	assert.True(t, restarted.Seen(2, 43))
+	assert.False(t, restarted.Seen(3, 43))
+
+	// the hashes of a truncated file are ignored
+	require.NoError(t, ioutil.WriteFile(filepath.Join(directory, "4"+hashesFileSuffix), []byte{1, 2, 3}, 0640))
+	assert.False(t, restarted.Seen(4, 43))
+	assert.Empty(t, restarted.partitions[4].added)
+}
+
+func TestSpanDeduplicatorLoadsNewestEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dedup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	d, err := NewSpanDeduplicator(metrics.NullFactory, DeduplicatorParams{TTL: time.Minute, MaxEntries: 3, Directory: dir})
+	require.NoError(t, err)
+	d.Add(1, 41)
+	d.Add(1, 42)
+	d.Add(1, 43)
+	d.Release(1)
+
+	d.maxEntries = 2
+	assert.True(t, d.Seen(1, 43))
+	assert.True(t, d.Seen(1, 42))
+	assert.False(t, d.Seen(1, 41))
+}
+
+func TestNewSpanDeduplicatorErrors(t *testing.T) {
+	_, err := NewSpanDeduplicator(metrics.NullFactory, DeduplicatorParams{TTL: time.Minute})
+	assert.EqualError(t, err, "the span deduplication ttl and max entries must be positive")
+	_, err = NewSpanDeduplicator(metrics.NullFactory, DeduplicatorParams{MaxEntries: 1})
+	assert.EqualError(t, err, "the span deduplication ttl and max entries must be positive")
+
+	file, err := ioutil.TempFile("", "dedup")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = NewSpanDeduplicator(metrics.NullFactory, DeduplicatorParams{TTL: time.Minute, MaxEntries: 1, Directory: filepath.Join(file.Name(), "spans")})
+	assert.Contains(t, err.Error(), "failed to create the span deduplication directory")
+}
@@ -16,9 +16,11 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
+	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
@@ -36,33 +38,134 @@ type Message interface {
 	Value() []byte
 }
 
+// HeadersMessage is implemented by the messages which carry kafka record headers
+type HeadersMessage interface {
+	Headers() map[string]string
+}
+
+// IsPermanent returns true if processing the same message again cannot resolve err, i.e. err or any error
+// it wraps is a spanstore.PermanentError, e.g. a malformed message or a span rejected by the storage
+func IsPermanent(err error) bool {
+	var permanent spanstore.PermanentError
+	return errors.As(err, &permanent)
+}
+
 // SpanProcessorParams stores the necessary parameters for a SpanProcessor
 type SpanProcessorParams struct {
 	Writer       spanstore.Writer
 	Unmarshaller kafka.Unmarshaller
+	// Deduplicator skips the spans already written from the same partition, nil disables it
+	Deduplicator *SpanDeduplicator
+	// ServiceNamePrefix is prepended to the service name of the spans
+	ServiceNamePrefix string
 }
 
 // KafkaSpanProcessor implements SpanProcessor for Kafka messages
 type KafkaSpanProcessor struct {
-	unmarshaller kafka.Unmarshaller
-	writer       spanstore.Writer
+	unmarshaller      kafka.Unmarshaller
+	writer            spanstore.Writer
+	deduplicator      *SpanDeduplicator
+	serviceNamePrefix string
 	io.Closer
 }
 
 // NewSpanProcessor creates a new KafkaSpanProcessor
 func NewSpanProcessor(params SpanProcessorParams) *KafkaSpanProcessor {
 	return &KafkaSpanProcessor{
-		unmarshaller: params.Unmarshaller,
-		writer:       params.Writer,
+		unmarshaller:      params.Unmarshaller,
+		writer:            params.Writer,
+		deduplicator:      params.Deduplicator,
+		serviceNamePrefix: params.ServiceNamePrefix,
 	}
 }
 
 // Process unmarshals and writes a single kafka message
 func (s KafkaSpanProcessor) Process(message Message) error {
+	if unmarshaller, ok := s.unmarshaller.(kafka.BatchUnmarshaller); ok {
+		spans, err := unmarshaller.UnmarshalBatch(message.Value())
+		if err != nil {
+			return spanstore.PermanentError{Err: fmt.Errorf("cannot unmarshall byte array into spans: %w", err)}
+		}
+		return s.writeSpans(message, spans)
+	}
+	if m, ok := message.(HeadersMessage); ok {
+		if _, ok := m.Headers()[kafka.HeaderSpanCount]; ok {
+			return s.processChunk(message)
+		}
+	}
 	span, err := s.unmarshaller.Unmarshal(message.Value())
 	if err != nil {
-		return fmt.Errorf("cannot unmarshall byte array into span: %w", err)
+		return spanstore.PermanentError{Err: fmt.Errorf("cannot unmarshall byte array into span: %w", err)}
+	}
+	return s.writeSpans(message, []*model.Span{span})
+}
+
+// processChunk unmarshals and writes the spans of a trace chunk produced by the kafka span writer
+func (s KafkaSpanProcessor) processChunk(message Message) error {
+	unmarshaller, ok := s.unmarshaller.(kafka.ChunkUnmarshaller)
+	if !ok {
+		return spanstore.PermanentError{Err: errors.New("cannot unmarshall trace chunk, the unmarshaller does not support chunks")}
+	}
+	spans, err := unmarshaller.UnmarshalChunk(message.Value())
+	if err != nil {
+		return spanstore.PermanentError{Err: fmt.Errorf("cannot unmarshall byte array into trace chunk: %w", err)}
+	}
+	return s.writeSpans(message, spans)
+}
+
+// ClosePartition releases the spans written from the partition, the topic is the one of the processor
+func (s KafkaSpanProcessor) ClosePartition(topic string, partition int32) {
+	if s.deduplicator != nil {
+		s.deduplicator.Release(partition)
+	}
+}
+
+func (s KafkaSpanProcessor) writeSpans(message Message, spans []*model.Span) error {
+	m, dedup := message.(PartitionMessage)
+	dedup = dedup && s.deduplicator != nil
+	// the spans of a message are written together when the storage supports batches
+	batchWriter, batch := s.writer.(spanstore.BatchWriter)
+	batch = batch && len(spans) > 1
+	var batchSpans []*model.Span
+	var batchHashes []uint64
+	for _, span := range spans {
+		if s.serviceNamePrefix != "" && span.Process != nil {
+			// the spans of a batch may share the process, so it is copied rather than modified
+			process := *span.Process
+			process.ServiceName = s.serviceNamePrefix + process.ServiceName
+			span.Process = &process
+		}
+		var hash uint64
+		var hashErr error
+		if dedup {
+			// the span is written anyway when its hash cannot be computed
+			if hash, hashErr = model.HashCode(span); hashErr == nil && s.deduplicator.Seen(m.Partition(), hash) {
+				continue
+			}
+		}
+		if batch {
+			batchSpans = append(batchSpans, span)
+			if dedup && hashErr == nil {
+				batchHashes = append(batchHashes, hash)
+			}
+			continue
+		}
+		// TODO context should be propagated from upstream components
+		if err := s.writer.WriteSpan(context.TODO(), span); err != nil {
+			return err
+		}
+		if dedup && hashErr == nil {
+			s.deduplicator.Add(m.Partition(), hash)
+		}
+	}
+	if len(batchSpans) == 0 {
+		return nil
+	}
+	if err := batchWriter.WriteSpans(context.TODO(), batchSpans); err != nil {
+		return err
+	}
+	for _, hash := range batchHashes {
+		s.deduplicator.Add(m.Partition(), hash)
 	}
-	// TODO context should be propagated from upstream components
-	return s.writer.WriteSpan(context.TODO(), span)
+	return nil
 }
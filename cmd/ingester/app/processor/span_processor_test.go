@@ -17,13 +17,24 @@ package processor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	cmocks "github.com/jaegertracing/jaeger/cmd/ingester/app/consumer/mocks"
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/converter/otlp"
 	umocks "github.com/jaegertracing/jaeger/pkg/kafka/mocks"
+	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+	otlpcommon "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	otlpresource "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	otlptrace "github.com/jaegertracing/jaeger/proto-gen/otlp/trace/v1"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 	smocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
 
@@ -68,8 +79,240 @@ func TestSpanProcessor_ProcessError(t *testing.T) {
 	message.On("Value").Return(data)
 	unmarshallerMock.On("Unmarshal", data).Return(nil, errors.New("moocow"))
 
-	assert.Error(t, processor.Process(message))
+	err := processor.Process(message)
+	assert.Error(t, err)
+	assert.True(t, IsPermanent(err))
 
 	message.AssertExpectations(t)
 	writer.AssertNotCalled(t, "WriteSpan")
 }
+
+type headersMessage struct {
+	value   []byte
+	headers map[string]string
+}
+
+func (m headersMessage) Value() []byte {
+	return m.value
+}
+
+func (m headersMessage) Headers() map[string]string {
+	return m.headers
+}
+
+func TestSpanProcessor_ProcessHeadersWithoutChunk(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewProtobufUnmarshaller(),
+		Writer:       writer,
+	})
+	span := &model.Span{OperationName: "foo"}
+	data, err := proto.Marshal(span)
+	require.NoError(t, err)
+	writer.On("WriteSpan", context.TODO(), span).Return(nil)
+
+	assert.NoError(t, processor.Process(headersMessage{value: data, headers: map[string]string{kafka.HeaderService: "svc"}}))
+	writer.AssertExpectations(t)
+}
+
+func TestSpanProcessor_ProcessChunk(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewProtobufUnmarshaller(),
+		Writer:       writer,
+	})
+	spans := []*model.Span{{OperationName: "foo"}, {OperationName: "bar"}}
+	data, err := proto.Marshal(&model.Batch{Spans: spans})
+	require.NoError(t, err)
+	writer.On("WriteSpan", context.TODO(), spans[0]).Return(nil)
+	writer.On("WriteSpan", context.TODO(), spans[1]).Return(errors.New("made-up error"))
+	chunk := map[string]string{kafka.HeaderSpanCount: "2"}
+
+	assert.EqualError(t, processor.Process(headersMessage{value: data, headers: chunk}), "made-up error")
+	writer.AssertNumberOfCalls(t, "WriteSpan", 2)
+
+	assert.Error(t, processor.Process(headersMessage{value: []byte("foo"), headers: chunk}))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 2)
+}
+
+func TestSpanProcessor_ProcessChunkNotSupported(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewZipkinThriftUnmarshaller(),
+		Writer:       writer,
+	})
+	chunk := map[string]string{kafka.HeaderSpanCount: "1"}
+
+	assert.EqualError(t, processor.Process(headersMessage{value: []byte("foo"), headers: chunk}),
+		"cannot unmarshall trace chunk, the unmarshaller does not support chunks")
+	writer.AssertNotCalled(t, "WriteSpan")
+}
+
+func TestSpanProcessor_ProcessBatch(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewOTLPProtobufUnmarshaller(),
+		Writer:       writer,
+	})
+	request := &otlpcollector.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{
+				Spans: []*otlptrace.Span{
+					{TraceId: make([]byte, 16), SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+					{TraceId: make([]byte, 16), SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 2}},
+				},
+			}},
+		}},
+	}
+	data, err := proto.Marshal(request)
+	require.NoError(t, err)
+	writer.On("WriteSpan", context.TODO(), mock.AnythingOfType("*model.Span")).Return(nil)
+
+	// the chunk header is not needed, OTLP messages always carry a batch of spans
+	message := &cmocks.Message{}
+	message.On("Value").Return(data)
+	assert.NoError(t, processor.Process(message))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 2)
+
+	message = &cmocks.Message{}
+	message.On("Value").Return([]byte("foo"))
+	assert.Error(t, processor.Process(message))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 2)
+}
+
+type batchWriter struct {
+	smocks.Writer
+}
+
+func (w *batchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	return w.Called(ctx, spans).Error(0)
+}
+
+func TestSpanProcessor_ProcessBatchWriter(t *testing.T) {
+	writer := &batchWriter{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewOTLPProtobufUnmarshaller(),
+		Writer:       writer,
+		Deduplicator: newTestDeduplicator(t, ""),
+	})
+	request := &otlpcollector.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{
+				Spans: []*otlptrace.Span{
+					{TraceId: make([]byte, 16), SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+					{TraceId: make([]byte, 16), SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 2}},
+				},
+			}},
+		}},
+	}
+	data, err := proto.Marshal(request)
+	require.NoError(t, err)
+	writer.On("WriteSpans", context.TODO(), mock.AnythingOfType("[]*model.Span")).Return(errors.New("storage down")).Once()
+	writer.On("WriteSpans", context.TODO(), mock.AnythingOfType("[]*model.Span")).Return(nil)
+
+	// the spans of the failed batches are written again
+	assert.EqualError(t, processor.Process(partitionMessage{value: data}), "storage down")
+	assert.NoError(t, processor.Process(partitionMessage{value: data}))
+	assert.NoError(t, processor.Process(partitionMessage{value: data}))
+	writer.AssertNumberOfCalls(t, "WriteSpans", 2)
+	writer.AssertNotCalled(t, "WriteSpan")
+	assert.Len(t, writer.Calls[1].Arguments.Get(1), 2)
+}
+
+func TestPermanentError(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", spanstore.PermanentError{Err: errors.New("malformed")})
+	assert.EqualError(t, err, "wrapped: malformed")
+	assert.True(t, IsPermanent(err))
+	assert.False(t, IsPermanent(errors.New("malformed")))
+	assert.False(t, IsPermanent(nil))
+}
+
+type partitionMessage struct {
+	value     []byte
+	partition int32
+}
+
+func (m partitionMessage) Value() []byte {
+	return m.value
+}
+
+func (m partitionMessage) Partition() int32 {
+	return m.partition
+}
+
+func TestSpanProcessor_ProcessDeduplicated(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewProtobufUnmarshaller(),
+		Writer:       writer,
+		Deduplicator: newTestDeduplicator(t, ""),
+	})
+	span := &model.Span{OperationName: "foo"}
+	data, err := proto.Marshal(span)
+	require.NoError(t, err)
+	writer.On("WriteSpan", context.TODO(), mock.AnythingOfType("*model.Span")).Return(nil)
+
+	assert.NoError(t, processor.Process(partitionMessage{value: data, partition: 1}))
+	assert.NoError(t, processor.Process(partitionMessage{value: data, partition: 1}))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 1)
+
+	// the spans are deduplicated per partition
+	assert.NoError(t, processor.Process(partitionMessage{value: data, partition: 2}))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 2)
+
+	// messages without a partition are always written
+	assert.NoError(t, processor.Process(headersMessage{value: data}))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 3)
+}
+
+func TestSpanProcessor_ProcessDeduplicatedWriteError(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller: kafka.NewProtobufUnmarshaller(),
+		Writer:       writer,
+		Deduplicator: newTestDeduplicator(t, ""),
+	})
+	data, err := proto.Marshal(&model.Span{OperationName: "foo"})
+	require.NoError(t, err)
+	writer.On("WriteSpan", context.TODO(), mock.AnythingOfType("*model.Span")).Return(errors.New("storage down")).Once()
+	writer.On("WriteSpan", context.TODO(), mock.AnythingOfType("*model.Span")).Return(nil)
+
+	// the spans which failed to be written are written again
+	assert.EqualError(t, processor.Process(partitionMessage{value: data}), "storage down")
+	assert.NoError(t, processor.Process(partitionMessage{value: data}))
+	writer.AssertNumberOfCalls(t, "WriteSpan", 2)
+}
+
+func TestSpanProcessor_ProcessServiceNamePrefix(t *testing.T) {
+	writer := &smocks.Writer{}
+	processor := NewSpanProcessor(SpanProcessorParams{
+		Unmarshaller:      kafka.NewOTLPProtobufUnmarshaller(),
+		Writer:            writer,
+		ServiceNamePrefix: "cluster-b.",
+	})
+	request := &otlpcollector.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			Resource: &otlpresource.Resource{Attributes: []*otlpcommon.KeyValue{{
+				Key:   otlp.ServiceNameAttribute,
+				Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "frontend"}},
+			}}},
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{
+				Spans: []*otlptrace.Span{
+					{TraceId: make([]byte, 16), SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+					{TraceId: make([]byte, 16), SpanId: []byte{0, 0, 0, 0, 0, 0, 0, 2}},
+				},
+			}},
+		}},
+	}
+	data, err := proto.Marshal(request)
+	require.NoError(t, err)
+	var services []string
+	writer.On("WriteSpan", context.TODO(), mock.AnythingOfType("*model.Span")).Run(func(args mock.Arguments) {
+		services = append(services, args.Get(1).(*model.Span).Process.ServiceName)
+	}).Return(nil)
+
+	message := &cmocks.Message{}
+	message.On("Value").Return(data)
+	assert.NoError(t, processor.Process(message))
+	assert.Equal(t, []string{"cluster-b.frontend", "cluster-b.frontend"}, services)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+// TopicMessage is implemented by the messages which know the kafka topic they were consumed from
+type TopicMessage interface {
+	Topic() string
+}
+
+type topicRouter struct {
+	processors map[string]SpanProcessor
+	io.Closer
+}
+
+// NewTopicRouter returns a processor which passes the messages to the processor of the topic they were consumed from
+func NewTopicRouter(processors map[string]SpanProcessor) SpanProcessor {
+	return &topicRouter{processors: processors}
+}
+
+func (r *topicRouter) Process(message Message) error {
+	var topic string
+	if m, ok := message.(TopicMessage); ok {
+		topic = m.Topic()
+	}
+	processor, ok := r.processors[topic]
+	if !ok {
+		return spanstore.PermanentError{Err: fmt.Errorf("no span processor for topic %q", topic)}
+	}
+	return processor.Process(message)
+}
+
+func (r *topicRouter) ClosePartition(topic string, partition int32) {
+	if closer, ok := r.processors[topic].(PartitionCloser); ok {
+		closer.ClosePartition(topic, partition)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmocks "github.com/jaegertracing/jaeger/cmd/ingester/app/consumer/mocks"
+)
+
+type fakeProcessor struct {
+	messages   []Message
+	err        error
+	partitions []int32
+}
+
+func (p *fakeProcessor) Process(message Message) error {
+	p.messages = append(p.messages, message)
+	return p.err
+}
+
+func (p *fakeProcessor) Close() error {
+	return nil
+}
+
+func (p *fakeProcessor) ClosePartition(topic string, partition int32) {
+	p.partitions = append(p.partitions, partition)
+}
+
+func TestTopicRouter(t *testing.T) {
+	spansA := &fakeProcessor{}
+	spansB := &fakeProcessor{err: errors.New("made-up error")}
+	router := NewTopicRouter(map[string]SpanProcessor{"spans-a": spansA, "spans-b": spansB})
+
+	messageA := &cmocks.Message{}
+	messageA.On("Topic").Return("spans-a")
+	assert.NoError(t, router.Process(messageA))
+
+	messageB := &cmocks.Message{}
+	messageB.On("Topic").Return("spans-b")
+	assert.EqualError(t, router.Process(messageB), "made-up error")
+
+	assert.Equal(t, []Message{messageA}, spansA.messages)
+	assert.Equal(t, []Message{messageB}, spansB.messages)
+
+	messageC := &cmocks.Message{}
+	messageC.On("Topic").Return("spans-c")
+	err := router.Process(messageC)
+	assert.EqualError(t, err, `no span processor for topic "spans-c"`)
+	assert.True(t, IsPermanent(err))
+
+	err = router.Process(headersMessage{})
+	assert.EqualError(t, err, `no span processor for topic ""`)
+
+	closer := router.(PartitionCloser)
+	closer.ClosePartition("spans-a", 3)
+	closer.ClosePartition("spans-c", 4)
+	assert.Equal(t, []int32{3}, spansA.partitions)
+	assert.Empty(t, spansB.partitions)
+}
@@ -26,6 +26,7 @@ import (
 	_ "go.uber.org/automaxprocs"
 	"go.uber.org/zap"
 
+	"github.com/jaegertracing/jaeger/cmd/badger"
 	"github.com/jaegertracing/jaeger/cmd/docs"
 	"github.com/jaegertracing/jaeger/cmd/env"
 	"github.com/jaegertracing/jaeger/cmd/flags"
@@ -62,6 +63,7 @@ func main() {
 			if err := storageFactory.Initialize(baseFactory, logger); err != nil {
 				logger.Fatal("Failed to init storage factory", zap.Error(err))
 			}
+			storageFactory.RegisterAdminHandlers(svc.Admin.Handle)
 			spanWriter, err := storageFactory.CreateSpanWriter()
 			if err != nil {
 				logger.Fatal("Failed to create span writer", zap.Error(err))
@@ -73,6 +75,11 @@ func main() {
 			if err != nil {
 				logger.Fatal("Unable to create consumer", zap.Error(err))
 			}
+			go func() {
+				for s := range consumer.HealthCheckStatus() {
+					svc.SetHealthCheckStatus(s)
+				}
+			}()
 			consumer.Start()
 
 			svc.RunAndThen(func() {
@@ -98,7 +105,9 @@ func main() {
 
 	command.AddCommand(version.Command())
 	command.AddCommand(env.Command())
+	command.AddCommand(badger.Command())
 	command.AddCommand(docs.Command(v))
+	command.AddCommand(replayDeadLettersCommand(storageFactory))
 
 	config.AddFlags(
 		v,
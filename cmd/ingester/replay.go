@@ -0,0 +1,89 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/cmd/flags"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app"
+	"github.com/jaegertracing/jaeger/cmd/ingester/app/builder"
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/plugin/storage"
+)
+
+// replayDeadLettersCommand creates the command which processes the messages of the dead-letter topic again.
+// It takes the same flags as the ingester and exits once all messages present at its start are processed.
+func replayDeadLettersCommand(storageFactory *storage.Factory) *cobra.Command {
+	v := viper.New()
+	command := &cobra.Command{
+		Use:   "replay-dead-letters",
+		Short: "Processes the messages of the dead-letter topic again",
+		Long: `Reads the messages of the dead-letter topic set by --ingester.dead-letter-topic and writes their spans to the storage,
+e.g. once a storage outage is resolved. The dead-letter topic is not modified, messages which fail again are logged.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.TryLoadConfigFile(v); err != nil {
+				return fmt.Errorf("cannot load config file: %w", err)
+			}
+			logger, err := new(flags.SharedFlags).InitFromViper(v).NewLogger(zap.NewProductionConfig())
+			if err != nil {
+				return fmt.Errorf("cannot create logger: %w", err)
+			}
+
+			storageFactory.InitFromViper(v)
+			if err := storageFactory.Initialize(metrics.NullFactory, logger); err != nil {
+				return fmt.Errorf("failed to init storage factory: %w", err)
+			}
+			defer storageFactory.Close()
+			spanWriter, err := storageFactory.CreateSpanWriter()
+			if err != nil {
+				return fmt.Errorf("failed to create span writer: %w", err)
+			}
+			if closer, ok := spanWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			options := app.Options{}
+			options.InitFromViper(v)
+			replayer, err := builder.CreateDeadLetterReplayer(logger, metrics.NullFactory, spanWriter, options)
+			if err != nil {
+				return fmt.Errorf("unable to create dead-letter replayer: %w", err)
+			}
+			defer replayer.Close()
+
+			result, err := replayer.Replay()
+			if err != nil {
+				return fmt.Errorf("failed to replay the dead-letter topic: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Replayed %d messages, %d failed\n", result.Processed, result.Failed)
+			return nil
+		},
+	}
+	config.AddFlags(
+		v,
+		command,
+		flags.AddConfigFileFlag,
+		flags.AddFlags,
+		storageFactory.AddFlags,
+		app.AddFlags,
+	)
+	return command
+}
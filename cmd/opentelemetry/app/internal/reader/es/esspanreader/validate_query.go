@@ -32,6 +32,9 @@ func validateQuery(p *spanstore.TraceQueryParameters) error {
 	if p == nil {
 		return errNilQuery
 	}
+	if len(p.TagKeys) > 0 || len(p.TagValues) > 0 {
+		return spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	if p.ServiceName == "" && len(p.Tags) > 0 {
 		return errServiceNameNotSet
 	}
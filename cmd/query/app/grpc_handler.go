@@ -171,5 +171,27 @@ func (g *GRPCHandler) GetDependencies(ctx context.Context, r *api_v2.GetDependen
 		return nil, status.Errorf(codes.Internal, "failed to fetch dependencies: %v", err)
 	}
 
-	return &api_v2.GetDependenciesResponse{Dependencies: dependencies}, nil
+	return &api_v2.GetDependenciesResponse{Dependencies: mergeDependencies(dependencies)}, nil
+}
+
+// mergeDependencies sums the call counts of the links between the same services, which are stored
+// once per interval and per collector when the dependency links are written by the collectors
+func mergeDependencies(dependencies []model.DependencyLink) []model.DependencyLink {
+	type key struct {
+		parent string
+		child  string
+		source string
+	}
+	indexes := make(map[key]int, len(dependencies))
+	merged := make([]model.DependencyLink, 0, len(dependencies))
+	for _, link := range dependencies {
+		k := key{parent: link.Parent, child: link.Child, source: link.Source}
+		if i, ok := indexes[k]; ok {
+			merged[i].CallCount += link.CallCount
+			continue
+		}
+		indexes[k] = len(merged)
+		merged = append(merged, link)
+	}
+	return merged
 }
@@ -469,6 +469,28 @@ func TestGetDependenciesSuccessGRPC(t *testing.T) {
 	})
 }
 
+func TestGetDependenciesMergesLinksGRPC(t *testing.T) {
+	withServerAndClient(t, func(server *grpcServer, client *grpcClient) {
+		endTs := time.Now().UTC()
+		server.depReader.On("GetDependencies", endTs.Add(time.Duration(-1)*defaultDependencyLookbackDuration), defaultDependencyLookbackDuration).
+			Return([]model.DependencyLink{
+				{Parent: "killer", Child: "queen", CallCount: 12},
+				{Parent: "queen", Child: "bishop", CallCount: 1},
+				{Parent: "killer", Child: "queen", CallCount: 3},
+			}, nil).Times(1)
+
+		res, err := client.GetDependencies(context.Background(), &api_v2.GetDependenciesRequest{
+			StartTime: endTs.Add(time.Duration(-1) * defaultDependencyLookbackDuration),
+			EndTime:   endTs,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []model.DependencyLink{
+			{Parent: "killer", Child: "queen", CallCount: 15},
+			{Parent: "queen", Child: "bishop", CallCount: 1},
+		}, res.Dependencies)
+	})
+}
+
 func TestGetDependenciesFailureGRPC(t *testing.T) {
 	withServerAndClient(t, func(server *grpcServer, client *grpcClient) {
 		endTs := time.Now().UTC()
@@ -33,6 +33,8 @@ const (
 	operationParam   = "operation"
 	tagParam         = "tag"
 	tagsParam        = "tags"
+	tagKeyParam      = "tagKey"
+	tagValuesParam   = "tagValues"
 	startTimeParam   = "start"
 	limitParam       = "limit"
 	minDurationParam = "minDuration"
@@ -64,7 +66,7 @@ type traceQueryParameters struct {
 // parse takes a request and constructs a model of parameters
 // Trace query syntax:
 //     query ::= param | param '&' query
-//     param ::= service | operation | limit | start | end | minDuration | maxDuration | tag | tags
+//     param ::= service | operation | limit | start | end | minDuration | maxDuration | tag | tags | tagKey | tagValues
 //     service ::= 'service=' strValue
 //     operation ::= 'operation=' strValue
 //     limit ::= 'limit=' intValue
@@ -76,6 +78,11 @@ type traceQueryParameters struct {
 //     key := strValue
 //     keyValue := strValue ':' strValue
 //     tags :== 'tags=' jsonMap
+//     tagKey ::= 'tagKey=' key
+//     tagValues ::= 'tagValues=' jsonMap of key to a list of strValue
+//
+// tagKey and tagValues are only supported by the Badger storage, other backends reject them.
+// Badger only finds tag keys of the spans written after its tag key index was introduced.
 func (p *queryParser) parse(r *http.Request) (*traceQueryParameters, error) {
 	service := r.FormValue(serviceParam)
 	operation := r.FormValue(operationParam)
@@ -94,6 +101,11 @@ func (p *queryParser) parse(r *http.Request) (*traceQueryParameters, error) {
 		return nil, err
 	}
 
+	tagValues, err := p.parseTagValues(r.Form[tagValuesParam])
+	if err != nil {
+		return nil, err
+	}
+
 	limitParam := r.FormValue(limitParam)
 	limit := defaultQueryLimit
 	if limitParam != "" {
@@ -130,6 +142,8 @@ func (p *queryParser) parse(r *http.Request) (*traceQueryParameters, error) {
 			StartTimeMin:  startTime,
 			StartTimeMax:  endTime,
 			Tags:          tags,
+			TagKeys:       r.Form[tagKeyParam],
+			TagValues:     tagValues,
 			NumTraces:     limit,
 			DurationMin:   minDuration,
 			DurationMax:   maxDuration,
@@ -203,3 +217,20 @@ func (p *queryParser) parseTags(simpleTags []string, jsonTags []string) (map[str
 	}
 	return retMe, nil
 }
+
+func (p *queryParser) parseTagValues(jsonTagValues []string) (map[string][]string, error) {
+	var retMe map[string][]string
+	for _, tagValues := range jsonTagValues {
+		var fromJSON map[string][]string
+		if err := json.Unmarshal([]byte(tagValues), &fromJSON); err != nil {
+			return nil, fmt.Errorf("malformed '%s' parameter, cannot unmarshal JSON: %s", tagValuesParam, err)
+		}
+		for k, v := range fromJSON {
+			if retMe == nil {
+				retMe = make(map[string][]string)
+			}
+			retMe[k] = append(retMe[k], v...)
+		}
+	}
+	return retMe, nil
+}
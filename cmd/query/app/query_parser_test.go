@@ -88,6 +88,22 @@ func TestParseTraceQuery(t *testing.T) {
 				},
 			},
 		},
+		// tag keys and tag value lists
+		{`x?service=service&start=0&end=0&operation=operation&limit=200&tagKey=a&tagKey=b&tagValues={"x":["y","z"]}&tagValues={"x":["w"]}`, noErr,
+			&traceQueryParameters{
+				TraceQueryParameters: spanstore.TraceQueryParameters{
+					ServiceName:   "service",
+					OperationName: "operation",
+					StartTimeMin:  time.Unix(0, 0),
+					StartTimeMax:  time.Unix(0, 0),
+					NumTraces:     200,
+					Tags:          make(map[string]string),
+					TagKeys:       []string{"a", "b"},
+					TagValues:     map[string][]string{"x": {"y", "z", "w"}},
+				},
+			},
+		},
+		{`x?service=service&start=0&end=0&operation=operation&limit=200&tagValues={"x":"y"}`, "malformed 'tagValues' parameter, cannot unmarshal JSON", nil},
 		{"x?service=service&start=0&end=0&operation=operation&limit=200&minDuration=10s&maxDuration=20s", noErr,
 			&traceQueryParameters{
 				TraceQueryParameters: spanstore.TraceQueryParameters{
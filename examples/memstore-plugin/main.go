@@ -16,6 +16,8 @@ package main
 
 import (
 	"flag"
+	"log"
+	"net"
 	"path"
 	"strings"
 
@@ -28,10 +30,14 @@ import (
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
-var configPath string
+var (
+	configPath string
+	serverAddr string
+)
 
 func main() {
 	flag.StringVar(&configPath, "config", "", "A path to the plugin's configuration file")
+	flag.StringVar(&serverAddr, "server-addr", "", "Serve the storage over the network on host:port instead of as a plugin")
 	flag.Parse()
 
 	if configPath != "" {
@@ -50,10 +56,18 @@ func main() {
 		store:        memory.NewStore(),
 		archiveStore: memory.NewStore(),
 	}
-	grpc.Serve(&shared.PluginServices{
+	services := &shared.PluginServices{
 		Store:        plugin,
 		ArchiveStore: plugin,
-	})
+	}
+	if serverAddr != "" {
+		lis, err := net.Listen("tcp", serverAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Fatal(grpc.NewRemoteServer(services).Serve(lis))
+	}
+	grpc.Serve(services)
 }
 
 type memoryStorePlugin struct {
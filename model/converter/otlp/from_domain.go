@@ -0,0 +1,197 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"encoding/binary"
+
+	"github.com/opentracing/opentracing-go/ext"
+
+	"github.com/jaegertracing/jaeger/model"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+	otlpcommon "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	otlpresource "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	otlptrace "github.com/jaegertracing/jaeger/proto-gen/otlp/trace/v1"
+)
+
+const (
+	// ServiceNameAttribute is the resource attribute holding the service name, see the OpenTelemetry semantic conventions.
+	ServiceNameAttribute = "service.name"
+	// EventNameField is the log field holding the name of the event, as set by the OpenTracing instrumentation.
+	EventNameField = "event"
+	// StatusDescriptionTag is the span tag holding the message of the OTLP span status.
+	StatusDescriptionTag = "otel.status_description"
+	// RefTypeAttribute is the link attribute holding the type of the Jaeger span reference.
+	RefTypeAttribute = "opentracing.ref_type"
+
+	refTypeFollowsFrom = "follows_from"
+)
+
+var spanKinds = map[string]otlptrace.Span_SpanKind{
+	string(ext.SpanKindRPCClientEnum): otlptrace.Span_SPAN_KIND_CLIENT,
+	string(ext.SpanKindRPCServerEnum): otlptrace.Span_SPAN_KIND_SERVER,
+	string(ext.SpanKindProducerEnum):  otlptrace.Span_SPAN_KIND_PRODUCER,
+	string(ext.SpanKindConsumerEnum):  otlptrace.Span_SPAN_KIND_CONSUMER,
+	"internal":                        otlptrace.Span_SPAN_KIND_INTERNAL,
+}
+
+// FromDomain converts the spans to an OTLP export request. The spans are grouped
+// into one ResourceSpans per distinct process.
+func FromDomain(spans []*model.Span) *otlpcollector.ExportTraceServiceRequest {
+	var processes []*model.Process
+	var resourceSpans []*otlptrace.ResourceSpans
+	for _, span := range spans {
+		idx := -1
+		for i, process := range processes {
+			if process == span.Process || (process != nil && span.Process != nil && process.Equal(span.Process)) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			idx = len(processes)
+			processes = append(processes, span.Process)
+			resourceSpans = append(resourceSpans, &otlptrace.ResourceSpans{
+				Resource:                    resourceFromDomain(span.Process),
+				InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{}},
+			})
+		}
+		librarySpans := resourceSpans[idx].InstrumentationLibrarySpans[0]
+		librarySpans.Spans = append(librarySpans.Spans, spanFromDomain(span))
+	}
+	return &otlpcollector.ExportTraceServiceRequest{ResourceSpans: resourceSpans}
+}
+
+func resourceFromDomain(process *model.Process) *otlpresource.Resource {
+	if process == nil {
+		return nil
+	}
+	attributes := make([]*otlpcommon.KeyValue, 0, len(process.Tags)+1)
+	attributes = append(attributes, stringAttribute(ServiceNameAttribute, process.ServiceName))
+	return &otlpresource.Resource{Attributes: appendAttributes(attributes, process.Tags)}
+}
+
+func spanFromDomain(span *model.Span) *otlptrace.Span {
+	otlpSpan := &otlptrace.Span{
+		TraceId:           traceIDFromDomain(span.TraceID),
+		SpanId:            spanIDFromDomain(span.SpanID),
+		Name:              span.OperationName,
+		StartTimeUnixNano: uint64(span.StartTime.UnixNano()),
+		EndTimeUnixNano:   uint64(span.StartTime.Add(span.Duration).UnixNano()),
+	}
+
+	tags := make([]model.KeyValue, 0, len(span.Tags))
+	for _, tag := range span.Tags {
+		switch {
+		case tag.Key == string(ext.SpanKind) && tag.VType == model.StringType:
+			if kind, ok := spanKinds[tag.VStr]; ok {
+				otlpSpan.Kind = kind
+				continue
+			}
+		case tag.Key == string(ext.Error) && tag.VType == model.BoolType && tag.Bool():
+			otlpSpan.Status = statusWithCode(otlpSpan.Status, otlptrace.Status_STATUS_CODE_ERROR)
+			continue
+		case tag.Key == StatusDescriptionTag && tag.VType == model.StringType:
+			otlpSpan.Status = statusWithCode(otlpSpan.Status, otlpSpan.Status.GetCode())
+			otlpSpan.Status.Message = tag.VStr
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	otlpSpan.Attributes = appendAttributes(nil, tags)
+
+	parentSpanID := span.ParentSpanID()
+	for _, ref := range span.References {
+		if ref.RefType == model.ChildOf && ref.TraceID == span.TraceID && ref.SpanID == parentSpanID && otlpSpan.ParentSpanId == nil {
+			otlpSpan.ParentSpanId = spanIDFromDomain(ref.SpanID)
+			continue
+		}
+		link := &otlptrace.Span_Link{
+			TraceId: traceIDFromDomain(ref.TraceID),
+			SpanId:  spanIDFromDomain(ref.SpanID),
+		}
+		if ref.RefType == model.FollowsFrom {
+			link.Attributes = []*otlpcommon.KeyValue{stringAttribute(RefTypeAttribute, refTypeFollowsFrom)}
+		}
+		otlpSpan.Links = append(otlpSpan.Links, link)
+	}
+
+	for _, log := range span.Logs {
+		event := &otlptrace.Span_Event{TimeUnixNano: uint64(log.Timestamp.UnixNano())}
+		fields := make([]model.KeyValue, 0, len(log.Fields))
+		for _, field := range log.Fields {
+			if field.Key == EventNameField && field.VType == model.StringType && event.Name == "" {
+				event.Name = field.VStr
+				continue
+			}
+			fields = append(fields, field)
+		}
+		event.Attributes = appendAttributes(nil, fields)
+		otlpSpan.Events = append(otlpSpan.Events, event)
+	}
+	return otlpSpan
+}
+
+func statusWithCode(status *otlptrace.Status, code otlptrace.Status_StatusCode) *otlptrace.Status {
+	if status == nil {
+		status = &otlptrace.Status{}
+	}
+	status.Code = code
+	return status
+}
+
+func appendAttributes(attributes []*otlpcommon.KeyValue, tags []model.KeyValue) []*otlpcommon.KeyValue {
+	for i := range tags {
+		attributes = append(attributes, attributeFromDomain(&tags[i]))
+	}
+	return attributes
+}
+
+func attributeFromDomain(kv *model.KeyValue) *otlpcommon.KeyValue {
+	value := &otlpcommon.AnyValue{}
+	switch kv.VType {
+	case model.StringType:
+		value.Value = &otlpcommon.AnyValue_StringValue{StringValue: kv.VStr}
+	case model.BoolType:
+		value.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: kv.Bool()}
+	case model.Int64Type:
+		value.Value = &otlpcommon.AnyValue_IntValue{IntValue: kv.Int64()}
+	case model.Float64Type:
+		value.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: kv.Float64()}
+	case model.BinaryType:
+		value.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: kv.Binary()}
+	}
+	return &otlpcommon.KeyValue{Key: kv.Key, Value: value}
+}
+
+func stringAttribute(key, value string) *otlpcommon.KeyValue {
+	return &otlpcommon.KeyValue{
+		Key:   key,
+		Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func traceIDFromDomain(traceID model.TraceID) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], traceID.High)
+	binary.BigEndian.PutUint64(b[8:], traceID.Low)
+	return b
+}
+
+func spanIDFromDomain(spanID model.SpanID) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(spanID))
+	return b
+}
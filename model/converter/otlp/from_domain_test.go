@@ -0,0 +1,143 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+	otlpcommon "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	otlpresource "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	otlptrace "github.com/jaegertracing/jaeger/proto-gen/otlp/trace/v1"
+)
+
+var (
+	startTime = time.Date(2020, 8, 1, 12, 0, 0, 123456000, time.UTC)
+	traceID   = model.NewTraceID(1, 2)
+	process   = &model.Process{
+		ServiceName: "frontend",
+		Tags:        []model.KeyValue{model.String("hostname", "host-1")},
+	}
+)
+
+func domainSpan() *model.Span {
+	return &model.Span{
+		TraceID:       traceID,
+		SpanID:        model.NewSpanID(3),
+		OperationName: "HTTP GET",
+		References: []model.SpanRef{
+			model.NewChildOfRef(traceID, model.NewSpanID(2)),
+			model.NewFollowsFromRef(model.NewTraceID(0, 9), model.NewSpanID(10)),
+			model.NewChildOfRef(model.NewTraceID(0, 11), model.NewSpanID(12)),
+		},
+		Flags:     model.SampledFlag,
+		StartTime: startTime,
+		Duration:  5 * time.Millisecond,
+		Tags: []model.KeyValue{
+			model.String("http.method", "GET"),
+			model.Bool("cache.hit", false),
+			model.Int64("http.status_code", 500),
+			model.Float64("ratio", 0.5),
+			model.Binary("payload", []byte{1, 2}),
+			model.String("span.kind", "server"),
+			model.Bool("error", true),
+			model.String(StatusDescriptionTag, "internal error"),
+		},
+		Logs: []model.Log{
+			{
+				Timestamp: startTime.Add(time.Millisecond),
+				Fields: []model.KeyValue{
+					model.String("event", "retry"),
+					model.Int64("attempt", 2),
+				},
+			},
+			{
+				Timestamp: startTime.Add(2 * time.Millisecond),
+				Fields:    []model.KeyValue{model.String("message", "no event name")},
+			},
+		},
+		Process: process,
+	}
+}
+
+func stringValue(v string) *otlpcommon.AnyValue {
+	return &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}}
+}
+
+func TestFromDomain(t *testing.T) {
+	request := FromDomain([]*model.Span{domainSpan()})
+
+	require.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, &otlpresource.Resource{
+		Attributes: []*otlpcommon.KeyValue{
+			{Key: ServiceNameAttribute, Value: stringValue("frontend")},
+			{Key: "hostname", Value: stringValue("host-1")},
+		},
+	}, request.ResourceSpans[0].Resource)
+
+	require.Len(t, request.ResourceSpans[0].InstrumentationLibrarySpans, 1)
+	spans := request.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}, span.TraceId)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 3}, span.SpanId)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, span.ParentSpanId)
+	assert.Equal(t, "HTTP GET", span.Name)
+	assert.Equal(t, otlptrace.Span_SPAN_KIND_SERVER, span.Kind)
+	assert.Equal(t, uint64(startTime.UnixNano()), span.StartTimeUnixNano)
+	assert.Equal(t, uint64(startTime.Add(5*time.Millisecond).UnixNano()), span.EndTimeUnixNano)
+	assert.Equal(t, &otlptrace.Status{Code: otlptrace.Status_STATUS_CODE_ERROR, Message: "internal error"}, span.Status)
+	assert.Len(t, span.Attributes, 5)
+	assert.Equal(t, &otlpcommon.KeyValue{
+		Key:   "payload",
+		Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: []byte{1, 2}}},
+	}, span.Attributes[4])
+	require.Len(t, span.Links, 2)
+	assert.Equal(t, []*otlpcommon.KeyValue{{Key: RefTypeAttribute, Value: stringValue("follows_from")}}, span.Links[0].Attributes)
+	assert.Empty(t, span.Links[1].Attributes)
+	require.Len(t, span.Events, 2)
+	assert.Equal(t, "retry", span.Events[0].Name)
+	assert.Len(t, span.Events[0].Attributes, 1)
+	assert.Equal(t, "", span.Events[1].Name)
+}
+
+func TestFromDomainGroupsByProcess(t *testing.T) {
+	span1, span2, span3 := domainSpan(), domainSpan(), domainSpan()
+	// an equal process in a different instance shares the resource
+	span2.Process = &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("hostname", "host-1")}}
+	span3.Process = &model.Process{ServiceName: "backend"}
+	span4 := domainSpan()
+	span4.Process = nil
+
+	request := FromDomain([]*model.Span{span1, span2, span3, span4})
+
+	require.Len(t, request.ResourceSpans, 3)
+	assert.Len(t, request.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans, 2)
+	assert.Len(t, request.ResourceSpans[1].InstrumentationLibrarySpans[0].Spans, 1)
+	assert.Nil(t, request.ResourceSpans[2].Resource)
+}
+
+func TestFromDomainUnknownSpanKind(t *testing.T) {
+	span := domainSpan()
+	span.Tags = []model.KeyValue{model.String("span.kind", "foo")}
+
+	otlpSpan := FromDomain([]*model.Span{span}).ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0]
+	assert.Equal(t, otlptrace.Span_SPAN_KIND_UNSPECIFIED, otlpSpan.Kind)
+	assert.Equal(t, []*otlpcommon.KeyValue{{Key: "span.kind", Value: stringValue("foo")}}, otlpSpan.Attributes)
+}
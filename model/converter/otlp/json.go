@@ -0,0 +1,103 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+)
+
+// OTLP/JSON deviates from the protobuf JSON mapping: trace and span IDs are hex strings rather than base64
+// and enums are integers.
+var idFields = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// MarshalJSON encodes an export request as OTLP/JSON.
+func MarshalJSON(request *otlpcollector.ExportTraceServiceRequest) ([]byte, error) {
+	out := new(bytes.Buffer)
+	if err := (&jsonpb.Marshaler{EnumsAsInts: true}).Marshal(out, request); err != nil {
+		return nil, err
+	}
+	return convertIDs(out.Bytes(), base64ToHex)
+}
+
+// UnmarshalJSON decodes an OTLP/JSON export request. IDs encoded as base64 are accepted as well.
+func UnmarshalJSON(data []byte, request *otlpcollector.ExportTraceServiceRequest) error {
+	data, err := convertIDs(data, hexToBase64)
+	if err != nil {
+		return err
+	}
+	return jsonpb.Unmarshal(bytes.NewReader(data), request)
+}
+
+// convertIDs re-encodes the trace and span IDs of a JSON export request wherever they appear, i.e. in spans and links.
+func convertIDs(data []byte, convert func(string) (string, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if err := convertNodeIDs(document, convert); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+func convertNodeIDs(node interface{}, convert func(string) (string, error)) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, value := range n {
+			if id, ok := value.(string); ok && idFields[key] {
+				converted, err := convert(id)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %w", key, id, err)
+				}
+				n[key] = converted
+				continue
+			}
+			if err := convertNodeIDs(value, convert); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, value := range n {
+			if err := convertNodeIDs(value, convert); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func base64ToHex(id string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(id)
+	return hex.EncodeToString(b), err
+}
+
+// hexToBase64 leaves base64 IDs unchanged, those of 8 and 16 bytes are padded and never valid hex.
+func hexToBase64(id string) (string, error) {
+	b, err := hex.DecodeString(id)
+	if err != nil {
+		return id, nil
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	span := domainSpan()
+	data, err := MarshalJSON(FromDomain([]*model.Span{span}))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"traceId":"`+span.TraceID.String()+`"`)
+	assert.Contains(t, string(data), `"spanId":"`+span.SpanID.String()+`"`)
+
+	request := &otlpcollector.ExportTraceServiceRequest{}
+	require.NoError(t, UnmarshalJSON(data, request))
+	spans, err := ToDomain(request)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Span{span}, spans)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	hexIDs := `{"resourceSpans":[{"instrumentationLibrarySpans":[{"spans":[` +
+		`{"traceId":"0000000000000001000000000000000a","spanId":"000000000000000b","parentSpanId":"000000000000000c","kind":2}]}]}]}`
+	base64IDs := `{"resourceSpans":[{"instrumentationLibrarySpans":[{"spans":[` +
+		`{"traceId":"AAAAAAAAAAEAAAAAAAAACg==","spanId":"AAAAAAAAAAs=","parentSpanId":"AAAAAAAAAAw=","kind":2}]}]}]}`
+	for _, data := range []string{hexIDs, base64IDs} {
+		request := &otlpcollector.ExportTraceServiceRequest{}
+		require.NoError(t, UnmarshalJSON([]byte(data), request))
+		spans, err := ToDomain(request)
+		require.NoError(t, err)
+		require.Len(t, spans, 1)
+		assert.Equal(t, model.NewTraceID(1, 10), spans[0].TraceID)
+		assert.Equal(t, model.NewSpanID(11), spans[0].SpanID)
+		assert.Equal(t, model.NewSpanID(12), spans[0].ParentSpanID())
+	}
+
+	assert.Error(t, UnmarshalJSON([]byte("foo"), &otlpcollector.ExportTraceServiceRequest{}))
+	assert.Error(t, UnmarshalJSON([]byte(`{"resourceSpans":[{"instrumentationLibrarySpans":[{"spans":[{"spanId":"%"}]}]}]}`),
+		&otlpcollector.ExportTraceServiceRequest{}))
+}
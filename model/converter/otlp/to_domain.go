@@ -0,0 +1,191 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/opentracing/opentracing-go/ext"
+
+	"github.com/jaegertracing/jaeger/model"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+	otlpcommon "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	otlpresource "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	otlptrace "github.com/jaegertracing/jaeger/proto-gen/otlp/trace/v1"
+)
+
+// ToDomain converts an OTLP export request to spans. The spans of a ResourceSpans share the same process.
+// Spans are marked as sampled, OTLP does not carry the trace flags.
+func ToDomain(request *otlpcollector.ExportTraceServiceRequest) ([]*model.Span, error) {
+	var spans []*model.Span
+	for _, resourceSpans := range request.GetResourceSpans() {
+		process := processToDomain(resourceSpans.GetResource())
+		for _, librarySpans := range resourceSpans.GetInstrumentationLibrarySpans() {
+			for _, otlpSpan := range librarySpans.GetSpans() {
+				span, err := spanToDomain(otlpSpan, process)
+				if err != nil {
+					return nil, err
+				}
+				spans = append(spans, span)
+			}
+		}
+	}
+	return spans, nil
+}
+
+func processToDomain(resource *otlpresource.Resource) *model.Process {
+	process := &model.Process{}
+	for _, attribute := range resource.GetAttributes() {
+		if attribute.GetKey() == ServiceNameAttribute {
+			if value, ok := attribute.GetValue().GetValue().(*otlpcommon.AnyValue_StringValue); ok {
+				process.ServiceName = value.StringValue
+				continue
+			}
+		}
+		process.Tags = append(process.Tags, attributeToDomain(attribute))
+	}
+	return process
+}
+
+func spanToDomain(otlpSpan *otlptrace.Span, process *model.Process) (*model.Span, error) {
+	traceID, err := traceIDToDomain(otlpSpan.GetTraceId())
+	if err != nil {
+		return nil, err
+	}
+	spanID, err := spanIDToDomain(otlpSpan.GetSpanId())
+	if err != nil {
+		return nil, err
+	}
+	startTime := time.Unix(0, int64(otlpSpan.GetStartTimeUnixNano())).UTC()
+	duration := time.Unix(0, int64(otlpSpan.GetEndTimeUnixNano())).Sub(startTime)
+	if duration < 0 {
+		// the end time is missing or before the start time
+		duration = 0
+	}
+	span := &model.Span{
+		TraceID:       traceID,
+		SpanID:        spanID,
+		OperationName: otlpSpan.GetName(),
+		Flags:         model.SampledFlag,
+		StartTime:     startTime,
+		Duration:      duration,
+		Process:       process,
+	}
+
+	if len(otlpSpan.GetParentSpanId()) > 0 {
+		parentSpanID, err := spanIDToDomain(otlpSpan.GetParentSpanId())
+		if err != nil {
+			return nil, err
+		}
+		span.References = append(span.References, model.NewChildOfRef(traceID, parentSpanID))
+	}
+	for _, link := range otlpSpan.GetLinks() {
+		ref, err := linkToDomain(link)
+		if err != nil {
+			return nil, err
+		}
+		span.References = append(span.References, ref)
+	}
+
+	span.Tags = attributesToDomain(otlpSpan.GetAttributes())
+	for kind, otlpKind := range spanKinds {
+		if otlpSpan.GetKind() == otlpKind {
+			span.Tags = append(span.Tags, model.String(string(ext.SpanKind), kind))
+		}
+	}
+	if otlpSpan.GetStatus().GetCode() == otlptrace.Status_STATUS_CODE_ERROR {
+		span.Tags = append(span.Tags, model.Bool(string(ext.Error), true))
+	}
+	if message := otlpSpan.GetStatus().GetMessage(); message != "" {
+		span.Tags = append(span.Tags, model.String(StatusDescriptionTag, message))
+	}
+
+	for _, event := range otlpSpan.GetEvents() {
+		var fields []model.KeyValue
+		if event.GetName() != "" {
+			fields = append(fields, model.String(EventNameField, event.GetName()))
+		}
+		span.Logs = append(span.Logs, model.Log{
+			Timestamp: time.Unix(0, int64(event.GetTimeUnixNano())).UTC(),
+			Fields:    append(fields, attributesToDomain(event.GetAttributes())...),
+		})
+	}
+	return span, nil
+}
+
+func linkToDomain(link *otlptrace.Span_Link) (model.SpanRef, error) {
+	traceID, err := traceIDToDomain(link.GetTraceId())
+	if err != nil {
+		return model.SpanRef{}, err
+	}
+	spanID, err := spanIDToDomain(link.GetSpanId())
+	if err != nil {
+		return model.SpanRef{}, err
+	}
+	for _, attribute := range link.GetAttributes() {
+		if attribute.GetKey() == RefTypeAttribute && attribute.GetValue().GetStringValue() == refTypeFollowsFrom {
+			return model.NewFollowsFromRef(traceID, spanID), nil
+		}
+	}
+	return model.NewChildOfRef(traceID, spanID), nil
+}
+
+func attributesToDomain(attributes []*otlpcommon.KeyValue) []model.KeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	tags := make([]model.KeyValue, 0, len(attributes))
+	for _, attribute := range attributes {
+		tags = append(tags, attributeToDomain(attribute))
+	}
+	return tags
+}
+
+func attributeToDomain(attribute *otlpcommon.KeyValue) model.KeyValue {
+	key := attribute.GetKey()
+	switch value := attribute.GetValue().GetValue().(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		return model.String(key, value.StringValue)
+	case *otlpcommon.AnyValue_BoolValue:
+		return model.Bool(key, value.BoolValue)
+	case *otlpcommon.AnyValue_IntValue:
+		return model.Int64(key, value.IntValue)
+	case *otlpcommon.AnyValue_DoubleValue:
+		return model.Float64(key, value.DoubleValue)
+	case *otlpcommon.AnyValue_BytesValue:
+		return model.Binary(key, value.BytesValue)
+	case nil:
+		return model.String(key, "")
+	default:
+		// arrays and key-value lists have no Jaeger equivalent, they are kept in their text form
+		return model.String(key, attribute.GetValue().String())
+	}
+}
+
+func traceIDToDomain(b []byte) (model.TraceID, error) {
+	if len(b) != 16 {
+		return model.TraceID{}, fmt.Errorf("invalid OTLP trace ID length %d, expected 16 bytes", len(b))
+	}
+	return model.NewTraceID(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])), nil
+}
+
+func spanIDToDomain(b []byte) (model.SpanID, error) {
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid OTLP span ID length %d, expected 8 bytes", len(b))
+	}
+	return model.NewSpanID(binary.BigEndian.Uint64(b)), nil
+}
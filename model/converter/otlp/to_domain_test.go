@@ -0,0 +1,135 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/model"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
+	otlpcommon "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	otlpresource "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	otlptrace "github.com/jaegertracing/jaeger/proto-gen/otlp/trace/v1"
+)
+
+func TestRoundTrip(t *testing.T) {
+	span := domainSpan()
+	spans, err := ToDomain(FromDomain([]*model.Span{span, span}))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Span{span, span}, spans)
+	assert.True(t, spans[0].Process == spans[1].Process, "spans of a resource share the process")
+}
+
+func TestToDomainAttributes(t *testing.T) {
+	request := &otlpcollector.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			Resource: &otlpresource.Resource{
+				Attributes: []*otlpcommon.KeyValue{
+					{Key: ServiceNameAttribute, Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: 1}}},
+				},
+			},
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{
+				Spans: []*otlptrace.Span{{
+					TraceId: make([]byte, 16),
+					SpanId:  make([]byte, 8),
+					Attributes: []*otlpcommon.KeyValue{
+						{Key: "empty"},
+						{Key: "array", Value: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{
+							ArrayValue: &otlpcommon.ArrayValue{Values: []*otlpcommon.AnyValue{stringValue("a")}},
+						}}},
+					},
+					Kind: otlptrace.Span_SPAN_KIND_CLIENT,
+				}},
+			}},
+		}},
+	}
+	spans, err := ToDomain(request)
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, &model.Process{Tags: []model.KeyValue{model.Int64(ServiceNameAttribute, 1)}}, spans[0].Process)
+	assert.Equal(t, []model.KeyValue{
+		model.String("empty", ""),
+		model.String("array", `array_value:<values:<string_value:"a" > > `),
+		model.String("span.kind", "client"),
+	}, spans[0].Tags)
+}
+
+func TestToDomainWithoutEndTime(t *testing.T) {
+	spans, err := ToDomain(&otlpcollector.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{
+				Spans: []*otlptrace.Span{{
+					TraceId:           make([]byte, 16),
+					SpanId:            make([]byte, 8),
+					StartTimeUnixNano: uint64(time.Now().UnixNano()),
+				}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, spans, 1)
+	assert.Equal(t, time.Duration(0), spans[0].Duration)
+}
+
+func TestToDomainInvalidIDs(t *testing.T) {
+	validTraceID, validSpanID := make([]byte, 16), make([]byte, 8)
+	tests := []struct {
+		name string
+		span *otlptrace.Span
+		err  string
+	}{
+		{
+			name: "trace ID",
+			span: &otlptrace.Span{TraceId: []byte{1}, SpanId: validSpanID},
+			err:  "invalid OTLP trace ID length 1, expected 16 bytes",
+		},
+		{
+			name: "span ID",
+			span: &otlptrace.Span{TraceId: validTraceID, SpanId: []byte{1}},
+			err:  "invalid OTLP span ID length 1, expected 8 bytes",
+		},
+		{
+			name: "parent span ID",
+			span: &otlptrace.Span{TraceId: validTraceID, SpanId: validSpanID, ParentSpanId: []byte{1, 2}},
+			err:  "invalid OTLP span ID length 2, expected 8 bytes",
+		},
+		{
+			name: "link trace ID",
+			span: &otlptrace.Span{TraceId: validTraceID, SpanId: validSpanID, Links: []*otlptrace.Span_Link{{SpanId: validSpanID}}},
+			err:  "invalid OTLP trace ID length 0, expected 16 bytes",
+		},
+		{
+			name: "link span ID",
+			span: &otlptrace.Span{TraceId: validTraceID, SpanId: validSpanID, Links: []*otlptrace.Span_Link{{TraceId: validTraceID}}},
+			err:  "invalid OTLP span ID length 0, expected 8 bytes",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ToDomain(&otlpcollector.ExportTraceServiceRequest{
+				ResourceSpans: []*otlptrace.ResourceSpans{{
+					InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{
+						Spans: []*otlptrace.Span{test.span},
+					}},
+				}},
+			})
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
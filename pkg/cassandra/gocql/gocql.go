@@ -36,6 +36,11 @@ func (s CQLSession) Query(stmt string, values ...interface{}) cassandra.Query {
 	return WrapCQLQuery(s.session.Query(stmt, values...))
 }
 
+// NewBatch delegates to gocql.Session#NewBatch and wraps the result as Batch.
+func (s CQLSession) NewBatch() cassandra.Batch {
+	return CQLBatch{session: s.session, batch: s.session.NewBatch(gocql.UnloggedBatch)}
+}
+
 // Close delegates to gocql.Session#Close.
 func (s CQLSession) Close() {
 	s.session.Close()
@@ -43,6 +48,29 @@ func (s CQLSession) Close() {
 
 // ---
 
+// CQLBatch is a wrapper around gocql.Batch.
+type CQLBatch struct {
+	session *gocql.Session
+	batch   *gocql.Batch
+}
+
+// Query delegates to gocql.Batch#Query.
+func (b CQLBatch) Query(stmt string, values ...interface{}) {
+	b.batch.Query(stmt, values...)
+}
+
+// Size delegates to gocql.Batch#Size.
+func (b CQLBatch) Size() int {
+	return b.batch.Size()
+}
+
+// Exec delegates to gocql.Session#ExecuteBatch.
+func (b CQLBatch) Exec() error {
+	return b.session.ExecuteBatch(b.batch)
+}
+
+// ---
+
 // CQLQuery is a wrapper around gocql.Query.
 type CQLQuery struct {
 	query *gocql.Query
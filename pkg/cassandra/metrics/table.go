@@ -52,3 +52,17 @@ func (t *Table) Exec(query cassandra.UpdateQuery, logger *zap.Logger) error {
 	}
 	return nil
 }
+
+// ExecBatch executes a batch of updates and reports metrics/logs about it.
+func (t *Table) ExecBatch(batch cassandra.Batch, logger *zap.Logger) error {
+	start := time.Now()
+	err := batch.Exec()
+	t.Emit(err, time.Since(start))
+	if err != nil {
+		if logger != nil {
+			logger.Error("Failed to exec batch", zap.Int("size", batch.Size()), zap.Error(err))
+		}
+		return fmt.Errorf("failed to Exec batch of %d queries: %w", batch.Size(), err)
+	}
+	return nil
+}
@@ -141,6 +141,40 @@ func TestTableExec(t *testing.T) {
 	}
 }
 
+func TestTableExecBatch(t *testing.T) {
+	mf := metricstest.NewFactory(0)
+	tm := NewTable(mf, "a_table")
+	logger, logBuf := testutils.NewLogger()
+
+	assert.NoError(t, tm.ExecBatch(&insertBatch{}, logger))
+	err := tm.ExecBatch(&insertBatch{size: 3, err: errors.New("failed")}, logger)
+	assert.EqualError(t, err, "failed to Exec batch of 3 queries: failed")
+	assert.Contains(t, logBuf.String(), `"msg":"Failed to exec batch","size":3,"error":"failed"`)
+	counts, _ := mf.Snapshot()
+	assert.Equal(t, map[string]int64{
+		"attempts|table=a_table": 2,
+		"inserts|table=a_table":  1,
+		"errors|table=a_table":   1,
+	}, counts)
+}
+
+type insertBatch struct {
+	err  error
+	size int
+}
+
+func (b *insertBatch) Query(stmt string, values ...interface{}) {
+	b.size++
+}
+
+func (b *insertBatch) Size() int {
+	return b.size
+}
+
+func (b *insertBatch) Exec() error {
+	return b.err
+}
+
 type insertQuery struct {
 	err error
 	str string
@@ -42,6 +42,8 @@ const (
 // Session is an abstraction of gocql.Session
 type Session interface {
 	Query(stmt string, values ...interface{}) Query
+	// NewBatch creates an unlogged batch of queries
+	NewBatch() Batch
 	Close()
 }
 
@@ -66,6 +68,14 @@ type Query interface {
 	PageSize(int) Query
 }
 
+// Batch is an abstraction of gocql.Batch
+type Batch interface {
+	// Query adds the query to the batch
+	Query(stmt string, values ...interface{})
+	Size() int
+	Exec() error
+}
+
 // Iterator is an abstraction of gocql.Iter
 type Iterator interface {
 	Scan(dest ...interface{}) bool
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereloader
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Reloader loads a configuration file and reloads it periodically, the content is applied again only when it changed.
+// The previous content stays in use when the file cannot be read or its new content cannot be applied.
+type Reloader struct {
+	path   string
+	name   string
+	apply  func(content []byte) error
+	logger *zap.Logger
+
+	lock      sync.Mutex
+	lastValue string
+
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+}
+
+// New reads the file and applies its content, then reloads it at the given interval if positive.
+// The name describes the content of the file in the errors and logs, e.g. "rate limits".
+func New(path string, name string, reloadInterval time.Duration, apply func(content []byte) error, logger *zap.Logger) (*Reloader, error) {
+	r := &Reloader{
+		path:   path,
+		name:   name,
+		apply:  apply,
+		logger: logger,
+	}
+	content, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(content); err != nil {
+		return nil, err
+	}
+	r.lastValue = string(content)
+
+	r.ctx, r.cancelFunc = context.WithCancel(context.Background())
+	if reloadInterval > 0 {
+		go r.autoReload(reloadInterval)
+	}
+	return r, nil
+}
+
+func (r *Reloader) read() ([]byte, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(r.path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s file %s: %w", r.name, r.path, err)
+	}
+	return content, nil
+}
+
+func (r *Reloader) autoReload(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.Reload()
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// Reload reads the file again and applies its content if it changed
+func (r *Reloader) Reload() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	content, err := r.read()
+	if err != nil {
+		r.logger.Error("failed to re-load "+r.name, zap.Error(err))
+		return
+	}
+	if r.lastValue == string(content) {
+		return
+	}
+	if err := r.apply(content); err != nil {
+		r.logger.Error("failed to update "+r.name, zap.Error(err))
+		return
+	}
+	r.lastValue = string(content)
+	r.logger.Info("Updated " + r.name)
+}
+
+// Close stops reloading the file
+func (r *Reloader) Close() error {
+	r.cancelFunc()
+	return nil
+}
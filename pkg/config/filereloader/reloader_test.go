@@ -0,0 +1,111 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereloader
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "reloader")
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
+
+// appliedValues records the contents applied, rejecting the ones starting with "invalid"
+type appliedValues struct {
+	lock   sync.Mutex
+	values []string
+}
+
+func (a *appliedValues) apply(content []byte) error {
+	if len(content) >= 7 && string(content[:7]) == "invalid" {
+		return errors.New("invalid content")
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.values = append(a.values, string(content))
+	return nil
+}
+
+func (a *appliedValues) get() []string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]string(nil), a.values...)
+}
+
+func TestReloader(t *testing.T) {
+	path := writeFile(t, "first")
+	defer os.Remove(path)
+	applied := &appliedValues{}
+	r, err := New(path, "test values", 0, applied.apply, zap.NewNop())
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, []string{"first"}, applied.get())
+
+	// the unchanged content is not applied again
+	r.Reload()
+	assert.Equal(t, []string{"first"}, applied.get())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("second"), 0600))
+	r.Reload()
+	assert.Equal(t, []string{"first", "second"}, applied.get())
+
+	// the previous content stays in use when the new one cannot be applied or read
+	require.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0600))
+	r.Reload()
+	require.NoError(t, os.Remove(path))
+	r.Reload()
+	assert.Equal(t, []string{"first", "second"}, applied.get())
+	assert.Equal(t, "second", r.lastValue)
+}
+
+func TestReloaderAutoReload(t *testing.T) {
+	path := writeFile(t, "first")
+	defer os.Remove(path)
+	applied := &appliedValues{}
+	r, err := New(path, "test values", 10*time.Millisecond, applied.apply, zap.NewNop())
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("second"), 0600))
+	for i := 0; i < 100 && len(applied.get()) < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, []string{"first", "second"}, applied.get())
+}
+
+func TestReloaderErrors(t *testing.T) {
+	applied := &appliedValues{}
+	_, err := New("does-not-exist.yaml", "test values", 0, applied.apply, zap.NewNop())
+	assert.Contains(t, err.Error(), "failed to read test values file does-not-exist.yaml")
+
+	path := writeFile(t, "invalid")
+	defer os.Remove(path)
+	_, err = New(path, "test values", 0, applied.apply, zap.NewNop())
+	assert.EqualError(t, err, "invalid content")
+}
@@ -27,6 +27,8 @@ type Client interface {
 	IndexExists(index string) IndicesExistsService
 	CreateIndex(index string) IndicesCreateService
 	CreateTemplate(id string) TemplateCreateService
+	CreateIndexTemplate(id string) TemplateCreateService
+	CreateIlmPolicy(name string) IlmPolicyCreateService
 	Index() IndexService
 	Search(indices ...string) SearchService
 	MultiSearch() MultiSearchService
@@ -51,11 +53,18 @@ type TemplateCreateService interface {
 	Do(ctx context.Context) (*elastic.IndicesPutTemplateResponse, error)
 }
 
+// IlmPolicyCreateService is an abstraction for creating an index lifecycle management policy
+type IlmPolicyCreateService interface {
+	Body(policy string) IlmPolicyCreateService
+	Do(ctx context.Context) (*elastic.XPackIlmPutLifecycleResponse, error)
+}
+
 // IndexService is an abstraction for elastic BulkService
 type IndexService interface {
 	Index(index string) IndexService
 	Type(typ string) IndexService
 	Id(id string) IndexService
+	OpType(opType string) IndexService
 	BodyJson(body interface{}) IndexService
 	Add()
 }
@@ -24,7 +24,6 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -63,8 +62,26 @@ type Configuration struct {
 	Enabled               bool           `mapstructure:"-"`
 	TLS                   tlscfg.Options `mapstructure:"tls"`
 	UseReadWriteAliases   bool           `mapstructure:"use_aliases"`
+	UseDataStreams        bool           `mapstructure:"use_data_streams"`
 	CreateIndexTemplates  bool           `mapstructure:"create_mappings"`
 	Version               uint           `mapstructure:"version"`
+	Distribution          string         `mapstructure:"distribution"`
+	MinorVersion          *uint          `mapstructure:"-"`
+	IndexLifecycle        IndexLifecycle `mapstructure:"ilm"`
+}
+
+// IndexLifecycle holds configuration for index lifecycle management (ILM).
+// When enabled the indices are created behind read and write aliases, rolled over
+// and deleted by Elasticsearch itself, replacing the esRollover.py and esCleaner.py scripts.
+type IndexLifecycle struct {
+	// Create the ILM policy and bootstrap the aliases at startup
+	Enabled bool `mapstructure:"enabled"`
+	// Name of the ILM policy attached to the indices
+	PolicyName string `mapstructure:"policy_name"`
+	// Age of the write index after which it is rolled over
+	RolloverMaxAge time.Duration `mapstructure:"rollover_max_age"`
+	// Age of a rolled over index after which it is deleted, zero keeps the indices forever
+	DeleteAfter time.Duration `mapstructure:"delete_after"`
 }
 
 // TagsAsFields holds configuration for tag schema.
@@ -93,10 +110,14 @@ type ClientBuilder interface {
 	GetAllTagsAsFields() bool
 	GetTagDotReplacement() string
 	GetUseReadWriteAliases() bool
+	GetUseDataStreams() bool
+	GetIndexLifecycle() IndexLifecycle
 	GetTokenFilePath() string
 	IsStorageEnabled() bool
 	IsCreateIndexTemplates() bool
 	GetVersion() uint
+	GetMinorVersion() (uint, bool)
+	GetDistribution() string
 	TagKeysAsFields() ([]string, error)
 }
 
@@ -167,17 +188,21 @@ func (c *Configuration) NewClient(logger *zap.Logger, metricsFactory metrics.Fac
 	}
 
 	if c.Version == 0 {
-		// Determine ElasticSearch Version
-		pingResult, _, err := rawClient.Ping(c.Servers[0]).Do(context.Background())
+		// Determine the distribution and the Elasticsearch API version
+		info, err := DetectCluster(context.Background(), rawClient)
 		if err != nil {
 			return nil, err
 		}
-		esVersion, err := strconv.Atoi(string(pingResult.Version.Number[0]))
-		if err != nil {
-			return nil, err
+		if info.Distribution == OpenSearchDistribution {
+			logger.Info("OpenSearch detected", zap.String("number", info.Number), zap.Uint("compatible-version", info.Version))
+		} else {
+			logger.Info("Elasticsearch detected", zap.Uint("version", info.Version))
+		}
+		c.Version = info.Version
+		c.MinorVersion = &info.Minor
+		if c.Distribution == "" {
+			c.Distribution = info.Distribution
 		}
-		logger.Info("Elasticsearch detected", zap.Int("version", esVersion))
-		c.Version = uint(esVersion)
 	}
 
 	return eswrapper.WrapESClient(rawClient, service, c.Version), nil
@@ -275,15 +300,42 @@ func (c *Configuration) GetVersion() uint {
 	return c.Version
 }
 
+// GetMinorVersion returns the minor Elasticsearch version, it is only known when the version is detected
+func (c *Configuration) GetMinorVersion() (uint, bool) {
+	if c.MinorVersion == nil {
+		return 0, false
+	}
+	return *c.MinorVersion, true
+}
+
+// GetDistribution returns the search engine distribution, Elasticsearch unless OpenSearch is configured or detected
+func (c *Configuration) GetDistribution() string {
+	if c.Distribution == "" {
+		return ElasticsearchDistribution
+	}
+	return c.Distribution
+}
+
 // GetTagDotReplacement returns character is used to replace dots in tag keys, when
 // the tag is stored as object field.
 func (c *Configuration) GetTagDotReplacement() string {
 	return c.Tags.DotReplacement
 }
 
-// GetUseReadWriteAliases indicates whether read alias should be used
+// GetUseReadWriteAliases indicates whether read alias should be used.
+// Index lifecycle management always reads and writes through aliases.
 func (c *Configuration) GetUseReadWriteAliases() bool {
-	return c.UseReadWriteAliases
+	return c.UseReadWriteAliases || c.IndexLifecycle.Enabled
+}
+
+// GetUseDataStreams indicates whether spans and services are stored in data streams
+func (c *Configuration) GetUseDataStreams() bool {
+	return c.UseDataStreams
+}
+
+// GetIndexLifecycle returns the index lifecycle management configuration
+func (c *Configuration) GetIndexLifecycle() IndexLifecycle {
+	return c.IndexLifecycle
 }
 
 // GetTokenFilePath returns file path containing the bearer token
@@ -0,0 +1,100 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/olivere/elastic"
+)
+
+const (
+	// ElasticsearchDistribution identifies clusters running Elasticsearch.
+	ElasticsearchDistribution = "elasticsearch"
+	// OpenSearchDistribution identifies clusters running OpenSearch.
+	OpenSearchDistribution = "opensearch"
+
+	// OpenSearch speaks the Elasticsearch 7 REST API, i.e. typeless mappings and object search hits total
+	openSearchCompatibleVersion = 7
+	// OpenSearch forked from Elasticsearch 7.10.2
+	openSearchCompatibleMinor = 10
+)
+
+// ClusterInfo describes the search engine behind the configured servers.
+type ClusterInfo struct {
+	// Distribution is either ElasticsearchDistribution or OpenSearchDistribution
+	Distribution string
+	// Number is the version reported by the cluster, e.g. 7.10.2 or 1.3.0
+	Number string
+	// Version is the major Elasticsearch version whose API the cluster is compatible with
+	Version uint
+	// Minor is the minor Elasticsearch version whose API the cluster is compatible with
+	Minor uint
+}
+
+type rootResponse struct {
+	Version struct {
+		Number       string `json:"number"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
+// DetectCluster queries the root endpoint of the cluster to find out which distribution
+// and version it runs. OpenSearch is mapped to the Elasticsearch version it is compatible with.
+func DetectCluster(ctx context.Context, client *elastic.Client) (ClusterInfo, error) {
+	res, err := client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   "/",
+	})
+	if err != nil {
+		return ClusterInfo{}, err
+	}
+	return parseClusterInfo(res.Body)
+}
+
+func parseClusterInfo(body []byte) (ClusterInfo, error) {
+	var root rootResponse
+	if err := json.Unmarshal(body, &root); err != nil {
+		return ClusterInfo{}, fmt.Errorf("cannot parse cluster info: %w", err)
+	}
+	parts := strings.SplitN(root.Version.Number, ".", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return ClusterInfo{}, fmt.Errorf("cannot parse version %q: %w", root.Version.Number, err)
+	}
+	var minor int
+	if len(parts) > 1 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil {
+			return ClusterInfo{}, fmt.Errorf("cannot parse version %q: %w", root.Version.Number, err)
+		}
+	}
+	info := ClusterInfo{
+		Distribution: ElasticsearchDistribution,
+		Number:       root.Version.Number,
+		Version:      uint(major),
+		Minor:        uint(minor),
+	}
+	if root.Version.Distribution == OpenSearchDistribution {
+		info.Distribution = OpenSearchDistribution
+		info.Version = openSearchCompatibleVersion
+		info.Minor = openSearchCompatibleMinor
+	}
+	return info, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/olivere/elastic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectCluster(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected ClusterInfo
+		err      string
+	}{
+		{
+			name:     "elasticsearch 6",
+			body:     `{"version":{"number":"6.8.2","build_flavor":"default"}}`,
+			expected: ClusterInfo{Distribution: ElasticsearchDistribution, Number: "6.8.2", Version: 6, Minor: 8},
+		},
+		{
+			name:     "elasticsearch 7",
+			body:     `{"version":{"number":"7.10.2","build_flavor":"oss"}}`,
+			expected: ClusterInfo{Distribution: ElasticsearchDistribution, Number: "7.10.2", Version: 7, Minor: 10},
+		},
+		{
+			name:     "opensearch 1",
+			body:     `{"version":{"distribution":"opensearch","number":"1.3.0"}}`,
+			expected: ClusterInfo{Distribution: OpenSearchDistribution, Number: "1.3.0", Version: 7, Minor: 10},
+		},
+		{
+			name:     "opensearch 2",
+			body:     `{"version":{"distribution":"opensearch","number":"2.11.0"}}`,
+			expected: ClusterInfo{Distribution: OpenSearchDistribution, Number: "2.11.0", Version: 7, Minor: 10},
+		},
+		{
+			name:     "major version only",
+			body:     `{"version":{"number":"6"}}`,
+			expected: ClusterInfo{Distribution: ElasticsearchDistribution, Number: "6", Version: 6},
+		},
+		{
+			name: "invalid version",
+			body: `{"version":{"number":"x.y"}}`,
+			err:  `cannot parse version "x.y": strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			name: "invalid minor version",
+			body: `{"version":{"number":"7.x"}}`,
+			err:  `cannot parse version "7.x": strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			name: "invalid body",
+			body: `[]`,
+			err:  "cannot parse cluster info: json: cannot unmarshal array into Go value of type config.rootResponse",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+			client, err := elastic.NewClient(elastic.SetURL(server.URL), elastic.SetSniff(false), elastic.SetHealthcheck(false))
+			require.NoError(t, err)
+
+			info, err := DetectCluster(context.Background(), client)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, info)
+		})
+	}
+}
+
+func TestGetDistribution(t *testing.T) {
+	assert.Equal(t, ElasticsearchDistribution, (&Configuration{}).GetDistribution())
+	assert.Equal(t, OpenSearchDistribution, (&Configuration{Distribution: OpenSearchDistribution}).GetDistribution())
+}
+
+func TestGetMinorVersion(t *testing.T) {
+	_, ok := (&Configuration{Version: 6}).GetMinorVersion()
+	assert.False(t, ok)
+
+	minor := uint(6)
+	v, ok := (&Configuration{Version: 6, MinorVersion: &minor}).GetMinorVersion()
+	assert.True(t, ok)
+	assert.Equal(t, uint(6), v)
+}
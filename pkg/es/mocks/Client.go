@@ -58,6 +58,38 @@ func (_m *Client) CreateIndex(index string) es.IndicesCreateService {
 	return r0
 }
 
+// CreateIlmPolicy provides a mock function with given fields: name
+func (_m *Client) CreateIlmPolicy(name string) es.IlmPolicyCreateService {
+	ret := _m.Called(name)
+
+	var r0 es.IlmPolicyCreateService
+	if rf, ok := ret.Get(0).(func(string) es.IlmPolicyCreateService); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(es.IlmPolicyCreateService)
+		}
+	}
+
+	return r0
+}
+
+// CreateIndexTemplate provides a mock function with given fields: id
+func (_m *Client) CreateIndexTemplate(id string) es.TemplateCreateService {
+	ret := _m.Called(id)
+
+	var r0 es.TemplateCreateService
+	if rf, ok := ret.Get(0).(func(string) es.TemplateCreateService); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(es.TemplateCreateService)
+		}
+	}
+
+	return r0
+}
+
 // CreateTemplate provides a mock function with given fields: id
 func (_m *Client) CreateTemplate(id string) es.TemplateCreateService {
 	ret := _m.Called(id)
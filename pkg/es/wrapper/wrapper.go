@@ -17,6 +17,8 @@ package eswrapper
 
 import (
 	"context"
+	"encoding/json"
+	"net/url"
 
 	"github.com/olivere/elastic"
 
@@ -57,6 +59,16 @@ func (c ClientWrapper) CreateTemplate(ttype string) es.TemplateCreateService {
 	return WrapESTemplateCreateService(c.client.IndexPutTemplate(ttype))
 }
 
+// CreateIndexTemplate creates a composable index template, which the legacy client has no service for.
+func (c ClientWrapper) CreateIndexTemplate(name string) es.TemplateCreateService {
+	return IndexTemplateCreateServiceWrapper{client: c.client, name: name}
+}
+
+// CreateIlmPolicy calls this function to internal client.
+func (c ClientWrapper) CreateIlmPolicy(name string) es.IlmPolicyCreateService {
+	return WrapESIlmPolicyCreateService(c.client.XPackIlmPutLifecycle().Policy(name))
+}
+
 // Index calls this function to internal client.
 func (c ClientWrapper) Index() es.IndexService {
 	r := elastic.NewBulkIndexRequest()
@@ -145,6 +157,58 @@ func (c TemplateCreateServiceWrapper) Do(ctx context.Context) (*elastic.IndicesP
 	return c.mappingCreateService.Do(ctx)
 }
 
+// IndexTemplateCreateServiceWrapper puts a composable index template through the _index_template API.
+type IndexTemplateCreateServiceWrapper struct {
+	client *elastic.Client
+	name   string
+	body   string
+}
+
+// Body sets the template.
+func (c IndexTemplateCreateServiceWrapper) Body(template string) es.TemplateCreateService {
+	c.body = template
+	return c
+}
+
+// Do puts the template.
+func (c IndexTemplateCreateServiceWrapper) Do(ctx context.Context) (*elastic.IndicesPutTemplateResponse, error) {
+	res, err := c.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: "PUT",
+		Path:   "/_index_template/" + url.PathEscape(c.name),
+		Body:   c.body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret := new(elastic.IndicesPutTemplateResponse)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ---
+
+// IlmPolicyCreateServiceWrapper is a wrapper around elastic.XPackIlmPutLifecycleService.
+type IlmPolicyCreateServiceWrapper struct {
+	policyCreateService *elastic.XPackIlmPutLifecycleService
+}
+
+// WrapESIlmPolicyCreateService creates an IlmPolicyCreateService out of *elastic.XPackIlmPutLifecycleService.
+func WrapESIlmPolicyCreateService(policyCreateService *elastic.XPackIlmPutLifecycleService) IlmPolicyCreateServiceWrapper {
+	return IlmPolicyCreateServiceWrapper{policyCreateService: policyCreateService}
+}
+
+// Body calls this function to internal service.
+func (c IlmPolicyCreateServiceWrapper) Body(policy string) es.IlmPolicyCreateService {
+	return WrapESIlmPolicyCreateService(c.policyCreateService.BodyString(policy))
+}
+
+// Do calls this function to internal service.
+func (c IlmPolicyCreateServiceWrapper) Do(ctx context.Context) (*elastic.XPackIlmPutLifecycleResponse, error) {
+	return c.policyCreateService.Do(ctx)
+}
+
 // ---
 
 // IndexServiceWrapper is a wrapper around elastic.ESIndexService.
@@ -173,6 +237,11 @@ func (i IndexServiceWrapper) Type(typ string) es.IndexService {
 	return WrapESIndexService(i.bulkIndexReq.Type(typ), i.bulkService, i.esVersion)
 }
 
+// OpType calls this function to internal service.
+func (i IndexServiceWrapper) OpType(opType string) es.IndexService {
+	return WrapESIndexService(i.bulkIndexReq.OpType(opType), i.bulkService, i.esVersion)
+}
+
 // Add adds the request to bulk service
 func (i IndexServiceWrapper) Add() {
 	i.bulkService.Add(i.bulkIndexReq)
@@ -35,6 +35,8 @@ const (
 	Ready
 	// Broken indicates that the healthcheck itself is broken, not serving HTTP
 	Broken
+	// Degraded indicates the service handles requests, but one of its dependencies is failing
+	Degraded
 )
 
 func (s Status) String() string {
@@ -45,11 +47,17 @@ func (s Status) String() string {
 		return "ready"
 	case Broken:
 		return "broken"
+	case Degraded:
+		return "degraded"
 	default:
 		return "unknown"
 	}
 }
 
+func (s Status) available() bool {
+	return s == Ready || s == Degraded
+}
+
 type healthCheckResponse struct {
 	statusCode int
 	StatusMsg  string    `json:"status"`
@@ -82,6 +90,10 @@ func New() *HealthCheck {
 				statusCode: http.StatusOK,
 				StatusMsg:  "Server available",
 			},
+			Degraded: {
+				statusCode: http.StatusOK,
+				StatusMsg:  "Server available but degraded",
+			},
 		},
 	}
 	hc.state.Store(state{status: Unavailable})
@@ -107,7 +119,7 @@ func (hc *HealthCheck) Handler() http.Handler {
 
 func (hc *HealthCheck) createRespBody(state state, template healthCheckResponse) []byte {
 	resp := template // clone
-	if state.status == Ready {
+	if state.status.available() {
 		resp.UpSince = state.upSince
 		resp.Uptime = fmt.Sprintf("%v", time.Since(state.upSince))
 	}
@@ -119,8 +131,11 @@ func (hc *HealthCheck) createRespBody(state state, template healthCheckResponse)
 func (hc *HealthCheck) Set(status Status) {
 	oldState := hc.getState()
 	newState := state{status: status}
-	if status == Ready {
-		if oldState.status != Ready {
+	if status.available() {
+		// the service stays up while degraded
+		if oldState.status.available() {
+			newState.upSince = oldState.upSince
+		} else {
 			newState.upSince = time.Now()
 		}
 	}
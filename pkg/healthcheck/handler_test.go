@@ -29,6 +29,7 @@ func TestStatusString(t *testing.T) {
 		Unavailable: "unavailable",
 		Ready:       "ready",
 		Broken:      "broken",
+		Degraded:    "degraded",
 		Status(-1):  "unknown",
 	}
 	for k, v := range tests {
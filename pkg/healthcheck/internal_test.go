@@ -50,6 +50,16 @@ func TestHttpCall(t *testing.T) {
 	assert.NotZero(t, hr.Uptime)
 	t.Logf("uptime=%v", hr.Uptime)
 
+	hc.Set(Degraded)
+
+	resp, err = http.Get(server.URL + "/")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	hrDegraded := parseHealthCheckResponse(t, resp)
+	assert.Equal(t, "Server available but degraded", hrDegraded.StatusMsg)
+	assert.True(t, hr.UpSince.Equal(hrDegraded.UpSince), "the service stays up while degraded")
+
 	time.Sleep(time.Millisecond)
 	hc.Set(Unavailable)
 
@@ -44,9 +44,13 @@ type Configuration struct {
 
 	Brokers         []string `mapstructure:"brokers"`
 	Topic           string   `mapstructure:"topic"`
+	Topics          []string `mapstructure:"topics"`
 	GroupID         string   `mapstructure:"group_id"`
 	ClientID        string   `mapstructure:"client_id"`
 	ProtocolVersion string   `mapstructure:"protocol_version"`
+	// RebalanceFlushTimeout is the time the released partitions have to mark the offsets
+	// of their in-flight messages before the offsets are committed on rebalance
+	RebalanceFlushTimeout time.Duration `mapstructure:"rebalance_flush_timeout"`
 }
 
 // NewConsumer creates a new kafka consumer
@@ -54,14 +58,7 @@ func (c *Configuration) NewConsumer(logger *zap.Logger) (Consumer, error) {
 	saramaConfig := cluster.NewConfig()
 	saramaConfig.Group.Mode = cluster.ConsumerModePartitions
 	saramaConfig.ClientID = c.ClientID
-	if len(c.ProtocolVersion) > 0 {
-		ver, err := sarama.ParseKafkaVersion(c.ProtocolVersion)
-		if err != nil {
-			return nil, err
-		}
-		saramaConfig.Config.Version = ver
-	}
-	if err := c.AuthenticationConfig.SetConfiguration(&saramaConfig.Config, logger); err != nil {
+	if err := c.setConfiguration(&saramaConfig.Config, logger); err != nil {
 		return nil, err
 	}
 	// cluster.NewConfig() uses sarama.NewConfig() to create the config.
@@ -69,5 +66,33 @@ func (c *Configuration) NewConsumer(logger *zap.Logger) (Consumer, error) {
 	// that does not set saramaConfig.Consumer.Offsets.CommitInterval to its default value 1s.
 	// then the samara-cluster fails if the default interval is not 1s.
 	saramaConfig.Consumer.Offsets.CommitInterval = time.Second
-	return cluster.NewConsumer(c.Brokers, c.GroupID, []string{c.Topic}, saramaConfig)
+	if c.RebalanceFlushTimeout > 0 {
+		saramaConfig.Group.Offsets.Synchronization.DwellTime = c.RebalanceFlushTimeout
+	}
+	topics := c.Topics
+	if len(topics) == 0 {
+		topics = []string{c.Topic}
+	}
+	return cluster.NewConsumer(c.Brokers, c.GroupID, topics, saramaConfig)
+}
+
+// NewClient creates a kafka client outside of the consumer group, e.g. to read partitions from given offsets
+func (c *Configuration) NewClient(logger *zap.Logger) (sarama.Client, error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.ClientID = c.ClientID
+	if err := c.setConfiguration(saramaConfig, logger); err != nil {
+		return nil, err
+	}
+	return sarama.NewClient(c.Brokers, saramaConfig)
+}
+
+func (c *Configuration) setConfiguration(saramaConfig *sarama.Config, logger *zap.Logger) error {
+	if len(c.ProtocolVersion) > 0 {
+		ver, err := sarama.ParseKafkaVersion(c.ProtocolVersion)
+		if err != nil {
+			return err
+		}
+		saramaConfig.Version = ver
+	}
+	return c.AuthenticationConfig.SetConfiguration(saramaConfig, logger)
 }
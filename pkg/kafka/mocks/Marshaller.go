@@ -46,3 +46,26 @@ func (_m *Marshaller) Marshal(_a0 *model.Span) ([]byte, error) {
 
 	return r0, r1
 }
+
+// MarshalChunk provides a mock function with given fields: _a0
+func (_m *Marshaller) MarshalChunk(_a0 []*model.Span) ([]byte, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]*model.Span) []byte); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*model.Span) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -16,6 +16,7 @@
 package queue
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,6 +41,8 @@ type BoundedQueue struct {
 	onDroppedItem func(item interface{})
 	consumer      func(item interface{})
 	stopCh        chan struct{}
+	// roomCh wakes up a producer waiting for room in the queue when an item is consumed
+	roomCh chan struct{}
 }
 
 // NewBoundedQueue constructs the new queue of specified capacity, and with an optional
@@ -50,6 +53,7 @@ func NewBoundedQueue(capacity int, onDroppedItem func(item interface{})) *Bounde
 		onDroppedItem: onDroppedItem,
 		items:         &queue,
 		stopCh:        make(chan struct{}),
+		roomCh:        make(chan struct{}, 1),
 		capacity:      uatomic.NewUint32(uint32(capacity)),
 		stopped:       uatomic.NewUint32(0),
 		size:          uatomic.NewUint32(0),
@@ -74,6 +78,7 @@ func (q *BoundedQueue) StartConsumers(num int, consumer func(item interface{}))
 				case item, ok := <-queue:
 					if ok {
 						q.size.Sub(1)
+						q.notifyRoom()
 						q.consumer(item)
 					} else {
 						// channel closed, finish worker
@@ -91,17 +96,43 @@ func (q *BoundedQueue) StartConsumers(num int, consumer func(item interface{}))
 
 // Produce is used by the producer to submit new item to the queue. Returns false in case of queue overflow.
 func (q *BoundedQueue) Produce(item interface{}) bool {
-	if q.stopped.Load() != 0 {
-		q.onDroppedItem(item)
+	if q.stopped.Load() != 0 || !q.tryProduce(item) {
+		// note that all items will be dropped if the capacity is 0
+		q.dropItem(item)
 		return false
 	}
+	return true
+}
 
+// ProduceWait is like Produce, but waits for room in the queue until the context is done or the queue is stopped
+// instead of dropping the item at once when the queue is full. Returns false if the item was dropped.
+func (q *BoundedQueue) ProduceWait(ctx context.Context, item interface{}) bool {
+	for q.stopped.Load() == 0 {
+		if q.tryProduce(item) {
+			// the producers waiting for room are woken up one at a time
+			if q.Size() < q.Capacity() {
+				q.notifyRoom()
+			}
+			return true
+		}
+		select {
+		case <-q.roomCh:
+		case <-ctx.Done():
+			q.dropItem(item)
+			return false
+		case <-q.stopCh:
+		}
+	}
+	q.dropItem(item)
+	return false
+}
+
+// tryProduce submits the item to the queue if there is room for it
+func (q *BoundedQueue) tryProduce(item interface{}) bool {
 	// we might have two concurrent backing queues at the moment
 	// their combined size is stored in q.size, and their combined capacity
 	// should match the capacity of the new queue
 	if q.Size() >= q.Capacity() {
-		// note that all items will be dropped if the capacity is 0
-		q.onDroppedItem(item)
 		return false
 	}
 
@@ -112,13 +143,23 @@ func (q *BoundedQueue) Produce(item interface{}) bool {
 	default:
 		// should not happen, as overflows should have been captured earlier
 		q.size.Sub(1)
-		if q.onDroppedItem != nil {
-			q.onDroppedItem(item)
-		}
 		return false
 	}
 }
 
+func (q *BoundedQueue) dropItem(item interface{}) {
+	if q.onDroppedItem != nil {
+		q.onDroppedItem(item)
+	}
+}
+
+func (q *BoundedQueue) notifyRoom() {
+	select {
+	case q.roomCh <- struct{}{}:
+	default:
+	}
+}
+
 // Stop stops all consumers, as well as the length reporter if started,
 // and releases the items channel. It blocks until all consumers have stopped.
 func (q *BoundedQueue) Stop() {
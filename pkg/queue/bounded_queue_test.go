@@ -16,6 +16,7 @@
 package queue
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
@@ -304,6 +305,48 @@ func TestResizeOldQueueIsDrained(t *testing.T) {
 	expected.Wait() // once this returns, we've consumed all items, meaning that both queues are drained
 }
 
+func TestProduceWait(t *testing.T) {
+	var dropped uatomic.Uint32
+	q := NewBoundedQueue(1, func(item interface{}) {
+		dropped.Add(1)
+	})
+
+	var startLock sync.Mutex
+	startLock.Lock() // block consumers
+	consumerState := newConsumerState(t)
+	q.StartConsumers(1, func(item interface{}) {
+		consumerState.record(item.(string))
+		startLock.Lock()
+		//lint:ignore SA2001 empty section is ok
+		startLock.Unlock()
+	})
+
+	assert.True(t, q.ProduceWait(context.Background(), "a"))
+	consumerState.waitToConsumeOnce()
+	assert.True(t, q.ProduceWait(context.Background(), "b"))
+
+	// the queue is full until the consumer is unblocked
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, q.ProduceWait(ctx, "c"))
+	assert.EqualValues(t, 1, dropped.Load())
+
+	produced := make(chan bool, 2)
+	for _, item := range []string{"d", "e"} {
+		go func(item string) {
+			produced <- q.ProduceWait(context.Background(), item)
+		}(item)
+	}
+	startLock.Unlock()
+	assert.True(t, <-produced)
+	assert.True(t, <-produced)
+	consumerState.assertConsumed(map[string]bool{"a": true, "b": true, "d": true, "e": true})
+
+	q.Stop()
+	assert.False(t, q.ProduceWait(context.Background(), "x"), "cannot push to closed queue")
+	assert.EqualValues(t, 2, dropped.Load())
+}
+
 func TestNoopResize(t *testing.T) {
 	q := NewBoundedQueue(2, func(item interface{}) {
 	})
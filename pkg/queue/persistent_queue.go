@@ -0,0 +1,753 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	segmentFileSuffix    = ".segment"
+	checkpointFileSuffix = ".checkpoint"
+
+	// every record is prefixed by the length and the CRC-32 of its data
+	recordHeaderSize = 8
+
+	defaultRetryInterval = time.Second
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errCorruptedRecord = errors.New("corrupted record")
+)
+
+// PersistentQueueOptions are the options of a PersistentQueue
+type PersistentQueueOptions struct {
+	// Directory is where the segments are stored, it is created if needed
+	Directory string
+	// Capacity is the maximum number of items waiting to be consumed
+	Capacity int
+	// MaxBytes is the maximum size of the segments on disk
+	MaxBytes int64
+	// SegmentBytes is the size above which the items are written to a new segment, it must be lower than MaxBytes
+	// since a segment is only deleted once the items of the following ones are written
+	SegmentBytes int64
+	// SyncInterval is how often the segment being written is synced to disk and the consumed offsets are saved,
+	// the segments are always synced when they are rotated and when the queue is stopped, 0 disables the periodic sync
+	SyncInterval time.Duration
+	// RetryInterval is how long a consumer waits before processing an item again after a failure, 1s by default
+	RetryInterval time.Duration
+	// Marshal encodes the items written to the segments
+	Marshal func(item interface{}) ([]byte, error)
+	// Unmarshal decodes the items read from the segments
+	Unmarshal func(data []byte) (interface{}, error)
+	// MetricsFactory is used to report the backlog of the queue
+	MetricsFactory metrics.Factory
+	// Logger is used to report the errors of the queue
+	Logger *zap.Logger
+}
+
+type persistentQueueMetrics struct {
+	// BacklogBytes is the size of the segments on disk
+	BacklogBytes metrics.Gauge `metric:"backlog-bytes"`
+	// Segments is the number of segments on disk
+	Segments metrics.Gauge `metric:"segments"`
+	// ReplayedItems is the number of items found on disk when the queue is opened
+	ReplayedItems metrics.Counter `metric:"replayed-items"`
+	// CorruptedRecords is the number of records that could not be read back
+	CorruptedRecords metrics.Counter `metric:"corrupted-records"`
+}
+
+type segment struct {
+	id   uint64
+	path string
+	size int64
+	// start is the offset of the first item to read, the items before were consumed before the queue was reopened
+	start int64
+	// checkpoint is the last consumed offset saved to the checkpoint file of the segment
+	checkpoint int64
+	// unread is the number of items not read from the segment yet
+	unread int
+	// pending holds the offsets of the items read from the segment but not consumed yet
+	pending map[int64]struct{}
+	// read is set once all the items of the segment have been read, the segment
+	// is deleted when they are all consumed
+	read bool
+}
+
+type persistentItem struct {
+	item    interface{}
+	segment *segment
+	offset  int64
+}
+
+// PersistentQueue implements the Queue contract on disk, so that the items survive restarts.
+// The items are appended to segment files, which are read in order by the consumers
+// and deleted once all their items have been consumed. The offset below which all the items
+// of a segment are consumed is saved to a checkpoint file when the queue is stopped and at
+// every sync interval, the items after it are consumed again when the queue is reopened.
+// The items consumed since the last checkpoint, e.g. before a crash, are thus delivered again,
+// i.e. the items are delivered at least once. The consumers started by StartAckConsumers
+// keep processing an item until it succeeds, so that the items survive the failures of the consumer.
+type PersistentQueue struct {
+	options       PersistentQueueOptions
+	onDroppedItem func(item interface{})
+	metrics       persistentQueueMetrics
+
+	mu         sync.Mutex
+	segments   []*segment // ordered by id, the last one is being written
+	writer     *os.File
+	readSeg    *segment
+	reader     *os.File
+	readOffset int64
+	size       int
+	capacity   int
+	bytes      int64
+	stopped    bool
+	// unsynced is set when records were written since the segment was last synced
+	unsynced bool
+
+	items    chan persistentItem
+	notify   chan struct{}
+	stopCh   chan struct{}
+	stopWG   sync.WaitGroup
+	consumer func(item interface{}) bool
+}
+
+// NewPersistentQueue opens the queue stored in the directory of the options, with an optional
+// callback for dropped items (e.g. useful to emit metrics).
+func NewPersistentQueue(options PersistentQueueOptions, onDroppedItem func(item interface{})) (*PersistentQueue, error) {
+	if options.Directory == "" {
+		return nil, errors.New("the persistent queue requires a directory")
+	}
+	if options.MaxBytes <= 0 || options.SegmentBytes <= 0 {
+		return nil, errors.New("the sizes of the persistent queue must be positive")
+	}
+	if options.SegmentBytes >= options.MaxBytes {
+		return nil, errors.New("the segment size of the persistent queue must be lower than its max size")
+	}
+	if options.Marshal == nil || options.Unmarshal == nil {
+		return nil, errors.New("the persistent queue requires a marshaller and an unmarshaller")
+	}
+	if options.MetricsFactory == nil {
+		options.MetricsFactory = metrics.NullFactory
+	}
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	if options.RetryInterval <= 0 {
+		options.RetryInterval = defaultRetryInterval
+	}
+	if onDroppedItem == nil {
+		onDroppedItem = func(item interface{}) {}
+	}
+	q := &PersistentQueue{
+		options:       options,
+		onDroppedItem: onDroppedItem,
+		capacity:      options.Capacity,
+		items:         make(chan persistentItem),
+		notify:        make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+	}
+	metrics.MustInit(&q.metrics, options.MetricsFactory.Namespace(metrics.NSOptions{Name: "persistent-queue"}), nil)
+
+	if err := os.MkdirAll(options.Directory, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create the persistent queue directory: %w", err)
+	}
+	if err := q.loadSegments(); err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+	if q.size > 0 {
+		q.options.Logger.Info("Replaying the items of the persistent queue",
+			zap.Int("items", q.size),
+			zap.Int64("bytes", q.bytes))
+		q.metrics.ReplayedItems.Inc(int64(q.size))
+	}
+	q.updateGauges()
+	if options.SyncInterval > 0 {
+		q.stopWG.Add(1)
+		go q.syncPeriodically(options.SyncInterval)
+	}
+	return q, nil
+}
+
+// loadSegments scans the segments left on disk and starts a new segment to write to.
+func (q *PersistentQueue) loadSegments() error {
+	files, err := ioutil.ReadDir(q.options.Directory)
+	if err != nil {
+		return fmt.Errorf("failed to list the persistent queue segments: %w", err)
+	}
+	var lastID uint64
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), segmentFileSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), segmentFileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		if id > lastID {
+			lastID = id
+		}
+		seg := q.newSegment(id)
+		seg.path = filepath.Join(q.options.Directory, file.Name())
+		seg.start = q.readCheckpoint(seg)
+		seg.checkpoint = seg.start
+		items, err := q.scanSegment(seg)
+		if err != nil {
+			return err
+		}
+		if items == 0 {
+			if err := os.Remove(seg.path); err != nil {
+				return fmt.Errorf("failed to remove the empty segment %s: %w", seg.path, err)
+			}
+			q.removeCheckpoint(seg)
+			continue
+		}
+		seg.unread = items
+		q.segments = append(q.segments, seg)
+		q.size += items
+		q.bytes += seg.size
+	}
+	sort.Slice(q.segments, func(i, j int) bool { return q.segments[i].id < q.segments[j].id })
+
+	if err := q.createSegment(lastID + 1); err != nil {
+		return err
+	}
+	return q.openReader(q.segments[0])
+}
+
+func (q *PersistentQueue) newSegment(id uint64) *segment {
+	return &segment{id: id, path: q.segmentPath(id), pending: make(map[int64]struct{})}
+}
+
+func (q *PersistentQueue) checkpointPath(seg *segment) string {
+	return strings.TrimSuffix(seg.path, segmentFileSuffix) + checkpointFileSuffix
+}
+
+// readCheckpoint returns the consumed offset saved for the segment, or 0 if there is none.
+func (q *PersistentQueue) readCheckpoint(seg *segment) int64 {
+	data, err := ioutil.ReadFile(q.checkpointPath(seg))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			q.options.Logger.Warn("failed to read the checkpoint of the segment", zap.String("segment", seg.path), zap.Error(err))
+		}
+		return 0
+	}
+	if len(data) != 8 {
+		q.options.Logger.Warn("Ignoring the invalid checkpoint of the segment", zap.String("segment", seg.path))
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(data))
+}
+
+// writeCheckpoint saves the consumed offset of the segment, the file is replaced atomically.
+func (q *PersistentQueue) writeCheckpoint(seg *segment, offset int64) error {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(offset))
+	path := q.checkpointPath(seg)
+	if err := ioutil.WriteFile(path+".tmp", data, 0640); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (q *PersistentQueue) removeCheckpoint(seg *segment) {
+	if err := os.Remove(q.checkpointPath(seg)); err != nil && !os.IsNotExist(err) {
+		q.options.Logger.Error("failed to remove the checkpoint of the segment", zap.String("segment", seg.path), zap.Error(err))
+	}
+}
+
+// checkpoint saves the offsets below which the items of the segments are consumed, must be called while holding the lock.
+func (q *PersistentQueue) checkpoint() {
+	for _, seg := range q.segments {
+		offset := seg.start
+		if seg == q.readSeg {
+			offset = q.readOffset
+		} else if seg.read {
+			offset = seg.size
+		}
+		for pending := range seg.pending {
+			if pending < offset {
+				offset = pending
+			}
+		}
+		if offset <= seg.checkpoint {
+			continue
+		}
+		if err := q.writeCheckpoint(seg, offset); err != nil {
+			q.options.Logger.Error("failed to save the checkpoint of the segment", zap.String("segment", seg.path), zap.Error(err))
+			continue
+		}
+		seg.checkpoint = offset
+	}
+}
+
+// scanSegment counts the items of the segment after its start, it truncates the segment after the last valid record
+// since the records after a partial write cannot be read back.
+func (q *PersistentQueue) scanSegment(seg *segment) (int, error) {
+	f, err := os.OpenFile(seg.path, os.O_RDWR, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open the segment %s: %w", seg.path, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat the segment %s: %w", seg.path, err)
+	}
+	items := 0
+	started := seg.start == 0
+	for seg.size < info.Size() {
+		if seg.size == seg.start {
+			started = true
+		}
+		data, err := readRecord(f, seg.size, info.Size())
+		if err != nil {
+			q.options.Logger.Warn("Truncating the corrupted segment",
+				zap.String("segment", seg.path),
+				zap.Int64("offset", seg.size),
+				zap.Error(err))
+			q.metrics.CorruptedRecords.Inc(1)
+			if err := f.Truncate(seg.size); err != nil {
+				return 0, fmt.Errorf("failed to truncate the segment %s: %w", seg.path, err)
+			}
+			break
+		}
+		seg.size += int64(recordHeaderSize + len(data))
+		if started {
+			items++
+		}
+	}
+	if seg.size == seg.start {
+		started = true
+	}
+	if !started {
+		// the checkpoint is not at a record boundary, e.g. the segment was truncated
+		q.options.Logger.Warn("Ignoring the invalid checkpoint of the segment",
+			zap.String("segment", seg.path),
+			zap.Int64("checkpoint", seg.start))
+		seg.start, seg.checkpoint, seg.size = 0, 0, 0
+		return q.scanSegment(seg)
+	}
+	return items, nil
+}
+
+// readRecord reads the record at the offset of a segment of the given size.
+func readRecord(f *os.File, offset int64, size int64) ([]byte, error) {
+	if size-offset < recordHeaderSize {
+		return nil, errCorruptedRecord
+	}
+	header := make([]byte, recordHeaderSize)
+	if _, err := f.ReadAt(header, offset); err != nil {
+		return nil, err
+	}
+	length := int64(binary.BigEndian.Uint32(header))
+	if size-offset-recordHeaderSize < length {
+		return nil, errCorruptedRecord
+	}
+	data := make([]byte, length)
+	if _, err := f.ReadAt(data, offset+recordHeaderSize); err != nil {
+		return nil, err
+	}
+	if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, errCorruptedRecord
+	}
+	return data, nil
+}
+
+func (q *PersistentQueue) segmentPath(id uint64) string {
+	return filepath.Join(q.options.Directory, fmt.Sprintf("%020d%s", id, segmentFileSuffix))
+}
+
+// createSegment starts a new segment to write to, must be called while holding the lock.
+func (q *PersistentQueue) createSegment(id uint64) error {
+	seg := q.newSegment(id)
+	writer, err := os.OpenFile(seg.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_TRUNC, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to create the segment %s: %w", seg.path, err)
+	}
+	if q.writer != nil {
+		q.sync()
+		if err := q.writer.Close(); err != nil {
+			q.options.Logger.Error("failed to close the segment", zap.Error(err))
+		}
+	}
+	q.writer = writer
+	q.segments = append(q.segments, seg)
+	return nil
+}
+
+// openReader starts reading the segment, must be called while holding the lock.
+func (q *PersistentQueue) openReader(seg *segment) error {
+	reader, err := os.Open(seg.path)
+	if err != nil {
+		return fmt.Errorf("failed to open the segment %s: %w", seg.path, err)
+	}
+	if q.reader != nil {
+		if err := q.reader.Close(); err != nil {
+			q.options.Logger.Error("failed to close the segment", zap.Error(err))
+		}
+	}
+	q.reader = reader
+	q.readSeg = seg
+	q.readOffset = seg.start
+	return nil
+}
+
+// sync flushes the records written to the segment to disk, must be called while holding the lock.
+func (q *PersistentQueue) sync() {
+	if !q.unsynced {
+		return
+	}
+	if err := q.writer.Sync(); err != nil {
+		q.options.Logger.Error("failed to sync the persistent queue segment", zap.Error(err))
+		return
+	}
+	q.unsynced = false
+}
+
+func (q *PersistentQueue) syncPeriodically(interval time.Duration) {
+	defer q.stopWG.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			q.mu.Lock()
+			q.sync()
+			q.checkpoint()
+			q.mu.Unlock()
+		case <-q.stopCh:
+			return
+		}
+	}
+}
+
+// StartConsumers starts a given number of goroutines consuming items from the queue
+// and passing them into the consumer callback.
+func (q *PersistentQueue) StartConsumers(num int, consumer func(item interface{})) {
+	q.StartAckConsumers(num, func(item interface{}) bool {
+		consumer(item)
+		return true
+	})
+}
+
+// StartAckConsumers starts a given number of goroutines consuming items from the queue
+// and passing them into the consumer callback. The items for which the consumer returns false
+// are passed again after the retry interval, until it returns true or the queue is stopped,
+// in which case they are kept on disk.
+func (q *PersistentQueue) StartAckConsumers(num int, consumer func(item interface{}) bool) {
+	q.consumer = consumer
+	q.stopWG.Add(1)
+	go q.readItems()
+	for i := 0; i < num; i++ {
+		q.stopWG.Add(1)
+		go func() {
+			defer q.stopWG.Done()
+			for {
+				select {
+				case item := <-q.items:
+					if !q.consume(item) {
+						return
+					}
+				case <-q.stopCh:
+					return
+				}
+			}
+		}()
+	}
+}
+
+// consume passes the item to the consumer until it succeeds, it returns false if the queue was stopped before.
+func (q *PersistentQueue) consume(item persistentItem) bool {
+	for !q.consumer(item.item) {
+		select {
+		case <-time.After(q.options.RetryInterval):
+		case <-q.stopCh:
+			// the item was not consumed, its segment is kept on disk
+			return false
+		}
+	}
+	q.consumed(item)
+	return true
+}
+
+// readItems reads the items in order and hands them over to the consumers.
+func (q *PersistentQueue) readItems() {
+	defer q.stopWG.Done()
+	for {
+		item, ok := q.next()
+		if !ok {
+			select {
+			case <-q.notify:
+				continue
+			case <-q.stopCh:
+				return
+			}
+		}
+		select {
+		case q.items <- item:
+		case <-q.stopCh:
+			// the item was not consumed, its segment is kept on disk
+			return
+		}
+	}
+}
+
+// next returns the next item to consume, or false if all the items written have been read.
+func (q *PersistentQueue) next() (persistentItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for !q.stopped {
+		seg := q.readSeg
+		if q.readOffset >= seg.size {
+			if seg == q.segments[len(q.segments)-1] {
+				return persistentItem{}, false
+			}
+			if !q.nextSegment() {
+				return persistentItem{}, false
+			}
+			continue
+		}
+		data, err := readRecord(q.reader, q.readOffset, seg.size)
+		if err != nil {
+			// the records were validated when written or loaded, skip the rest of the segment
+			q.options.Logger.Error("failed to read the persistent queue",
+				zap.String("segment", seg.path),
+				zap.Int64("offset", q.readOffset),
+				zap.Error(err))
+			q.metrics.CorruptedRecords.Inc(1)
+			q.readOffset = seg.size
+			q.size -= seg.unread
+			seg.unread = 0
+			continue
+		}
+		offset := q.readOffset
+		q.readOffset += int64(recordHeaderSize + len(data))
+		q.size--
+		seg.unread--
+		item, err := q.options.Unmarshal(data)
+		if err != nil {
+			q.options.Logger.Error("failed to unmarshal an item of the persistent queue", zap.Error(err))
+			q.metrics.CorruptedRecords.Inc(1)
+			continue
+		}
+		seg.pending[offset] = struct{}{}
+		return persistentItem{item: item, segment: seg, offset: offset}, true
+	}
+	return persistentItem{}, false
+}
+
+// nextSegment moves the reader to the segment following the one fully read, must be called while holding the lock.
+func (q *PersistentQueue) nextSegment() bool {
+	current := q.readSeg
+	var next *segment
+	for i, seg := range q.segments {
+		if seg == current {
+			next = q.segments[i+1]
+			break
+		}
+	}
+	if err := q.openReader(next); err != nil {
+		q.options.Logger.Error("failed to read the persistent queue", zap.Error(err))
+		return false
+	}
+	current.read = true
+	q.removeIfConsumed(current)
+	return true
+}
+
+func (q *PersistentQueue) consumed(item persistentItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(item.segment.pending, item.offset)
+	q.removeIfConsumed(item.segment)
+}
+
+// removeIfConsumed deletes the segment once all its items are consumed, must be called while holding the lock.
+func (q *PersistentQueue) removeIfConsumed(seg *segment) {
+	if !seg.read || len(seg.pending) > 0 {
+		return
+	}
+	for i, s := range q.segments {
+		if s == seg {
+			q.segments = append(q.segments[:i], q.segments[i+1:]...)
+			break
+		}
+	}
+	if err := os.Remove(seg.path); err != nil {
+		q.options.Logger.Error("failed to remove the consumed segment", zap.String("segment", seg.path), zap.Error(err))
+	}
+	q.removeCheckpoint(seg)
+	q.bytes -= seg.size
+	q.updateGauges()
+}
+
+// Produce is used by the producer to submit new item to the queue. Returns false if the queue is full
+// or the item cannot be written.
+func (q *PersistentQueue) Produce(item interface{}) bool {
+	data, err := q.options.Marshal(item)
+	if err != nil {
+		q.options.Logger.Error("failed to marshal an item of the persistent queue", zap.Error(err))
+		q.onDroppedItem(item)
+		return false
+	}
+	q.mu.Lock()
+	ok := q.append(data)
+	q.mu.Unlock()
+	if !ok {
+		q.onDroppedItem(item)
+		return false
+	}
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// append writes the record of the data to the last segment, must be called while holding the lock.
+func (q *PersistentQueue) append(data []byte) bool {
+	recordSize := int64(recordHeaderSize + len(data))
+	if q.stopped || q.size >= q.capacity {
+		return false
+	}
+	head := q.segments[len(q.segments)-1]
+	if q.bytes+recordSize > q.options.MaxBytes && q.readSeg == head && head.size > 0 &&
+		q.readOffset >= head.size && len(head.pending) == 0 {
+		// the segment being written is fully consumed, rotate it so that it is deleted
+		if err := q.createSegment(head.id + 1); err != nil {
+			q.options.Logger.Error("failed to rotate the persistent queue segment", zap.Error(err))
+			return false
+		}
+		if !q.nextSegment() {
+			return false
+		}
+		head = q.segments[len(q.segments)-1]
+	}
+	if q.bytes+recordSize > q.options.MaxBytes {
+		return false
+	}
+	if head.size > 0 && head.size+recordSize > q.options.SegmentBytes {
+		if err := q.createSegment(head.id + 1); err != nil {
+			q.options.Logger.Error("failed to rotate the persistent queue segment", zap.Error(err))
+			return false
+		}
+		head = q.segments[len(q.segments)-1]
+	}
+
+	record := make([]byte, recordSize)
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:], crc32.Checksum(data, crcTable))
+	copy(record[recordHeaderSize:], data)
+	if _, err := q.writer.Write(record); err != nil {
+		q.options.Logger.Error("failed to write to the persistent queue", zap.Error(err))
+		// the following records cannot be read back after a partial write
+		if err := q.writer.Truncate(head.size); err != nil {
+			q.options.Logger.Error("failed to truncate the persistent queue segment", zap.Error(err))
+		}
+		return false
+	}
+	head.size += recordSize
+	head.unread++
+	q.unsynced = true
+	q.bytes += recordSize
+	q.size++
+	q.updateGauges()
+	return true
+}
+
+// updateGauges reports the backlog, must be called while holding the lock.
+func (q *PersistentQueue) updateGauges() {
+	q.metrics.BacklogBytes.Update(q.bytes)
+	q.metrics.Segments.Update(int64(len(q.segments)))
+}
+
+// Stop stops all consumers, then syncs and closes the segments, it blocks until all consumers have stopped.
+// The items not consumed yet are kept on disk and the offsets of the consumed ones are saved.
+func (q *PersistentQueue) Stop() {
+	q.mu.Lock()
+	q.stopped = true // disable producer
+	q.mu.Unlock()
+	close(q.stopCh)
+	q.stopWG.Wait()
+	q.mu.Lock()
+	if q.writer != nil {
+		q.sync()
+		q.checkpoint()
+	}
+	q.closeFiles()
+	q.mu.Unlock()
+}
+
+func (q *PersistentQueue) closeFiles() {
+	for _, f := range []*os.File{q.writer, q.reader} {
+		if f == nil {
+			continue
+		}
+		if err := f.Close(); err != nil {
+			q.options.Logger.Error("failed to close the segment", zap.Error(err))
+		}
+	}
+	q.writer, q.reader = nil, nil
+}
+
+// Size returns the number of items waiting to be consumed
+func (q *PersistentQueue) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Capacity returns capacity of the queue
+func (q *PersistentQueue) Capacity() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.capacity
+}
+
+// Bytes returns the size of the segments on disk
+func (q *PersistentQueue) Bytes() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.bytes
+}
+
+// Resize changes the capacity of the queue, returning whether the action was successful
+func (q *PersistentQueue) Resize(capacity int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if capacity == q.capacity {
+		return false
+	}
+	q.capacity = capacity
+	return true
+}
@@ -0,0 +1,447 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	uatomic "go.uber.org/atomic"
+)
+
+func stringQueueOptions(dir string) PersistentQueueOptions {
+	return PersistentQueueOptions{
+		Directory:    dir,
+		Capacity:     100,
+		MaxBytes:     1024 * 1024,
+		SegmentBytes: 100,
+		Marshal: func(item interface{}) ([]byte, error) {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("not a string")
+			}
+			return []byte(s), nil
+		},
+		Unmarshal: func(data []byte) (interface{}, error) {
+			if string(data) == "poison" {
+				return nil, errors.New("poison")
+			}
+			return string(data), nil
+		},
+	}
+}
+
+func tempQueueDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "persistent-queue")
+	require.NoError(t, err)
+	return dir
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentFileSuffix))
+	require.NoError(t, err)
+	return files
+}
+
+type collectingConsumer struct {
+	mu    sync.Mutex
+	items []string
+}
+
+func (c *collectingConsumer) consume(item interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = append(c.items, item.(string))
+}
+
+func (c *collectingConsumer) waitFor(t *testing.T, n int) []string {
+	for i := 0; i < 200; i++ {
+		c.mu.Lock()
+		count := len(c.items)
+		c.mu.Unlock()
+		if count >= n {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	require.Len(t, c.items, n)
+	return append([]string(nil), c.items...)
+}
+
+func expectedItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+	return items
+}
+
+func TestPersistentQueue(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	mFact := metricstest.NewFactory(0)
+	opts := stringQueueOptions(dir)
+	opts.MetricsFactory = mFact
+	q, err := NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+
+	consumer := &collectingConsumer{}
+	q.StartConsumers(1, consumer.consume)
+	for i := 0; i < 100; i++ {
+		require.True(t, q.Produce(fmt.Sprintf("item-%d", i)))
+	}
+	items := consumer.waitFor(t, 100)
+	for i, item := range items {
+		assert.Equal(t, fmt.Sprintf("item-%d", i), item, "the items are consumed in order")
+	}
+	assert.Equal(t, 0, q.Size())
+	q.Stop()
+
+	// the consumed segments are deleted, only the last one is kept
+	assert.Len(t, segmentFiles(t, dir), 1)
+	assert.False(t, q.Produce("stopped"))
+	_, gauges := mFact.Snapshot()
+	assert.EqualValues(t, 1, gauges["persistent-queue.segments"])
+	assert.EqualValues(t, q.Bytes(), gauges["persistent-queue.backlog-bytes"])
+}
+
+func TestPersistentQueueReplay(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	q, err := NewPersistentQueue(stringQueueOptions(dir), nil)
+	require.NoError(t, err)
+	for i := 0; i < 50; i++ {
+		require.True(t, q.Produce(fmt.Sprintf("item-%d", i)))
+	}
+	assert.Equal(t, 50, q.Size())
+	assert.Greater(t, len(segmentFiles(t, dir)), 1)
+	q.Stop()
+
+	mFact := metricstest.NewFactory(0)
+	opts := stringQueueOptions(dir)
+	opts.MetricsFactory = mFact
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 50, q.Size())
+	mFact.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "persistent-queue.replayed-items", Value: 50})
+
+	consumer := &collectingConsumer{}
+	q.StartConsumers(4, consumer.consume)
+	require.True(t, q.Produce("item-50"))
+	assert.ElementsMatch(t, expectedItems(51), consumer.waitFor(t, 51))
+	q.Stop()
+}
+
+func TestPersistentQueueKeepsUnconsumedItems(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	opts := stringQueueOptions(dir)
+	opts.SegmentBytes = 1
+	q, err := NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+
+	var blockLock sync.Mutex
+	blockLock.Lock()
+	consumed := make(chan string, 10)
+	q.StartConsumers(1, func(item interface{}) {
+		consumed <- item.(string)
+		blockLock.Lock()
+		//lint:ignore SA2001 empty section is ok
+		blockLock.Unlock()
+	})
+	require.True(t, q.Produce("a"))
+	require.True(t, q.Produce("b"))
+	assert.Equal(t, "a", <-consumed)
+	blockLock.Unlock()
+	assert.Equal(t, "b", <-consumed)
+	q.Stop()
+
+	// the items are only deleted once consumed, the stop can happen before "b" is acknowledged
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, q.Size(), 1)
+	q.Stop()
+}
+
+func TestPersistentQueueRetriesFailedItems(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	opts := stringQueueOptions(dir)
+	opts.SegmentBytes = 1
+	opts.SyncInterval = time.Millisecond
+	opts.RetryInterval = time.Millisecond
+	q, err := NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+
+	failing := uatomic.NewBool(true)
+	attempts := make(chan string, 100)
+	q.StartAckConsumers(1, func(item interface{}) bool {
+		attempts <- item.(string)
+		return !failing.Load()
+	})
+	require.True(t, q.Produce("a"))
+	require.True(t, q.Produce("b"))
+	assert.Equal(t, "a", <-attempts)
+	assert.Equal(t, "a", <-attempts, "the failed item is processed again")
+	failing.Store(false)
+	for item := range attempts {
+		if item == "b" {
+			break
+		}
+		assert.Equal(t, "a", item)
+	}
+	q.Stop()
+
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, q.Size(), 1, "the successful items are deleted")
+	q.Stop()
+}
+
+func TestPersistentQueueKeepsFailedItems(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	opts := stringQueueOptions(dir)
+	opts.RetryInterval = time.Hour
+	q, err := NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+
+	attempts := make(chan string, 10)
+	q.StartAckConsumers(2, func(item interface{}) bool {
+		attempts <- item.(string)
+		return false
+	})
+	require.True(t, q.Produce("a"))
+	require.True(t, q.Produce("b"))
+	<-attempts
+	<-attempts
+	q.Stop()
+
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, q.Size(), "the items that failed are kept on disk")
+	q.Stop()
+}
+
+func TestPersistentQueueCheckpoint(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	opts := stringQueueOptions(dir)
+	opts.RetryInterval = time.Hour
+	q, err := NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+
+	attempts := make(chan string, 10)
+	q.StartAckConsumers(1, func(item interface{}) bool {
+		attempts <- item.(string)
+		return item != "c"
+	})
+	require.True(t, q.Produce("a"))
+	require.True(t, q.Produce("b"))
+	require.True(t, q.Produce("c"))
+	for _, item := range []string{"a", "b", "c"} {
+		assert.Equal(t, item, <-attempts)
+	}
+	q.Stop()
+	checkpoints, err := filepath.Glob(filepath.Join(dir, "*"+checkpointFileSuffix))
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 1)
+
+	// only the item which was not consumed is replayed
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, q.Size())
+	consumer := &collectingConsumer{}
+	q.StartConsumers(1, consumer.consume)
+	assert.Equal(t, []string{"c"}, consumer.waitFor(t, 1))
+	q.Stop()
+
+	// the segment and its checkpoint are deleted once consumed
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, q.Size())
+	q.Stop()
+	checkpoints, err = filepath.Glob(filepath.Join(dir, "*"+checkpointFileSuffix))
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+}
+
+func TestPersistentQueueInvalidCheckpoint(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	q, err := NewPersistentQueue(stringQueueOptions(dir), nil)
+	require.NoError(t, err)
+	require.True(t, q.Produce("a"))
+	require.True(t, q.Produce("b"))
+	q.Stop()
+
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 1)
+	checkpoint := strings.TrimSuffix(files[0], segmentFileSuffix) + checkpointFileSuffix
+	require.NoError(t, ioutil.WriteFile(checkpoint, []byte{0, 0, 0, 0, 0, 0, 0, 3}, 0600))
+	q, err = NewPersistentQueue(stringQueueOptions(dir), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, q.Size(), "the checkpoint which is not at a record boundary is ignored")
+	q.Stop()
+
+	require.NoError(t, ioutil.WriteFile(checkpoint, []byte{1}, 0600))
+	q, err = NewPersistentQueue(stringQueueOptions(dir), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, q.Size())
+	q.Stop()
+}
+
+func TestPersistentQueueLimits(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	opts := stringQueueOptions(dir)
+	opts.Capacity = 2
+	var dropped []interface{}
+	q, err := NewPersistentQueue(opts, func(item interface{}) {
+		dropped = append(dropped, item)
+	})
+	require.NoError(t, err)
+	defer q.Stop()
+
+	assert.True(t, q.Produce("a"))
+	assert.True(t, q.Produce("b"))
+	assert.False(t, q.Produce("c"), "the queue is full")
+	assert.False(t, q.Produce(42), "the item cannot be marshalled")
+
+	assert.True(t, q.Resize(3))
+	assert.False(t, q.Resize(3))
+	assert.Equal(t, 3, q.Capacity())
+	q.options.MaxBytes = q.Bytes() + recordHeaderSize
+	assert.False(t, q.Produce("d"), "the queue exceeds its size on disk")
+	assert.Equal(t, []interface{}{"c", 42, "d"}, dropped)
+	assert.Equal(t, 2, q.Size())
+}
+
+func consumedAll(q *PersistentQueue) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size == 0 && len(q.readSeg.pending) == 0
+}
+
+func TestPersistentQueueRotatesConsumedSegment(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	opts := stringQueueOptions(dir)
+	// a record of 6 bytes takes 14 bytes, the segment holds 4 records and the queue 5
+	opts.SegmentBytes = 4 * 14
+	opts.MaxBytes = 5 * 14
+	q, err := NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	defer q.Stop()
+	consumer := &collectingConsumer{}
+	q.StartConsumers(1, consumer.consume)
+
+	for _, item := range expectedItems(4) {
+		require.True(t, q.Produce(item))
+	}
+	consumer.waitFor(t, 4)
+	for i := 0; i < 100 && !consumedAll(q); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the record would exceed the max size, the consumed segment is deleted to make room for it
+	require.True(t, q.Produce("large-item"))
+	assert.Equal(t, int64(recordHeaderSize+len("large-item")), q.Bytes())
+	assert.Len(t, segmentFiles(t, dir), 1)
+	assert.Equal(t, append(expectedItems(4), "large-item"), consumer.waitFor(t, 5))
+}
+
+func TestPersistentQueueCorruption(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+	q, err := NewPersistentQueue(stringQueueOptions(dir), nil)
+	require.NoError(t, err)
+	require.True(t, q.Produce("a"))
+	require.True(t, q.Produce("poison"))
+	require.True(t, q.Produce("b"))
+	q.Stop()
+
+	// simulate a partial write
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 10, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	mFact := metricstest.NewFactory(0)
+	opts := stringQueueOptions(dir)
+	opts.MetricsFactory = mFact
+	q, err = NewPersistentQueue(opts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, q.Size())
+	mFact.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "persistent-queue.corrupted-records", Value: 1})
+
+	consumer := &collectingConsumer{}
+	q.StartConsumers(1, consumer.consume)
+	assert.Equal(t, []string{"a", "b"}, consumer.waitFor(t, 2), "the items that cannot be unmarshalled are skipped")
+	q.Stop()
+	mFact.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "persistent-queue.corrupted-records", Value: 2})
+}
+
+func TestPersistentQueueOptionErrors(t *testing.T) {
+	dir := tempQueueDir(t)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		update func(opts *PersistentQueueOptions)
+		err    string
+	}{
+		{
+			update: func(opts *PersistentQueueOptions) { opts.Directory = "" },
+			err:    "the persistent queue requires a directory",
+		},
+		{
+			update: func(opts *PersistentQueueOptions) { opts.SegmentBytes = 0 },
+			err:    "the sizes of the persistent queue must be positive",
+		},
+		{
+			update: func(opts *PersistentQueueOptions) { opts.SegmentBytes = opts.MaxBytes },
+			err:    "the segment size of the persistent queue must be lower than its max size",
+		},
+		{
+			update: func(opts *PersistentQueueOptions) { opts.Unmarshal = nil },
+			err:    "the persistent queue requires a marshaller and an unmarshaller",
+		},
+	}
+	for _, test := range tests {
+		opts := stringQueueOptions(dir)
+		test.update(&opts)
+		_, err := NewPersistentQueue(opts, nil)
+		assert.EqualError(t, err, test.err)
+	}
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	_, err := NewPersistentQueue(stringQueueOptions(file), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+// Queue is a bounded producer-consumer exchange, implemented in memory by BoundedQueue
+// and on disk by PersistentQueue.
+type Queue interface {
+	// StartConsumers starts a given number of goroutines consuming items from the queue
+	// and passing them into the consumer callback.
+	StartConsumers(num int, consumer func(item interface{}))
+	// Produce submits a new item to the queue. Returns false if the item is dropped.
+	Produce(item interface{}) bool
+	// Stop stops all consumers, it blocks until all consumers have stopped.
+	Stop()
+	// Size returns the number of items waiting to be consumed
+	Size() int
+	// Capacity returns the maximum number of items in the queue
+	Capacity() int
+	// Resize changes the capacity of the queue, returning whether the action was successful
+	Resize(capacity int) bool
+}
+
+// AckQueue is a Queue which keeps the items until the consumer reports that it processed them
+type AckQueue interface {
+	Queue
+	// StartAckConsumers starts a given number of goroutines consuming items from the queue
+	// and passing them into the consumer callback, which returns false if the item must be processed again.
+	StartAckConsumers(num int, consumer func(item interface{}) bool)
+}
+
+var (
+	_ Queue    = (*BoundedQueue)(nil)
+	_ AckQueue = (*PersistentQueue)(nil)
+)
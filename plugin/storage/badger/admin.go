@@ -0,0 +1,170 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	badgerStore "github.com/jaegertracing/jaeger/plugin/storage/badger/spanstore"
+)
+
+const (
+	// AdminBackupPath is the admin server route streaming an online backup of the store.
+	// It is only registered when the admin backup option is enabled.
+	AdminBackupPath = "/badger/backup"
+	// AdminGCPath is the admin server route triggering a value log garbage collection.
+	AdminGCPath = "/badger/gc"
+	// AdminStatsPath is the admin server route returning the storage statistics.
+	AdminStatsPath = "/badger/stats"
+
+	// BackupVersionHeader is the HTTP trailer carrying the version to pass as `since` to the next incremental backup.
+	BackupVersionHeader = "X-Badger-Backup-Version"
+
+	sinceParam        = "since"
+	discardRatioParam = "discard-ratio"
+)
+
+// Stats describes the current state of the Badger storage.
+type Stats struct {
+	KeyDirectory        string `json:"keyDirectory"`
+	ValueDirectory      string `json:"valueDirectory"`
+	KeyBytesAvailable   int64  `json:"keyBytesAvailable"`
+	ValueBytesAvailable int64  `json:"valueBytesAvailable"`
+	LSMSizeBytes        int64  `json:"lsmSizeBytes"`
+	ValueLogSizeBytes   int64  `json:"valueLogSizeBytes"`
+	Tables              int    `json:"tables"`
+}
+
+// GCResult describes the outcome of a value log garbage collection.
+type GCResult struct {
+	Rewrites int `json:"rewrites"`
+}
+
+// Backup writes all the entries newer than the given version to w. It returns the version
+// which can be passed to a later invocation to take an incremental backup.
+func (f *Factory) Backup(w io.Writer, since uint64) (uint64, error) {
+	readTs, err := f.store.Backup(w, since)
+	if err != nil {
+		return 0, err
+	}
+	// Backup includes the entries at exactly `since`, while readTs is the version of the last backed up commit
+	return readTs + 1, nil
+}
+
+// Restore loads a backup taken with Backup into the store. It must only be called on a
+// store which is not serving any other reads or writes, usually a fresh one.
+func (f *Factory) Restore(r io.Reader) error {
+	if err := f.store.Load(r); err != nil {
+		return err
+	}
+	// Readers and writers created from now on see the restored services and operations
+	f.cache = badgerStore.NewCacheStore(f.store, f.Options.Primary.SpanStoreTTL, true)
+	return nil
+}
+
+// RunValueLogGC runs the value log garbage collection until there's nothing left to clean
+// and returns the number of value log files rewritten.
+func (f *Factory) RunValueLogGC(discardRatio float64) (int, error) {
+	return f.runValueLogGC(discardRatio, time.Now())
+}
+
+// Stats returns the current storage statistics.
+func (f *Factory) Stats() Stats {
+	keyAvailable, valueAvailable := f.diskSpaceAvailable()
+	lsmSize, vlogSize := f.store.Size()
+	return Stats{
+		KeyDirectory:        f.Options.GetPrimary().KeyDirectory,
+		ValueDirectory:      f.Options.GetPrimary().ValueDirectory,
+		KeyBytesAvailable:   keyAvailable,
+		ValueBytesAvailable: valueAvailable,
+		LSMSizeBytes:        lsmSize,
+		ValueLogSizeBytes:   vlogSize,
+		Tables:              len(f.store.Tables()),
+	}
+}
+
+// RegisterAdminHandlers implements storage.AdminFactory
+func (f *Factory) RegisterAdminHandlers(handle func(path string, handler http.Handler)) {
+	if f.Options.GetPrimary().AdminBackup {
+		handle(AdminBackupPath, http.HandlerFunc(f.backupHandler))
+	}
+	handle(AdminGCPath, http.HandlerFunc(f.gcHandler))
+	handle(AdminStatsPath, http.HandlerFunc(f.statsHandler))
+}
+
+func (f *Factory) backupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var since uint64
+	if s := r.FormValue(sinceParam); s != "" {
+		var err error
+		if since, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, fmt.Sprintf("cannot parse %s: %v", sinceParam, err), http.StatusBadRequest)
+			return
+		}
+	}
+	w.Header().Set("Trailer", BackupVersionHeader)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	version, err := f.Backup(w, since)
+	if err != nil {
+		// The body is already being streamed, the client detects the failure by the missing trailer
+		f.logger.Error("Failed to backup badger storage", zap.Error(err))
+		return
+	}
+	w.Header().Set(BackupVersionHeader, strconv.FormatUint(version, 10))
+}
+
+func (f *Factory) gcHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	discardRatio := defaultDiscardRatio
+	if s := r.FormValue(discardRatioParam); s != "" {
+		var err error
+		if discardRatio, err = strconv.ParseFloat(s, 64); err != nil {
+			http.Error(w, fmt.Sprintf("cannot parse %s: %v", discardRatioParam, err), http.StatusBadRequest)
+			return
+		}
+	}
+	rewrites, err := f.RunValueLogGC(discardRatio)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, GCResult{Rewrites: rewrites})
+}
+
+func (f *Factory) statsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, f.Stats())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
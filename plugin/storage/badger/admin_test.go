@@ -0,0 +1,184 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func initEphemeralFactory(t *testing.T, flags ...string) *Factory {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags(flags)
+	f.InitFromViper(v)
+	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	return f
+}
+
+func writeTestSpan(t *testing.T, f *Factory, traceID uint64) {
+	w, err := f.CreateSpanWriter()
+	assert.NoError(t, err)
+	err = w.WriteSpan(context.Background(), &model.Span{
+		TraceID:       model.NewTraceID(0, traceID),
+		SpanID:        model.NewSpanID(1),
+		OperationName: "op",
+		Process:       model.NewProcess("svc", nil),
+		StartTime:     time.Now(),
+		Duration:      time.Millisecond,
+	})
+	assert.NoError(t, err)
+}
+
+func TestBackupRestore(t *testing.T) {
+	src := initEphemeralFactory(t)
+	defer src.Close()
+	writeTestSpan(t, src, 1)
+
+	buf := new(bytes.Buffer)
+	version, err := src.Backup(buf, 0)
+	assert.NoError(t, err)
+	assert.True(t, version > 0)
+
+	dst := initEphemeralFactory(t)
+	defer dst.Close()
+	assert.NoError(t, dst.Restore(buf))
+
+	r, err := dst.CreateSpanReader()
+	assert.NoError(t, err)
+	trace, err := r.GetTrace(context.Background(), model.NewTraceID(0, 1))
+	assert.NoError(t, err)
+	assert.Len(t, trace.Spans, 1)
+
+	// Incremental backup only contains the entries written after the previous one
+	incremental := new(bytes.Buffer)
+	_, err = src.Backup(incremental, version)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, incremental.Len())
+}
+
+func TestAdminHandlers(t *testing.T) {
+	f := initEphemeralFactory(t, "--badger.admin-backup=true")
+	defer f.Close()
+	writeTestSpan(t, f, 1)
+
+	mux := http.NewServeMux()
+	f.RegisterAdminHandlers(mux.Handle)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("backup", func(t *testing.T) {
+		resp, err := http.Get(server.URL + AdminBackupPath)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, body)
+		version, err := strconv.ParseUint(resp.Trailer.Get(BackupVersionHeader), 10, 64)
+		assert.NoError(t, err)
+		assert.True(t, version > 0)
+
+		dst := initEphemeralFactory(t)
+		defer dst.Close()
+		assert.NoError(t, dst.Restore(bytes.NewReader(body)))
+		r, _ := dst.CreateSpanReader()
+		services, err := r.GetServices(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"svc"}, services)
+	})
+
+	t.Run("backup bad since", func(t *testing.T) {
+		resp, err := http.Get(server.URL + AdminBackupPath + "?since=x")
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("gc", func(t *testing.T) {
+		resp, err := http.Post(server.URL+AdminGCPath+"?discard-ratio=0.7", "", nil)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var result GCResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, 0, result.Rewrites)
+	})
+
+	t.Run("gc errors", func(t *testing.T) {
+		resp, err := http.Post(server.URL+AdminGCPath+"?discard-ratio=x", "", nil)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, err = http.Post(server.URL+AdminGCPath+"?discard-ratio=2", "", nil)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		resp, err = http.Get(server.URL + AdminGCPath)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		resp, err := http.Get(server.URL + AdminStatsPath)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		var stats Stats
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+		assert.Equal(t, f.tmpDir, stats.KeyDirectory)
+		assert.Equal(t, f.tmpDir, stats.ValueDirectory)
+
+		resp, err = http.Post(server.URL+AdminStatsPath, "", nil)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
+
+func TestAdminBackupDisabledByDefault(t *testing.T) {
+	f := initEphemeralFactory(t)
+	defer f.Close()
+
+	mux := http.NewServeMux()
+	f.RegisterAdminHandlers(mux.Handle)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + AdminBackupPath)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + AdminStatsPath)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
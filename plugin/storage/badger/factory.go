@@ -39,6 +39,9 @@ const (
 	keyLogSpaceAvailableName   = "badger_key_log_bytes_available"
 	lastMaintenanceRunName     = "badger_storage_maintenance_last_run"
 	lastValueLogCleanedName    = "badger_storage_valueloggc_last_run"
+
+	// defaultDiscardRatio is selected to rewrite a file if half of it can be discarded
+	defaultDiscardRatio = 0.5
 )
 
 // Factory implements storage.Factory for Badger backend.
@@ -195,15 +198,7 @@ func (f *Factory) maintenance() {
 		case <-f.maintenanceDone:
 			return
 		case t := <-maintenanceTicker.C:
-			var err error
-
-			// After there's nothing to clean, the err is raised
-			for err == nil {
-				err = f.store.RunValueLogGC(0.5) // 0.5 is selected to rewrite a file if half of it can be discarded
-			}
-			if err == badger.ErrNoRewrite {
-				f.metrics.LastValueLogCleaned.Update(t.UnixNano())
-			} else {
+			if _, err := f.runValueLogGC(defaultDiscardRatio, t); err != nil {
 				f.logger.Error("Failed to run ValueLogGC", zap.Error(err))
 			}
 
@@ -213,6 +208,25 @@ func (f *Factory) maintenance() {
 	}
 }
 
+// runValueLogGC rewrites value log files until there's nothing left to clean and
+// returns the number of files rewritten.
+func (f *Factory) runValueLogGC(discardRatio float64, t time.Time) (int, error) {
+	var err error
+	rewrites := 0
+
+	// After there's nothing to clean, the err is raised
+	for err == nil {
+		if err = f.store.RunValueLogGC(discardRatio); err == nil {
+			rewrites++
+		}
+	}
+	if err != badger.ErrNoRewrite {
+		return rewrites, err
+	}
+	f.metrics.LastValueLogCleaned.Update(t.UnixNano())
+	return rewrites, nil
+}
+
 func (f *Factory) metricsCopier() {
 	metricsTicker := time.NewTicker(f.Options.Primary.MetricsUpdateInterval)
 	defer metricsTicker.Stop()
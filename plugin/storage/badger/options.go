@@ -42,6 +42,8 @@ type NamespaceConfig struct {
 	MetricsUpdateInterval time.Duration `mapstructure:"metrics_update_interval"`
 	Truncate              bool          `mapstructure:"truncate"`
 	ReadOnly              bool          `mapstructure:"read_only"`
+	// Setting this to true serves online backups of the whole store from the admin server
+	AdminBackup bool `mapstructure:"admin_backup"`
 }
 
 const (
@@ -60,6 +62,7 @@ const (
 	suffixMetricsInterval     = ".metrics-update-interval" // Intended only for testing purposes
 	suffixTruncate            = ".truncate"
 	suffixReadOnly            = ".read-only"
+	suffixAdminBackup         = ".admin-backup"
 	defaultDataDir            = string(os.PathSeparator) + "data"
 	defaultValueDir           = defaultDataDir + string(os.PathSeparator) + "values"
 	defaultKeysDir            = defaultDataDir + string(os.PathSeparator) + "keys"
@@ -143,6 +146,11 @@ func addFlags(flagSet *flag.FlagSet, nsConfig NamespaceConfig) {
 		nsConfig.ReadOnly,
 		"Allows to open badger database in read only mode. Multiple instances can open same database in read-only mode. Values still in the write-ahead-log must be replayed before opening.",
 	)
+	flagSet.Bool(
+		nsConfig.namespace+suffixAdminBackup,
+		nsConfig.AdminBackup,
+		"Serve online backups from the "+AdminBackupPath+" admin endpoint. The admin port is not authenticated, so enabling this exposes all the stored trace data to anyone who can reach it.",
+	)
 }
 
 // InitFromViper initializes Options with properties from viper
@@ -160,6 +168,7 @@ func initFromViper(cfg *NamespaceConfig, v *viper.Viper) {
 	cfg.MetricsUpdateInterval = v.GetDuration(cfg.namespace + suffixMetricsInterval)
 	cfg.Truncate = v.GetBool(cfg.namespace + suffixTruncate)
 	cfg.ReadOnly = v.GetBool(cfg.namespace + suffixReadOnly)
+	cfg.AdminBackup = v.GetBool(cfg.namespace + suffixAdminBackup)
 }
 
 // GetPrimary returns the primary namespace configuration
@@ -93,6 +93,48 @@ func TestWriteReadBack(t *testing.T) {
 	})
 }
 
+func TestWriteSpansReadBack(t *testing.T) {
+	runFactoryTest(t, func(tb testing.TB, sw spanstore.Writer, sr spanstore.Reader) {
+		batchWriter, ok := sw.(spanstore.BatchWriter)
+		assert.True(t, ok)
+
+		tid := time.Now()
+		var spans []*model.Span
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 5; j++ {
+				spans = append(spans, &model.Span{
+					TraceID:       model.TraceID{Low: uint64(i), High: 2},
+					SpanID:        model.SpanID(j),
+					OperationName: fmt.Sprintf("operation-%d", j),
+					Process:       &model.Process{ServiceName: fmt.Sprintf("service-%d", i)},
+					StartTime:     tid.Add(time.Duration(i)),
+					Duration:      time.Duration(i + j),
+					Tags:          model.KeyValues{model.String("key", "value")},
+				})
+			}
+		}
+		assert.NoError(t, batchWriter.WriteSpans(context.Background(), spans))
+
+		for i := 0; i < 4; i++ {
+			tr, err := sr.GetTrace(context.Background(), model.TraceID{Low: uint64(i), High: 2})
+			assert.NoError(t, err)
+			assert.Len(t, tr.Spans, 5)
+		}
+		traces, err := sr.FindTraces(context.Background(), &spanstore.TraceQueryParameters{
+			ServiceName:  "service-1",
+			Tags:         map[string]string{"key": "value"},
+			StartTimeMin: tid.Add(-time.Hour),
+			StartTimeMax: tid.Add(time.Hour),
+			NumTraces:    10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, traces, 1)
+		services, err := sr.GetServices(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, services, 4)
+	})
+}
+
 func TestValidation(t *testing.T) {
 	runFactoryTest(t, func(tb testing.TB, sw spanstore.Writer, sr spanstore.Reader) {
 		tid := time.Now()
@@ -292,6 +334,123 @@ func TestIndexSeeks(t *testing.T) {
 	})
 }
 
+func TestTagKeyAndValueListSeeks(t *testing.T) {
+	runFactoryTest(t, func(tb testing.TB, sw spanstore.Writer, sr spanstore.Reader) {
+		startT := time.Now()
+		traces := 12
+
+		for i := 0; i < traces; i++ {
+			tags := model.KeyValues{
+				model.String("http.status_code", fmt.Sprintf("%d", 200+i%3)),
+			}
+			if i%2 == 0 {
+				tags = append(tags, model.Bool("error", true))
+			}
+			s := model.Span{
+				TraceID: model.TraceID{
+					Low:  uint64(i),
+					High: 1,
+				},
+				SpanID:        model.SpanID(1),
+				OperationName: "operation",
+				Process: &model.Process{
+					ServiceName: "service",
+				},
+				StartTime: startT.Add(time.Duration(i) * time.Millisecond),
+				Duration:  time.Duration(i) * time.Millisecond,
+				Tags:      tags,
+			}
+			err := sw.WriteSpan(context.Background(), &s)
+			assert.NoError(t, err)
+		}
+
+		params := &spanstore.TraceQueryParameters{
+			StartTimeMin: startT,
+			StartTimeMax: startT.Add(time.Hour),
+			ServiceName:  "service",
+			TagKeys:      []string{"error"},
+		}
+		ids, err := sr.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 6)
+
+		params.TagKeys = []string{"missing"}
+		ids, err = sr.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		params.TagKeys = nil
+		params.TagValues = map[string][]string{"http.status_code": {"200", "202"}}
+		ids, err = sr.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 8)
+		// Results of multiple values are in descending time order
+		assert.Equal(t, uint64(11), ids[0].Low)
+		assert.Equal(t, uint64(9), ids[1].Low)
+
+		params.TagKeys = []string{"error"}
+		params.Tags = map[string]string{"http.status_code": "200"}
+		params.OperationName = "operation"
+		params.DurationMin = 5 * time.Millisecond
+		ids, err = sr.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.TraceID{{High: 1, Low: 6}}, ids)
+
+		params.ServiceName = ""
+		_, err = sr.FindTraceIDs(context.Background(), params)
+		assert.EqualError(t, err, "service name must be set")
+
+		// Duration only search without a service uses the duration index
+		params = &spanstore.TraceQueryParameters{
+			StartTimeMin: startT,
+			StartTimeMax: startT.Add(time.Hour),
+			DurationMin:  3 * time.Millisecond,
+			DurationMax:  7 * time.Millisecond,
+			NumTraces:    3,
+		}
+		ids, err = sr.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.TraceID{{High: 1, Low: 7}, {High: 1, Low: 6}, {High: 1, Low: 5}}, ids)
+	})
+}
+
+func TestFindByDurationOnlyRespectsStartTime(t *testing.T) {
+	runFactoryTest(t, func(tb testing.TB, sw spanstore.Writer, sr spanstore.Reader) {
+		startT := time.Now()
+		spans := []*model.Span{
+			{
+				TraceID:   model.TraceID{High: 1, Low: 1},
+				SpanID:    model.SpanID(1),
+				Process:   &model.Process{ServiceName: "service"},
+				StartTime: startT,
+				Duration:  5 * time.Millisecond,
+			},
+			{
+				// older and longer than the span in range, it is past the first duration of the seek key
+				TraceID:   model.TraceID{High: 1, Low: 2},
+				SpanID:    model.SpanID(2),
+				Process:   &model.Process{ServiceName: "service"},
+				StartTime: startT.Add(-2 * time.Hour),
+				Duration:  time.Second,
+			},
+		}
+		for _, span := range spans {
+			assert.NoError(t, sw.WriteSpan(context.Background(), span))
+		}
+
+		params := &spanstore.TraceQueryParameters{
+			StartTimeMin: startT.Add(-time.Hour),
+			StartTimeMax: startT.Add(time.Hour),
+			DurationMin:  time.Millisecond,
+			DurationMax:  time.Minute,
+			NumTraces:    10,
+		}
+		ids, err := sr.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.TraceID{{High: 1, Low: 1}}, ids)
+	})
+}
+
 func TestFindNothing(t *testing.T) {
 	runFactoryTest(t, func(tb testing.TB, sw spanstore.Writer, sr spanstore.Reader) {
 		startT := time.Now()
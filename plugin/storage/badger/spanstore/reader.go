@@ -56,8 +56,17 @@ var (
 
 	// ErrInternalConsistencyError indicates internal data consistency issue
 	ErrInternalConsistencyError = errors.New("internal data consistency issue")
+
+	// ErrDurationQueryTooBroad occurs when a duration query without service would scan more than
+	// maxDurationIndexKeys keys of the duration index
+	ErrDurationQueryTooBroad = errors.New("duration range is too broad to query without service")
 )
 
+// maxDurationIndexKeys bounds the duration index keys scanned by a duration query without service. The index is
+// sorted by duration before start time, so all the keys in the duration range are scanned to find the most recent
+// traces, rather than returning partial results the query fails past them.
+var maxDurationIndexKeys = 100000
+
 const (
 	defaultNumTraces = 100
 	sizeOfTraceID    = 16
@@ -70,6 +79,21 @@ type TraceReader struct {
 	cache *CacheStore
 }
 
+// Estimated selectivity of the index seeks, lower is more selective. The index contents have no
+// statistics, so these follow the usual cardinality of the indexed values.
+const (
+	tagSelectivity       = 1 // tag value IN-lists multiply this by the number of values
+	operationSelectivity = 2
+	tagKeySelectivity    = 4
+	serviceSelectivity   = 8
+)
+
+// indexSeek is a single index lookup of the execution plan, matching any of its index keys
+type indexSeek struct {
+	keys        [][]byte
+	selectivity int
+}
+
 // executionPlan is internal structure to track the index filtering
 type executionPlan struct {
 	startTimeMin []byte
@@ -77,6 +101,9 @@ type executionPlan struct {
 
 	limit int
 
+	// seeks are the index lookups whose results are intersected, the most selective first
+	seeks []indexSeek
+
 	// mergeOuter is the result of merge-join of inner and outer result sets
 	mergeOuter [][]byte
 
@@ -261,41 +288,68 @@ func setQueryDefaults(query *spanstore.TraceQueryParameters) {
 }
 
 // serviceQueries parses the query to index seeks which are unique index seeks
-func serviceQueries(query *spanstore.TraceQueryParameters, indexSeeks [][]byte) [][]byte {
-	if query.ServiceName != "" {
-		indexSearchKey := make([]byte, 0, 64) // 64 is a magic guess
-		tagQueryUsed := false
-		for k, v := range query.Tags {
-			tagSearch := []byte(query.ServiceName + k + v)
-			tagSearchKey := make([]byte, 0, len(tagSearch)+1)
-			tagSearchKey = append(tagSearchKey, tagIndexKey)
-			tagSearchKey = append(tagSearchKey, tagSearch...)
-			indexSeeks = append(indexSeeks, tagSearchKey)
-			tagQueryUsed = true
-		}
-
-		if query.OperationName != "" {
-			indexSearchKey = append(indexSearchKey, operationNameIndexKey)
-			indexSearchKey = append(indexSearchKey, []byte(query.ServiceName+query.OperationName)...)
-		} else {
-			if !tagQueryUsed { // Tag query already reduces the search set with a serviceName
-				indexSearchKey = append(indexSearchKey, serviceNameIndexKey)
-				indexSearchKey = append(indexSearchKey, []byte(query.ServiceName)...)
-			}
+func serviceQueries(query *spanstore.TraceQueryParameters, plan *executionPlan) {
+	if query.ServiceName == "" {
+		return
+	}
+	tagQueryUsed := false
+	for k, v := range query.Tags {
+		plan.addSeek(tagSelectivity, createSearchKey(tagIndexKey, query.ServiceName+k+v))
+		tagQueryUsed = true
+	}
+	for k, values := range query.TagValues {
+		keys := make([][]byte, 0, len(values))
+		for _, v := range values {
+			keys = append(keys, createSearchKey(tagIndexKey, query.ServiceName+k+v))
 		}
+		plan.addSeek(tagSelectivity*len(values), keys...)
+		tagQueryUsed = true
+	}
+	for _, k := range query.TagKeys {
+		plan.addSeek(tagKeySelectivity, createSearchKey(tagKeyIndexKey, query.ServiceName+k))
+		tagQueryUsed = true
+	}
 
-		if len(indexSearchKey) > 0 {
-			indexSeeks = append(indexSeeks, indexSearchKey)
-		}
+	if query.OperationName != "" {
+		plan.addSeek(operationSelectivity, createSearchKey(operationNameIndexKey, query.ServiceName+query.OperationName))
+	} else if !tagQueryUsed { // Tag query already reduces the search set with a serviceName
+		plan.addSeek(serviceSelectivity, createSearchKey(serviceNameIndexKey, query.ServiceName))
 	}
-	return indexSeeks
+}
+
+func createSearchKey(indexKey byte, value string) []byte {
+	searchKey := make([]byte, 0, len(value)+1)
+	searchKey = append(searchKey, indexKey)
+	return append(searchKey, value...)
+}
+
+// addSeek adds an index seek matching any of the given keys to the plan
+func (plan *executionPlan) addSeek(selectivity int, keys ...[]byte) {
+	plan.seeks = append(plan.seeks, indexSeek{keys: keys, selectivity: selectivity})
+}
+
+// sortSeeks orders the seeks so that the most selective one is scanned first
+func (plan *executionPlan) sortSeeks() {
+	sort.SliceStable(plan.seeks, func(i, j int) bool {
+		return plan.seeks[i].selectivity < plan.seeks[j].selectivity
+	})
 }
 
 // indexSeeksToTraceIDs does the index scanning against badger based on the parsed index queries
-func (r *TraceReader) indexSeeksToTraceIDs(plan *executionPlan, indexSeeks [][]byte) ([]model.TraceID, error) {
+func (r *TraceReader) indexSeeksToTraceIDs(plan *executionPlan) ([]model.TraceID, error) {
+	plan.sortSeeks()
 
-	for i := len(indexSeeks) - 1; i > 0; i-- {
-		indexResults, err := r.scanIndexKeys(indexSeeks[i], plan)
+	// The most selective seek gets us the results in correct timestamp order, the others only filter them
+	ids, err := r.scanIndexSeek(plan.seeks[0], plan)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	for _, seek := range plan.seeks[1:] {
+		indexResults, err := r.scanIndexSeek(seek, plan)
 		if err != nil {
 			return nil, err
 		}
@@ -306,7 +360,7 @@ func (r *TraceReader) indexSeeksToTraceIDs(plan *executionPlan, indexSeeks [][]b
 
 		// Same traceID can be returned multiple times, but always in sorted order so checking the previous key is enough
 		prevTraceID := []byte{}
-		innerIDs := make([][]byte, 0, len(indexSeeks))
+		innerIDs := make([][]byte, 0, len(indexResults))
 		for j := 0; j < len(indexResults); j++ {
 			traceID := indexResults[j]
 			if !bytes.Equal(prevTraceID, traceID) {
@@ -321,12 +375,11 @@ func (r *TraceReader) indexSeeksToTraceIDs(plan *executionPlan, indexSeeks [][]b
 		} else {
 			plan.mergeOuter = mergeJoinIds(plan.mergeOuter, innerIDs)
 		}
-	}
 
-	// Last scan should get us in correct timestamp order
-	ids, err := r.scanIndexKeys(indexSeeks[0], plan)
-	if err != nil {
-		return nil, err
+		// Nothing can match anymore, skip scanning the less selective indexes
+		if len(plan.mergeOuter) == 0 {
+			return nil, nil
+		}
 	}
 
 	if plan.mergeOuter != nil {
@@ -342,6 +395,34 @@ func (r *TraceReader) indexSeeksToTraceIDs(plan *executionPlan, indexSeeks [][]b
 	return traceIDs, nil
 }
 
+// scanIndexSeek returns the traceIDs matching any of the seek's index keys in descending timestamp order
+func (r *TraceReader) scanIndexSeek(seek indexSeek, plan *executionPlan) ([][]byte, error) {
+	indexResults := make([][]byte, 0)
+	for _, key := range seek.keys {
+		keyResults, err := r.scanIndexKeys(key, plan)
+		if err != nil {
+			return nil, err
+		}
+		indexResults = append(indexResults, keyResults...)
+	}
+	if len(seek.keys) > 1 {
+		// Results of a single key are already sorted
+		sortByTimestampDesc(indexResults)
+	}
+	ids := make([][]byte, len(indexResults))
+	for i, k := range indexResults {
+		ids[i] = k[8:]
+	}
+	return ids, nil
+}
+
+// sortByTimestampDesc sorts keys ending with <timestamp><traceId> to descending timestamp order
+func sortByTimestampDesc(keys [][]byte) {
+	sort.Slice(keys, func(k, h int) bool {
+		return bytes.Compare(keys[k][len(keys[k])-sizeOfTraceID-8:], keys[h][len(keys[h])-sizeOfTraceID-8:]) > 0
+	})
+}
+
 func filterIDs(plan *executionPlan, innerIDs [][]byte) []model.TraceID {
 	traces := make([]model.TraceID, 0, plan.limit)
 
@@ -392,6 +473,47 @@ func buildHash(plan *executionPlan, outerIDs [][]byte) map[model.TraceID]struct{
 
 // durationQueries checks non unique index of durations and returns a map for further filtering purposes
 func (r *TraceReader) durationQueries(plan *executionPlan, query *spanstore.TraceQueryParameters) map[model.TraceID]struct{} {
+	// This is not unique index result - same TraceID can be matched from multiple spans
+	indexResults, _ := r.scanDurationIndex(plan, query, 0)
+	hashFilter := make(map[model.TraceID]struct{})
+	var value struct{}
+	for _, k := range indexResults {
+		id := bytesToTraceID(k[len(k)-sizeOfTraceID:])
+		if _, exists := hashFilter[id]; !exists {
+			hashFilter[id] = value
+		}
+	}
+
+	return hashFilter
+}
+
+// durationIndexToTraceIDs uses the duration index to find the traceIDs when there are no other index seeks
+func (r *TraceReader) durationIndexToTraceIDs(plan *executionPlan, query *spanstore.TraceQueryParameters) ([]model.TraceID, error) {
+	indexResults, err := r.scanDurationIndex(plan, query, maxDurationIndexKeys)
+	if err != nil {
+		return nil, err
+	}
+	sortByTimestampDesc(indexResults)
+
+	traceIDs := make([]model.TraceID, 0, plan.limit)
+	seen := make(map[model.TraceID]struct{})
+	for _, k := range indexResults {
+		id := bytesToTraceID(k[len(k)-sizeOfTraceID:])
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		traceIDs = append(traceIDs, id)
+		if len(traceIDs) == plan.limit {
+			break
+		}
+	}
+	return traceIDs, nil
+}
+
+// scanDurationIndex returns the duration index keys in the queried duration and time range, it fails with
+// ErrDurationQueryTooBroad past maxKeys scanned keys unless maxKeys is zero
+func (r *TraceReader) scanDurationIndex(plan *executionPlan, query *spanstore.TraceQueryParameters, maxKeys int) ([][]byte, error) {
 	durMax := uint64(model.DurationAsMicroseconds(query.DurationMax))
 	durMin := uint64(model.DurationAsMicroseconds(query.DurationMin))
 
@@ -408,22 +530,7 @@ func (r *TraceReader) durationQueries(plan *executionPlan, query *spanstore.Trac
 	binary.BigEndian.PutUint64(endKey[1:], durMax)
 	binary.BigEndian.PutUint64(startKey[1:], durMin)
 
-	// This is not unique index result - same TraceID can be matched from multiple spans
-	indexResults, _ := r.scanRangeIndex(plan, startKey, endKey)
-	hashFilter := make(map[model.TraceID]struct{})
-	var value struct{}
-	for _, k := range indexResults {
-		key := k[len(k)-sizeOfTraceID:]
-		id := model.TraceID{
-			High: binary.BigEndian.Uint64(key[:8]),
-			Low:  binary.BigEndian.Uint64(key[8:]),
-		}
-		if _, exists := hashFilter[id]; !exists {
-			hashFilter[id] = value
-		}
-	}
-
-	return hashFilter
+	return r.scanRangeIndex(plan, startKey, endKey, maxKeys)
 }
 
 func mergeJoinIds(left, right [][]byte) [][]byte {
@@ -475,10 +582,6 @@ func (r *TraceReader) FindTraceIDs(ctx context.Context, query *spanstore.TraceQu
 
 	setQueryDefaults(query)
 
-	// Find matches using indexes that are using service as part of the key
-	indexSeeks := make([][]byte, 0, 1)
-	indexSeeks = serviceQueries(query, indexSeeks)
-
 	startStampBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(startStampBytes, model.TimeAsEpochMicroseconds(query.StartTimeMin))
 
@@ -491,12 +594,15 @@ func (r *TraceReader) FindTraceIDs(ctx context.Context, query *spanstore.TraceQu
 		limit:        query.NumTraces,
 	}
 
-	if query.DurationMax != 0 || query.DurationMin != 0 {
-		plan.hashOuter = r.durationQueries(plan, query)
-	}
+	// Find matches using indexes that are using service as part of the key
+	serviceQueries(query, plan)
+	durationQuery := query.DurationMax != 0 || query.DurationMin != 0
 
-	if len(indexSeeks) > 0 {
-		keys, err := r.indexSeeksToTraceIDs(plan, indexSeeks)
+	if len(plan.seeks) > 0 {
+		if durationQuery {
+			plan.hashOuter = r.durationQueries(plan, query)
+		}
+		keys, err := r.indexSeeksToTraceIDs(plan)
 		if err != nil {
 			return nil, err
 		}
@@ -504,6 +610,10 @@ func (r *TraceReader) FindTraceIDs(ctx context.Context, query *spanstore.TraceQu
 		return keys, nil
 	}
 
+	if durationQuery {
+		return r.durationIndexToTraceIDs(plan, query)
+	}
+
 	return r.scanTimeRange(plan)
 }
 
@@ -512,7 +622,7 @@ func validateQuery(p *spanstore.TraceQueryParameters) error {
 	if p == nil {
 		return ErrMalformedRequestObject
 	}
-	if p.ServiceName == "" && len(p.Tags) > 0 {
+	if p.ServiceName == "" && (len(p.Tags) > 0 || len(p.TagKeys) > 0 || len(p.TagValues) > 0) {
 		return ErrServiceNameNotSet
 	}
 	if p.ServiceName == "" && p.OperationName != "" {
@@ -530,7 +640,8 @@ func validateQuery(p *spanstore.TraceQueryParameters) error {
 	return nil
 }
 
-// scanIndexKeys scans the time range for index keys matching the given prefix.
+// scanIndexKeys scans the time range for index keys matching the given prefix and returns
+// their <timestamp><traceId> suffixes in descending timestamp order.
 func (r *TraceReader) scanIndexKeys(indexKeyValue []byte, plan *executionPlan) ([][]byte, error) {
 	indexResults := make([][]byte, 0)
 
@@ -554,11 +665,10 @@ func (r *TraceReader) scanIndexKeys(indexKeyValue []byte, plan *executionPlan) (
 			// Now we need to match only the exact key if we want to add it
 			timestampStartIndex := len(it.Item().Key()) - (sizeOfTraceID + 8) // timestamp is stored with 8 bytes
 			if bytes.Equal(indexKeyValue, it.Item().Key()[:timestampStartIndex]) {
-				traceIDBytes := item.Key()[len(item.Key())-sizeOfTraceID:]
-
-				traceIDCopy := make([]byte, sizeOfTraceID)
-				copy(traceIDCopy, traceIDBytes)
-				indexResults = append(indexResults, traceIDCopy)
+				// Keep the timestamp also, so that results of multiple keys can be ordered
+				timestampAndTraceID := make([]byte, 8+sizeOfTraceID)
+				copy(timestampAndTraceID, item.Key()[timestampStartIndex:])
+				indexResults = append(indexResults, timestampAndTraceID)
 			}
 		}
 		return nil
@@ -586,7 +696,7 @@ func scanFunction(it *badger.Iterator, indexPrefix []byte, timeBytesEnd []byte)
 }
 
 // scanRangeIndex scans the time range for index keys matching the given prefix.
-func (r *TraceReader) scanRangeIndex(plan *executionPlan, indexStartValue []byte, indexEndValue []byte) ([][]byte, error) {
+func (r *TraceReader) scanRangeIndex(plan *executionPlan, indexStartValue []byte, indexEndValue []byte, maxKeys int) ([][]byte, error) {
 	indexResults := make([][]byte, 0)
 
 	err := r.store.View(func(txn *badger.Txn) error {
@@ -600,14 +710,19 @@ func (r *TraceReader) scanRangeIndex(plan *executionPlan, indexStartValue []byte
 		copy(startIndex, indexStartValue)
 		copy(startIndex[len(indexStartValue):], plan.startTimeMin)
 
+		scanned := 0
 		for it.Seek(startIndex); scanRangeFunction(it, indexEndValue); it.Next() {
+			if scanned++; maxKeys > 0 && scanned > maxKeys {
+				return ErrDurationQueryTooBroad
+			}
 			item := it.Item()
 
 			// ScanFunction is a prefix scanning (since we could have for example service1 & service12)
 			// Now we need to match only the exact key if we want to add it
 			timestampStartIndex := len(it.Item().Key()) - (sizeOfTraceID + 8) // timestamp is stored with 8 bytes
 			timestamp := it.Item().Key()[timestampStartIndex : timestampStartIndex+8]
-			if bytes.Compare(timestamp, plan.startTimeMax) <= 0 {
+			// the seek key only bounds the start time of the first duration, the others are filtered here
+			if bytes.Compare(timestamp, plan.startTimeMin) >= 0 && bytes.Compare(timestamp, plan.startTimeMax) <= 0 {
 				key := make([]byte, len(item.Key()))
 				copy(key, item.Key())
 				indexResults = append(indexResults, key)
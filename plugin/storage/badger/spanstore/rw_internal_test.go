@@ -129,6 +129,43 @@ func TestDuplicateTraceIDDetection(t *testing.T) {
 	})
 }
 
+func TestFindByDurationOnlyTooBroad(t *testing.T) {
+	defer func(maxKeys int) { maxDurationIndexKeys = maxKeys }(maxDurationIndexKeys)
+	maxDurationIndexKeys = 2
+	runWithBadger(t, func(store *badger.DB, t *testing.T) {
+		cache := NewCacheStore(store, time.Duration(1*time.Hour), true)
+		sw := NewSpanWriter(store, cache, time.Duration(1*time.Hour), nil)
+		rw := NewTraceReader(store, cache)
+
+		startT := time.Now()
+		for i := 1; i <= 3; i++ {
+			err := sw.WriteSpan(context.Background(), &model.Span{
+				TraceID:   model.TraceID{High: 1, Low: uint64(i)},
+				SpanID:    model.SpanID(i),
+				Process:   &model.Process{ServiceName: "service"},
+				StartTime: startT.Add(time.Duration(i) * time.Second),
+				Duration:  time.Duration(i) * time.Millisecond,
+			})
+			assert.NoError(t, err)
+		}
+
+		params := &spanstore.TraceQueryParameters{
+			StartTimeMin: startT.Add(-time.Hour),
+			StartTimeMax: startT.Add(time.Hour),
+			DurationMin:  time.Millisecond,
+			NumTraces:    1,
+		}
+		_, err := rw.FindTraceIDs(context.Background(), params)
+		assert.Equal(t, ErrDurationQueryTooBroad, err)
+
+		// the keys out of the duration range are not scanned
+		params.DurationMin = 2 * time.Millisecond
+		ids, err := rw.FindTraceIDs(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.TraceID{{High: 1, Low: 3}}, ids)
+	})
+}
+
 func createDummySpan() model.Span {
 	tid := time.Now()
 
@@ -198,3 +235,29 @@ func TestMergeJoin(t *testing.T) {
 	assert.Equal(2, len(merged))
 	assert.Equal(uint32(2), binary.BigEndian.Uint32(merged[1]))
 }
+
+func TestExecutionPlanSeekOrder(t *testing.T) {
+	plan := &executionPlan{}
+	serviceQueries(&spanstore.TraceQueryParameters{
+		ServiceName:   "service",
+		OperationName: "operation",
+		Tags:          map[string]string{"k": "v"},
+		TagKeys:       []string{"error"},
+		TagValues:     map[string][]string{"status": {"1", "2", "3"}},
+	}, plan)
+	plan.sortSeeks()
+
+	selectivities := make([]int, 0, len(plan.seeks))
+	for _, seek := range plan.seeks {
+		selectivities = append(selectivities, seek.selectivity)
+	}
+	assert.Equal(t, []int{tagSelectivity, operationSelectivity, 3 * tagSelectivity, tagKeySelectivity}, selectivities)
+	assert.Len(t, plan.seeks[2].keys, 3)
+
+	// Service index is only used if nothing more selective is available
+	plan = &executionPlan{}
+	serviceQueries(&spanstore.TraceQueryParameters{ServiceName: "service"}, plan)
+	assert.Len(t, plan.seeks, 1)
+	assert.Equal(t, serviceSelectivity, plan.seeks[0].selectivity)
+	assert.Equal(t, append([]byte{serviceNameIndexKey}, "service"...), plan.seeks[0].keys[0])
+}
@@ -42,6 +42,7 @@ const (
 	operationNameIndexKey byte = 0x82
 	tagIndexKey           byte = 0x83
 	durationIndexKey      byte = 0x84
+	tagKeyIndexKey        byte = 0x85
 	jsonEncoding          byte = 0x01 // Last 4 bits of the meta byte are for encoding type
 	protoEncoding         byte = 0x02 // Last 4 bits of the meta byte are for encoding type
 	defaultEncoding       byte = protoEncoding
@@ -70,14 +71,81 @@ func NewSpanWriter(db *badger.DB, c *CacheStore, ttl time.Duration, storageClose
 // WriteSpan writes the encoded span as well as creates indexes with defined TTL
 func (w *SpanWriter) WriteSpan(ctx context.Context, span *model.Span) error {
 	expireTime := uint64(time.Now().Add(w.ttl).Unix())
-	startTime := model.TimeAsEpochMicroseconds(span.StartTime)
 
 	// Avoid doing as much as possible inside the transaction boundary, create entries here
-	entriesToStore := make([]*badger.Entry, 0, len(span.Tags)+4+len(span.Process.Tags)+len(span.Logs)*4)
+	entriesToStore, err := w.createEntries(span, expireTime)
+	if err != nil {
+		return err
+	}
+
+	err = w.store.Update(func(txn *badger.Txn) error {
+		// Write the entries
+		for i := range entriesToStore {
+			err = txn.SetEntry(entriesToStore[i])
+			if err != nil {
+				// Most likely primary key conflict, but let the caller check this
+				return err
+			}
+		}
+
+		// TODO Alternative option is to use simpler keys with the merge value interface.
+		// Requires at least this to be solved: https://github.com/dgraph-io/badger/issues/373
+
+		return nil
+	})
+
+	// Do cache refresh here to release the transaction earlier
+	w.cache.Update(span.Process.ServiceName, span.OperationName, expireTime)
+
+	return err
+}
+
+// WriteSpans writes the encoded spans and their indexes in a single transaction, which is only
+// split when the spans do not fit into one
+func (w *SpanWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	expireTime := uint64(time.Now().Add(w.ttl).Unix())
+
+	var entriesToStore []*badger.Entry
+	for _, span := range spans {
+		entries, err := w.createEntries(span, expireTime)
+		if err != nil {
+			return err
+		}
+		entriesToStore = append(entriesToStore, entries...)
+	}
+
+	txn := w.store.NewTransaction(true)
+	for _, entry := range entriesToStore {
+		err := txn.SetEntry(entry)
+		if err == badger.ErrTxnTooBig {
+			if err = txn.Commit(nil); err != nil {
+				return err
+			}
+			txn = w.store.NewTransaction(true)
+			err = txn.SetEntry(entry)
+		}
+		if err != nil {
+			txn.Discard()
+			return err
+		}
+	}
+	err := txn.Commit(nil)
+
+	for _, span := range spans {
+		w.cache.Update(span.Process.ServiceName, span.OperationName, expireTime)
+	}
+
+	return err
+}
+
+// createEntries creates the entries of the encoded span and of its indexes
+func (w *SpanWriter) createEntries(span *model.Span, expireTime uint64) ([]*badger.Entry, error) {
+	startTime := model.TimeAsEpochMicroseconds(span.StartTime)
+	entriesToStore := make([]*badger.Entry, 0, (len(span.Tags)+len(span.Process.Tags)+len(span.Logs)*4)*2+4)
 
 	trace, err := w.createTraceEntry(span, startTime, expireTime)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	entriesToStore = append(entriesToStore, trace)
@@ -92,39 +160,27 @@ func (w *SpanWriter) WriteSpan(ctx context.Context, span *model.Span) error {
 	for _, kv := range span.Tags {
 		// Convert everything to string since queries are done that way also
 		// KEY: it<serviceName><tagsKey><traceId> VALUE: <tagsValue>
-		entriesToStore = append(entriesToStore, w.createBadgerEntry(createIndexKey(tagIndexKey, []byte(span.Process.ServiceName+kv.Key+kv.AsString()), startTime, span.TraceID), nil, expireTime))
+		entriesToStore = append(entriesToStore, w.createTagEntries(span, kv, startTime, expireTime)...)
 	}
 
 	for _, kv := range span.Process.Tags {
-		entriesToStore = append(entriesToStore, w.createBadgerEntry(createIndexKey(tagIndexKey, []byte(span.Process.ServiceName+kv.Key+kv.AsString()), startTime, span.TraceID), nil, expireTime))
+		entriesToStore = append(entriesToStore, w.createTagEntries(span, kv, startTime, expireTime)...)
 	}
 
 	for _, log := range span.Logs {
 		for _, kv := range log.Fields {
-			entriesToStore = append(entriesToStore, w.createBadgerEntry(createIndexKey(tagIndexKey, []byte(span.Process.ServiceName+kv.Key+kv.AsString()), startTime, span.TraceID), nil, expireTime))
+			entriesToStore = append(entriesToStore, w.createTagEntries(span, kv, startTime, expireTime)...)
 		}
 	}
+	return entriesToStore, nil
+}
 
-	err = w.store.Update(func(txn *badger.Txn) error {
-		// Write the entries
-		for i := range entriesToStore {
-			err = txn.SetEntry(entriesToStore[i])
-			if err != nil {
-				// Most likely primary key conflict, but let the caller check this
-				return err
-			}
-		}
-
-		// TODO Alternative option is to use simpler keys with the merge value interface.
-		// Requires at least this to be solved: https://github.com/dgraph-io/badger/issues/373
-
-		return nil
-	})
-
-	// Do cache refresh here to release the transaction earlier
-	w.cache.Update(span.Process.ServiceName, span.OperationName, expireTime)
-
-	return err
+// createTagEntries creates the index entries for the tag value as well as for the existence of the tag key
+func (w *SpanWriter) createTagEntries(span *model.Span, kv model.KeyValue, startTime, expireTime uint64) []*badger.Entry {
+	return []*badger.Entry{
+		w.createBadgerEntry(createIndexKey(tagIndexKey, []byte(span.Process.ServiceName+kv.Key+kv.AsString()), startTime, span.TraceID), nil, expireTime),
+		w.createBadgerEntry(createIndexKey(tagKeyIndexKey, []byte(span.Process.ServiceName+kv.Key), startTime, span.TraceID), nil, expireTime),
+	}
 }
 
 func createIndexKey(indexPrefixKey byte, value []byte, startTime uint64, traceID model.TraceID) []byte {
@@ -19,3 +19,8 @@ package badger
 func (f *Factory) diskStatisticsUpdate() error {
 	return nil
 }
+
+// diskSpaceAvailable is not supported on this platform and always returns zero.
+func (f *Factory) diskSpaceAvailable() (keyAvailable int64, valueAvailable int64) {
+	return 0, 0
+}
@@ -19,6 +19,23 @@ import (
 )
 
 func (f *Factory) diskStatisticsUpdate() error {
+	keyAvailable, valueAvailable := f.diskSpaceAvailable()
+	f.metrics.ValueLogSpaceAvailable.Update(valueAvailable)
+	f.metrics.KeyLogSpaceAvailable.Update(keyAvailable)
+
+	/*
+	 TODO If we wanted to clean up oldest data to free up diskspace, we need at a minimum an index to the StartTime
+	 Additionally to that, the deletion might not save anything if the ratio of removed values is lower than the RunValueLogGC's deletion ratio
+	 and with the keys the LSM compaction must remove the offending files also. Thus, there's no guarantee the clean up would
+	 actually reduce the amount of diskspace used any faster than allowing TTL to remove them.
+
+	 If badger supports TimeWindow based compaction, then this should be resolved. Not available in 1.5.3
+	*/
+	return nil
+}
+
+// diskSpaceAvailable returns the space available in bytes on the key and value directory mount points.
+func (f *Factory) diskSpaceAvailable() (keyAvailable int64, valueAvailable int64) {
 	// These stats are not interesting with Windows as there's no separate tmpfs
 	// In case of ephemeral these are the same, but we'll report them separately for consistency
 	var keyDirStatfs unix.Statfs_t
@@ -30,16 +47,5 @@ func (f *Factory) diskStatisticsUpdate() error {
 	_ = unix.Statfs(f.Options.GetPrimary().ValueDirectory, &valDirStatfs)
 
 	// Using Bavail instead of Bfree to get non-priviledged user space available
-	f.metrics.ValueLogSpaceAvailable.Update(int64(valDirStatfs.Bavail) * int64(valDirStatfs.Bsize))
-	f.metrics.KeyLogSpaceAvailable.Update(int64(keyDirStatfs.Bavail) * int64(keyDirStatfs.Bsize))
-
-	/*
-	 TODO If we wanted to clean up oldest data to free up diskspace, we need at a minimum an index to the StartTime
-	 Additionally to that, the deletion might not save anything if the ratio of removed values is lower than the RunValueLogGC's deletion ratio
-	 and with the keys the LSM compaction must remove the offending files also. Thus, there's no guarantee the clean up would
-	 actually reduce the amount of diskspace used any faster than allowing TTL to remove them.
-
-	 If badger supports TimeWindow based compaction, then this should be resolved. Not available in 1.5.3
-	*/
-	return nil
+	return int64(keyDirStatfs.Bavail) * int64(keyDirStatfs.Bsize), int64(valDirStatfs.Bavail) * int64(valDirStatfs.Bsize)
 }
@@ -33,6 +33,7 @@ import (
 )
 
 var _ storage.Factory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
 var _ storage.ArchiveFactory = new(Factory)
 
 type mockSessionBuilder struct {
@@ -86,6 +87,9 @@ func TestCassandraFactory(t *testing.T) {
 	_, err = f.CreateDependencyReader()
 	assert.NoError(t, err)
 
+	_, err = f.CreateDependencyWriter()
+	assert.NoError(t, err)
+
 	_, err = f.CreateArchiveSpanReader()
 	assert.EqualError(t, err, "archive storage not configured")
 
@@ -68,6 +68,16 @@ const (
 	// limitMultiple exists because many spans that are returned from indices can have the same trace, limitMultiple increases
 	// the number of responses from the index, so we can respect the user's limit value they provided.
 	limitMultiple = 3
+	// maxDurationBucketsWithTags bounds the number of duration_index partitions a query combining
+	// duration and tags reads, the tag_index results are intersected with all of them. Both ends of
+	// the time range are rounded to the hour and included, so a week spans 24*7+1 buckets.
+	maxDurationBucketsWithTags = 24*7 + 1
+	// maxTagRowsWithDuration bounds the tag_index rows read for each tag of a query combining duration and tags,
+	// the duration_index entries are filtered by the trace IDs found
+	maxTagRowsWithDuration = 100000
+	// maxDurationRowsPerBucketWithTags bounds the duration_index rows read from each bucket by a query
+	// combining duration and tags, the query fails when a bucket has more matching durations
+	maxDurationRowsPerBucketWithTags = 10000
 )
 
 var (
@@ -83,8 +93,23 @@ var (
 	// ErrMalformedRequestObject occurs when a request object is nil
 	ErrMalformedRequestObject = errors.New("malformed request object")
 
-	// ErrDurationAndTagQueryNotSupported occurs when duration and tags are both set
-	ErrDurationAndTagQueryNotSupported = errors.New("cannot query for duration and tags simultaneously")
+	// ErrDurationAndTagQueryTooBroad occurs when duration and tags are both set and the time range
+	// covers more duration buckets than can be intersected with the tag results
+	ErrDurationAndTagQueryTooBroad = errors.New("time range is too broad to query for duration and tags simultaneously")
+
+	// ErrDurationAndTagQueryNotSupported occurred when duration and tags were both set.
+	//
+	// Deprecated: duration and tags can now be queried together, such queries can fail with
+	// ErrDurationAndTagQueryTooBroad, ErrDurationAndTagQueryTooManyTraces or ErrDurationAndTagQueryTooManyDurations instead.
+	ErrDurationAndTagQueryNotSupported = ErrDurationAndTagQueryTooBroad
+
+	// ErrDurationAndTagQueryTooManyTraces occurs when duration and tags are both set and a tag matches
+	// more spans than can be used to filter the duration results
+	ErrDurationAndTagQueryTooManyTraces = errors.New("tags match too many spans to query for duration and tags simultaneously")
+
+	// ErrDurationAndTagQueryTooManyDurations occurs when duration and tags are both set and a time bucket has
+	// more spans matching the duration than can be filtered by the tag results
+	ErrDurationAndTagQueryTooManyDurations = errors.New("duration matches too many spans to query for duration and tags simultaneously")
 
 	// ErrStartAndEndTimeNotSet occurs when start time and end time are not set
 	ErrStartAndEndTimeNotSet = errors.New("start and End Time must be set")
@@ -217,6 +242,9 @@ func validateQuery(p *spanstore.TraceQueryParameters) error {
 	if p == nil {
 		return ErrMalformedRequestObject
 	}
+	if len(p.TagKeys) > 0 || len(p.TagValues) > 0 {
+		return spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	if p.ServiceName == "" && len(p.Tags) > 0 {
 		return ErrServiceNameNotSet
 	}
@@ -230,7 +258,9 @@ func validateQuery(p *spanstore.TraceQueryParameters) error {
 		return ErrDurationMinGreaterThanMax
 	}
 	if (p.DurationMin != 0 || p.DurationMax != 0) && len(p.Tags) > 0 {
-		return ErrDurationAndTagQueryNotSupported
+		if buckets := len(durationBuckets(p)); buckets > maxDurationBucketsWithTags {
+			return fmt.Errorf("%w: %d hourly buckets exceed the limit of %d", ErrDurationAndTagQueryTooBroad, buckets, maxDurationBucketsWithTags)
+		}
 	}
 	return nil
 }
@@ -279,7 +309,10 @@ func (s *SpanReader) FindTraceIDs(ctx context.Context, traceQuery *spanstore.Tra
 
 func (s *SpanReader) findTraceIDs(ctx context.Context, traceQuery *spanstore.TraceQueryParameters) (dbmodel.UniqueTraceIDs, error) {
 	if traceQuery.DurationMin != 0 || traceQuery.DurationMax != 0 {
-		return s.queryByDuration(ctx, traceQuery)
+		if len(traceQuery.Tags) > 0 {
+			return s.queryByDurationAndTags(ctx, traceQuery)
+		}
+		return s.queryByDuration(ctx, traceQuery, nil)
 	}
 
 	if traceQuery.OperationName != "" {
@@ -306,9 +339,17 @@ func (s *SpanReader) findTraceIDs(ctx context.Context, traceQuery *spanstore.Tra
 }
 
 func (s *SpanReader) queryByTagsAndLogs(ctx context.Context, tq *spanstore.TraceQueryParameters) (dbmodel.UniqueTraceIDs, error) {
+	traceIDs, _, err := s.queryByTags(ctx, tq, tq.NumTraces*limitMultiple)
+	return traceIDs, err
+}
+
+// queryByTags intersects the tag_index results of every tag, reading at most limit rows per tag.
+// It also reports whether the results are complete, i.e. no tag reached the limit.
+func (s *SpanReader) queryByTags(ctx context.Context, tq *spanstore.TraceQueryParameters, limit int) (dbmodel.UniqueTraceIDs, bool, error) {
 	span, ctx := startSpanForQuery(ctx, "queryByTagsAndLogs", queryByTag)
 	defer span.Finish()
 
+	complete := true
 	results := make([]dbmodel.UniqueTraceIDs, 0, len(tq.Tags))
 	for k, v := range tq.Tags {
 		childSpan, _ := opentracing.StartSpanFromContext(ctx, "queryByTag")
@@ -320,19 +361,50 @@ func (s *SpanReader) queryByTagsAndLogs(ctx context.Context, tq *spanstore.Trace
 			v,
 			model.TimeAsEpochMicroseconds(tq.StartTimeMin),
 			model.TimeAsEpochMicroseconds(tq.StartTimeMax),
-			tq.NumTraces*limitMultiple,
+			limit,
 		).PageSize(0)
-		t, err := s.executeQuery(childSpan, query, s.metrics.queryTagIndex)
+		t := dbmodel.UniqueTraceIDs{}
+		rows := 0
+		err := s.scanQuery(childSpan, query, s.metrics.queryTagIndex, func(traceID dbmodel.TraceID) bool {
+			t.Add(traceID)
+			rows++
+			return true
+		})
 		childSpan.Finish()
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+		if rows >= limit {
+			complete = false
 		}
 		results = append(results, t)
 	}
-	return dbmodel.IntersectTraceIDs(results), nil
+	return dbmodel.IntersectTraceIDs(results), complete, nil
 }
 
-func (s *SpanReader) queryByDuration(ctx context.Context, traceQuery *spanstore.TraceQueryParameters) (dbmodel.UniqueTraceIDs, error) {
+// queryByDurationAndTags filters the duration_index entries of every time bucket by the tag_index results.
+// Neither index is cut to the most recent entries, since the recent traces matching the duration may not carry
+// the tags and the recent traces carrying the tags may not match the duration. Rather than returning partial
+// results, the query fails when a tag matches more than maxTagRowsWithDuration spans, or when a time bucket
+// has more than maxDurationRowsPerBucketWithTags spans matching the duration.
+func (s *SpanReader) queryByDurationAndTags(ctx context.Context, traceQuery *spanstore.TraceQueryParameters) (dbmodel.UniqueTraceIDs, error) {
+	tagTraceIDs, complete, err := s.queryByTags(ctx, traceQuery, maxTagRowsWithDuration)
+	if err != nil {
+		return nil, err
+	}
+	if !complete {
+		return nil, ErrDurationAndTagQueryTooManyTraces
+	}
+	if len(tagTraceIDs) == 0 {
+		return tagTraceIDs, nil
+	}
+	return s.queryByDuration(ctx, traceQuery, tagTraceIDs)
+}
+
+// queryByDuration reads the duration buckets from the most recent one until NumTraces trace IDs are found.
+// If tagTraceIDs is not nil only those trace IDs are kept, and up to maxDurationRowsPerBucketWithTags rows
+// of every bucket are read, the query fails with ErrDurationAndTagQueryTooManyDurations past them.
+func (s *SpanReader) queryByDuration(ctx context.Context, traceQuery *spanstore.TraceQueryParameters, tagTraceIDs dbmodel.UniqueTraceIDs) (dbmodel.UniqueTraceIDs, error) {
 	span, ctx := startSpanForQuery(ctx, "queryByDuration", queryByDuration)
 	defer span.Finish()
 
@@ -343,13 +415,12 @@ func (s *SpanReader) queryByDuration(ctx context.Context, traceQuery *spanstore.
 	if traceQuery.DurationMax != 0 {
 		maxDurationMicros = traceQuery.DurationMax.Nanoseconds() / int64(time.Microsecond/time.Nanosecond)
 	}
+	limit := traceQuery.NumTraces * limitMultiple
+	if tagTraceIDs != nil {
+		limit = maxDurationRowsPerBucketWithTags
+	}
 
-	// See writer.go:indexByDuration  for how this is indexed
-	// This is indexed in hours since epoch
-	startTimeByHour := traceQuery.StartTimeMin.Round(durationBucketSize)
-	endTimeByHour := traceQuery.StartTimeMax.Round(durationBucketSize)
-
-	for timeBucket := endTimeByHour; timeBucket.After(startTimeByHour) || timeBucket.Equal(startTimeByHour); timeBucket = timeBucket.Add(-1 * durationBucketSize) {
+	for _, timeBucket := range durationBuckets(traceQuery) {
 		childSpan, _ := opentracing.StartSpanFromContext(ctx, "queryForTimeBucket")
 		childSpan.LogFields(otlog.String("timeBucket", timeBucket.String()))
 		query := s.session.Query(
@@ -359,23 +430,44 @@ func (s *SpanReader) queryByDuration(ctx context.Context, traceQuery *spanstore.
 			traceQuery.OperationName,
 			minDurationMicros,
 			maxDurationMicros,
-			traceQuery.NumTraces*limitMultiple)
-		t, err := s.executeQuery(childSpan, query, s.metrics.queryDurationIndex)
+			limit)
+		rows := 0
+		err := s.scanQuery(childSpan, query, s.metrics.queryDurationIndex, func(traceID dbmodel.TraceID) bool {
+			rows++
+			if _, ok := tagTraceIDs[traceID]; ok || tagTraceIDs == nil {
+				results.Add(traceID)
+			}
+			return len(results) < traceQuery.NumTraces
+		})
 		childSpan.Finish()
 		if err != nil {
 			return nil, err
 		}
-
-		for traceID := range t {
-			results.Add(traceID)
-			if len(results) == traceQuery.NumTraces {
-				break
-			}
+		if len(results) >= traceQuery.NumTraces {
+			break
+		}
+		// the bucket may have more rows, whose traces carrying the tags would be missed
+		if tagTraceIDs != nil && rows >= limit {
+			return nil, ErrDurationAndTagQueryTooManyDurations
 		}
 	}
 	return results, nil
 }
 
+// durationBuckets returns the duration_index time buckets covering the query, the most recent first.
+func durationBuckets(traceQuery *spanstore.TraceQueryParameters) []time.Time {
+	// See writer.go:indexByDuration  for how this is indexed
+	// This is indexed in hours since epoch
+	startTimeByHour := traceQuery.StartTimeMin.Round(durationBucketSize)
+	endTimeByHour := traceQuery.StartTimeMax.Round(durationBucketSize)
+
+	var buckets []time.Time
+	for timeBucket := endTimeByHour; timeBucket.After(startTimeByHour) || timeBucket.Equal(startTimeByHour); timeBucket = timeBucket.Add(-1 * durationBucketSize) {
+		buckets = append(buckets, timeBucket)
+	}
+	return buckets
+}
+
 func (s *SpanReader) queryByServiceNameAndOperation(ctx context.Context, tq *spanstore.TraceQueryParameters) (dbmodel.UniqueTraceIDs, error) {
 	span, _ := startSpanForQuery(ctx, "queryByServiceNameAndOperation", queryByServiceAndOperationName)
 	defer span.Finish()
@@ -404,12 +496,26 @@ func (s *SpanReader) queryByService(ctx context.Context, tq *spanstore.TraceQuer
 }
 
 func (s *SpanReader) executeQuery(span opentracing.Span, query cassandra.Query, tableMetrics *casMetrics.Table) (dbmodel.UniqueTraceIDs, error) {
+	retMe := dbmodel.UniqueTraceIDs{}
+	err := s.scanQuery(span, query, tableMetrics, func(traceID dbmodel.TraceID) bool {
+		retMe.Add(traceID)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retMe, nil
+}
+
+// scanQuery passes the trace ID of every row to onRow until it returns false.
+func (s *SpanReader) scanQuery(span opentracing.Span, query cassandra.Query, tableMetrics *casMetrics.Table, onRow func(dbmodel.TraceID) bool) error {
 	start := time.Now()
 	i := query.Iter()
-	retMe := dbmodel.UniqueTraceIDs{}
 	var traceID dbmodel.TraceID
 	for i.Scan(&traceID) {
-		retMe.Add(traceID)
+		if !onRow(traceID) {
+			break
+		}
 	}
 	err := i.Close()
 	tableMetrics.Emit(err, time.Since(start))
@@ -417,9 +523,9 @@ func (s *SpanReader) executeQuery(span opentracing.Span, query cassandra.Query,
 		logErrorToSpan(span, err)
 		span.LogFields(otlog.String("query", query.String()))
 		s.logger.Error("Failed to exec query", zap.Error(err), zap.String("query", query.String()))
-		return nil, err
+		return err
 	}
-	return retMe, nil
+	return nil
 }
 
 func startSpanForQuery(ctx context.Context, name, query string) (opentracing.Span, context.Context) {
@@ -272,6 +272,34 @@ func TestSpanReaderFindTraces(t *testing.T) {
 				"duration query error",
 			},
 		},
+		{
+			caption:       "duration and tag query",
+			queryDuration: true,
+			queryTags:     true,
+			expectedCount: 2,
+		},
+		{
+			caption:        "duration and tag error on tag query",
+			queryDuration:  true,
+			queryTags:      true,
+			tagsQueryError: errors.New("tags query error"),
+			expectedError:  "tags query error",
+			expectedLogs: []string{
+				"Failed to exec query",
+				"tags query error",
+			},
+		},
+		{
+			caption:            "duration and tag error on duration query",
+			queryDuration:      true,
+			queryTags:          true,
+			durationQueryError: errors.New("duration query error"),
+			expectedError:      "duration query error",
+			expectedLogs: []string{
+				"Failed to exec query",
+				"duration query error",
+			},
+		},
 		{
 			caption:        "load trace error",
 			loadQueryError: errors.New("load query error"),
@@ -402,6 +430,90 @@ func TestSpanReaderFindTraces(t *testing.T) {
 	}
 }
 
+// traceIDsIter scans the low trace IDs in order, or rows times the first one if rows is set
+type traceIDsIter struct {
+	lows []uint64
+	rows int
+}
+
+func (i *traceIDsIter) Scan(dest ...interface{}) bool {
+	if len(i.lows) == 0 {
+		return false
+	}
+	*dest[0].(*dbmodel.TraceID) = dbmodel.TraceIDFromDomain(model.NewTraceID(0, i.lows[0]))
+	if i.rows--; i.rows <= 0 {
+		i.lows = i.lows[1:]
+	}
+	return true
+}
+
+func (i *traceIDsIter) Close() error {
+	return nil
+}
+
+func TestSpanReaderFindTraceIDsByDurationAndTags(t *testing.T) {
+	testCases := []struct {
+		caption       string
+		tagIter       *traceIDsIter
+		durationIter  *traceIDsIter
+		expected      []model.TraceID
+		expectedError error
+	}{
+		{
+			caption: "older slow traces carrying the tags",
+			tagIter: &traceIDsIter{lows: []uint64{1, 2}},
+			// the most recent entries of the duration index do not carry the tags
+			durationIter: &traceIDsIter{lows: []uint64{3, 4, 5, 1}},
+			expected:     []model.TraceID{model.NewTraceID(0, 1)},
+		},
+		{
+			caption:       "tags matching too many spans",
+			tagIter:       &traceIDsIter{lows: []uint64{1}, rows: maxTagRowsWithDuration},
+			durationIter:  &traceIDsIter{lows: []uint64{1}},
+			expectedError: ErrDurationAndTagQueryTooManyTraces,
+		},
+		{
+			caption:       "duration matching too many spans",
+			tagIter:       &traceIDsIter{lows: []uint64{1, 2}},
+			durationIter:  &traceIDsIter{lows: []uint64{3}, rows: maxDurationRowsPerBucketWithTags},
+			expectedError: ErrDurationAndTagQueryTooManyDurations,
+		},
+	}
+	for _, tc := range testCases {
+		testCase := tc // capture loop var
+		t.Run(testCase.caption, func(t *testing.T) {
+			withSpanReader(func(r *spanReaderTest) {
+				tagQuery := &mocks.Query{}
+				tagQuery.On("PageSize", 0).Return(tagQuery)
+				tagQuery.On("Iter").Return(testCase.tagIter)
+				r.session.On("Query", stringMatcher(queryByTag), matchEverything()).Return(tagQuery)
+				durationQuery := &mocks.Query{}
+				durationQuery.On("Iter").Return(testCase.durationIter)
+				// the rows read from every duration bucket are capped
+				r.session.On("Query", stringMatcher(queryByDuration), mock.MatchedBy(func(v []interface{}) bool {
+					return v[len(v)-1] == maxDurationRowsPerBucketWithTags
+				})).Return(durationQuery)
+
+				startTime := time.Now().Truncate(durationBucketSize)
+				traceIDs, err := r.reader.FindTraceIDs(context.Background(), &spanstore.TraceQueryParameters{
+					ServiceName:  "service-a",
+					Tags:         map[string]string{"x": "y"},
+					DurationMin:  time.Second,
+					NumTraces:    1,
+					StartTimeMin: startTime,
+					StartTimeMax: startTime,
+				})
+				if testCase.expectedError != nil {
+					assert.Equal(t, testCase.expectedError, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expected, traceIDs)
+			})
+		})
+	}
+}
+
 func TestTraceQueryParameterValidation(t *testing.T) {
 	tsp := &spanstore.TraceQueryParameters{
 		ServiceName: "",
@@ -427,10 +539,23 @@ func TestTraceQueryParameterValidation(t *testing.T) {
 	tsp.DurationMin = time.Minute
 	tsp.DurationMax = time.Hour
 	err = validateQuery(tsp)
-	assert.EqualError(t, err, ErrDurationAndTagQueryNotSupported.Error())
+	assert.NoError(t, err)
+
+	// a week of lookback, as offered by the UI, is not too broad
+	tsp.StartTimeMin = tsp.StartTimeMax.Add(-7 * 24 * time.Hour)
+	err = validateQuery(tsp)
+	assert.NoError(t, err)
+
+	tsp.StartTimeMin = tsp.StartTimeMax.Add(-2 * maxDurationBucketsWithTags * durationBucketSize)
+	err = validateQuery(tsp)
+	assert.True(t, errors.Is(err, ErrDurationAndTagQueryTooBroad))
 
 	tsp.StartTimeMin = time.Time{} //time.Unix(0,0) doesn't work because timezones
 	tsp.StartTimeMax = time.Time{}
 	err = validateQuery(tsp)
 	assert.EqualError(t, err, ErrStartAndEndTimeNotSet.Error())
+
+	tsp.TagKeys = []string{"michael"}
+	err = validateQuery(tsp)
+	assert.Equal(t, spanstore.ErrTagKeysAndValuesNotSupported, err)
 }
@@ -18,11 +18,13 @@ package spanstore
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"github.com/gocql/gocql"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/cassandra"
 	casMetrics "github.com/jaegertracing/jaeger/pkg/cassandra/metrics"
 	"github.com/jaegertracing/jaeger/plugin/storage/cassandra/spanstore/dbmodel"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 const (
@@ -66,6 +69,13 @@ const (
 	defaultNumBuckets = 10
 
 	durationBucketSize = time.Hour
+
+	// maxBatchSize is the maximum number of spans inserted with a single batch
+	maxBatchSize = 10
+	// maxBatchBytes is the maximum size of the spans inserted with a single batch, estimated with their protobuf size.
+	// The batches larger than batch_size_fail_threshold_in_kb in cassandra.yaml (50KB by default) are rejected,
+	// in which case their spans are inserted one at a time.
+	maxBatchBytes = 40 * 1024
 )
 
 const (
@@ -151,6 +161,96 @@ func (s *SpanWriter) WriteSpan(ctx context.Context, span *model.Span) error {
 	return nil
 }
 
+// WriteSpans writes the spans of the same trace with batches, which only touch one partition of the
+// traces table, and creates their indexes one span at a time
+func (s *SpanWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	dss := make([]*dbmodel.Span, len(spans))
+	for i, span := range spans {
+		dss[i] = dbmodel.FromDomain(span)
+	}
+	if s.storageMode&storeFlag == storeFlag {
+		if err := s.writeSpanBatches(spans, dss); err != nil {
+			return err
+		}
+	}
+	if s.storageMode&indexFlag == indexFlag {
+		for i, span := range spans {
+			if err := s.writeIndexes(span, dss[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SpanWriter) writeSpanBatches(spans []*model.Span, dss []*dbmodel.Span) error {
+	var traceIDs []model.TraceID
+	byTrace := make(map[model.TraceID][]int)
+	for i, span := range spans {
+		if _, ok := byTrace[span.TraceID]; !ok {
+			traceIDs = append(traceIDs, span.TraceID)
+		}
+		byTrace[span.TraceID] = append(byTrace[span.TraceID], i)
+	}
+	for _, traceID := range traceIDs {
+		indexes := byTrace[traceID]
+		for len(indexes) > 0 {
+			n, bytes := 1, spans[indexes[0]].Size()
+			for n < len(indexes) && n < maxBatchSize && bytes+spans[indexes[n]].Size() <= maxBatchBytes {
+				bytes += spans[indexes[n]].Size()
+				n++
+			}
+			if err := s.writeSpanBatch(spans, dss, indexes[:n]); err != nil {
+				return err
+			}
+			indexes = indexes[n:]
+		}
+	}
+	return nil
+}
+
+func (s *SpanWriter) writeSpanBatch(spans []*model.Span, dss []*dbmodel.Span, indexes []int) error {
+	if len(indexes) == 1 {
+		return s.writeSpan(spans[indexes[0]], dss[indexes[0]])
+	}
+	batch := s.session.NewBatch()
+	for _, i := range indexes {
+		ds := dss[i]
+		batch.Query(
+			insertSpan,
+			ds.TraceID,
+			ds.SpanID,
+			ds.SpanHash,
+			ds.ParentID,
+			ds.OperationName,
+			ds.Flags,
+			ds.StartTime,
+			ds.Duration,
+			ds.Tags,
+			ds.Logs,
+			ds.Refs,
+			ds.Process,
+		)
+	}
+	err := s.writerMetrics.traces.ExecBatch(batch, s.logger)
+	if err == nil {
+		return nil
+	}
+	if !isPermanent(err) {
+		return s.logError(dss[indexes[0]], err, "Failed to insert spans", s.logger)
+	}
+	// the batch is rejected, e.g. it is too large, the spans are inserted one at a time so that
+	// only the spans which are rejected themselves fail
+	s.logger.Warn("Batch of spans rejected, inserting the spans one at a time", zap.Int("spans", len(indexes)), zap.Error(err))
+	var firstErr error
+	for _, i := range indexes {
+		if err := s.writeSpan(spans[i], dss[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 func (s *SpanWriter) writeSpan(span *model.Span, ds *dbmodel.Span) error {
 	mainQuery := s.session.Query(
 		insertSpan,
@@ -282,7 +382,33 @@ func (s *SpanWriter) logError(span *dbmodel.Span, err error, msg string, logger
 		With(zap.Int64("span_id", span.SpanID)).
 		With(zap.Error(err)).
 		Error(msg)
-	return fmt.Errorf("%s: %w", msg, err)
+	err = fmt.Errorf("%s: %w", msg, err)
+	if isPermanent(err) {
+		return spanstore.PermanentError{Err: err}
+	}
+	return err
+}
+
+// Cassandra error codes of the requests which fail again when retried,
+// see https://github.com/apache/cassandra/blob/trunk/doc/native_protocol_v4.spec
+const (
+	errCodeSyntax       = 0x2000
+	errCodeUnauthorized = 0x2100
+	errCodeInvalid      = 0x2200
+)
+
+// isPermanent returns true if err wraps a Cassandra error rejecting the request itself,
+// and not a transient failure of the cluster such as a timeout
+func isPermanent(err error) bool {
+	var reqErr gocql.RequestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	switch reqErr.Code() {
+	case errCodeSyntax, errCodeUnauthorized, errCodeInvalid:
+		return true
+	}
+	return false
 }
 
 func (s *SpanWriter) saveServiceNameAndOperationName(operation dbmodel.Operation) error {
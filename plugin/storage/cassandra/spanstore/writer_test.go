@@ -62,7 +62,10 @@ func withSpanWriter(writeCacheTTL time.Duration, fn func(w *spanWriterTest), opt
 	fn(w)
 }
 
-var _ spanstore.Writer = &SpanWriter{} // check API conformance
+var (
+	_ spanstore.Writer      = &SpanWriter{} // check API conformance
+	_ spanstore.BatchWriter = &SpanWriter{}
+)
 
 func TestClientClose(t *testing.T) {
 	withSpanWriter(0, func(w *spanWriterTest) {
@@ -228,6 +231,143 @@ func TestSpanWriter(t *testing.T) {
 	}
 }
 
+func TestSpanWriterWriteSpans(t *testing.T) {
+	traceA, traceB := model.NewTraceID(0, 1), model.NewTraceID(0, 2)
+	var spans []*model.Span
+	for i := 0; i < maxBatchSize+2; i++ {
+		spans = append(spans, &model.Span{TraceID: traceA, SpanID: model.SpanID(i), Process: &model.Process{ServiceName: "svc"}})
+	}
+	spans = append(spans, &model.Span{TraceID: traceB, Process: &model.Process{ServiceName: "svc"}})
+
+	withSpanWriter(0, func(w *spanWriterTest) {
+		batch := &mocks.Batch{}
+		batch.On("Query", stringMatcher(insertSpan), matchEverything()).Return()
+		batch.On("Exec").Return(nil)
+		w.session.On("NewBatch").Return(batch)
+		spanQuery := &mocks.Query{}
+		spanQuery.On("Exec").Return(nil)
+		w.session.On("Query", stringMatcher(insertSpan), matchEverything()).Return(spanQuery)
+
+		assert.NoError(t, w.writer.WriteSpans(context.Background(), spans))
+		// the spans of traceA are split into two batches, the span of traceB is inserted alone
+		w.session.AssertNumberOfCalls(t, "NewBatch", 2)
+		batch.AssertNumberOfCalls(t, "Query", maxBatchSize+2)
+		spanQuery.AssertNumberOfCalls(t, "Exec", 1)
+	}, StoreWithoutIndexing())
+
+	withSpanWriter(0, func(w *spanWriterTest) {
+		batch := &mocks.Batch{}
+		batch.On("Query", stringMatcher(insertSpan), matchEverything()).Return()
+		batch.On("Exec").Return(errors.New("batch error"))
+		batch.On("Size").Return(maxBatchSize)
+		w.session.On("NewBatch").Return(batch)
+
+		err := w.writer.WriteSpans(context.Background(), spans)
+		assert.EqualError(t, err, "Failed to insert spans: failed to Exec batch of 10 queries: batch error")
+	}, StoreWithoutIndexing())
+
+	withSpanWriter(0, func(w *spanWriterTest) {
+		batch := &mocks.Batch{}
+		batch.On("Query", stringMatcher(insertSpan), matchEverything()).Return()
+		batch.On("Exec").Return(requestError{code: errCodeInvalid})
+		batch.On("Size").Return(maxBatchSize)
+		w.session.On("NewBatch").Return(batch)
+		spanQuery := &mocks.Query{}
+		spanQuery.On("Exec").Return(nil)
+		w.session.On("Query", stringMatcher(insertSpan), matchEverything()).Return(spanQuery)
+
+		assert.NoError(t, w.writer.WriteSpans(context.Background(), spans))
+		// the rejected batches are inserted one span at a time
+		spanQuery.AssertNumberOfCalls(t, "Exec", len(spans))
+	}, StoreWithoutIndexing())
+
+	withSpanWriter(0, func(w *spanWriterTest) {
+		batch := &mocks.Batch{}
+		batch.On("Query", stringMatcher(insertSpan), matchEverything()).Return()
+		batch.On("Exec").Return(requestError{code: errCodeInvalid})
+		batch.On("Size").Return(2)
+		w.session.On("NewBatch").Return(batch)
+		badQuery := &mocks.Query{}
+		badQuery.On("Exec").Return(requestError{code: errCodeInvalid})
+		badQuery.On("String").Return("insert span")
+		goodQuery := &mocks.Query{}
+		goodQuery.On("Exec").Return(nil)
+		w.session.On("Query", stringMatcher(insertSpan), mock.MatchedBy(func(args []interface{}) bool {
+			return args[1] == int64(0)
+		})).Return(badQuery)
+		w.session.On("Query", stringMatcher(insertSpan), matchEverything()).Return(goodQuery)
+
+		err := w.writer.WriteSpans(context.Background(), spans[:2])
+		assert.False(t, spanstore.IsRetryable(err), "the span rejected on its own fails permanently")
+		goodQuery.AssertNumberOfCalls(t, "Exec", 1)
+	}, StoreWithoutIndexing())
+
+	withSpanWriter(0, func(w *spanWriterTest) {
+		large := []*model.Span{
+			{TraceID: traceA, OperationName: strings.Repeat("a", maxBatchBytes/2), Process: &model.Process{ServiceName: "svc"}},
+			{TraceID: traceA, OperationName: strings.Repeat("b", maxBatchBytes/2), Process: &model.Process{ServiceName: "svc"}},
+			{TraceID: traceA, Process: &model.Process{ServiceName: "svc"}},
+		}
+		batch := &mocks.Batch{}
+		batch.On("Query", stringMatcher(insertSpan), matchEverything()).Return()
+		batch.On("Exec").Return(nil)
+		w.session.On("NewBatch").Return(batch)
+		spanQuery := &mocks.Query{}
+		spanQuery.On("Exec").Return(nil)
+		w.session.On("Query", stringMatcher(insertSpan), matchEverything()).Return(spanQuery)
+
+		assert.NoError(t, w.writer.WriteSpans(context.Background(), large))
+		// the batches are limited by the size of their spans
+		spanQuery.AssertNumberOfCalls(t, "Exec", 1)
+		w.session.AssertNumberOfCalls(t, "NewBatch", 1)
+		batch.AssertNumberOfCalls(t, "Query", 2)
+	}, StoreWithoutIndexing())
+
+	withSpanWriter(0, func(w *spanWriterTest) {
+		indexQuery := &mocks.Query{}
+		indexQuery.On("Bind", matchEverything()).Return(indexQuery)
+		indexQuery.On("Exec").Return(nil)
+		w.session.On("Query", mock.Anything, matchEverything()).Return(indexQuery)
+		var services []string
+		w.writer.serviceNamesWriter = func(serviceName string) error {
+			services = append(services, serviceName)
+			return nil
+		}
+		w.writer.operationNamesWriter = func(operation dbmodel.Operation) error { return nil }
+
+		assert.NoError(t, w.writer.WriteSpans(context.Background(), spans))
+		assert.Len(t, services, len(spans), "the indexes are created for each span")
+		w.session.AssertNotCalled(t, "NewBatch")
+	}, StoreIndexesOnly())
+}
+
+type requestError struct {
+	code int
+}
+
+func (e requestError) Code() int       { return e.code }
+func (e requestError) Message() string { return "request error" }
+func (e requestError) Error() string   { return e.Message() }
+
+func TestSpanWriterPermanentErrors(t *testing.T) {
+	testCases := []struct {
+		err       error
+		retryable bool
+	}{
+		{err: errors.New("some error"), retryable: true},
+		{err: requestError{code: 0x1100}, retryable: true}, // write timeout
+		{err: requestError{code: errCodeInvalid}, retryable: false},
+		{err: fmt.Errorf("failed to Exec query: %w", requestError{code: errCodeSyntax}), retryable: false},
+	}
+	for _, tc := range testCases {
+		withSpanWriter(0, func(w *spanWriterTest) {
+			err := w.writer.logError(&dbmodel.Span{}, tc.err, "Failed to insert span", w.logger)
+			assert.True(t, errors.Is(err, tc.err))
+			assert.Equal(t, tc.retryable, spanstore.IsRetryable(err), tc.err.Error())
+		})
+	}
+}
+
 func TestSpanWriterSaveServiceNameAndOperationName(t *testing.T) {
 	expectedErr := errors.New("some error")
 	testCases := []struct {
@@ -223,6 +223,9 @@ func (r *TraceReader) FindTraceIDs(ctx context.Context, params *spanstore.TraceQ
 	span, ctx := opentracing.StartSpanFromContext(ctx, "FindTraceIDs")
 	defer span.Finish()
 
+	if len(params.TagKeys) > 0 || len(params.TagValues) > 0 {
+		return nil, spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	if params.StartTimeMin.IsZero() {
 		return nil, ErrStartTimeRequired
 	}
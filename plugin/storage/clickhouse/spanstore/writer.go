@@ -1,6 +1,7 @@
 package spanstore
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+var (
+	_ spanstore.Writer      = (*SpanWriter)(nil)
+	_ spanstore.BatchWriter = (*SpanWriter)(nil)
 )
 
 type Encoding string
@@ -129,7 +136,7 @@ func (w *SpanWriter) writeModelBatch(batch []*model.Span) error {
 
 	statement, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s (timestamp, traceID, model) VALUES (?, ?, ?)", w.spansTable))
 	if err != nil {
-		return nil
+		return err
 	}
 
 	defer statement.Close()
@@ -199,12 +206,17 @@ func (w *SpanWriter) writeIndexBatch(batch []*model.Span) error {
 	return tx.Commit()
 }
 
-// WriteSpan writes the encoded span
-func (w *SpanWriter) WriteSpan(span *model.Span) error {
+// WriteSpan adds the span to the batch written in the background
+func (w *SpanWriter) WriteSpan(_ context.Context, span *model.Span) error {
 	w.spans <- span
 	return nil
 }
 
+// WriteSpans writes the spans, which are already batched, right away
+func (w *SpanWriter) WriteSpans(_ context.Context, spans []*model.Span) error {
+	return w.writeBatch(spans)
+}
+
 // Close Implements io.Closer and closes the underlying storage
 func (w *SpanWriter) Close() error {
 	w.finish <- true
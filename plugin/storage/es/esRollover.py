@@ -205,7 +205,12 @@ def get_request_session(username, password, tls, ca, cert, key, skipHostVerify):
 def get_version(client):
     esVersion = os.getenv('ES_VERSION')
     if esVersion is None or esVersion == '':
-        esVersion = client.info()['version']['number'][0]
+        version = client.info()['version']
+        if version.get('distribution') == 'opensearch':
+            # OpenSearch is compatible with the ElasticSearch 7 API
+            print('Detected OpenSearch Version {}'.format(version['number']))
+            return 7
+        esVersion = version['number'].split('.')[0]
         print('Detected ElasticSearch Version {}'.format(esVersion))
         esVersion = int(esVersion)
     return esVersion
@@ -16,6 +16,8 @@
 package es
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -40,6 +42,12 @@ const (
 	archiveNamespace = "es-archive"
 )
 
+var (
+	errDataStreamsNotSupported   = errors.New("data streams require Elasticsearch 7.9 or later")
+	errILMNotSupportedOpenSearch = errors.New("index lifecycle management is not supported by OpenSearch, " +
+		"use an Index State Management policy or the rollover and cleaner scripts instead")
+)
+
 // Factory implements storage.Factory for Elasticsearch backend.
 type Factory struct {
 	Options *Options
@@ -90,15 +98,51 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 		return fmt.Errorf("failed to create primary Elasticsearch client: %w", err)
 	}
 	f.primaryClient = primaryClient
+	if err := initIndices(f.primaryClient, f.primaryConfig, false, logger); err != nil {
+		return fmt.Errorf("failed to initialize primary Elasticsearch indices: %w", err)
+	}
 	if f.archiveConfig.IsStorageEnabled() {
 		f.archiveClient, err = f.archiveConfig.NewClient(logger, metricsFactory)
 		if err != nil {
 			return fmt.Errorf("failed to create archive Elasticsearch client: %w", err)
 		}
+		if err := initIndices(f.archiveClient, f.archiveConfig, true, logger); err != nil {
+			return fmt.Errorf("failed to initialize archive Elasticsearch indices: %w", err)
+		}
+	}
+	return nil
+}
+
+// initIndices prepares what the configured index mode needs before spans are written or read.
+func initIndices(client es.Client, cfg config.ClientBuilder, archive bool, logger *zap.Logger) error {
+	switch cfg.GetDistribution() {
+	case config.ElasticsearchDistribution:
+	case config.OpenSearchDistribution:
+		if cfg.GetIndexLifecycle().Enabled {
+			return errILMNotSupportedOpenSearch
+		}
+	default:
+		return fmt.Errorf("unknown search engine distribution %q", cfg.GetDistribution())
+	}
+	if cfg.GetUseDataStreams() && !versionAtLeast(client, cfg, 7, 9) {
+		return errDataStreamsNotSupported
+	}
+	if cfg.GetIndexLifecycle().Enabled {
+		return initIndexLifecycle(client, cfg, archive, logger)
 	}
 	return nil
 }
 
+// versionAtLeast compares the Elasticsearch API version of the cluster with major.minor.
+// The minor version is only known when the version is detected, a configured version passes on its major.
+func versionAtLeast(client es.Client, cfg config.ClientBuilder, major, minor uint) bool {
+	if version := client.GetVersion(); version != major {
+		return version > major
+	}
+	actual, ok := cfg.GetMinorVersion()
+	return !ok || actual >= minor
+}
+
 // CreateSpanReader implements storage.Factory
 func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
 	return createSpanReader(f.metricsFactory, f.logger, f.primaryClient, f.primaryConfig, false)
@@ -115,6 +159,11 @@ func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
 	return reader, nil
 }
 
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *Factory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	return esDepStore.NewDependencyStore(f.primaryClient, f.logger, f.primaryConfig.GetIndexPrefix(), f.primaryConfig.GetMaxDocCount()), nil
+}
+
 // CreateArchiveSpanReader implements storage.ArchiveFactory
 func (f *Factory) CreateArchiveSpanReader() (spanstore.Reader, error) {
 	if !f.archiveConfig.IsStorageEnabled() {
@@ -147,6 +196,7 @@ func createSpanReader(
 		IndexPrefix:         cfg.GetIndexPrefix(),
 		TagDotReplacement:   cfg.GetTagDotReplacement(),
 		UseReadWriteAliases: cfg.GetUseReadWriteAliases(),
+		UseDataStreams:      cfg.GetUseDataStreams(),
 		Archive:             archive,
 	}), nil
 }
@@ -176,9 +226,19 @@ func createSpanWriter(
 		TagDotReplacement:   cfg.GetTagDotReplacement(),
 		Archive:             archive,
 		UseReadWriteAliases: cfg.GetUseReadWriteAliases(),
+		UseDataStreams:      cfg.GetUseDataStreams(),
 	})
 	if cfg.IsCreateIndexTemplates() {
-		err := writer.CreateTemplates(spanMapping, serviceMapping)
+		if cfg.GetUseDataStreams() {
+			var ilmPolicy string
+			if ilm := cfg.GetIndexLifecycle(); ilm.Enabled {
+				ilmPolicy = ilm.PolicyName
+			}
+			spanMapping, serviceMapping = GetSpanServiceDataStreamTemplates(cfg.GetNumShards(), cfg.GetNumReplicas(), cfg.GetIndexPrefix(), archive, ilmPolicy)
+			err = writer.CreateDataStreamTemplates(spanMapping, serviceMapping)
+		} else {
+			err = writer.CreateTemplates(spanMapping, serviceMapping)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -196,6 +256,32 @@ func GetSpanServiceMappings(shards, replicas int64, esVersion uint) (string, str
 		fixMapping(loadMapping("/jaeger-service.json"), shards, replicas)
 }
 
+// GetSpanServiceDataStreamTemplates returns the span and service index templates of the data streams.
+// Each template only matches its own data stream, the archive has no service template.
+// The templates attach the ILM policy to the backing indices if the policy is not empty.
+func GetSpanServiceDataStreamTemplates(shards, replicas int64, indexPrefix string, archive bool, ilmPolicy string) (string, string) {
+	spanDataStream, serviceDataStream := esSpanStore.DataStreamNames(indexPrefix, archive)
+	spanTemplate := dataStreamTemplate(fixMapping(loadMapping("/jaeger-span-datastream.json"), shards, replicas), spanDataStream, ilmPolicy)
+	if serviceDataStream == "" {
+		return spanTemplate, ""
+	}
+	return spanTemplate, dataStreamTemplate(fixMapping(loadMapping("/jaeger-service-datastream.json"), shards, replicas), serviceDataStream, ilmPolicy)
+}
+
+func dataStreamTemplate(template, dataStream, ilmPolicy string) string {
+	var t map[string]interface{}
+	if err := json.Unmarshal([]byte(template), &t); err != nil {
+		return template
+	}
+	// templates with overlapping patterns and the same priority are rejected by Elasticsearch
+	t["index_patterns"] = []string{dataStream}
+	if ilmPolicy != "" {
+		settings := t["template"].(map[string]interface{})["settings"].(map[string]interface{})
+		settings["index.lifecycle.name"] = ilmPolicy
+	}
+	return toJSON(t)
+}
+
 // GetDependenciesMappings returns dependencies mappings
 func GetDependenciesMappings(shards, replicas int64, esVersion uint) string {
 	if esVersion == 7 {
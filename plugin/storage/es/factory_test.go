@@ -17,12 +17,14 @@ package es
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,11 +40,13 @@ import (
 )
 
 var _ storage.Factory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
 
 type mockClientBuilder struct {
 	escfg.Configuration
 	err                 error
 	createTemplateError error
+	esVersion           uint
 }
 
 func (m *mockClientBuilder) NewClient(logger *zap.Logger, metricsFactory metrics.Factory) (es.Client, error) {
@@ -52,7 +56,11 @@ func (m *mockClientBuilder) NewClient(logger *zap.Logger, metricsFactory metrics
 		tService.On("Body", mock.Anything).Return(tService)
 		tService.On("Do", context.Background()).Return(nil, m.createTemplateError)
 		c.On("CreateTemplate", mock.Anything).Return(tService)
-		c.On("GetVersion").Return(uint(6))
+		c.On("CreateIndexTemplate", mock.Anything).Return(tService)
+		if m.esVersion == 0 {
+			m.esVersion = 6
+		}
+		c.On("GetVersion").Return(m.esVersion)
 		return c, nil
 	}
 	return nil, m.err
@@ -85,6 +93,9 @@ func TestElasticsearchFactory(t *testing.T) {
 	_, err = f.CreateDependencyReader()
 	assert.NoError(t, err)
 
+	_, err = f.CreateDependencyWriter()
+	assert.NoError(t, err)
+
 	_, err = f.CreateArchiveSpanReader()
 	assert.NoError(t, err)
 
@@ -94,6 +105,97 @@ func TestElasticsearchFactory(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestElasticsearchFactoryIndexLifecycle(t *testing.T) {
+	f := NewFactory()
+	ilm := escfg.IndexLifecycle{Enabled: true, RolloverMaxAge: time.Hour}
+	f.primaryConfig = &mockClientBuilder{esVersion: 5, Configuration: escfg.Configuration{IndexLifecycle: ilm}}
+	f.archiveConfig = &mockClientBuilder{}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		"failed to initialize primary Elasticsearch indices: "+errILMNotSupported.Error())
+
+	f.primaryConfig = &mockClientBuilder{}
+	f.archiveConfig = &mockClientBuilder{esVersion: 5, Configuration: escfg.Configuration{Enabled: true, IndexLifecycle: ilm}}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		"failed to initialize archive Elasticsearch indices: "+errILMNotSupported.Error())
+}
+
+func TestElasticsearchFactoryDistribution(t *testing.T) {
+	f := NewFactory()
+	f.primaryConfig = &mockClientBuilder{Configuration: escfg.Configuration{
+		Distribution:   escfg.OpenSearchDistribution,
+		IndexLifecycle: escfg.IndexLifecycle{Enabled: true},
+	}}
+	f.archiveConfig = &mockClientBuilder{}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		"failed to initialize primary Elasticsearch indices: "+errILMNotSupportedOpenSearch.Error())
+
+	f.primaryConfig = &mockClientBuilder{Configuration: escfg.Configuration{Distribution: "solr"}}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		`failed to initialize primary Elasticsearch indices: unknown search engine distribution "solr"`)
+
+	f.primaryConfig = &mockClientBuilder{esVersion: 7, Configuration: escfg.Configuration{
+		Distribution:   escfg.OpenSearchDistribution,
+		UseDataStreams: true,
+	}}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+}
+
+func TestElasticsearchFactoryDataStreams(t *testing.T) {
+	f := NewFactory()
+	f.primaryConfig = &mockClientBuilder{Configuration: escfg.Configuration{UseDataStreams: true}}
+	f.archiveConfig = &mockClientBuilder{}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		"failed to initialize primary Elasticsearch indices: "+errDataStreamsNotSupported.Error())
+
+	minor := uint(8)
+	f.primaryConfig = &mockClientBuilder{esVersion: 7, Configuration: escfg.Configuration{UseDataStreams: true, MinorVersion: &minor}}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		"failed to initialize primary Elasticsearch indices: "+errDataStreamsNotSupported.Error())
+
+	primary := &mockClientBuilder{esVersion: 7, Configuration: escfg.Configuration{UseDataStreams: true, CreateIndexTemplates: true}}
+	f.primaryConfig = primary
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	_, err := f.CreateSpanWriter()
+	require.NoError(t, err)
+	client := f.primaryClient.(*mocks.Client)
+	client.AssertCalled(t, "CreateIndexTemplate", "jaeger-span-ds")
+	client.AssertCalled(t, "CreateIndexTemplate", "jaeger-service-ds")
+	client.AssertNotCalled(t, "CreateTemplate", mock.Anything)
+}
+
+func TestGetSpanServiceDataStreamTemplates(t *testing.T) {
+	for _, policy := range []string{"", "jaeger-ilm-policy"} {
+		span, service := GetSpanServiceDataStreamTemplates(3, 2, "foo", false, policy)
+		for template, dataStream := range map[string]string{span: "foo-jaeger-span-ds", service: "foo-jaeger-service-ds"} {
+			var parsed struct {
+				IndexPatterns []string               `json:"index_patterns"`
+				DataStream    map[string]interface{} `json:"data_stream"`
+				Template      struct {
+					Settings map[string]interface{} `json:"settings"`
+					Mappings struct {
+						Properties map[string]interface{} `json:"properties"`
+					} `json:"mappings"`
+				} `json:"template"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(template), &parsed))
+			assert.Equal(t, []string{dataStream}, parsed.IndexPatterns)
+			assert.NotNil(t, parsed.DataStream)
+			assert.Equal(t, float64(3), parsed.Template.Settings["index.number_of_shards"])
+			assert.Equal(t, float64(2), parsed.Template.Settings["index.number_of_replicas"])
+			assert.Contains(t, parsed.Template.Mappings.Properties, "@timestamp")
+			if policy != "" {
+				assert.Equal(t, policy, parsed.Template.Settings["index.lifecycle.name"])
+			} else {
+				assert.NotContains(t, parsed.Template.Settings, "index.lifecycle.name")
+			}
+		}
+	}
+
+	span, service := GetSpanServiceDataStreamTemplates(3, 2, "", true, "")
+	assert.Contains(t, span, `"index_patterns":["jaeger-span-archive-ds"]`)
+	assert.Empty(t, service)
+}
+
 func TestElasticsearchTagsFileDoNotExist(t *testing.T) {
 	f := NewFactory()
 	mockConf := &mockClientBuilder{}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/olivere/elastic"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/pkg/es"
+	"github.com/jaegertracing/jaeger/pkg/es/config"
+)
+
+const (
+	// the first index behind the write alias, ILM increments the suffix on every rollover
+	initialIndexSuffix = "-000001"
+	// rolled over indices are named <alias base>-NNNNNN, which daily and archive indices never match
+	ilmIndexPattern    = "-0*"
+	ilmTemplateSuffix  = "-ilm"
+	ilmTemplateOrder   = 1
+	writeAliasSuffix   = "-write"
+	readAliasSuffix    = "-read"
+	alreadyExistsError = "resource_already_exists_exception"
+)
+
+var (
+	errILMNotSupported       = errors.New("index lifecycle management requires Elasticsearch 6.6 or later")
+	errILMRolloverMaxAgeZero = errors.New("the ILM rollover max age must be greater than zero")
+)
+
+// initIndexLifecycle creates the ILM policy and index templates and bootstraps
+// the first index with its read and write aliases, unless the write alias already exists.
+// Data streams only need the policy, their index templates refer to it.
+func initIndexLifecycle(client es.Client, cfg config.ClientBuilder, archive bool, logger *zap.Logger) error {
+	ilm := cfg.GetIndexLifecycle()
+	if ilm.RolloverMaxAge <= 0 {
+		return errILMRolloverMaxAgeZero
+	}
+	if !versionAtLeast(client, cfg, 6, 6) {
+		return errILMNotSupported
+	}
+	aliasBases := ilmAliasBases(cfg.GetIndexPrefix(), archive)
+	if cfg.IsCreateIndexTemplates() {
+		if _, err := client.CreateIlmPolicy(ilm.PolicyName).Body(ilmPolicy(ilm)).Do(context.Background()); err != nil {
+			return fmt.Errorf("failed to create ILM policy %s: %w", ilm.PolicyName, err)
+		}
+	}
+	if cfg.GetUseDataStreams() {
+		return nil
+	}
+	if cfg.IsCreateIndexTemplates() {
+		// the base templates must exist before the first index is created to get the mappings
+		spanMapping, serviceMapping := GetSpanServiceMappings(cfg.GetNumShards(), cfg.GetNumReplicas(), client.GetVersion())
+		templates := map[string]string{"jaeger-span": spanMapping}
+		if !archive {
+			templates["jaeger-service"] = serviceMapping
+		}
+		for name, template := range templates {
+			if _, err := client.CreateTemplate(name).Body(template).Do(context.Background()); err != nil {
+				return err
+			}
+		}
+		for _, base := range aliasBases {
+			if _, err := client.CreateTemplate(base + ilmTemplateSuffix).Body(ilmTemplate(base, ilm.PolicyName)).Do(context.Background()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, base := range aliasBases {
+		exists, err := client.IndexExists(base + writeAliasSuffix).Do(context.Background())
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		index := base + initialIndexSuffix
+		_, err = client.CreateIndex(index).Body(ilmInitialIndex(base)).Do(context.Background())
+		if isAlreadyExists(err) {
+			// another instance bootstrapped the aliases concurrently
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index, err)
+		}
+		logger.Info("Created index with read and write aliases", zap.String("index", index))
+	}
+	return nil
+}
+
+// ilmAliasBases returns the names the read and write aliases are derived from, e.g. jaeger-span-write.
+func ilmAliasBases(prefix string, archive bool) []string {
+	if prefix != "" {
+		prefix += "-"
+	}
+	if archive {
+		return []string{prefix + "jaeger-span-archive"}
+	}
+	return []string{prefix + "jaeger-span", prefix + "jaeger-service"}
+}
+
+func ilmPolicy(ilm config.IndexLifecycle) string {
+	phases := map[string]interface{}{
+		"hot": map[string]interface{}{
+			"min_age": "0ms",
+			"actions": map[string]interface{}{
+				"rollover": map[string]interface{}{"max_age": esDuration(ilm.RolloverMaxAge)},
+			},
+		},
+	}
+	if ilm.DeleteAfter > 0 {
+		phases["delete"] = map[string]interface{}{
+			"min_age": esDuration(ilm.DeleteAfter),
+			"actions": map[string]interface{}{"delete": map[string]interface{}{}},
+		}
+	}
+	return toJSON(map[string]interface{}{"policy": map[string]interface{}{"phases": phases}})
+}
+
+// ilmTemplate is merged on top of the base template of the rolled over indices
+// so that the indices created by ILM join the read alias and keep the policy.
+func ilmTemplate(base, policyName string) string {
+	return toJSON(map[string]interface{}{
+		"index_patterns": []string{base + ilmIndexPattern},
+		"order":          ilmTemplateOrder,
+		"settings": map[string]interface{}{
+			"index.lifecycle.name":           policyName,
+			"index.lifecycle.rollover_alias": base + writeAliasSuffix,
+		},
+		"aliases": map[string]interface{}{
+			base + readAliasSuffix: map[string]interface{}{},
+		},
+	})
+}
+
+func ilmInitialIndex(base string) string {
+	return toJSON(map[string]interface{}{
+		"aliases": map[string]interface{}{
+			base + readAliasSuffix:  map[string]interface{}{},
+			base + writeAliasSuffix: map[string]interface{}{"is_write_index": true},
+		},
+	})
+}
+
+func esDuration(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
+
+func isAlreadyExists(err error) bool {
+	var esErr *elastic.Error
+	return errors.As(err, &esErr) && esErr.Details != nil && esErr.Details.Type == alreadyExistsError
+}
+
+func toJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
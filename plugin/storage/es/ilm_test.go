@@ -0,0 +1,160 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package es
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	escfg "github.com/jaegertracing/jaeger/pkg/es/config"
+	"github.com/jaegertracing/jaeger/pkg/es/mocks"
+)
+
+type ilmMocks struct {
+	client   *mocks.Client
+	policy   *mocks.IlmPolicyCreateService
+	template *mocks.TemplateCreateService
+	exists   *mocks.IndicesExistsService
+	create   *mocks.IndicesCreateService
+}
+
+func newILMMocks(version uint, aliasExists bool, createErr error) *ilmMocks {
+	m := &ilmMocks{
+		client:   &mocks.Client{},
+		policy:   &mocks.IlmPolicyCreateService{},
+		template: &mocks.TemplateCreateService{},
+		exists:   &mocks.IndicesExistsService{},
+		create:   &mocks.IndicesCreateService{},
+	}
+	m.client.On("GetVersion").Return(version)
+	m.client.On("CreateIlmPolicy", mock.Anything).Return(m.policy)
+	m.policy.On("Body", mock.Anything).Return(m.policy)
+	m.policy.On("Do", mock.Anything).Return(nil, nil)
+	m.client.On("CreateTemplate", mock.Anything).Return(m.template)
+	m.template.On("Body", mock.Anything).Return(m.template)
+	m.template.On("Do", mock.Anything).Return(nil, nil)
+	m.client.On("IndexExists", mock.Anything).Return(m.exists)
+	m.exists.On("Do", mock.Anything).Return(aliasExists, nil)
+	m.client.On("CreateIndex", mock.Anything).Return(m.create)
+	m.create.On("Body", mock.Anything).Return(m.create)
+	m.create.On("Do", mock.Anything).Return(nil, createErr)
+	return m
+}
+
+func ilmConfig() *escfg.Configuration {
+	return &escfg.Configuration{
+		IndexPrefix:          "foo",
+		CreateIndexTemplates: true,
+		IndexLifecycle: escfg.IndexLifecycle{
+			Enabled:        true,
+			PolicyName:     "jaeger-ilm-policy",
+			RolloverMaxAge: 24 * time.Hour,
+			DeleteAfter:    72 * time.Hour,
+		},
+	}
+}
+
+func TestInitIndexLifecycle(t *testing.T) {
+	m := newILMMocks(7, false, nil)
+	assert.NoError(t, initIndexLifecycle(m.client, ilmConfig(), false, zap.NewNop()))
+
+	m.client.AssertCalled(t, "CreateIlmPolicy", "jaeger-ilm-policy")
+	m.policy.AssertCalled(t, "Body",
+		`{"policy":{"phases":{"delete":{"actions":{"delete":{}},"min_age":"259200s"},"hot":{"actions":{"rollover":{"max_age":"86400s"}},"min_age":"0ms"}}}}`)
+	m.client.AssertCalled(t, "CreateTemplate", "jaeger-span")
+	m.client.AssertCalled(t, "CreateTemplate", "jaeger-service")
+	m.client.AssertCalled(t, "CreateTemplate", "foo-jaeger-span-ilm")
+	m.client.AssertCalled(t, "CreateTemplate", "foo-jaeger-service-ilm")
+	m.template.AssertCalled(t, "Body",
+		`{"aliases":{"foo-jaeger-span-read":{}},"index_patterns":["foo-jaeger-span-0*"],"order":1,"settings":{"index.lifecycle.name":"jaeger-ilm-policy","index.lifecycle.rollover_alias":"foo-jaeger-span-write"}}`)
+	m.client.AssertCalled(t, "IndexExists", "foo-jaeger-span-write")
+	m.client.AssertCalled(t, "IndexExists", "foo-jaeger-service-write")
+	m.client.AssertCalled(t, "CreateIndex", "foo-jaeger-span-000001")
+	m.client.AssertCalled(t, "CreateIndex", "foo-jaeger-service-000001")
+	m.create.AssertCalled(t, "Body", `{"aliases":{"foo-jaeger-span-read":{},"foo-jaeger-span-write":{"is_write_index":true}}}`)
+}
+
+func TestInitIndexLifecycleArchive(t *testing.T) {
+	m := newILMMocks(6, false, nil)
+	cfg := ilmConfig()
+	cfg.IndexPrefix = ""
+	cfg.IndexLifecycle.DeleteAfter = 0
+	assert.NoError(t, initIndexLifecycle(m.client, cfg, true, zap.NewNop()))
+
+	m.policy.AssertCalled(t, "Body", `{"policy":{"phases":{"hot":{"actions":{"rollover":{"max_age":"86400s"}},"min_age":"0ms"}}}}`)
+	m.client.AssertCalled(t, "CreateTemplate", "jaeger-span")
+	m.client.AssertNotCalled(t, "CreateTemplate", "jaeger-service")
+	m.client.AssertCalled(t, "CreateTemplate", "jaeger-span-archive-ilm")
+	m.client.AssertCalled(t, "CreateIndex", "jaeger-span-archive-000001")
+}
+
+func TestInitIndexLifecycleExistingAliases(t *testing.T) {
+	m := newILMMocks(7, true, nil)
+	cfg := ilmConfig()
+	cfg.CreateIndexTemplates = false
+	assert.NoError(t, initIndexLifecycle(m.client, cfg, false, zap.NewNop()))
+	m.client.AssertNotCalled(t, "CreateIlmPolicy", mock.Anything)
+	m.client.AssertNotCalled(t, "CreateTemplate", mock.Anything)
+	m.client.AssertNotCalled(t, "CreateIndex", mock.Anything)
+}
+
+func TestInitIndexLifecycleDataStreams(t *testing.T) {
+	m := newILMMocks(7, false, nil)
+	cfg := ilmConfig()
+	cfg.UseDataStreams = true
+	assert.NoError(t, initIndexLifecycle(m.client, cfg, false, zap.NewNop()))
+	m.client.AssertCalled(t, "CreateIlmPolicy", "jaeger-ilm-policy")
+	m.client.AssertNotCalled(t, "CreateTemplate", mock.Anything)
+	m.client.AssertNotCalled(t, "CreateIndex", mock.Anything)
+}
+
+func TestInitIndexLifecycleErrors(t *testing.T) {
+	m := newILMMocks(5, false, nil)
+	assert.Equal(t, errILMNotSupported, initIndexLifecycle(m.client, ilmConfig(), false, zap.NewNop()))
+
+	minor := uint(5)
+	cfg := ilmConfig()
+	cfg.MinorVersion = &minor
+	m = newILMMocks(6, false, nil)
+	assert.Equal(t, errILMNotSupported, initIndexLifecycle(m.client, cfg, false, zap.NewNop()))
+
+	minor = 6
+	assert.NoError(t, initIndexLifecycle(m.client, cfg, false, zap.NewNop()))
+
+	cfg.IndexLifecycle.RolloverMaxAge = 0
+	assert.Equal(t, errILMRolloverMaxAgeZero, initIndexLifecycle(m.client, cfg, false, zap.NewNop()))
+
+	alreadyExists := &elastic.Error{Status: 400, Details: &elastic.ErrorDetails{Type: "resource_already_exists_exception"}}
+	m = newILMMocks(7, false, alreadyExists)
+	assert.NoError(t, initIndexLifecycle(m.client, ilmConfig(), false, zap.NewNop()))
+
+	m = newILMMocks(7, false, errors.New("made-up error"))
+	assert.EqualError(t, initIndexLifecycle(m.client, ilmConfig(), false, zap.NewNop()),
+		"failed to create index foo-jaeger-span-000001: made-up error")
+
+	m = &ilmMocks{client: &mocks.Client{}, policy: &mocks.IlmPolicyCreateService{}}
+	m.client.On("GetVersion").Return(uint(7))
+	m.client.On("CreateIlmPolicy", mock.Anything).Return(m.policy)
+	m.policy.On("Body", mock.Anything).Return(m.policy)
+	m.policy.On("Do", mock.Anything).Return(nil, errors.New("made-up error"))
+	assert.EqualError(t, initIndexLifecycle(m.client, ilmConfig(), false, zap.NewNop()),
+		"failed to create ILM policy jaeger-ilm-policy: made-up error")
+}
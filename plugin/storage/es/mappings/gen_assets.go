@@ -213,10 +213,10 @@ var _escData = map[string]*_escFile{
 		name:    ".nocover",
 		local:   "plugin/storage/es/mappings/.nocover",
 		size:    43,
-		modtime: 1597437395,
+		modtime: 1603898330,
 		compressed: `
-H4sIAAAAAAAC/youSSzJzFYoSEzOTkxPVcjILy4pVkgsLcnXTU/NSy1KLElNUUjLzEkt1uMCBAAA//8y
-IKK1KwAAAA==
+H4sIAAAAAAAC/youSSzJzFYoSEzOTkxPVcjILy4pVkgsLcnXTU/NSy1KLElNUUjLzEkt1uMCDAAyIKK1
+KwAAAA==
 `,
 	},
 
@@ -224,12 +224,12 @@ IKK1KwAAAA==
 		name:    "jaeger-dependencies-7.json",
 		local:   "plugin/storage/es/mappings/jaeger-dependencies-7.json",
 		size:    283,
-		modtime: 1597437395,
+		modtime: 1603898330,
 		compressed: `
 H4sIAAAAAAAC/2zPz0vDQBDF8Xv+imXxVNrFi5fcqlYU/EWK52GbfU1HknHdmYBQ8r9LRA/S3t/nC+9Y
 OedZEr4oRzMUUV87v3iP6FBWCRmSIC1DVwu/nNcKM5ZOfT3jPx5kHHYo9LEnPcSS5szFkej57el609DL
 HW3v183tlmhanmcFuec2nsJm8/r4cLM+oUPMmaULAjUk2jP6pKHngc3XV5f/tgWfI9Q0tLE9IEDiroev
-rYyonPvp+t/efGyqpuo7AAD//66cHf8bAQAA
+rYyonPvp+t/efGyqpup7AK6cHf8bAQAA
 `,
 	},
 
@@ -237,12 +237,12 @@ rYyonPvp+t/efGyqpuo7AAD//66cHf8bAQAA
 		name:    "jaeger-dependencies.json",
 		local:   "plugin/storage/es/mappings/jaeger-dependencies.json",
 		size:    277,
-		modtime: 1597437395,
+		modtime: 1603898330,
 		compressed: `
 H4sIAAAAAAAC/2zPzUoDMRTF8f08Rbi4Km1w4ya7qhUFv5ji+pJOTqeRTIy5d0Ao8+4y4kbG/fn94Zwb
 Y0gxlOQV5Ayt3j161E1AQQ7IXYRsVrSedwLVmHshNzNjKOaAL5vH4YDKH0eWk69ByJmLM/Pz29P1ruWX
 O97fb9vbPfO0/p9VlBQ7v4Tt7vXx4Wa7oIMvJebeZogi8DEiBbEpDlHJXV3+2VZ8jhAV2/nuBIvsDwnk
-tI5ojPnp0m9vPjY1U/MdAAD//5ZQx/QVAQAA
+tI5ojPnp0m9vPjY1U/M9AJZQx/QVAQAA
 `,
 	},
 
@@ -250,14 +250,30 @@ tI5ojPnp0m9vPjY1U/MdAAD//5ZQx/QVAQAA
 		name:    "jaeger-service-7.json",
 		local:   "plugin/storage/es/mappings/jaeger-service-7.json",
 		size:    878,
-		modtime: 1597437395,
+		modtime: 1603898330,
 		compressed: `
-H4sIAAAAAAAC/8ySwW7aQBCG736K1agnBFZViR72RluqVmppBcopikaDPdibeNeb3YEEIb97ZGTAhHDL
-IReP5H++35/t3SVKgXE5P6MnEQ4uglYwuCcuOIwih43JeDSAYbsYWcS4IoJuuQOZurVdcsB6hbGkkLcN
-n3aIs5u/36Zz/PcTF78m8x8LxGb4NhbYVyajS3A+/f/n9/fJBWrJe+OK1HEUznFluMpjWhlrBPT489lu
-4Mc1R4lpRlnJKTtaVgxawpoTpfa90PWdXizfOrImQ2HrKxKOoG/3iVK7brbfw5NDoSKiJX9gu6yrPL+r
-FMjWM2h44O1THXIYnqemcHVgpGW9YdBfxl97cdPfBU9SoiXJStAgVKQDOMZN8oroOftQZxzjh9DuXNJr
-+vt51/1NH2rPQQzHkxx0B3RGlvvK13Wvqh41oX0Miande7Qmh2uTNMlLAAAA//8YcMrbbgMAAA==
+H4sIAAAAAAAC/8ySz0/CMBTH7/srmhdPBBZjgofeUDGaKBqIJ2Oax/bYqmtX2wdKyP53MzIYiHjy4GUv
+ed8f+zTtKhICtE3pUzlkJm8DSAGdV6SMfC+QX+iEeh3o1sZAzNpmAWSd2yRjOzdT8qqcqZCjT+uGk5VS
+o6f7i+FYPVyryc1gfDVRqur+HPPkCp3gYXA8fLy7vRwcRA06p20WWwpMqZppKtIQF9poBtk/3fN6ep9T
+4BAnmOQUk8VpQSDZzykSYt0LTV97sHRp0ehEMRlXIFMA+bxWhFg1UwgIDq1izIIy6EC2Slu5vxUCeOkI
+JLzR8qP0KXT3VZ3Z0pPCabkgkGf98x252vWCQ86VQU5ykMCYxR3YylX0LbHD7HyZUAj/ArthiY/hr+dL
+c5vOl448awotHDQPdISG2u1vuEdRt5hQ/wZZl/YvWqPNt4qq6GsAGHDK224DAAA=
+`,
+	},
+
+	"/jaeger-service-datastream.json": {
+		name:    "jaeger-service-datastream.json",
+		local:   "plugin/storage/es/mappings/jaeger-service-datastream.json",
+		size:    1094,
+		modtime: 1792361531,
+		compressed: `
+H4sIAAAAAAAC/8xTTW/UMBC951dYI07VNkJI5eATBYpAgoK24oQqazaeTQzxB57ZwirKf0cp2SUbQk8c
+mkNijd97ee8l7gqlwAVLP01CEcqBQX8plFIKzr4i1ZTPmfKdq+jcMhRK3a4GikVBw5IJPeiuv58J+dSi
+EOjutwCTiAs1HwaHV5Vh5zeUTdwabjBbBv2kM+b684eXV2vz8Y25eXu5fn1jTL/6Fy9Tal2FfzHXV5/e
+v3t1ucD1mJILdRmIhazZOmotl63zTkBfPJ2hM33fEQuXFVYNlRRw0xJoyTu6B47qMKpOI9p9QO8qc6jj
+WOhwdceVUsAJgxGs2XhMoKd7f6Tnc6VA9olAwzfa/4jZwmq+7+oQMxncxDsC/ezi+QmgP8VDQmmMR6ka
+0CBYl2cwAfTFAu8kRcqxIuZHFmR0VT4UaFzdHr99yjFRFkc8NQsvxHliQT/LdvBvh59+aga2MXsU0EAp
+Vo3xrm0dw1KVMJ6ua/S0rL7UzgPNTLWHNCguhv+nXkyfw70v+uLXAArdHX9GBAAA
 `,
 	},
 
@@ -265,15 +281,15 @@ FMjWM2h44O1THXIYnqemcHVgpGW9YdBfxl97cdPfBU9SoiXJStAgVKQDOMZN8oroOftQZxzjh9DuXNJr
 		name:    "jaeger-service.json",
 		local:   "plugin/storage/es/mappings/jaeger-service.json",
 		size:    1060,
-		modtime: 1597437395,
+		modtime: 1603898330,
 		compressed: `
-H4sIAAAAAAAC/8yTT2/UMBDF7/kU1ojTamshpHLwrUARSFDQVpwQGs3Gs1mD7RjbKayqfHfk4pKkW+2J
-Q3PIn/F78/xz7NtGCMjsgqXMoASsvhN3HM8SxxvT8tkK1kWSOGfjuwSqOIQA4zX/ln5wW47Y7zDtKeoE
-Sjy7Rbz68vHV5QY/vcXrdxebN9eI4/pxW+RgTUvHxs3l5w/vX18cWR2FYHwnPafMGneGrU7SGmcyqPPn
-C23knwOnnGRL7Z4le9paBpXjwEc9OUp98ORMC2pHNnEjxF0y1MQJHTXvaLAZ7yulRtZOn0LA3zA9NStX
-RRECahbeL30C9fWfeWpTVj6Qx0xdQkdhHnE3Wif3sF5+6iEwKPjBh1991LB+OG4630dG2vY3DOrF+cuF
-YFzqIVDeo6Pc7kFBpk6uYCYYm0d8C4oQ+5ZTemIgdVbyFFB9+9bMukE9HbMNEGIfOGbDabENqvCKHC/R
-TmGdQJrhQAmkbHr//7o382e5j83Y/AkAAP//qd2MzCQEAAA=
+H4sIAAAAAAAC/8yTzW7UMBDH73mK0YjTahshpHLwrUARSFDQVpwQsmaT2azBdoxnUlhVeXeUkpKkW+2J
+Qy/5GP8//Iuc2wIAlUPypIwGcPWduOF8JpxvXMVnK1wPEmFVFxtBMzgA0MWaf5exC1vOtt1Z2VOuBQ08
+u7X26svHV5cb++mtvX53sXlzbW2/ftyWOXlX0bFxc/n5w/vXF0fWQCm52JSRRbm2O8e+ltK74BTN+fOF
+NvPPjkWlrKjac8mRtp7RaO74KJNzWR8iBVeh2ZEXLgDumnFsnNBtzTvqvNr7yTAj76dXAPxbVk9hAGPg
+3fLYZe8/vaD5+s88xQCgJIpWqREbKM0rAKbNPZwDoB4So8EffPjV5hrXD9ddE9vMlrbtDaN5cf5yIeiX
+ekykextIqz0aVGrKFc4EffGIb0GRcluxyBMDGXdVngIan74VszQc/47ZAUi5TZzVsSyOwSi8osBLtFNY
+J5BmODgUkro2/r/0Yn4frn3RF38GAKndjMwkBAAA
 `,
 	},
 
@@ -281,18 +297,38 @@ TmGdQJrhQAmkbHr//7o382e5j83Y/AkAAP//qd2MzCQEAAA=
 		name:    "jaeger-span-7.json",
 		local:   "plugin/storage/es/mappings/jaeger-span-7.json",
 		size:    3420,
-		modtime: 1597437395,
+		modtime: 1603898330,
+		compressed: `
+H4sIAAAAAAAC/+xWTW/TQBC9+1eMRpyq1EJI5eBboUVUogW15YTQamKPnS3eD3YnhajKf0cmaRPXdoqE
+gxDikig7+57f7M578V0CgNoW/F15EuFgI2aABzfEFYfD6MkeHuAEEgCMLKJtFTFrUPe41M7NlINypYoz
+CkWDf3an1MXH81enl+r9G3X19vjy5Eqp5aQfFtjXOqcu8PL0w7uz18cdqCHvta1Sy1G4UKXmuohprY0W
+zI6et/YG/jrnKDHNKZ9xypamNWMmYc4JwE9eXPNtGisWlozOlbDxNQlHzD4lAAAAd+tvAGwORwlVURny
+mG0qG8r2KgDKwjNm+IUX31wocNKu6sq6wIqm7pYxe3H0cqu83N6LnmSmDEk+wwyFqvQAH8rL5BFiS7MP
+LucY/wrZay3pkPwEAODz+jZ9cJ6DaI4bcSiBcj472azskjoo80Eiegps5cqTHZE0jkvXHAOJdvaCDI8n
+UijIte5nrJ2tcBhyrutaxz5gQcLbOkoXDAlmyN7lM2VWwA5zMV91+OtaypqqXgXaShNkXUTt+gGrTNkW
+vQ4DzEqqI09aXuqM5IpKG45Cxg/5aLuJrklWeTaE7QjcKXKnUACAZly6q09Z/knbd7oCAMBbquf8x54m
+VF0v/N6elwz9Wg4n8X3mtSZvcI4ih1ud82Ojj5rIQoNp76Y3nAvugv6f0n9ySgOXHNjmvLeIDFz2dT3e
+WHffDEbl7/6p/y79jut45NF+f7a27+3eenw42pn2+m7MoBt74gZeWZvPZbJMfgwAkrkG0FwNAAA=
+`,
+	},
+
+	"/jaeger-span-datastream.json": {
+		name:    "jaeger-span-datastream.json",
+		local:   "plugin/storage/es/mappings/jaeger-span-datastream.json",
+		size:    3898,
+		modtime: 1792361531,
 		compressed: `
-H4sIAAAAAAAC/+xWXW+UQBR951eQG5+aLTEm9YG3amtsYqtp65Mxk7twYaedL2fuVjcN/93A0hYKbE2k
-xhhflix3zuHcmXsO3EZxDNLk9EM4ZCZvAqQx7F0hleT3g0OzvweLuF4WiFmaMkBao+5wiVnrJXlhCxFW
-6PMa/+JWiLPPp2+Oz8XHd+Li/eH50YUQ1WIc5skpmeEQeH786cPJ28MBVKNz0pSJocCUi0KSykOipJYM
-6cHL3lpP39YUOCQZZitKyOBSEaTs1xTFccMLLd9DY/nGoJaZYNJOIVOA9EtTiePb9lrvh0MjGMsgNLo7
-bFtrKft34xh44whSuKbNd+tzWPSrsjTWk8ClvSFIXx287pSr7lpwyCuhkbMVpMBYJntwX66iR4iOZudt
-RiH8FbJbLcmU/Ob6tT1N560jz5LCgzhgjxmdHHXlTkudlHkvERx6Mnzh0MxIGualq7cBWVpzhprmE8no
-+VKOMyprSpiGnEqlZBgD5sjU1VFYr5EhBXI2Wwm9BQ6Y8/W2w1/XUigsRxVIw3WQDRHKjgO2mdIV3YYB
-pAWqQIuelwYjuaWSmgKjdlM+6jYxNMk2z6awA4E7Re4U2hSvaTO8+5Tln7T9oKsGcYNqTX/saYzlZUP7
-PM+Lpv5V00l8l3m9yZuco0D+Rmb02OizJjLjZNrb5RVlDLug/6f0n5xSTwV5Mhk9W0R6Ksa6nm+sh18G
-s/IPX+q/S7/jOB55dNyfveXPdm4jPpxtT0d9N2fQzT1xE5+s9W8VVdHPAAAA//+SuQbQXA0AAA==
+H4sIAAAAAAAC/+xXQWvbShC+61cMwzsFRzwe5B10enlNSgNNWpL0VMIylkbyptJquzt2a4z+e1Ftp7ay
+kluwSqG9GHl25ttvZme+lVYRAGqT8WdlSYSd8Zi8jwAA8OSRuGB36i2Z08zjJGAml870gtvlCOChdcGM
+hJQXx1Rhsmq+2oQrW5IwJqs1imcRbQq/NWxpxGZeTdmpOld+Ri7zmPy1Uurm3fX/l7fqzUt19+r89uJO
+qWbSF+fYljqlZ5G3l29fX704D8RWZK02RWzYC2cq11xmPi51pQWTs7873o4/ztmLj1NKZxyzoWnJmIib
+cwQAsEHHDepuitnSUKVTtS3HU7EBAFZPTwDYVlcJFV5VZDHZXfsG3bUDoCwtY4IfePmpdhlOuuu6MLVj
+RdN6wZj8c/bvnkOz74+WZKYqknSGCQoV8QnuODRRIG4vC+vqlL3/xRLZsIqHEto8PTydvXW1ZSea/S5Z
+/E90xV6o6uS25Z+1Tb9LBvPaVSSYINs6nalKl6X2GColiqOUry7CyKHKDFRlF9eSYyN3lswI4H4c2Lb6
+JLo2N1Tx8UkLObnXfchlbQocDrxen+PxmyCbr/P+UWZ5SUUPH22klfBwXFn3ha3lcT+RjaJhklPpedIZ
+/sDErAHDQ9OfWGim1zLdjxCge4DyAdoAAG2TheyHFes7VCuQJQAALqic80/fVai4X9qR942G/jeHLput
+lHf6daDzPLuFTvm5iIxw6QgNXGz19JFTwUMAf/r7t+5vxzk7NimPLsmO83A1jj0UoXeaEfYJvYYcY5uD
+R/Zs6vsmvhM0+gkHJ/vIVe+Z5OML6zi9Ovgt0ETb3yZqoi8DAA2LIgc6DwAA
 `,
 	},
 
@@ -300,19 +336,19 @@ s/IPX+q/S7/jOB55dNyfveXPdm4jPpxtT0d9N2fQzT1xE5+s9W8VVdHPAAAA//+SuQbQXA0AAA==
 		name:    "jaeger-span.json",
 		local:   "plugin/storage/es/mappings/jaeger-span.json",
 		size:    3830,
-		modtime: 1597437395,
+		modtime: 1603898330,
 		compressed: `
-H4sIAAAAAAAC/+xW0W/TPhB+z18RnX5PUxf9hDQe8jbYEJPYQNt4Qsi6JpfUm2Mb+zqopv7vKE1Lm9ZJ
-QGoQEvShbWx/391n333xcxTHwFRZhUyQxnDygFSSO/UW9ekJTOp5T8xSlx7Senkcg9Q5fUv0vJqSE6YQ
-foYu95DG/z0LcfPx+tXlrXj/Rty9Pb+9uBNiOQnDHFklMzwE3l5+eHf1+vwAWqG1UpeJJs+Ui0KSyn2i
-ZCUZ0rP/W2sdfZmTZ59kmM0oIY1TRZCym9MBJ7kkX2isZAZpgcpTFMeryLCOuJUucipwrlhsRuoxVGr7
-GMfQBMu3ZPVnLSWOYR1LbPbdQ/rpB3hLU++8RS0YSy8qtLshVrPr5PbH6xNdWIIUHmnx1bgcJvvzstTG
-kcCpeSJIX5y9bC1YtteDRZ6JCjmbQQqMZXICOwuWUQDXUmGdycj7P0zIOqukT9D63+doh211KDunb52x
-5FiSb9UAO8zo6qKtqU9Pj5YdHWDRkeY7i3oEcj8Obb1ByNLoG6zo+EkzOr6XXczK6BL6gddSKenD8Lw2
-xlZWhXEVMqRA1mQzUTXgYIR83uj+1cwKhWVHPlJzbdBhnDJdsMYy20LanjfZa9lAUTeEsiLPWNnudm0L
-C3ViY93dDIF0B1IeSHs1/UiL0Piwz/yE1wRUrlBPqOb026Mylvcr8jHjRn3Py6FXxMaA9+q1p/I8uSeZ
-0aGJjPCqYOx5HZnpA2UMQwT/6vuvrm9HBTnSGY1uyY6K8G4cuylCd5oR4oSuIccIM3hkB13f1fF7oNFP
-ONjZR971jk4+vrGOU6u9N/jmt/5eRsvoewAAAP//W45CgfYOAAA=
+H4sIAAAAAAAC/+xWUW/TMBB+z684nXiaugghjYe8DTbEJDbQNp4Qsq7JJfVwHGO7hWrKf0dZW9q0TgJS
+g5DgZVqu/r67z7777McIAD2XRpFnTABPHogLtqfOkD49wUkEgI69l7pwmDTLAVDqjL/Hel5O2YoqF25G
+NnOYwLNHIW4+Xr+6vBXv34i7t+e3F3dC1JMwzLJRMqVD4O3lh3dXr88PoCUZI3URa3aeM5FLVpmLlSyl
+x+TseWut5a9zdt7FKaUzjlnTVDEm3s75gJNtnC01lTLFJCflOAJ4yozrjFvpIuOc5sqLTaSJkVLbTwBc
+Jcu2ZABrQgAAXOcSm313mHz6Cd7SAGBzCsJT4URJZjcFwLa4/TgA+qVhTPALL79VNsPJ/u+y0JVlQdNq
+wZi8OHvZWlC316MhPxMl+XSGCXoq4hPcWVBHAVxLhbFVys79ZULWVcV9gtb/fY522J4OZef0ja0MWy/Z
+bYMA6C2lfHWxG+vX06NlRwcasqz9nSE9Arkbh7bZIPKy0jdU8vGL9mT9vexiVpUusB94LZWSLgzPGmNs
+VZVXtiSPCbKp0pkoV+Bghmy+0v27leWKio56pPaNQYdxquqCrSyzLaTteZO9kQ009YpQluw8laZ7XNvC
+QpO4su5uhkC5AyUPlA0A0DRZKD7sM7/gNQGVAAC4IDXnP57VU3G/NCPnjfq+66ErYmPAe/3a03mO7UKm
+fGgiI1wVnnquo2r6wKnHIYL//f1P97flnC3rlEe3ZMt5eDeOPRShN80IeULPkGOkGTyyg6nvmvg90Ogn
+HJzsI+96xyQf31jH6dXeF3wdbf7WUR39GABbjkKB9g4AAA==
 `,
 	},
 
@@ -330,8 +366,10 @@ var _escDirs = map[string][]os.FileInfo{
 		_escData["/jaeger-dependencies-7.json"],
 		_escData["/jaeger-dependencies.json"],
 		_escData["/jaeger-service-7.json"],
+		_escData["/jaeger-service-datastream.json"],
 		_escData["/jaeger-service.json"],
 		_escData["/jaeger-span-7.json"],
+		_escData["/jaeger-span-datastream.json"],
 		_escData["/jaeger-span.json"],
 	},
 }
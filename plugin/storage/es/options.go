@@ -51,15 +51,23 @@ const (
 	suffixTagsFile            = suffixTagsAsFields + ".config-file"
 	suffixTagDeDotChar        = suffixTagsAsFields + ".dot-replacement"
 	suffixReadAlias           = ".use-aliases"
+	suffixDataStreams         = ".use-data-streams"
 	suffixCreateIndexTemplate = ".create-index-templates"
 	suffixEnabled             = ".enabled"
 	suffixVersion             = ".version"
+	suffixDistribution        = ".distribution"
 	suffixMaxDocCount         = ".max-doc-count"
+	suffixUseILM              = ".use-ilm"
+	suffixILMPolicyName       = ".ilm.policy-name"
+	suffixILMRolloverMaxAge   = ".ilm.rollover-max-age"
+	suffixILMDeleteAfter      = ".ilm.delete-after"
 
 	// default number of documents to return from a query (elasticsearch allowed limit)
 	// see search.max_buckets and index.max_result_window
 	defaultMaxDocCount = 10_000
 	defaultServerURL   = "http://127.0.0.1:9200"
+
+	defaultILMRolloverMaxAge = 24 * time.Hour
 )
 
 // TODO this should be moved next to config.Configuration struct (maybe ./flags package)
@@ -102,6 +110,10 @@ func NewOptions(primaryNamespace string, otherNamespaces ...string) *Options {
 				Version:              0,
 				Servers:              []string{defaultServerURL},
 				MaxDocCount:          defaultMaxDocCount,
+				IndexLifecycle: config.IndexLifecycle{
+					PolicyName:     "jaeger-ilm-policy",
+					RolloverMaxAge: defaultILMRolloverMaxAge,
+				},
 			},
 			namespace: primaryNamespace,
 		},
@@ -109,7 +121,16 @@ func NewOptions(primaryNamespace string, otherNamespaces ...string) *Options {
 	}
 
 	for _, namespace := range otherNamespaces {
-		options.others[namespace] = &namespaceConfig{namespace: namespace}
+		options.others[namespace] = &namespaceConfig{
+			Configuration: config.Configuration{
+				// e.g. archived traces get their own policy which keeps them until deleted manually
+				IndexLifecycle: config.IndexLifecycle{
+					PolicyName:     "jaeger-" + strings.TrimPrefix(namespace, primaryNamespace+"-") + "-ilm-policy",
+					RolloverMaxAge: defaultILMRolloverMaxAge,
+				},
+			},
+			namespace: namespace,
+		}
 	}
 
 	return options
@@ -232,6 +253,30 @@ func addFlags(flagSet *flag.FlagSet, nsConfig *namespaceConfig) {
 		"Use read and write aliases for indices. Use this option with Elasticsearch rollover "+
 			"API. It requires an external component to create aliases before startup and then performing its management. "+
 			"Note that "+nsConfig.namespace+suffixMaxSpanAge+" is not taken into the account and has to be substituted by external component managing read alias.")
+	flagSet.Bool(
+		nsConfig.namespace+suffixDataStreams,
+		nsConfig.UseDataStreams,
+		"Store spans and services in the data streams \"jaeger-span-ds\" and \"jaeger-service-ds\", prefixed with the index prefix, instead of daily indices or aliases. "+
+			"Requires Elasticsearch 7.9 or later. Takes precedence over "+nsConfig.namespace+suffixReadAlias+", "+
+			"when "+nsConfig.namespace+suffixUseILM+" is also set the data streams are managed by the ILM policy.")
+	flagSet.Bool(
+		nsConfig.namespace+suffixUseILM,
+		nsConfig.IndexLifecycle.Enabled,
+		"Use Elasticsearch index lifecycle management (ILM) instead of esRollover.py and esCleaner.py. "+
+			"The policy and index templates are created at startup unless "+nsConfig.namespace+suffixCreateIndexTemplate+" is false, "+
+			"the first index and its read and write aliases are created if missing. Implies "+nsConfig.namespace+suffixReadAlias+". Requires Elasticsearch 6.6 or later.")
+	flagSet.String(
+		nsConfig.namespace+suffixILMPolicyName,
+		nsConfig.IndexLifecycle.PolicyName,
+		"The name of the ILM policy attached to the indices.")
+	flagSet.Duration(
+		nsConfig.namespace+suffixILMRolloverMaxAge,
+		nsConfig.IndexLifecycle.RolloverMaxAge,
+		"The age of the write index after which ILM rolls it over to a new index.")
+	flagSet.Duration(
+		nsConfig.namespace+suffixILMDeleteAfter,
+		nsConfig.IndexLifecycle.DeleteAfter,
+		"The age of a rolled over index after which ILM deletes it. Zero, the default, keeps the indices forever.")
 	flagSet.Bool(
 		nsConfig.namespace+suffixCreateIndexTemplate,
 		nsConfig.CreateIndexTemplates,
@@ -240,6 +285,10 @@ func addFlags(flagSet *flag.FlagSet, nsConfig *namespaceConfig) {
 		nsConfig.namespace+suffixVersion,
 		0,
 		"The major Elasticsearch version. If not specified, the value will be auto-detected from Elasticsearch.")
+	flagSet.String(
+		nsConfig.namespace+suffixDistribution,
+		"",
+		"The search engine distribution, elasticsearch or opensearch. If not specified, the value will be auto-detected together with the version.")
 	flagSet.Bool(
 		nsConfig.namespace+suffixSnifferTLSEnabled,
 		nsConfig.SnifferTLSEnabled,
@@ -286,9 +335,15 @@ func initFromViper(cfg *namespaceConfig, v *viper.Viper) {
 	cfg.Tags.File = v.GetString(cfg.namespace + suffixTagsFile)
 	cfg.Tags.DotReplacement = v.GetString(cfg.namespace + suffixTagDeDotChar)
 	cfg.UseReadWriteAliases = v.GetBool(cfg.namespace + suffixReadAlias)
+	cfg.UseDataStreams = v.GetBool(cfg.namespace + suffixDataStreams)
 	cfg.Enabled = v.GetBool(cfg.namespace + suffixEnabled)
 	cfg.CreateIndexTemplates = v.GetBool(cfg.namespace + suffixCreateIndexTemplate)
+	cfg.IndexLifecycle.Enabled = v.GetBool(cfg.namespace + suffixUseILM)
+	cfg.IndexLifecycle.PolicyName = v.GetString(cfg.namespace + suffixILMPolicyName)
+	cfg.IndexLifecycle.RolloverMaxAge = v.GetDuration(cfg.namespace + suffixILMRolloverMaxAge)
+	cfg.IndexLifecycle.DeleteAfter = v.GetDuration(cfg.namespace + suffixILMDeleteAfter)
 	cfg.Version = uint(v.GetInt(cfg.namespace + suffixVersion))
+	cfg.Distribution = v.GetString(cfg.namespace + suffixDistribution)
 
 	cfg.MaxDocCount = v.GetInt(cfg.namespace + suffixMaxDocCount)
 
@@ -65,6 +65,7 @@ func TestOptionsWithFlags(t *testing.T) {
 		"--es.tags-as-fields.include=test,tags",
 		"--es.tags-as-fields.config-file=./file.txt",
 		"--es.tags-as-fields.dot-replacement=!",
+		"--es.distribution=opensearch",
 	})
 	opts.InitFromViper(v)
 
@@ -81,6 +82,7 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, "!", primary.Tags.DotReplacement)
 	assert.Equal(t, "./file.txt", primary.Tags.File)
 	assert.Equal(t, "test,tags", primary.Tags.Include)
+	assert.Equal(t, "opensearch", primary.GetDistribution())
 
 	aux := opts.Get("es.aux")
 	assert.Equal(t, []string{"3.3.3.3", "4.4.4.4"}, aux.Servers)
@@ -148,3 +150,27 @@ func TestMaxDocCount(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexLifecycleOptions(t *testing.T) {
+	opts := NewOptions("es", "es-archive")
+	v, command := config.Viperize(opts.AddFlags)
+	command.ParseFlags([]string{
+		"--es.use-ilm=true",
+		"--es.ilm.rollover-max-age=12h",
+		"--es-archive.use-ilm=true",
+	})
+	opts.InitFromViper(v)
+
+	primary := opts.GetPrimary()
+	assert.True(t, primary.IndexLifecycle.Enabled)
+	assert.True(t, primary.GetUseReadWriteAliases())
+	assert.Equal(t, "jaeger-ilm-policy", primary.IndexLifecycle.PolicyName)
+	assert.Equal(t, 12*time.Hour, primary.IndexLifecycle.RolloverMaxAge)
+	assert.Zero(t, primary.IndexLifecycle.DeleteAfter)
+
+	archive := opts.Get("es-archive")
+	assert.True(t, archive.IndexLifecycle.Enabled)
+	assert.Equal(t, "jaeger-archive-ilm-policy", archive.IndexLifecycle.PolicyName)
+	assert.Equal(t, 24*time.Hour, archive.IndexLifecycle.RolloverMaxAge)
+	assert.Zero(t, archive.IndexLifecycle.DeleteAfter)
+}
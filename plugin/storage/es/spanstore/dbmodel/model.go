@@ -99,3 +99,15 @@ type Service struct {
 	ServiceName   string `json:"serviceName"`
 	OperationName string `json:"operationName"`
 }
+
+// DataStreamSpan is the JSON struct for spans in a data stream, which requires every document to have a timestamp
+type DataStreamSpan struct {
+	*Span
+	Timestamp uint64 `json:"@timestamp"` // milliseconds
+}
+
+// DataStreamService is the JSON struct for service:operation documents in a data stream
+type DataStreamService struct {
+	Service
+	Timestamp uint64 `json:"@timestamp"` // milliseconds
+}
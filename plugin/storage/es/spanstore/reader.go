@@ -42,12 +42,14 @@ const (
 	archiveIndexSuffix      = "archive"
 	archiveReadIndexSuffix  = archiveIndexSuffix + "-read"
 	archiveWriteIndexSuffix = archiveIndexSuffix + "-write"
+	dataStreamSuffix        = "ds"
 	traceIDAggregation      = "traceIDs"
 	indexPrefixSeparator    = "-"
 
 	traceIDField           = "traceID"
 	durationField          = "duration"
 	startTimeField         = "startTime"
+	timestampField         = "@timestamp"
 	serviceNameField       = "process.serviceName"
 	operationNameField     = "operationName"
 	objectTagsField        = "tag"
@@ -101,6 +103,9 @@ type SpanReader struct {
 	timeRangeIndices        timeRangeIndexFn
 	sourceFn                sourceFn
 	maxDocCount             int
+	// data streams are not split by date, so queries filter by time to let Elasticsearch skip the shards out of range.
+	// Archived traces are read regardless of their age.
+	useDataStreams bool
 }
 
 // SpanReaderParams holds constructor params for NewSpanReader
@@ -114,6 +119,7 @@ type SpanReaderParams struct {
 	TagDotReplacement   string
 	Archive             bool
 	UseReadWriteAliases bool
+	UseDataStreams      bool
 }
 
 // NewSpanReader returns a new SpanReader with a metrics.
@@ -126,9 +132,10 @@ func NewSpanReader(p SpanReaderParams) *SpanReader {
 		spanIndexPrefix:         indexNames(p.IndexPrefix, spanIndex),
 		serviceIndexPrefix:      indexNames(p.IndexPrefix, serviceIndex),
 		spanConverter:           dbmodel.NewToDomain(p.TagDotReplacement),
-		timeRangeIndices:        getTimeRangeIndexFn(p.Archive, p.UseReadWriteAliases),
+		timeRangeIndices:        getTimeRangeIndexFn(p.Archive, p.UseReadWriteAliases, p.UseDataStreams),
 		sourceFn:                getSourceFn(p.Archive, p.MaxDocCount),
 		maxDocCount:             p.MaxDocCount,
+		useDataStreams:          p.UseDataStreams && !p.Archive,
 	}
 }
 
@@ -136,7 +143,12 @@ type timeRangeIndexFn func(indexName string, startTime time.Time, endTime time.T
 
 type sourceFn func(query elastic.Query, nextTime uint64) *elastic.SearchSource
 
-func getTimeRangeIndexFn(archive, useReadWriteAliases bool) timeRangeIndexFn {
+func getTimeRangeIndexFn(archive, useReadWriteAliases, useDataStreams bool) timeRangeIndexFn {
+	if useDataStreams {
+		return func(indexName string, startTime time.Time, endTime time.Time) []string {
+			return []string{dataStreamName(indexName, archive)}
+		}
+	}
 	if archive {
 		var archivePrefix string
 		if useReadWriteAliases {
@@ -184,6 +196,24 @@ func timeRangeIndices(indexName string, startTime time.Time, endTime time.Time)
 	return indices
 }
 
+// DataStreamNames returns the names of the span and service data streams, the archive has no service data stream.
+func DataStreamNames(indexPrefix string, archive bool) (string, string) {
+	if indexPrefix != "" {
+		indexPrefix += indexPrefixSeparator
+	}
+	if archive {
+		return dataStreamName(indexPrefix+spanIndex, true), ""
+	}
+	return dataStreamName(indexPrefix+spanIndex, false), dataStreamName(indexPrefix+serviceIndex, false)
+}
+
+func dataStreamName(indexName string, archive bool) string {
+	if archive {
+		return archiveIndex(indexName, archiveIndexSuffix) + indexPrefixSeparator + dataStreamSuffix
+	}
+	return indexName + dataStreamSuffix
+}
+
 func indexNames(prefix, index string) string {
 	if prefix != "" {
 		return prefix + indexPrefixSeparator + index
@@ -242,7 +272,7 @@ func (s *SpanReader) GetServices(ctx context.Context) ([]string, error) {
 	defer span.Finish()
 	currentTime := time.Now()
 	jaegerIndices := s.timeRangeIndices(s.serviceIndexPrefix, currentTime.Add(-s.maxSpanAge), currentTime)
-	return s.serviceOperationStorage.getServices(ctx, jaegerIndices, s.maxDocCount)
+	return s.serviceOperationStorage.getServices(ctx, jaegerIndices, s.buildServiceTimeFilter(currentTime), s.maxDocCount)
 }
 
 // GetOperations returns all operations for a specific service traced by Jaeger
@@ -254,7 +284,7 @@ func (s *SpanReader) GetOperations(
 	defer span.Finish()
 	currentTime := time.Now()
 	jaegerIndices := s.timeRangeIndices(s.serviceIndexPrefix, currentTime.Add(-s.maxSpanAge), currentTime)
-	operations, err := s.serviceOperationStorage.getOperations(ctx, jaegerIndices, query.ServiceName, s.maxDocCount)
+	operations, err := s.serviceOperationStorage.getOperations(ctx, jaegerIndices, s.buildServiceTimeFilter(currentTime), query.ServiceName, s.maxDocCount)
 	if err != nil {
 		return nil, err
 	}
@@ -339,6 +369,9 @@ func (s *SpanReader) multiRead(ctx context.Context, traceIDs []model.TraceID, st
 		searchRequests := make([]*elastic.SearchRequest, len(traceIDs))
 		for i, traceID := range traceIDs {
 			query := buildTraceByIDQuery(traceID)
+			if s.useDataStreams {
+				query = elastic.NewBoolQuery().Must(query).Filter(s.buildStartTimeQuery(startTime.Add(-time.Hour), endTime.Add(time.Hour)))
+			}
 			if val, ok := searchAfterTime[traceID]; ok {
 				nextTime = val
 			}
@@ -439,6 +472,9 @@ func validateQuery(p *spanstore.TraceQueryParameters) error {
 	if p == nil {
 		return ErrMalformedRequestObject
 	}
+	if len(p.TagKeys) > 0 || len(p.TagValues) > 0 {
+		return spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	if p.ServiceName == "" && len(p.Tags) > 0 {
 		return ErrServiceNameNotSet
 	}
@@ -596,6 +632,16 @@ func (s *SpanReader) buildStartTimeQuery(startTimeMin time.Time, startTimeMax ti
 	return elastic.NewRangeQuery(startTimeField).Gte(minStartTimeMicros).Lte(maxStartTimeMicros)
 }
 
+// buildServiceTimeFilter returns the filter limiting service:operation documents to the lookback, if they are not in daily indices
+func (s *SpanReader) buildServiceTimeFilter(currentTime time.Time) elastic.Query {
+	if !s.useDataStreams {
+		return nil
+	}
+	return elastic.NewRangeQuery(timestampField).
+		Gte(model.TimeAsEpochMicroseconds(currentTime.Add(-s.maxSpanAge)) / 1000).
+		Lte(model.TimeAsEpochMicroseconds(currentTime) / 1000)
+}
+
 func (s *SpanReader) buildServiceNameQuery(serviceName string) elastic.Query {
 	return elastic.NewMatchQuery(serviceNameField, serviceName)
 }
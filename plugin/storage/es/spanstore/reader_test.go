@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -173,6 +174,12 @@ func TestSpanReaderIndices(t *testing.T) {
 		{params: SpanReaderParams{Client: client, Logger: logger, MetricsFactory: metricsFactory,
 			IndexPrefix: "foo:", Archive: true, UseReadWriteAliases: true},
 			index: "foo:" + indexPrefixSeparator + spanIndex + archiveReadIndexSuffix},
+		{params: SpanReaderParams{Client: client, Logger: logger, MetricsFactory: metricsFactory,
+			IndexPrefix: "foo:", UseDataStreams: true, UseReadWriteAliases: true},
+			index: "foo:-jaeger-span-ds"},
+		{params: SpanReaderParams{Client: client, Logger: logger, MetricsFactory: metricsFactory,
+			IndexPrefix: "", Archive: true, UseDataStreams: true},
+			index: "jaeger-span-archive-ds"},
 	}
 	for _, testCase := range testCases {
 		r := NewSpanReader(testCase.params)
@@ -909,6 +916,10 @@ func TestTraceQueryParameterValidation(t *testing.T) {
 	tqp.DurationMax = time.Minute
 	err = validateQuery(tqp)
 	assert.EqualError(t, err, ErrDurationMinGreaterThanMax.Error())
+
+	tqp.TagValues = map[string][]string{"hello": {"world"}}
+	err = validateQuery(tqp)
+	assert.Equal(t, spanstore.ErrTagKeysAndValuesNotSupported, err)
 }
 
 func TestSpanReader_buildTraceIDAggregation(t *testing.T) {
@@ -1146,6 +1157,46 @@ func TestSpanReader_ArchiveTraces_ReadAlias(t *testing.T) {
 	})
 }
 
+func TestSpanReader_DataStreamTimeFilters(t *testing.T) {
+	client := &mocks.Client{}
+	reader := NewSpanReader(SpanReaderParams{
+		Client:         client,
+		Logger:         zap.NewNop(),
+		MaxSpanAge:     time.Hour,
+		MaxDocCount:    defaultMaxDocCount,
+		UseDataStreams: true,
+	})
+	searchService := &mocks.SearchService{}
+	searchService.On("Size", mock.Anything).Return(searchService)
+	searchService.On("IgnoreUnavailable", mock.Anything).Return(searchService)
+	searchService.On("Aggregation", mock.Anything, mock.Anything).Return(searchService)
+	searchService.On("Query", mock.Anything).Return(searchService)
+	searchService.On("Do", mock.Anything).Return(&elastic.SearchResult{}, nil)
+	client.On("Search", "jaeger-service-ds").Return(searchService)
+	multiSearchService := &mocks.MultiSearchService{}
+	multiSearchService.On("Add", mock.Anything).Return(multiSearchService)
+	multiSearchService.On("Index", "jaeger-span-ds").Return(multiSearchService)
+	multiSearchService.On("Do", mock.Anything).Return(&elastic.MultiSearchResult{}, nil)
+	client.On("MultiSearch").Return(multiSearchService)
+
+	_, err := reader.GetServices(context.Background())
+	require.NoError(t, err)
+	searchService.AssertCalled(t, "Query", mock.AnythingOfType("*elastic.RangeQuery"))
+
+	_, err = reader.GetOperations(context.Background(), spanstore.OperationQueryParameters{ServiceName: "svc"})
+	require.NoError(t, err)
+	searchService.AssertCalled(t, "Query", mock.AnythingOfType("*elastic.BoolQuery"))
+
+	_, err = reader.GetTrace(context.Background(), model.NewTraceID(0, 1))
+	assert.Equal(t, spanstore.ErrTraceNotFound, err)
+	multiSearchService.AssertCalled(t, "Add", mock.MatchedBy(func(request *elastic.SearchRequest) bool {
+		source, err := request.Body()
+		require.NoError(t, err)
+		// the trace ID query is restricted to the lookback so that Elasticsearch can skip the shards out of range
+		return strings.Contains(source, `"filter":{"range":{"startTime"`)
+	}))
+}
+
 func TestConvertTraceIDsStringsToModels(t *testing.T) {
 	ids, err := convertTraceIDsStringsToModels([]string{"1", "2", "01", "02", "001", "002"})
 	require.NoError(t, err)
@@ -77,13 +77,35 @@ func (s *ServiceOperationStorage) Write(indexName string, jsonSpan *dbmodel.Span
 	}
 }
 
-func (s *ServiceOperationStorage) getServices(context context.Context, indices []string, maxDocCount int) ([]string, error) {
+// WriteToDataStream saves a service to operation pair into a data stream.
+// Documents in a data stream can only be created, so the ID is left to Elasticsearch
+// instead of failing on the pairs already written before the cache expired.
+func (s *ServiceOperationStorage) WriteToDataStream(dataStream string, jsonSpan *dbmodel.Span) {
+	service := dbmodel.DataStreamService{
+		Service: dbmodel.Service{
+			ServiceName:   jsonSpan.Process.ServiceName,
+			OperationName: jsonSpan.OperationName,
+		},
+		Timestamp: jsonSpan.StartTimeMillis,
+	}
+
+	cacheKey := hashCode(service.Service)
+	if !keyInCache(cacheKey, s.serviceCache) {
+		s.client.Index().Index(dataStream).Type(serviceType).OpType(createOpType).BodyJson(service).Add()
+		writeCache(cacheKey, s.serviceCache)
+	}
+}
+
+func (s *ServiceOperationStorage) getServices(context context.Context, indices []string, timeFilter elastic.Query, maxDocCount int) ([]string, error) {
 	serviceAggregation := getServicesAggregation(maxDocCount)
 
 	searchService := s.client.Search(indices...).
 		Size(0). // set to 0 because we don't want actual documents.
 		IgnoreUnavailable(true).
 		Aggregation(servicesAggregation, serviceAggregation)
+	if timeFilter != nil {
+		searchService = searchService.Query(timeFilter)
+	}
 
 	searchResult, err := searchService.Do(context)
 	if err != nil {
@@ -106,8 +128,11 @@ func getServicesAggregation(maxDocCount int) elastic.Query {
 		Size(maxDocCount) // ES deprecated size omission for aggregating all. https://github.com/elastic/elasticsearch/issues/18838
 }
 
-func (s *ServiceOperationStorage) getOperations(context context.Context, indices []string, service string, maxDocCount int) ([]string, error) {
-	serviceQuery := elastic.NewTermQuery(serviceName, service)
+func (s *ServiceOperationStorage) getOperations(context context.Context, indices []string, timeFilter elastic.Query, service string, maxDocCount int) ([]string, error) {
+	var serviceQuery elastic.Query = elastic.NewTermQuery(serviceName, service)
+	if timeFilter != nil {
+		serviceQuery = elastic.NewBoolQuery().Must(serviceQuery).Filter(timeFilter)
+	}
 	serviceFilter := getOperationsAggregation(maxDocCount)
 
 	searchService := s.client.Search(indices...).
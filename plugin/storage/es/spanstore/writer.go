@@ -32,6 +32,9 @@ import (
 const (
 	spanType    = "span"
 	serviceType = "service"
+
+	// data streams only accept the create operation
+	createOpType = "create"
 )
 
 type spanWriterMetrics struct {
@@ -49,6 +52,8 @@ type SpanWriter struct {
 	serviceWriter    serviceWriter
 	spanConverter    dbmodel.FromDomain
 	spanServiceIndex spanAndServiceIndexFn
+	useDataStreams   bool
+	dataStreams      [2]string
 }
 
 // SpanWriterParams holds constructor parameters for NewSpanWriter
@@ -62,19 +67,26 @@ type SpanWriterParams struct {
 	TagDotReplacement   string
 	Archive             bool
 	UseReadWriteAliases bool
+	UseDataStreams      bool
 }
 
 // NewSpanWriter creates a new SpanWriter for use
 func NewSpanWriter(p SpanWriterParams) *SpanWriter {
 	// TODO: Configurable TTL
 	serviceOperationStorage := NewServiceOperationStorage(p.Client, p.Logger, time.Hour*12)
+	serviceWriter := serviceOperationStorage.Write
+	if p.UseDataStreams {
+		serviceWriter = serviceOperationStorage.WriteToDataStream
+	}
+	var dataStreams [2]string
+	dataStreams[0], dataStreams[1] = DataStreamNames(p.IndexPrefix, p.Archive)
 	return &SpanWriter{
 		client: p.Client,
 		logger: p.Logger,
 		writerMetrics: spanWriterMetrics{
 			indexCreate: storageMetrics.NewWriteMetrics(p.MetricsFactory, "index_create"),
 		},
-		serviceWriter: serviceOperationStorage.Write,
+		serviceWriter: serviceWriter,
 		indexCache: cache.NewLRUWithOptions(
 			5,
 			&cache.Options{
@@ -82,7 +94,9 @@ func NewSpanWriter(p SpanWriterParams) *SpanWriter {
 			},
 		),
 		spanConverter:    dbmodel.NewFromDomain(p.AllTagsAsFields, p.TagKeysAsFields, p.TagDotReplacement),
-		spanServiceIndex: getSpanAndServiceIndexFn(p.Archive, p.UseReadWriteAliases, p.IndexPrefix),
+		spanServiceIndex: getSpanAndServiceIndexFn(p.Archive, p.UseReadWriteAliases, p.UseDataStreams, p.IndexPrefix),
+		useDataStreams:   p.UseDataStreams,
+		dataStreams:      dataStreams,
 	}
 }
 
@@ -99,15 +113,40 @@ func (s *SpanWriter) CreateTemplates(spanTemplate, serviceTemplate string) error
 	return nil
 }
 
+// CreateDataStreamTemplates creates the composable index templates of the span and service data streams.
+// Each template is named after its data stream, so prefixed and archive stores keep their own templates.
+func (s *SpanWriter) CreateDataStreamTemplates(spanTemplate, serviceTemplate string) error {
+	templates := [2]string{spanTemplate, serviceTemplate}
+	for i, dataStream := range s.dataStreams {
+		if dataStream == "" {
+			continue
+		}
+		if _, err := s.client.CreateIndexTemplate(dataStream).Body(templates[i]).Do(context.Background()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // spanAndServiceIndexFn returns names of span and service indices
 type spanAndServiceIndexFn func(spanTime time.Time) (string, string)
 
-func getSpanAndServiceIndexFn(archive, useReadWriteAliases bool, prefix string) spanAndServiceIndexFn {
+func getSpanAndServiceIndexFn(archive, useReadWriteAliases, useDataStreams bool, prefix string) spanAndServiceIndexFn {
 	if prefix != "" {
 		prefix += indexPrefixSeparator
 	}
 	spanIndexPrefix := prefix + spanIndex
 	serviceIndexPrefix := prefix + serviceIndex
+	if useDataStreams {
+		spanDataStream := dataStreamName(spanIndexPrefix, archive)
+		serviceDataStream := dataStreamName(serviceIndexPrefix, false)
+		return func(date time.Time) (string, string) {
+			if archive {
+				return spanDataStream, ""
+			}
+			return spanDataStream, serviceDataStream
+		}
+	}
 	if archive {
 		return func(date time.Time) (string, string) {
 			if useReadWriteAliases {
@@ -156,5 +195,10 @@ func (s *SpanWriter) writeService(indexName string, jsonSpan *dbmodel.Span) {
 }
 
 func (s *SpanWriter) writeSpan(indexName string, jsonSpan *dbmodel.Span) {
+	if s.useDataStreams {
+		dsSpan := &dbmodel.DataStreamSpan{Span: jsonSpan, Timestamp: jsonSpan.StartTimeMillis}
+		s.client.Index().Index(indexName).Type(spanType).OpType(createOpType).BodyJson(dsSpan).Add()
+		return
+	}
 	s.client.Index().Index(indexName).Type(spanType).BodyJson(&jsonSpan).Add()
 }
@@ -88,6 +88,12 @@ func TestSpanWriterIndices(t *testing.T) {
 		{params: SpanWriterParams{Client: client, Logger: logger, MetricsFactory: metricsFactory,
 			IndexPrefix: "foo:", Archive: true, UseReadWriteAliases: true},
 			indices: []string{"foo:" + indexPrefixSeparator + spanIndex + archiveWriteIndexSuffix, ""}},
+		{params: SpanWriterParams{Client: client, Logger: logger, MetricsFactory: metricsFactory,
+			IndexPrefix: "", UseDataStreams: true, UseReadWriteAliases: true},
+			indices: []string{"jaeger-span-ds", "jaeger-service-ds"}},
+		{params: SpanWriterParams{Client: client, Logger: logger, MetricsFactory: metricsFactory,
+			IndexPrefix: "foo:", Archive: true, UseDataStreams: true},
+			indices: []string{"foo:-jaeger-span-archive-ds", ""}},
 	}
 	for _, testCase := range testCases {
 		w := NewSpanWriter(testCase.params)
@@ -302,6 +308,99 @@ func TestWriteSpanInternalError(t *testing.T) {
 	})
 }
 
+func TestWriteSpanToDataStream(t *testing.T) {
+	client := &mocks.Client{}
+	writer := NewSpanWriter(SpanWriterParams{Client: client, Logger: zap.NewNop(), MetricsFactory: metricstest.NewFactory(0), UseDataStreams: true})
+	indexService := &mocks.IndexService{}
+	indexService.On("Index", mock.Anything).Return(indexService)
+	indexService.On("Type", mock.Anything).Return(indexService)
+	indexService.On("OpType", createOpType).Return(indexService)
+	indexService.On("BodyJson", mock.Anything).Return(indexService)
+	indexService.On("Add")
+	client.On("Index").Return(indexService)
+
+	date := time.Date(1995, 4, 21, 22, 8, 41, 0, time.UTC)
+	err := writer.WriteSpan(context.Background(), &model.Span{
+		TraceID:       model.NewTraceID(0, 1),
+		OperationName: "operation",
+		Process:       model.NewProcess("service", nil),
+		StartTime:     date,
+	})
+	require.NoError(t, err)
+
+	indexService.AssertCalled(t, "Index", "jaeger-span-ds")
+	indexService.AssertCalled(t, "Index", "jaeger-service-ds")
+	indexService.AssertNumberOfCalls(t, "OpType", 2)
+	// Elasticsearch generates the IDs of the service documents in data streams
+	indexService.AssertNotCalled(t, "Id", mock.Anything)
+	millis := uint64(date.UnixNano() / int64(time.Millisecond))
+	indexService.AssertCalled(t, "BodyJson", dbmodel.DataStreamService{
+		Service:   dbmodel.Service{ServiceName: "service", OperationName: "operation"},
+		Timestamp: millis,
+	})
+	indexService.AssertCalled(t, "BodyJson", mock.MatchedBy(func(span *dbmodel.DataStreamSpan) bool {
+		return span.Timestamp == millis && span.OperationName == "operation"
+	}))
+}
+
+func TestCreateDataStreamTemplates(t *testing.T) {
+	tests := []struct {
+		err          string
+		spanError    error
+		serviceError error
+	}{
+		{},
+		{err: "span-template-error", spanError: errors.New("span-template-error")},
+		{err: "service-template-error", serviceError: errors.New("service-template-error")},
+	}
+	for _, test := range tests {
+		withSpanWriter(func(w *spanWriterTest) {
+			spanTemplate := &mocks.TemplateCreateService{}
+			spanTemplate.On("Body", mock.Anything).Return(spanTemplate)
+			spanTemplate.On("Do", mock.Anything).Return(nil, test.spanError)
+			serviceTemplate := &mocks.TemplateCreateService{}
+			serviceTemplate.On("Body", mock.Anything).Return(serviceTemplate)
+			serviceTemplate.On("Do", mock.Anything).Return(nil, test.serviceError)
+			w.client.On("CreateIndexTemplate", "jaeger-span-ds").Return(spanTemplate)
+			w.client.On("CreateIndexTemplate", "jaeger-service-ds").Return(serviceTemplate)
+
+			err := w.writer.CreateDataStreamTemplates(mock.Anything, mock.Anything)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateDataStreamTemplatesNames(t *testing.T) {
+	testCases := []struct {
+		prefix    string
+		archive   bool
+		templates []string
+	}{
+		{prefix: "foo", templates: []string{"foo-jaeger-span-ds", "foo-jaeger-service-ds"}},
+		{archive: true, templates: []string{"jaeger-span-archive-ds"}},
+		{prefix: "foo", archive: true, templates: []string{"foo-jaeger-span-archive-ds"}},
+	}
+	for _, testCase := range testCases {
+		client := &mocks.Client{}
+		template := &mocks.TemplateCreateService{}
+		template.On("Body", mock.Anything).Return(template)
+		template.On("Do", mock.Anything).Return(nil, nil)
+		client.On("CreateIndexTemplate", mock.Anything).Return(template)
+		writer := NewSpanWriter(SpanWriterParams{Client: client, Logger: zap.NewNop(), MetricsFactory: metricstest.NewFactory(0),
+			IndexPrefix: testCase.prefix, Archive: testCase.archive, UseDataStreams: true})
+
+		require.NoError(t, writer.CreateDataStreamTemplates("span", "service"))
+		client.AssertNumberOfCalls(t, "CreateIndexTemplate", len(testCase.templates))
+		for _, name := range testCase.templates {
+			client.AssertCalled(t, "CreateIndexTemplate", name)
+		}
+	}
+}
+
 func TestNewSpanTags(t *testing.T) {
 	client := &mocks.Client{}
 	logger, _ := testutils.NewLogger()
@@ -19,6 +19,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 
 	"github.com/spf13/viper"
 	"github.com/uber/jaeger-lib/metrics"
@@ -29,11 +30,13 @@ import (
 	"github.com/jaegertracing/jaeger/plugin/storage/badger"
 	"github.com/jaegertracing/jaeger/plugin/storage/cassandra"
 	"github.com/jaegertracing/jaeger/plugin/storage/es"
+	"github.com/jaegertracing/jaeger/plugin/storage/forward"
 	"github.com/jaegertracing/jaeger/plugin/storage/grpc"
 	"github.com/jaegertracing/jaeger/plugin/storage/kafka"
 	"github.com/jaegertracing/jaeger/plugin/storage/memory"
 	"github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -44,6 +47,7 @@ const (
 	kafkaStorageType         = "kafka"
 	grpcPluginStorageType    = "grpc-plugin"
 	badgerStorageType        = "badger"
+	forwardStorageType       = "forward"
 	downsamplingRatio        = "downsampling.ratio"
 	downsamplingHashSalt     = "downsampling.hashsalt"
 	spanStorageType          = "span-storage-type"
@@ -55,7 +59,7 @@ const (
 )
 
 // AllStorageTypes defines all available storage backends
-var AllStorageTypes = []string{cassandraStorageType, elasticsearchStorageType, memoryStorageType, kafkaStorageType, badgerStorageType, grpcPluginStorageType}
+var AllStorageTypes = []string{cassandraStorageType, elasticsearchStorageType, memoryStorageType, kafkaStorageType, badgerStorageType, grpcPluginStorageType, forwardStorageType}
 
 // Factory implements storage.Factory interface as a meta-factory for storage components.
 type Factory struct {
@@ -99,6 +103,8 @@ func (f *Factory) getFactoryOfType(factoryType string) (storage.Factory, error)
 		return badger.NewFactory(), nil
 	case grpcPluginStorageType:
 		return grpc.NewFactory(), nil
+	case forwardStorageType:
+		return forward.NewFactory(), nil
 	default:
 		return nil, fmt.Errorf("unknown storage type %s. Valid types are %v", factoryType, AllStorageTypes)
 	}
@@ -235,6 +241,41 @@ func (f *Factory) CreateArchiveSpanWriter() (spanstore.Writer, error) {
 	return archive.CreateArchiveSpanWriter()
 }
 
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *Factory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	factory, ok := f.factories[f.DependenciesStorageType]
+	if !ok {
+		return nil, fmt.Errorf("no %s backend registered for span store", f.DependenciesStorageType)
+	}
+	writer, ok := factory.(storage.DependencyWriterFactory)
+	if !ok {
+		return nil, storage.ErrDependencyWriterNotSupported
+	}
+	return writer.CreateDependencyWriter()
+}
+
+// CreateSamplingStore implements storage.SamplingStoreFactory
+func (f *Factory) CreateSamplingStore() (samplingstore.Store, error) {
+	factory, ok := f.factories[f.SpanWriterTypes[0]]
+	if !ok {
+		return nil, fmt.Errorf("no %s backend registered for span store", f.SpanWriterTypes[0])
+	}
+	sampling, ok := factory.(storage.SamplingStoreFactory)
+	if !ok {
+		return nil, storage.ErrSamplingStoreNotSupported
+	}
+	return sampling.CreateSamplingStore()
+}
+
+// RegisterAdminHandlers implements storage.AdminFactory
+func (f *Factory) RegisterAdminHandlers(handle func(path string, handler http.Handler)) {
+	for _, factory := range f.factories {
+		if admin, ok := factory.(storage.AdminFactory); ok {
+			admin.RegisterAdminHandlers(handle)
+		}
+	}
+}
+
 var _ io.Closer = (*Factory)(nil)
 
 // Close closes the resources held by the factory
@@ -20,6 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
@@ -38,12 +39,16 @@ import (
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	depStoreMocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
 	"github.com/jaegertracing/jaeger/storage/mocks"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	samplingStoreMocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 	spanStoreMocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
 
 var _ storage.Factory = new(Factory)
 var _ storage.ArchiveFactory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
+var _ storage.SamplingStoreFactory = new(Factory)
 
 func defaultCfg() FactoryConfig {
 	return FactoryConfig{
@@ -79,6 +84,14 @@ func TestNewFactory(t *testing.T) {
 	assert.Equal(t, elasticsearchStorageType, f.SpanReaderType)
 	assert.Equal(t, memoryStorageType, f.DependenciesStorageType)
 
+	forwardFactory, err := NewFactory(FactoryConfig{
+		SpanWriterTypes:         []string{forwardStorageType},
+		SpanReaderType:          memoryStorageType,
+		DependenciesStorageType: memoryStorageType,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, forwardFactory.factories[forwardStorageType])
+
 	_, err = NewFactory(FactoryConfig{SpanWriterTypes: []string{"x"}, DependenciesStorageType: "y", SpanReaderType: "z"})
 	require.Error(t, err)
 	expected := "unknown storage type" // could be 'x' or 'y' since code iterates through map.
@@ -334,6 +347,79 @@ func TestConfigurable(t *testing.T) {
 	assert.Equal(t, v, mock.viper)
 }
 
+type adminFactory struct {
+	mocks.Factory
+	paths []string
+}
+
+// RegisterAdminHandlers implements storage.AdminFactory
+func (f *adminFactory) RegisterAdminHandlers(handle func(path string, handler http.Handler)) {
+	for _, path := range f.paths {
+		handle(path, http.NotFoundHandler())
+	}
+}
+
+func TestRegisterAdminHandlers(t *testing.T) {
+	clearEnv()
+	defer clearEnv()
+
+	f, err := NewFactory(defaultCfg())
+	require.NoError(t, err)
+	f.factories[cassandraStorageType] = &adminFactory{paths: []string{"/a", "/b"}}
+
+	var registered []string
+	f.RegisterAdminHandlers(func(path string, handler http.Handler) {
+		registered = append(registered, path)
+	})
+	assert.Equal(t, []string{"/a", "/b"}, registered)
+}
+
+type dependencyAndSamplingFactory struct {
+	mocks.Factory
+	dependencyWriter dependencystore.Writer
+	samplingStore    samplingstore.Store
+}
+
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *dependencyAndSamplingFactory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	return f.dependencyWriter, nil
+}
+
+// CreateSamplingStore implements storage.SamplingStoreFactory
+func (f *dependencyAndSamplingFactory) CreateSamplingStore() (samplingstore.Store, error) {
+	return f.samplingStore, nil
+}
+
+func TestCreateDependencyWriterAndSamplingStore(t *testing.T) {
+	f, err := NewFactory(defaultCfg())
+	require.NoError(t, err)
+	f.factories[cassandraStorageType] = &mocks.Factory{}
+
+	_, err = f.CreateDependencyWriter()
+	assert.Equal(t, storage.ErrDependencyWriterNotSupported, err)
+	_, err = f.CreateSamplingStore()
+	assert.Equal(t, storage.ErrSamplingStoreNotSupported, err)
+
+	mock := &dependencyAndSamplingFactory{
+		dependencyWriter: new(depStoreMocks.Writer),
+		samplingStore:    new(samplingStoreMocks.Store),
+	}
+	f.factories[cassandraStorageType] = mock
+
+	w, err := f.CreateDependencyWriter()
+	require.NoError(t, err)
+	assert.Equal(t, mock.dependencyWriter, w)
+	s, err := f.CreateSamplingStore()
+	require.NoError(t, err)
+	assert.Equal(t, mock.samplingStore, s)
+
+	delete(f.factories, cassandraStorageType)
+	_, err = f.CreateDependencyWriter()
+	assert.EqualError(t, err, "no cassandra backend registered for span store")
+	_, err = f.CreateSamplingStore()
+	assert.EqualError(t, err, "no cassandra backend registered for span store")
+}
+
 func TestParsingDownsamplingRatio(t *testing.T) {
 	f := Factory{}
 	v, command := config.Viperize(addDownsamplingFlags)
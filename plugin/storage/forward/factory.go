@@ -0,0 +1,136 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/spf13/viper"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/jaegertracing/jaeger/pkg/discovery"
+	"github.com/jaegertracing/jaeger/pkg/discovery/grpcresolver"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+var errWriteOnly = errors.New("forward storage is write-only")
+
+// Factory implements storage.Factory and creates write-only storage components
+// forwarding the spans to other collectors over gRPC.
+type Factory struct {
+	options Options
+
+	metricsFactory metrics.Factory
+	logger         *zap.Logger
+	conn           *grpc.ClientConn
+
+	// Notifier and Discoverer can be set before Initialize to discover the collectors dynamically,
+	// by default the spans are load balanced across the static list of host:ports of the options.
+	Notifier   discovery.Notifier
+	Discoverer discovery.Discoverer
+}
+
+// NewFactory creates a new Factory.
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// AddFlags implements plugin.Configurable
+func (f *Factory) AddFlags(flagSet *flag.FlagSet) {
+	f.options.AddFlags(flagSet)
+}
+
+// InitFromViper implements plugin.Configurable
+func (f *Factory) InitFromViper(v *viper.Viper) {
+	f.options.InitFromViper(v)
+}
+
+// InitFromOptions initializes factory from options.
+func (f *Factory) InitFromOptions(o Options) {
+	f.options = o
+}
+
+// Initialize implements storage.Factory
+func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error {
+	f.metricsFactory, f.logger = metricsFactory, logger
+	if err := f.options.validate(); err != nil {
+		return err
+	}
+	if f.Discoverer == nil {
+		if len(f.options.HostPorts) == 0 {
+			return errors.New("at least one collector host:port is required to forward the spans")
+		}
+		f.Discoverer = discovery.FixedDiscoverer(f.options.HostPorts)
+		f.Notifier = &discovery.Dispatcher{}
+	}
+	logger.Info("Forwarding spans to collectors", zap.Strings("host-ports", f.options.HostPorts))
+
+	var dialOptions []grpc.DialOption
+	if f.options.TLS.Enabled {
+		tlsConf, err := f.options.TLS.Config(logger)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	}
+	dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(grpcresolver.GRPCServiceConfig))
+
+	resolver := grpcresolver.New(f.Notifier, f.Discoverer, logger, f.options.DiscoveryMinPeers)
+	conn, err := grpc.Dial(resolver.Scheme()+":///round_robin", dialOptions...)
+	if err != nil {
+		return err
+	}
+	f.conn = conn
+	return nil
+}
+
+// CreateSpanReader implements storage.Factory
+func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
+	return nil, errWriteOnly
+}
+
+// CreateSpanWriter implements storage.Factory
+func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
+	return NewSpanWriter(api_v2.NewCollectorServiceClient(f.conn), f.options, f.metricsFactory, f.logger), nil
+}
+
+// CreateDependencyReader implements storage.Factory
+func (f *Factory) CreateDependencyReader() (dependencystore.Reader, error) {
+	return nil, errWriteOnly
+}
+
+var _ io.Closer = (*Factory)(nil)
+
+// Close closes the connection to the collectors
+func (f *Factory) Close() error {
+	var err error
+	if f.conn != nil {
+		err = f.conn.Close()
+	}
+	if tlsErr := f.options.TLS.Close(); err == nil {
+		err = tlsErr
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger/storage"
+)
+
+// Checks that Factory conforms to storage.Factory API
+var _ storage.Factory = new(Factory)
+
+type collectorServer struct {
+	lock     sync.Mutex
+	spans    int
+	failures int
+}
+
+func (s *collectorServer) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, status.Error(codes.Unavailable, "busy")
+	}
+	s.spans += len(r.Batch.Spans)
+	return &api_v2.PostSpansResponse{}, nil
+}
+
+func startCollector(t *testing.T, collector *collectorServer) (*grpc.Server, string) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	api_v2.RegisterCollectorServiceServer(server, collector)
+	go server.Serve(lis)
+	return server, lis.Addr().String()
+}
+
+func TestForwardFactory(t *testing.T) {
+	collector := &collectorServer{failures: 1}
+	server, addr := startCollector(t, collector)
+	defer server.Stop()
+
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--forward.host-port=" + addr, "--forward.retry.backoff=10ms"})
+	f.InitFromViper(v)
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+
+	w, err := f.CreateSpanWriter()
+	require.NoError(t, err)
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	require.NoError(t, w.(*SpanWriter).Close())
+
+	collector.lock.Lock()
+	assert.Equal(t, 1, collector.spans, "the rejected batch is retried")
+	collector.lock.Unlock()
+
+	_, err = f.CreateSpanReader()
+	assert.EqualError(t, err, "forward storage is write-only")
+	_, err = f.CreateDependencyReader()
+	assert.EqualError(t, err, "forward storage is write-only")
+	assert.NoError(t, f.Close())
+}
+
+func TestForwardFactoryErrors(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{})
+	f.InitFromViper(v)
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()), "at least one collector host:port is required to forward the spans")
+
+	f.InitFromOptions(Options{})
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()), "forward batch size, queue size and workers must be positive")
+
+	command.ParseFlags([]string{
+		"--forward.host-port=localhost:14250",
+		"--forward.tls.enabled=true",
+		"--forward.tls.ca=invalid/path",
+	})
+	f.InitFromViper(v)
+	err := f.Initialize(metrics.NullFactory, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load TLS config")
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
+)
+
+const (
+	configPrefix             = "forward"
+	suffixHostPort           = ".host-port"
+	suffixRetryMax           = ".retry.max"
+	suffixRetryBackoff       = ".retry.backoff"
+	suffixDiscoveryMinPeers  = ".discovery.min-peers"
+	suffixBatchSize          = ".batch.size"
+	suffixBatchFlushInterval = ".batch.flush-interval"
+	suffixQueueSize          = ".queue.size"
+	suffixWorkers            = ".workers"
+	suffixTimeout            = ".timeout"
+
+	defaultRetryMax           = 3
+	defaultRetryBackoff       = time.Second
+	defaultDiscoveryMinPeers  = 3
+	defaultBatchSize          = 100
+	defaultBatchFlushInterval = time.Second
+	defaultQueueSize          = 1000
+	defaultWorkers            = 4
+	defaultTimeout            = 5 * time.Second
+)
+
+var tlsFlagsConfig = tlscfg.ClientFlagsConfig{
+	Prefix:         configPrefix,
+	ShowEnabled:    true,
+	ShowServerName: true,
+}
+
+// Options stores the configuration of the forwarding to other collectors
+type Options struct {
+	// HostPorts is the static list of host:port of the collectors receiving the spans
+	HostPorts []string
+	// MaxRetry is the maximum number of retries of a batch failing to be forwarded, after which its spans are dropped
+	MaxRetry uint
+	// RetryBackoff is the wait before the first retry of a batch, doubled at each retry
+	RetryBackoff time.Duration
+	// DiscoveryMinPeers is the number of collectors the spans are load balanced to
+	DiscoveryMinPeers int
+	// BatchSize is the maximum number of spans sent in a single request
+	BatchSize int
+	// BatchFlushInterval is the maximum time the spans wait for their batch to be full
+	BatchFlushInterval time.Duration
+	// QueueSize is the number of batches waiting to be sent, above which the spans wait for room up to Timeout
+	QueueSize int
+	// Workers is the number of batches sent concurrently
+	Workers int
+	// Timeout is the timeout of a single forwarding request
+	Timeout time.Duration
+	TLS     tlscfg.Options
+}
+
+// AddFlags adds flags for Options
+func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
+	flagSet.String(configPrefix+suffixHostPort, "", "Comma-separated list of host:port of the collectors to forward the spans to")
+	flagSet.Uint(configPrefix+suffixRetryMax, defaultRetryMax, "The maximum number of retries of a batch failing to be forwarded, after which its spans are dropped")
+	flagSet.Duration(configPrefix+suffixRetryBackoff, defaultRetryBackoff, "The wait before the first retry of a batch, doubled at each retry")
+	flagSet.Int(configPrefix+suffixDiscoveryMinPeers, defaultDiscoveryMinPeers, "The number of collectors the spans are load balanced to")
+	flagSet.Int(configPrefix+suffixBatchSize, defaultBatchSize, "The maximum number of spans forwarded in a single request")
+	flagSet.Duration(configPrefix+suffixBatchFlushInterval, defaultBatchFlushInterval, "The maximum time a span waits for its batch to be full before being forwarded")
+	flagSet.Int(configPrefix+suffixQueueSize, defaultQueueSize, "The maximum number of batches waiting to be forwarded, above which the spans wait for room up to the timeout and are then rejected")
+	flagSet.Int(configPrefix+suffixWorkers, defaultWorkers, "The number of batches forwarded concurrently")
+	flagSet.Duration(configPrefix+suffixTimeout, defaultTimeout, "The timeout of a forwarding request")
+	tlsFlagsConfig.AddFlags(flagSet)
+}
+
+// InitFromViper initializes Options with properties from viper
+func (opt *Options) InitFromViper(v *viper.Viper) {
+	opt.HostPorts = nil
+	if hostPorts := strings.ReplaceAll(v.GetString(configPrefix+suffixHostPort), " ", ""); hostPorts != "" {
+		opt.HostPorts = strings.Split(hostPorts, ",")
+	}
+	opt.MaxRetry = v.GetUint(configPrefix + suffixRetryMax)
+	opt.RetryBackoff = v.GetDuration(configPrefix + suffixRetryBackoff)
+	opt.DiscoveryMinPeers = v.GetInt(configPrefix + suffixDiscoveryMinPeers)
+	opt.BatchSize = v.GetInt(configPrefix + suffixBatchSize)
+	opt.BatchFlushInterval = v.GetDuration(configPrefix + suffixBatchFlushInterval)
+	opt.QueueSize = v.GetInt(configPrefix + suffixQueueSize)
+	opt.Workers = v.GetInt(configPrefix + suffixWorkers)
+	opt.Timeout = v.GetDuration(configPrefix + suffixTimeout)
+	opt.TLS = tlsFlagsConfig.InitFromViper(v)
+}
+
+func (opt *Options) validate() error {
+	if opt.BatchSize <= 0 || opt.QueueSize <= 0 || opt.Workers <= 0 {
+		return errors.New("forward batch size, queue size and workers must be positive")
+	}
+	if opt.BatchFlushInterval <= 0 || opt.Timeout <= 0 {
+		return errors.New("forward batch flush interval and timeout must be positive")
+	}
+	if opt.RetryBackoff <= 0 {
+		return errors.New("forward retry backoff must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/pkg/config"
+)
+
+func TestOptionsWithFlags(t *testing.T) {
+	opts := &Options{}
+	v, command := config.Viperize(opts.AddFlags)
+	command.ParseFlags([]string{
+		"--forward.host-port=collector-1:14250, collector-2:14250",
+		"--forward.retry.max=5",
+		"--forward.retry.backoff=100ms",
+		"--forward.discovery.min-peers=2",
+		"--forward.batch.size=10",
+		"--forward.batch.flush-interval=100ms",
+		"--forward.queue.size=20",
+		"--forward.workers=8",
+		"--forward.timeout=1s",
+		"--forward.tls.enabled=true",
+		"--forward.tls.server-name=central",
+	})
+	opts.InitFromViper(v)
+
+	assert.Equal(t, []string{"collector-1:14250", "collector-2:14250"}, opts.HostPorts)
+	assert.Equal(t, uint(5), opts.MaxRetry)
+	assert.Equal(t, 100*time.Millisecond, opts.RetryBackoff)
+	assert.Equal(t, 2, opts.DiscoveryMinPeers)
+	assert.Equal(t, 10, opts.BatchSize)
+	assert.Equal(t, 100*time.Millisecond, opts.BatchFlushInterval)
+	assert.Equal(t, 20, opts.QueueSize)
+	assert.Equal(t, 8, opts.Workers)
+	assert.Equal(t, time.Second, opts.Timeout)
+	assert.True(t, opts.TLS.Enabled)
+	assert.Equal(t, "central", opts.TLS.ServerName)
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	opts := &Options{}
+	v, command := config.Viperize(opts.AddFlags)
+	command.ParseFlags([]string{})
+	opts.InitFromViper(v)
+
+	assert.Empty(t, opts.HostPorts)
+	assert.Equal(t, uint(defaultRetryMax), opts.MaxRetry)
+	assert.Equal(t, defaultRetryBackoff, opts.RetryBackoff)
+	assert.Equal(t, defaultBatchSize, opts.BatchSize)
+	assert.Equal(t, defaultBatchFlushInterval, opts.BatchFlushInterval)
+	assert.Equal(t, defaultQueueSize, opts.QueueSize)
+	assert.Equal(t, defaultWorkers, opts.Workers)
+	assert.Equal(t, defaultTimeout, opts.Timeout)
+	assert.False(t, opts.TLS.Enabled)
+	assert.NoError(t, opts.validate())
+}
+
+func TestOptionsValidate(t *testing.T) {
+	valid := Options{BatchSize: 1, QueueSize: 1, Workers: 1, BatchFlushInterval: time.Second, Timeout: time.Second, RetryBackoff: time.Second}
+	assert.NoError(t, valid.validate())
+
+	invalid := valid
+	invalid.Workers = 0
+	assert.EqualError(t, invalid.validate(), "forward batch size, queue size and workers must be positive")
+
+	invalid = valid
+	invalid.BatchFlushInterval = 0
+	assert.EqualError(t, invalid.validate(), "forward batch flush interval and timeout must be positive")
+
+	invalid = valid
+	invalid.RetryBackoff = 0
+	assert.EqualError(t, invalid.validate(), "forward retry backoff must be positive")
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"go.uber.org/zap"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/queue"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+)
+
+// ErrQueueFull is returned when a span cannot be added to a batch within the request timeout because too many
+// batches are waiting to be sent
+var ErrQueueFull = errors.New("forwarding queue is full")
+
+type spanWriterMetrics struct {
+	SpansWrittenSuccess metrics.Counter `metric:"forward_spans_written" tags:"status=success"`
+	SpansWrittenFailure metrics.Counter `metric:"forward_spans_written" tags:"status=failure"`
+	SpansDropped        metrics.Counter `metric:"forward_spans_written" tags:"status=dropped"`
+	QueueLength         metrics.Gauge   `metric:"forward_queue_length"`
+}
+
+// SpanWriter batches the spans and forwards them to other collectors. Implements spanstore.Writer
+//
+// The forwarding is asynchronous: WriteSpan only reports whether the span could be added to a batch. Each batch
+// is put in the bounded queue when its first span is added, and is sent once it is full or flushed, so a batch is
+// never dropped once it holds spans. When the queue is full the writer starting a new batch waits for room, up to
+// the request timeout, so the collectors slow down with the peers they forward to, and its span is rejected with
+// ErrQueueFull if there is still no room. The batches failing to be sent are retried with an exponential backoff,
+// up to MaxRetry times, before being dropped.
+type SpanWriter struct {
+	client  api_v2.CollectorServiceClient
+	options Options
+	logger  *zap.Logger
+	metrics spanWriterMetrics
+	queue   *queue.BoundedQueue
+
+	batchMu sync.Mutex
+	batch   *spanBatch // the batch being filled, nil until a span starts a new one
+	pending int        // the batches queued or being sent
+	closed  bool
+	sent    chan struct{} // closed once the writer is closed and all the batches are sent
+
+	done    chan struct{}
+	stopped chan struct{} // closed when Close stops waiting for the queued batches, to stop retrying them
+	wg      sync.WaitGroup
+}
+
+// spanBatch is queued when its first span is added, the workers send it once it is sealed
+type spanBatch struct {
+	spans  []*model.Span
+	sealed chan struct{}
+}
+
+// NewSpanWriter creates a SpanWriter and starts forwarding the batches
+func NewSpanWriter(client api_v2.CollectorServiceClient, options Options, metricsFactory metrics.Factory, logger *zap.Logger) *SpanWriter {
+	w := &SpanWriter{
+		client:  client,
+		options: options,
+		logger:  logger,
+		// the batches are only dropped before their first span is added
+		queue:   queue.NewBoundedQueue(options.QueueSize, func(item interface{}) {}),
+		sent:    make(chan struct{}),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	metrics.MustInit(&w.metrics, metricsFactory, nil)
+	w.queue.StartConsumers(options.Workers, func(item interface{}) {
+		w.sendBatch(item.(*spanBatch))
+	})
+	w.queue.StartLengthReporting(options.BatchFlushInterval, w.metrics.QueueLength)
+
+	w.wg.Add(1)
+	go w.flushPeriodically()
+	return w
+}
+
+// WriteSpan adds the span to the current batch, which is sent once full.
+// ErrQueueFull is returned when the span starts a new batch and the queue has no room for it before the context
+// is done or the request timeout expires, in which case the span is dropped.
+func (w *SpanWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	w.batchMu.Lock()
+	if w.closed {
+		w.batchMu.Unlock()
+		w.metrics.SpansDropped.Inc(1)
+		return ErrQueueFull
+	}
+	if w.batch == nil {
+		w.pending++
+		w.batchMu.Unlock()
+		batch := &spanBatch{spans: make([]*model.Span, 0, w.options.BatchSize), sealed: make(chan struct{})}
+		if !w.queueBatch(ctx, batch) {
+			w.batchDone()
+			w.logger.Error("Could not queue span to forward")
+			w.metrics.SpansDropped.Inc(1)
+			return ErrQueueFull
+		}
+		w.batchMu.Lock()
+		if w.batch != nil || w.closed {
+			// another writer started a batch or the writer was closed in the meantime, the queued batch is sent empty
+			close(batch.sealed)
+			if w.closed {
+				w.batchMu.Unlock()
+				w.metrics.SpansDropped.Inc(1)
+				return ErrQueueFull
+			}
+		} else {
+			w.batch = batch
+		}
+	}
+	w.batch.spans = append(w.batch.spans, span)
+	if len(w.batch.spans) >= w.options.BatchSize {
+		w.sealBatch()
+	}
+	w.batchMu.Unlock()
+	return nil
+}
+
+// queueBatch waits for room in the queue until the context is done or the request timeout expires
+func (w *SpanWriter) queueBatch(ctx context.Context, batch *spanBatch) bool {
+	ctx, cancel := context.WithTimeout(ctx, w.options.Timeout)
+	defer cancel()
+	return w.queue.ProduceWait(ctx, batch)
+}
+
+// sealBatch releases the current batch to the workers, must be called under batchMu
+func (w *SpanWriter) sealBatch() {
+	close(w.batch.sealed)
+	w.batch = nil
+}
+
+func (w *SpanWriter) flush() {
+	w.batchMu.Lock()
+	if w.batch != nil {
+		w.sealBatch()
+	}
+	w.batchMu.Unlock()
+}
+
+func (w *SpanWriter) flushPeriodically() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.options.BatchFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.flush()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// sendBatch waits for the batch to be full or flushed, and forwards its spans
+func (w *SpanWriter) sendBatch(batch *spanBatch) {
+	defer w.batchDone()
+	<-batch.sealed
+	if len(batch.spans) > 0 {
+		w.send(batch.spans)
+	}
+}
+
+// batchDone accounts for a batch which was sent or dropped, and tells Close once all the batches are
+func (w *SpanWriter) batchDone() {
+	w.batchMu.Lock()
+	defer w.batchMu.Unlock()
+	w.pending--
+	if w.closed && w.pending == 0 {
+		close(w.sent)
+	}
+}
+
+// send forwards the spans, retrying with an exponential backoff until MaxRetry retries failed or the writer is stopped
+func (w *SpanWriter) send(spans []*model.Span) {
+	backoff := w.options.RetryBackoff
+	for retry := uint(0); ; retry++ {
+		err := w.post(spans)
+		if err == nil {
+			w.metrics.SpansWrittenSuccess.Inc(int64(len(spans)))
+			return
+		}
+		if retry >= w.options.MaxRetry {
+			w.logger.Error("Could not forward spans", zap.Error(err), zap.Int("spans", len(spans)), zap.Uint("retries", retry))
+			w.metrics.SpansWrittenFailure.Inc(int64(len(spans)))
+			return
+		}
+		w.logger.Warn("Could not forward spans, retrying", zap.Error(err), zap.Int("spans", len(spans)), zap.Duration("backoff", backoff))
+		select {
+		case <-time.After(backoff):
+		case <-w.stopped:
+			w.logger.Error("Could not forward spans before closing", zap.Error(err), zap.Int("spans", len(spans)))
+			w.metrics.SpansWrittenFailure.Inc(int64(len(spans)))
+			return
+		}
+		backoff *= 2
+	}
+}
+
+func (w *SpanWriter) post(spans []*model.Span) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.options.Timeout)
+	defer cancel()
+	_, err := w.client.PostSpans(ctx, &api_v2.PostSpansRequest{Batch: model.Batch{Spans: spans}})
+	return err
+}
+
+// Close forwards the current batch and waits, up to the request timeout, for the queued batches to be sent
+func (w *SpanWriter) Close() error {
+	close(w.done)
+	w.wg.Wait()
+	w.batchMu.Lock()
+	w.closed = true
+	if w.batch != nil {
+		w.sealBatch()
+	}
+	if w.pending == 0 {
+		close(w.sent)
+	}
+	w.batchMu.Unlock()
+
+	select {
+	case <-w.sent:
+	case <-time.After(w.options.Timeout):
+		close(w.stopped)
+		w.logger.Warn("Timed out forwarding the queued spans", zap.Int("batches", w.queue.Size()))
+	}
+	w.queue.Stop()
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+)
+
+type fakeCollectorClient struct {
+	lock    sync.Mutex
+	batches [][]*model.Span
+	err     error
+	// failures is the number of requests failing with err before the next ones succeed, all fail if zero
+	failures int
+	attempts int
+	block    chan struct{}
+}
+
+func (c *fakeCollectorClient) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest, opts ...grpc.CallOption) (*api_v2.PostSpansResponse, error) {
+	if c.block != nil {
+		<-c.block
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.attempts++
+	if c.err != nil && (c.failures == 0 || c.attempts <= c.failures) {
+		return nil, c.err
+	}
+	c.batches = append(c.batches, r.Batch.Spans)
+	return &api_v2.PostSpansResponse{}, nil
+}
+
+func (c *fakeCollectorClient) getBatches() [][]*model.Span {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.batches
+}
+
+func testOptions() Options {
+	return Options{
+		BatchSize:          2,
+		BatchFlushInterval: time.Hour,
+		QueueSize:          1,
+		Workers:            1,
+		Timeout:            time.Second,
+		RetryBackoff:       time.Millisecond,
+	}
+}
+
+func waitForBatches(client *fakeCollectorClient, n int) {
+	for i := 0; i < 100 && len(client.getBatches()) < n; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func span(id uint64) *model.Span {
+	return &model.Span{TraceID: model.NewTraceID(0, 1), SpanID: model.NewSpanID(id)}
+}
+
+func TestSpanWriterBatches(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	client := &fakeCollectorClient{}
+	w := NewSpanWriter(client, testOptions(), metricsFactory, zap.NewNop())
+
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	require.NoError(t, w.WriteSpan(context.Background(), span(2)))
+	require.NoError(t, w.WriteSpan(context.Background(), span(3)))
+	waitForBatches(client, 1)
+	assert.Equal(t, [][]*model.Span{{span(1), span(2)}}, client.getBatches())
+
+	// the incomplete batch is forwarded on close
+	require.NoError(t, w.Close())
+	assert.Equal(t, [][]*model.Span{{span(1), span(2)}, {span(3)}}, client.getBatches())
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "forward_spans_written", Tags: map[string]string{"status": "success"}, Value: 3},
+	)
+}
+
+func TestSpanWriterFlushesPeriodically(t *testing.T) {
+	client := &fakeCollectorClient{}
+	opts := testOptions()
+	opts.BatchFlushInterval = 10 * time.Millisecond
+	w := NewSpanWriter(client, opts, metricstest.NewFactory(0), zap.NewNop())
+	defer w.Close()
+
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	waitForBatches(client, 1)
+	assert.Equal(t, [][]*model.Span{{span(1)}}, client.getBatches())
+}
+
+func TestSpanWriterQueueFull(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	client := &fakeCollectorClient{block: make(chan struct{})}
+	opts := testOptions()
+	opts.BatchSize = 1
+	w := NewSpanWriter(client, opts, metricsFactory, zap.NewNop())
+
+	// the first batch is held by the worker, the second one fills the queue
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	for i := 0; i < 100 && w.queue.Size() > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, w.WriteSpan(context.Background(), span(2)))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, ErrQueueFull, w.WriteSpan(ctx, span(3)))
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "forward_spans_written", Tags: map[string]string{"status": "dropped"}, Value: 1},
+	)
+
+	// the writer waits for room in the queue
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(client.block)
+	}()
+	require.NoError(t, w.WriteSpan(context.Background(), span(4)))
+	require.NoError(t, w.Close())
+	assert.Equal(t, [][]*model.Span{{span(1)}, {span(2)}, {span(4)}}, client.getBatches())
+	assert.Equal(t, ErrQueueFull, w.WriteSpan(context.Background(), span(5)), "the spans are rejected once closed")
+}
+
+func TestSpanWriterQueueFullRejectsOnlyNewBatches(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	client := &fakeCollectorClient{block: make(chan struct{})}
+	w := NewSpanWriter(client, testOptions(), metricsFactory, zap.NewNop())
+
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	require.NoError(t, w.WriteSpan(context.Background(), span(2)))
+	for i := 0; i < 100 && w.queue.Size() > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the started batch holds the room of the queue, so the span completing it is accepted
+	require.NoError(t, w.WriteSpan(context.Background(), span(3)))
+	require.NoError(t, w.WriteSpan(context.Background(), span(4)))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, ErrQueueFull, w.WriteSpan(ctx, span(5)))
+
+	close(client.block)
+	require.NoError(t, w.Close())
+	assert.Equal(t, [][]*model.Span{{span(1), span(2)}, {span(3), span(4)}}, client.getBatches())
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "forward_spans_written", Tags: map[string]string{"status": "dropped"}, Value: 1},
+		metricstest.ExpectedMetric{Name: "forward_spans_written", Tags: map[string]string{"status": "success"}, Value: 4},
+	)
+}
+
+func TestSpanWriterSendError(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	client := &fakeCollectorClient{err: errors.New("unavailable")}
+	opts := testOptions()
+	opts.MaxRetry = 2
+	w := NewSpanWriter(client, opts, metricsFactory, zap.NewNop())
+
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	require.NoError(t, w.Close())
+	assert.Equal(t, 3, client.attempts, "the batch is retried before being dropped")
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "forward_spans_written", Tags: map[string]string{"status": "failure"}, Value: 1},
+	)
+}
+
+func TestSpanWriterRetries(t *testing.T) {
+	metricsFactory := metricstest.NewFactory(0)
+	client := &fakeCollectorClient{err: errors.New("unavailable"), failures: 2}
+	opts := testOptions()
+	opts.MaxRetry = 2
+	w := NewSpanWriter(client, opts, metricsFactory, zap.NewNop())
+
+	require.NoError(t, w.WriteSpan(context.Background(), span(1)))
+	require.NoError(t, w.WriteSpan(context.Background(), span(2)))
+	require.NoError(t, w.Close())
+	assert.Equal(t, [][]*model.Span{{span(1), span(2)}}, client.getBatches())
+	metricsFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "forward_spans_written", Tags: map[string]string{"status": "success"}, Value: 2},
+	)
+}
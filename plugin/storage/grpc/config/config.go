@@ -15,36 +15,100 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"runtime"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
 	"github.com/jaegertracing/jaeger/plugin/storage/grpc/shared"
 )
 
 // Configuration describes the options to customize the storage behavior.
 type Configuration struct {
-	PluginBinary            string `yaml:"binary" mapstructure:"binary"`
-	PluginConfigurationFile string `yaml:"configuration-file" mapstructure:"configuration_file"`
-	PluginLogLevel          string `yaml:"log-level" mapstructure:"log_level"`
+	PluginBinary            string         `yaml:"binary" mapstructure:"binary"`
+	PluginConfigurationFile string         `yaml:"configuration-file" mapstructure:"configuration_file"`
+	PluginLogLevel          string         `yaml:"log-level" mapstructure:"log_level"`
+	RemoteServerAddr        string         `yaml:"server" mapstructure:"server"`
+	RemoteTLS               tlscfg.Options `yaml:"tls" mapstructure:"tls"`
+	RemoteConnectTimeout    time.Duration  `yaml:"connection-timeout" mapstructure:"connection_timeout"`
+	WriteBatchSize          int            `yaml:"write-batch-size" mapstructure:"write_batch_size"`
+	WriteBatchFlushInterval time.Duration  `yaml:"write-batch-flush-interval" mapstructure:"write_batch_flush_interval"`
 }
 
 // ClientPluginServices defines services plugin can expose and its capabilities
 type ClientPluginServices struct {
 	shared.PluginServices
 	Capabilities shared.PluginCapabilities
+	// Closer releases the connection to a remote storage server, nil for plugin sub-processes
+	Closer io.Closer
 }
 
 // PluginBuilder is used to create storage plugins. Implemented by Configuration.
 type PluginBuilder interface {
-	Build() (*ClientPluginServices, error)
+	Build(logger *zap.Logger) (*ClientPluginServices, error)
 }
 
-// Build instantiates a PluginServices
-func (c *Configuration) Build() (*ClientPluginServices, error) {
+// Build instantiates a PluginServices, connected to the remote storage server if one is configured
+// or else to the plugin binary started as a sub-process.
+func (c *Configuration) Build(logger *zap.Logger) (*ClientPluginServices, error) {
+	if c.RemoteServerAddr != "" {
+		return c.buildRemote(logger)
+	}
+	return c.buildPlugin()
+}
+
+func (c *Configuration) buildRemote(logger *zap.Logger) (*ClientPluginServices, error) {
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	if c.RemoteTLS.Enabled {
+		tlsCfg, err := c.RemoteTLS.Config(logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	ctx := context.Background()
+	if c.RemoteConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RemoteConnectTimeout)
+		defer cancel()
+	}
+	conn, err := grpc.DialContext(ctx, c.RemoteServerAddr, opts...)
+	if err != nil {
+		c.RemoteTLS.Close()
+		return nil, fmt.Errorf("error connecting to remote storage server %s: %w", c.RemoteServerAddr, err)
+	}
+
+	services, capabilities := shared.NewGRPCClientServices(conn)
+	return &ClientPluginServices{
+		PluginServices: *services,
+		Capabilities:   capabilities,
+		Closer:         &remoteCloser{conn: conn, tls: &c.RemoteTLS},
+	}, nil
+}
+
+type remoteCloser struct {
+	conn *grpc.ClientConn
+	tls  *tlscfg.Options
+}
+
+func (r *remoteCloser) Close() error {
+	r.tls.Close()
+	return r.conn.Close()
+}
+
+func (c *Configuration) buildPlugin() (*ClientPluginServices, error) {
 	// #nosec G204
 	cmd := exec.Command(c.PluginBinary, "--config", c.PluginConfigurationFile)
 
@@ -91,10 +155,23 @@ func (c *Configuration) Build() (*ClientPluginServices, error) {
 			raw, shared.StoragePluginIdentifier)
 	}
 
+	dependencyWriterPlugin, ok := raw.(shared.DependencyWriterPlugin)
+	if !ok {
+		return nil, fmt.Errorf("unable to cast %T to shared.DependencyWriterPlugin for plugin \"%s\"",
+			raw, shared.StoragePluginIdentifier)
+	}
+	samplingStorePlugin, ok := raw.(shared.SamplingStorePlugin)
+	if !ok {
+		return nil, fmt.Errorf("unable to cast %T to shared.SamplingStorePlugin for plugin \"%s\"",
+			raw, shared.StoragePluginIdentifier)
+	}
+
 	return &ClientPluginServices{
 		PluginServices: shared.PluginServices{
-			Store:        storagePlugin,
-			ArchiveStore: archiveStoragePlugin,
+			Store:           storagePlugin,
+			ArchiveStore:    archiveStoragePlugin,
+			DependencyStore: dependencyWriterPlugin,
+			SamplingStore:   samplingStorePlugin,
 		},
 		Capabilities: capabilities,
 	}, nil
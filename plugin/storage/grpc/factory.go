@@ -17,6 +17,7 @@ package grpc
 import (
 	"flag"
 	"fmt"
+	"io"
 
 	"github.com/spf13/viper"
 	"github.com/uber/jaeger-lib/metrics"
@@ -26,6 +27,7 @@ import (
 	"github.com/jaegertracing/jaeger/plugin/storage/grpc/shared"
 	"github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -37,9 +39,12 @@ type Factory struct {
 
 	builder config.PluginBuilder
 
-	store        shared.StoragePlugin
-	archiveStore shared.ArchiveStoragePlugin
-	capabilities shared.PluginCapabilities
+	store           shared.StoragePlugin
+	archiveStore    shared.ArchiveStoragePlugin
+	dependencyStore shared.DependencyWriterPlugin
+	samplingStore   shared.SamplingStorePlugin
+	capabilities    shared.PluginCapabilities
+	closer          io.Closer
 }
 
 // NewFactory creates a new Factory.
@@ -68,14 +73,17 @@ func (f *Factory) InitFromOptions(opts Options) {
 func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger) error {
 	f.metricsFactory, f.logger = metricsFactory, logger
 
-	services, err := f.builder.Build()
+	services, err := f.builder.Build(logger)
 	if err != nil {
 		return fmt.Errorf("grpc-plugin builder failed to create a store: %w", err)
 	}
 
 	f.store = services.Store
 	f.archiveStore = services.ArchiveStore
+	f.dependencyStore = services.DependencyStore
+	f.samplingStore = services.SamplingStore
 	f.capabilities = services.Capabilities
+	f.closer = services.Closer
 	logger.Info("External plugin storage configuration", zap.Any("configuration", f.options.Configuration))
 	return nil
 }
@@ -87,7 +95,28 @@ func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
 
 // CreateSpanWriter implements storage.Factory
 func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
-	return f.store.SpanWriter(), nil
+	writer := f.store.SpanWriter()
+	batchWriter, ok := writer.(spanstore.BatchWriter)
+	if !ok {
+		return writer, nil
+	}
+	if f.capabilities == nil || f.options.Configuration.WriteBatchSize <= 1 {
+		return singleSpanWriter{writer}, nil
+	}
+	capabilities, err := f.capabilities.Capabilities()
+	if err != nil {
+		return nil, err
+	}
+	if capabilities == nil || !capabilities.BatchSpanWriter {
+		return singleSpanWriter{writer}, nil
+	}
+	return spanstore.NewBatchingWriter(batchWriter, f.options.Configuration.WriteBatchSize, f.options.Configuration.WriteBatchFlushInterval), nil
+}
+
+// singleSpanWriter hides the WriteSpans method of the plugin client when the plugin doesn't support batches
+// or they are disabled, so that the callers write the spans one by one.
+type singleSpanWriter struct {
+	spanstore.Writer
 }
 
 // CreateDependencyReader implements storage.Factory
@@ -124,3 +153,41 @@ func (f *Factory) CreateArchiveSpanWriter() (spanstore.Writer, error) {
 	}
 	return f.archiveStore.ArchiveSpanWriter(), nil
 }
+
+// CreateDependencyWriter implements storage.DependencyWriterFactory
+func (f *Factory) CreateDependencyWriter() (dependencystore.Writer, error) {
+	if f.capabilities == nil || f.dependencyStore == nil {
+		return nil, storage.ErrDependencyWriterNotSupported
+	}
+	capabilities, err := f.capabilities.Capabilities()
+	if err != nil {
+		return nil, err
+	}
+	if capabilities == nil || !capabilities.DependencyWriter {
+		return nil, storage.ErrDependencyWriterNotSupported
+	}
+	return f.dependencyStore.DependencyWriter(), nil
+}
+
+// CreateSamplingStore implements storage.SamplingStoreFactory
+func (f *Factory) CreateSamplingStore() (samplingstore.Store, error) {
+	if f.capabilities == nil || f.samplingStore == nil {
+		return nil, storage.ErrSamplingStoreNotSupported
+	}
+	capabilities, err := f.capabilities.Capabilities()
+	if err != nil {
+		return nil, err
+	}
+	if capabilities == nil || !capabilities.SamplingStore {
+		return nil, storage.ErrSamplingStoreNotSupported
+	}
+	return f.samplingStore.SamplingStore(), nil
+}
+
+// Close closes the connection to the remote storage server, if any.
+func (f *Factory) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
@@ -15,15 +15,20 @@
 package grpc
 
 import (
+	"context"
 	"errors"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
+	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/pkg/config"
 	grpcConfig "github.com/jaegertracing/jaeger/plugin/storage/grpc/config"
 	"github.com/jaegertracing/jaeger/plugin/storage/grpc/mocks"
@@ -31,26 +36,32 @@ import (
 	"github.com/jaegertracing/jaeger/storage"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	dependencyStoreMocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	samplingStoreMocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 	spanStoreMocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
 
 var _ storage.Factory = new(Factory)
+var _ storage.DependencyWriterFactory = new(Factory)
+var _ storage.SamplingStoreFactory = new(Factory)
 
 type mockPluginBuilder struct {
 	plugin *mockPlugin
 	err    error
 }
 
-func (b *mockPluginBuilder) Build() (*grpcConfig.ClientPluginServices, error) {
+func (b *mockPluginBuilder) Build(logger *zap.Logger) (*grpcConfig.ClientPluginServices, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
 
 	services := &grpcConfig.ClientPluginServices{
 		PluginServices: shared.PluginServices{
-			Store:        b.plugin,
-			ArchiveStore: b.plugin,
+			Store:           b.plugin,
+			ArchiveStore:    b.plugin,
+			DependencyStore: b.plugin,
+			SamplingStore:   b.plugin,
 		},
 	}
 	if b.plugin.capabilities != nil {
@@ -67,6 +78,8 @@ type mockPlugin struct {
 	archiveWriter    spanstore.Writer
 	capabilities     shared.PluginCapabilities
 	dependencyReader dependencystore.Reader
+	dependencyWriter dependencystore.Writer
+	samplingStore    samplingstore.Store
 }
 
 func (mp *mockPlugin) Capabilities() (*shared.Capabilities, error) {
@@ -93,6 +106,14 @@ func (mp *mockPlugin) DependencyReader() dependencystore.Reader {
 	return mp.dependencyReader
 }
 
+func (mp *mockPlugin) DependencyWriter() dependencystore.Writer {
+	return mp.dependencyWriter
+}
+
+func (mp *mockPlugin) SamplingStore() samplingstore.Store {
+	return mp.samplingStore
+}
+
 func TestGRPCStorageFactory(t *testing.T) {
 	f := NewFactory()
 	v := viper.New()
@@ -161,6 +182,89 @@ func TestGRPCStorageFactory_Capabilities(t *testing.T) {
 	assert.NotNil(t, writer)
 }
 
+func TestGRPCStorageFactory_BatchSpanWriter(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	require.NoError(t, command.ParseFlags([]string{}))
+	f.InitFromViper(v)
+
+	capabilities := new(mocks.PluginCapabilities)
+	capabilities.On("Capabilities").
+		Return(&shared.Capabilities{
+			BatchSpanWriter: true,
+		}, nil)
+	writer := &struct {
+		*spanStoreMocks.Writer
+		fakeBatchWriter
+	}{new(spanStoreMocks.Writer), fakeBatchWriter{}}
+
+	f.builder = &mockPluginBuilder{
+		plugin: &mockPlugin{
+			capabilities: capabilities,
+			spanWriter:   writer,
+		},
+	}
+	assert.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	spanWriter, err := f.CreateSpanWriter()
+	require.NoError(t, err)
+	assert.IsType(t, &spanstore.BatchingWriter{}, spanWriter)
+
+	// batches are disabled by the options
+	f.options.Configuration.WriteBatchSize = 0
+	spanWriter, err = f.CreateSpanWriter()
+	require.NoError(t, err)
+	assert.Equal(t, singleSpanWriter{writer}, spanWriter)
+	_, ok := spanWriter.(spanstore.BatchWriter)
+	assert.False(t, ok, "the spans are written one by one")
+}
+
+type fakeBatchWriter struct{}
+
+func (fakeBatchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	return nil
+}
+
+func TestGRPCStorageFactory_DependencyWriterAndSamplingStore(t *testing.T) {
+	capabilities := new(mocks.PluginCapabilities)
+	capabilities.On("Capabilities").
+		Return(&shared.Capabilities{
+			DependencyWriter: true,
+			SamplingStore:    true,
+		}, nil).Twice()
+	capabilities.On("Capabilities").
+		Return(&shared.Capabilities{}, nil).Twice()
+	capabilities.On("Capabilities").
+		Return(nil, errors.New("made-up error"))
+	plugin := &mockPlugin{
+		capabilities:     capabilities,
+		dependencyWriter: new(dependencyStoreMocks.Writer),
+		samplingStore:    new(samplingStoreMocks.Store),
+	}
+
+	f := NewFactory()
+	f.InitFromViper(viper.New())
+	f.builder = &mockPluginBuilder{plugin: plugin}
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+
+	depWriter, err := f.CreateDependencyWriter()
+	require.NoError(t, err)
+	assert.Equal(t, plugin.dependencyWriter, depWriter)
+	samplingStore, err := f.CreateSamplingStore()
+	require.NoError(t, err)
+	assert.Equal(t, plugin.samplingStore, samplingStore)
+
+	// the plugin does not advertise the capabilities
+	_, err = f.CreateDependencyWriter()
+	assert.Equal(t, storage.ErrDependencyWriterNotSupported, err)
+	_, err = f.CreateSamplingStore()
+	assert.Equal(t, storage.ErrSamplingStoreNotSupported, err)
+
+	_, err = f.CreateDependencyWriter()
+	assert.EqualError(t, err, "made-up error")
+	_, err = f.CreateSamplingStore()
+	assert.EqualError(t, err, "made-up error")
+}
+
 func TestGRPCStorageFactory_CapabilitiesDisabled(t *testing.T) {
 	f := NewFactory()
 	v := viper.New()
@@ -239,6 +343,12 @@ func TestGRPCStorageFactory_CapabilitiesNil(t *testing.T) {
 	writer, err := f.CreateArchiveSpanWriter()
 	assert.Equal(t, err, storage.ErrArchiveStorageNotSupported)
 	assert.Nil(t, writer)
+	depWriter, err := f.CreateDependencyWriter()
+	assert.Equal(t, err, storage.ErrDependencyWriterNotSupported)
+	assert.Nil(t, depWriter)
+	samplingStore, err := f.CreateSamplingStore()
+	assert.Equal(t, err, storage.ErrSamplingStoreNotSupported)
+	assert.Nil(t, samplingStore)
 }
 
 func TestWithConfiguration(t *testing.T) {
@@ -267,3 +377,51 @@ func TestInitFromOptions(t *testing.T) {
 	assert.Equal(t, o, f.options)
 	assert.Equal(t, &o.Configuration, f.builder)
 }
+
+func TestGRPCStorageFactory_Remote(t *testing.T) {
+	spanReader := new(spanStoreMocks.Reader)
+	spanReader.On("GetServices", mock.Anything).Return([]string{"svc"}, nil)
+	server := NewRemoteServer(&shared.PluginServices{
+		Store: &mockPlugin{
+			spanReader: spanReader,
+		},
+	})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	require.NoError(t, command.ParseFlags([]string{
+		"--grpc-storage.server=" + lis.Addr().String(),
+	}))
+	f.InitFromViper(v)
+	require.NoError(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+
+	reader, err := f.CreateSpanReader()
+	require.NoError(t, err)
+	services, err := reader.GetServices(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc"}, services)
+	assert.NoError(t, f.Close())
+}
+
+func TestGRPCStorageFactory_RemoteUnavailable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	f := NewFactory()
+	f.InitFromOptions(Options{
+		Configuration: grpcConfig.Configuration{
+			RemoteServerAddr:     addr,
+			RemoteConnectTimeout: 100 * time.Millisecond,
+		},
+	})
+	err = f.Initialize(metrics.NullFactory, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error connecting to remote storage server "+addr)
+	assert.NoError(t, f.Close())
+}
@@ -35,11 +35,22 @@ func ServeWithGRPCServer(services *shared.PluginServices, grpcServer func([]grpc
 		VersionedPlugins: map[int]plugin.PluginSet{
 			1: map[string]plugin.Plugin{
 				shared.StoragePluginIdentifier: &shared.StorageGRPCPlugin{
-					Impl:        services.Store,
-					ArchiveImpl: services.ArchiveStore,
+					Impl:                 services.Store,
+					ArchiveImpl:          services.ArchiveStore,
+					DependencyWriterImpl: services.DependencyStore,
+					SamplingStoreImpl:    services.SamplingStore,
 				},
 			},
 		},
 		GRPCServer: grpcServer,
 	})
 }
+
+// NewRemoteServer creates a gRPC server exposing the storage services over the network, so that
+// a storage deployment can be shared by collectors and queries connecting with --grpc-storage.server.
+// The caller is responsible for serving it on a listener and stopping it.
+func NewRemoteServer(services *shared.PluginServices, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	shared.RegisterGRPCServices(server, services)
+	return server
+}
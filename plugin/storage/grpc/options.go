@@ -16,17 +16,27 @@ package grpc
 
 import (
 	"flag"
+	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/jaegertracing/jaeger/pkg/config/tlscfg"
 	"github.com/jaegertracing/jaeger/plugin/storage/grpc/config"
 )
 
 const (
-	pluginBinary            = "grpc-storage-plugin.binary"
-	pluginConfigurationFile = "grpc-storage-plugin.configuration-file"
-	pluginLogLevel          = "grpc-storage-plugin.log-level"
-	defaultPluginLogLevel   = "warn"
+	pluginBinary             = "grpc-storage-plugin.binary"
+	pluginConfigurationFile  = "grpc-storage-plugin.configuration-file"
+	pluginLogLevel           = "grpc-storage-plugin.log-level"
+	writeBatchSize           = "grpc-storage-plugin.write-batch-size"
+	writeBatchFlush          = "grpc-storage-plugin.write-batch-flush-interval"
+	remotePrefix             = "grpc-storage"
+	remoteServer             = remotePrefix + ".server"
+	remoteConnectionTimeout  = remotePrefix + ".connection-timeout"
+	defaultPluginLogLevel    = "warn"
+	defaultConnectionTimeout = 5 * time.Second
+	defaultWriteBatchSize    = 100
+	defaultWriteBatchFlush   = 10 * time.Millisecond
 )
 
 // Options contains GRPC plugins configs and provides the ability
@@ -40,6 +50,11 @@ func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
 	flagSet.String(pluginBinary, "", "The location of the plugin binary")
 	flagSet.String(pluginConfigurationFile, "", "A path pointing to the plugin's configuration file, made available to the plugin with the --config arg")
 	flagSet.String(pluginLogLevel, defaultPluginLogLevel, "Set the log level of the plugin's logger")
+	flagSet.Int(writeBatchSize, defaultWriteBatchSize, "The maximum number of spans sent to the plugin with a single call, if the plugin supports batches. Larger batches are split to stay under the default 4 MiB gRPC message size limit. Set to 0 to send the spans one by one")
+	flagSet.Duration(writeBatchFlush, defaultWriteBatchFlush, "The maximum time a span waits for its batch to fill up before the batch is sent to the plugin")
+	flagSet.String(remoteServer, "", "The remote storage gRPC server address as host:port, used instead of the plugin binary")
+	flagSet.Duration(remoteConnectionTimeout, defaultConnectionTimeout, "The timeout for connecting to the remote storage server")
+	tlsFlagsConfig().AddFlags(flagSet)
 }
 
 // InitFromViper initializes Options with properties from viper
@@ -47,4 +62,17 @@ func (opt *Options) InitFromViper(v *viper.Viper) {
 	opt.Configuration.PluginBinary = v.GetString(pluginBinary)
 	opt.Configuration.PluginConfigurationFile = v.GetString(pluginConfigurationFile)
 	opt.Configuration.PluginLogLevel = v.GetString(pluginLogLevel)
+	opt.Configuration.WriteBatchSize = v.GetInt(writeBatchSize)
+	opt.Configuration.WriteBatchFlushInterval = v.GetDuration(writeBatchFlush)
+	opt.Configuration.RemoteServerAddr = v.GetString(remoteServer)
+	opt.Configuration.RemoteConnectTimeout = v.GetDuration(remoteConnectionTimeout)
+	opt.Configuration.RemoteTLS = tlsFlagsConfig().InitFromViper(v)
+}
+
+func tlsFlagsConfig() tlscfg.ClientFlagsConfig {
+	return tlscfg.ClientFlagsConfig{
+		Prefix:         remotePrefix,
+		ShowEnabled:    true,
+		ShowServerName: true,
+	}
 }
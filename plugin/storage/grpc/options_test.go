@@ -16,6 +16,7 @@ package grpc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -37,3 +38,25 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, opts.Configuration.PluginConfigurationFile, "config.json")
 	assert.Equal(t, opts.Configuration.PluginLogLevel, "debug")
 }
+
+func TestRemoteAndBatchOptionsWithFlags(t *testing.T) {
+	opts := &Options{}
+	v, command := config.Viperize(opts.AddFlags)
+	err := command.ParseFlags([]string{
+		"--grpc-storage-plugin.write-batch-size=10",
+		"--grpc-storage-plugin.write-batch-flush-interval=1s",
+		"--grpc-storage.server=foo:12345",
+		"--grpc-storage.connection-timeout=60s",
+		"--grpc-storage.tls.enabled=true",
+		"--grpc-storage.tls.server-name=foo",
+	})
+	assert.NoError(t, err)
+	opts.InitFromViper(v)
+
+	assert.Equal(t, 10, opts.Configuration.WriteBatchSize)
+	assert.Equal(t, time.Second, opts.Configuration.WriteBatchFlushInterval)
+	assert.Equal(t, "foo:12345", opts.Configuration.RemoteServerAddr)
+	assert.Equal(t, time.Minute, opts.Configuration.RemoteConnectTimeout)
+	assert.True(t, opts.Configuration.RemoteTLS.Enabled)
+	assert.Equal(t, "foo", opts.Configuration.RemoteTLS.ServerName)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+)
+
+var _ dependencystore.Writer = (*dependencyWriter)(nil)
+
+// dependencyWriter wraps storage_v1.DependenciesWriterPluginClient into dependencystore.Writer
+type dependencyWriter struct {
+	client storage_v1.DependenciesWriterPluginClient
+}
+
+// WriteDependencies saves the dependency links calculated at the given time
+func (w *dependencyWriter) WriteDependencies(ts time.Time, dependencies []model.DependencyLink) error {
+	_, err := w.client.WriteDependencies(context.Background(), &storage_v1.WriteDependenciesRequest{
+		Timestamp:    ts,
+		Dependencies: dependencies,
+	})
+	if err != nil {
+		return fmt.Errorf("plugin error: %w", err)
+	}
+
+	return nil
+}
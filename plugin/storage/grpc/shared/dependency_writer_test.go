@@ -0,0 +1,45 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1/mocks"
+)
+
+func TestDependencyWriter_WriteDependencies(t *testing.T) {
+	ts := time.Now()
+	deps := []model.DependencyLink{{Parent: "parent", Child: "child", CallCount: 3}}
+
+	client := new(mocks.DependenciesWriterPluginClient)
+	client.On("WriteDependencies", mock.Anything, &storage_v1.WriteDependenciesRequest{
+		Timestamp:    ts,
+		Dependencies: deps,
+	}).Return(&storage_v1.WriteDependenciesResponse{}, nil).Once()
+	client.On("WriteDependencies", mock.Anything, mock.Anything).
+		Return(nil, errors.New("made-up error"))
+	writer := &dependencyWriter{client: client}
+
+	assert.NoError(t, writer.WriteDependencies(ts, deps))
+	assert.EqualError(t, writer.WriteDependencies(ts, deps), "plugin error: made-up error")
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -27,13 +28,21 @@ import (
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
+// maxWriteSpansBytes bounds the serialized size of the spans sent with a single WriteSpans call,
+// leaving headroom under the 4 MiB default receive limit of gRPC servers
+const maxWriteSpansBytes = 3 << 20
+
 var (
-	_ StoragePlugin        = (*grpcClient)(nil)
-	_ ArchiveStoragePlugin = (*grpcClient)(nil)
-	_ PluginCapabilities   = (*grpcClient)(nil)
+	_ StoragePlugin          = (*grpcClient)(nil)
+	_ ArchiveStoragePlugin   = (*grpcClient)(nil)
+	_ PluginCapabilities     = (*grpcClient)(nil)
+	_ BatchSpanWriter        = (*grpcClient)(nil)
+	_ DependencyWriterPlugin = (*grpcClient)(nil)
+	_ SamplingStorePlugin    = (*grpcClient)(nil)
 )
 
 // grpcClient implements shared.StoragePlugin and reads/writes spans and dependencies
@@ -44,6 +53,8 @@ type grpcClient struct {
 	archiveWriterClient storage_v1.ArchiveSpanWriterPluginClient
 	capabilitiesClient  storage_v1.PluginCapabilitiesClient
 	depsReaderClient    storage_v1.DependenciesReaderPluginClient
+	depsWriterClient    storage_v1.DependenciesWriterPluginClient
+	samplingStoreClient storage_v1.SamplingStorePluginClient
 }
 
 // upgradeContextWithBearerToken turns the context into a gRPC outgoing context with bearer token
@@ -83,6 +94,16 @@ func (c *grpcClient) ArchiveSpanWriter() spanstore.Writer {
 	return &archiveWriter{client: c.archiveWriterClient}
 }
 
+// DependencyWriter implements shared.DependencyWriterPlugin.
+func (c *grpcClient) DependencyWriter() dependencystore.Writer {
+	return &dependencyWriter{client: c.depsWriterClient}
+}
+
+// SamplingStore implements shared.SamplingStorePlugin.
+func (c *grpcClient) SamplingStore() samplingstore.Store {
+	return &samplingStore{client: c.samplingStoreClient}
+}
+
 // GetTrace takes a traceID and returns a Trace associated with that traceID
 func (c *grpcClient) GetTrace(ctx context.Context, traceID model.TraceID) (*model.Trace, error) {
 	stream, err := c.readerClient.GetTrace(upgradeContextWithBearerToken(ctx), &storage_v1.GetTraceRequest{
@@ -141,6 +162,10 @@ func (c *grpcClient) GetOperations(
 
 // FindTraces retrieves traces that match the traceQuery
 func (c *grpcClient) FindTraces(ctx context.Context, query *spanstore.TraceQueryParameters) ([]*model.Trace, error) {
+	// The plugin protocol does not carry tag keys and value lists
+	if len(query.TagKeys) > 0 || len(query.TagValues) > 0 {
+		return nil, spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	stream, err := c.readerClient.FindTraces(upgradeContextWithBearerToken(ctx), &storage_v1.FindTracesRequest{
 		Query: &storage_v1.TraceQueryParameters{
 			ServiceName:   query.ServiceName,
@@ -179,6 +204,10 @@ func (c *grpcClient) FindTraces(ctx context.Context, query *spanstore.TraceQuery
 
 // FindTraceIDs retrieves traceIDs that match the traceQuery
 func (c *grpcClient) FindTraceIDs(ctx context.Context, query *spanstore.TraceQueryParameters) ([]model.TraceID, error) {
+	// The plugin protocol does not carry tag keys and value lists
+	if len(query.TagKeys) > 0 || len(query.TagValues) > 0 {
+		return nil, spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	resp, err := c.readerClient.FindTraceIDs(upgradeContextWithBearerToken(ctx), &storage_v1.FindTraceIDsRequest{
 		Query: &storage_v1.TraceQueryParameters{
 			ServiceName:   query.ServiceName,
@@ -204,12 +233,66 @@ func (c *grpcClient) WriteSpan(ctx context.Context, span *model.Span) error {
 		Span: span,
 	})
 	if err != nil {
-		return fmt.Errorf("plugin error: %w", err)
+		return writeError(err)
 	}
 
 	return nil
 }
 
+// WriteSpans saves the spans with as few calls to the plugin as the message size allows
+func (c *grpcClient) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	for len(spans) > 0 {
+		n, size := 1, spans[0].Size()
+		for ; n < len(spans) && size+spans[n].Size() <= maxWriteSpansBytes; n++ {
+			size += spans[n].Size()
+		}
+		if err := c.writeSpans(ctx, spans[:n]); err != nil {
+			return err
+		}
+		spans = spans[n:]
+	}
+	return nil
+}
+
+// writeSpans saves the spans with a single call to the plugin, and splits them in halves
+// if the plugin rejects the message as too large
+func (c *grpcClient) writeSpans(ctx context.Context, spans []*model.Span) error {
+	_, err := c.writerClient.WriteSpans(ctx, &storage_v1.WriteSpansRequest{
+		Spans: spans,
+	})
+	if err == nil {
+		return nil
+	}
+	if len(spans) > 1 && isMessageTooLarge(err) {
+		half := len(spans) / 2
+		if err := c.writeSpans(ctx, spans[:half]); err != nil {
+			return err
+		}
+		return c.writeSpans(ctx, spans[half:])
+	}
+	return writeError(err)
+}
+
+// writeError marks the write errors of the plugin which writing the spans again cannot resolve as permanent
+func writeError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.Unimplemented:
+		return spanstore.PermanentError{Err: fmt.Errorf("plugin error: %w", err)}
+	}
+	if isMessageTooLarge(err) {
+		// a single span which is larger than the receive limit of the plugin
+		return spanstore.PermanentError{Err: fmt.Errorf("plugin error: %w", err)}
+	}
+	return fmt.Errorf("plugin error: %w", err)
+}
+
+// isMessageTooLarge returns true if the plugin rejected the message because it exceeds its receive limit,
+// as opposed to other ResourceExhausted errors like a quota which writing the spans again may satisfy
+func isMessageTooLarge(err error) bool {
+	s := status.Convert(err)
+	return s.Code() == codes.ResourceExhausted && strings.Contains(s.Message(), "larger than max")
+}
+
 // GetDependencies returns all interservice dependencies
 func (c *grpcClient) GetDependencies(ctx context.Context, endTs time.Time, lookback time.Duration) ([]model.DependencyLink, error) {
 	resp, err := c.depsReaderClient.GetDependencies(ctx, &storage_v1.GetDependenciesRequest{
@@ -235,6 +318,9 @@ func (c *grpcClient) Capabilities() (*Capabilities, error) {
 	return &Capabilities{
 		ArchiveSpanReader: capabilities.ArchiveSpanReader,
 		ArchiveSpanWriter: capabilities.ArchiveSpanWriter,
+		BatchSpanWriter:   capabilities.BatchSpanWriter,
+		DependencyWriter:  capabilities.DependencyWriter,
+		SamplingStore:     capabilities.SamplingStore,
 	}, nil
 }
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -259,6 +260,16 @@ func TestGRPCClientFindTraces_Error(t *testing.T) {
 	})
 }
 
+func TestGRPCClientFindTraces_TagKeysNotSupported(t *testing.T) {
+	withGRPCClient(func(r *grpcClientTest) {
+		_, err := r.client.FindTraces(context.Background(), &spanstore.TraceQueryParameters{TagKeys: []string{"k"}})
+		assert.Equal(t, spanstore.ErrTagKeysAndValuesNotSupported, err)
+
+		_, err = r.client.FindTraceIDs(context.Background(), &spanstore.TraceQueryParameters{TagValues: map[string][]string{"k": {"v"}}})
+		assert.Equal(t, spanstore.ErrTagKeysAndValuesNotSupported, err)
+	})
+}
+
 func TestGRPCClientFindTraces_RecvError(t *testing.T) {
 	withGRPCClient(func(r *grpcClientTest) {
 		traceClient := new(grpcMocks.SpanReaderPlugin_FindTracesClient)
@@ -298,6 +309,79 @@ func TestGRPCClientWriteSpan(t *testing.T) {
 	})
 }
 
+func TestGRPCClientWriteSpanPermanentError(t *testing.T) {
+	withGRPCClient(func(r *grpcClientTest) {
+		r.spanWriter.On("WriteSpan", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.InvalidArgument, "invalid span")).Once()
+		r.spanWriter.On("WriteSpan", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Unavailable, "unavailable"))
+
+		err := r.client.WriteSpan(context.Background(), &mockTraceSpans[0])
+		assert.EqualError(t, err, "plugin error: rpc error: code = InvalidArgument desc = invalid span")
+		assert.False(t, spanstore.IsRetryable(err))
+		err = r.client.WriteSpan(context.Background(), &mockTraceSpans[0])
+		assert.True(t, spanstore.IsRetryable(err))
+	})
+}
+
+func TestGRPCClientWriteSpans(t *testing.T) {
+	withGRPCClient(func(r *grpcClientTest) {
+		spans := []*model.Span{&mockTraceSpans[0], &mockTraceSpans[1]}
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{
+			Spans: spans,
+		}).Return(&storage_v1.WriteSpansResponse{}, nil).Once()
+		r.spanWriter.On("WriteSpans", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Internal, "internal error"))
+
+		assert.NoError(t, r.client.WriteSpans(context.Background(), spans))
+		assert.EqualError(t, r.client.WriteSpans(context.Background(), spans),
+			"plugin error: rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestGRPCClientWriteSpansSplitsLargeBatches(t *testing.T) {
+	withGRPCClient(func(r *grpcClientTest) {
+		large := func(id uint64) *model.Span {
+			return &model.Span{
+				TraceID:       model.NewTraceID(0, id),
+				SpanID:        model.NewSpanID(id),
+				OperationName: strings.Repeat("x", maxWriteSpansBytes/2),
+			}
+		}
+		spans := []*model.Span{large(1), large(2), large(3)}
+		// the size limit splits the spans before they are sent
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{Spans: spans[:1]}).
+			Return(&storage_v1.WriteSpansResponse{}, nil).Once()
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{Spans: spans[1:2]}).
+			Return(&storage_v1.WriteSpansResponse{}, nil).Once()
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{Spans: spans[2:]}).
+			Return(&storage_v1.WriteSpansResponse{}, nil).Once()
+		assert.NoError(t, r.client.WriteSpans(context.Background(), spans))
+		r.spanWriter.AssertNumberOfCalls(t, "WriteSpans", 3)
+	})
+}
+
+func TestGRPCClientWriteSpansMessageTooLarge(t *testing.T) {
+	withGRPCClient(func(r *grpcClientTest) {
+		tooLarge := status.Error(codes.ResourceExhausted, "grpc: received message larger than max (5000000 vs. 4194304)")
+		spans := []*model.Span{&mockTraceSpans[0], &mockTraceSpans[1]}
+		// the plugin rejects the batch, but accepts its halves
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{Spans: spans}).
+			Return(nil, tooLarge).Once()
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{Spans: spans[:1]}).
+			Return(&storage_v1.WriteSpansResponse{}, nil).Once()
+		r.spanWriter.On("WriteSpans", mock.Anything, &storage_v1.WriteSpansRequest{Spans: spans[1:]}).
+			Return(nil, tooLarge).Once()
+		err := r.client.WriteSpans(context.Background(), spans)
+		// a single span which is too large can never be written
+		assert.False(t, spanstore.IsRetryable(err))
+
+		r.spanWriter.On("WriteSpans", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.ResourceExhausted, "quota exceeded"))
+		assert.True(t, spanstore.IsRetryable(r.client.WriteSpans(context.Background(), spans)))
+	})
+}
+
 func TestGRPCClientGetDependencies(t *testing.T) {
 	withGRPCClient(func(r *grpcClientTest) {
 		lookback := time.Duration(1 * time.Second)
@@ -408,13 +492,22 @@ func TestGrpcClientGetArchiveTrace_StreamErrorTraceNotFound(t *testing.T) {
 func TestGrpcClientCapabilities(t *testing.T) {
 	withGRPCClient(func(r *grpcClientTest) {
 		r.capabilities.On("Capabilities", mock.Anything, &storage_v1.CapabilitiesRequest{}).
-			Return(&storage_v1.CapabilitiesResponse{ArchiveSpanReader: true, ArchiveSpanWriter: true}, nil)
+			Return(&storage_v1.CapabilitiesResponse{
+				ArchiveSpanReader: true,
+				ArchiveSpanWriter: true,
+				BatchSpanWriter:   true,
+				DependencyWriter:  true,
+				SamplingStore:     true,
+			}, nil)
 
 		capabilities, err := r.client.Capabilities()
 		assert.NoError(t, err)
 		assert.Equal(t, &Capabilities{
 			ArchiveSpanReader: true,
 			ArchiveSpanWriter: true,
+			BatchSpanWriter:   true,
+			DependencyWriter:  true,
+			SamplingStore:     true,
 		}, capabilities)
 	})
 }
@@ -30,8 +30,10 @@ const spanBatchSize = 1000
 
 // grpcServer implements shared.StoragePlugin and reads/writes spans and dependencies
 type grpcServer struct {
-	Impl        StoragePlugin
-	ArchiveImpl ArchiveStoragePlugin
+	Impl                 StoragePlugin
+	ArchiveImpl          ArchiveStoragePlugin
+	DependencyWriterImpl DependencyWriterPlugin
+	SamplingStoreImpl    SamplingStorePlugin
 }
 
 // GetDependencies returns all interservice dependencies
@@ -45,6 +47,18 @@ func (s *grpcServer) GetDependencies(ctx context.Context, r *storage_v1.GetDepen
 	}, nil
 }
 
+// WriteDependencies saves dependency links
+func (s *grpcServer) WriteDependencies(ctx context.Context, r *storage_v1.WriteDependenciesRequest) (*storage_v1.WriteDependenciesResponse, error) {
+	if s.DependencyWriterImpl == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	err := s.DependencyWriterImpl.DependencyWriter().WriteDependencies(r.Timestamp, r.Dependencies)
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.WriteDependenciesResponse{}, nil
+}
+
 // WriteSpan saves the span
 func (s *grpcServer) WriteSpan(ctx context.Context, r *storage_v1.WriteSpanRequest) (*storage_v1.WriteSpanResponse, error) {
 	err := s.Impl.SpanWriter().WriteSpan(ctx, r.Span)
@@ -54,6 +68,23 @@ func (s *grpcServer) WriteSpan(ctx context.Context, r *storage_v1.WriteSpanReque
 	return &storage_v1.WriteSpanResponse{}, nil
 }
 
+// WriteSpans saves a batch of spans
+func (s *grpcServer) WriteSpans(ctx context.Context, r *storage_v1.WriteSpansRequest) (*storage_v1.WriteSpansResponse, error) {
+	writer := s.Impl.SpanWriter()
+	if batchWriter, ok := writer.(BatchSpanWriter); ok {
+		if err := batchWriter.WriteSpans(ctx, r.Spans); err != nil {
+			return nil, err
+		}
+		return &storage_v1.WriteSpansResponse{}, nil
+	}
+	for _, span := range r.Spans {
+		if err := writer.WriteSpan(ctx, span); err != nil {
+			return nil, err
+		}
+	}
+	return &storage_v1.WriteSpansResponse{}, nil
+}
+
 // GetTrace takes a traceID and streams a Trace associated with that traceID
 func (s *grpcServer) GetTrace(r *storage_v1.GetTraceRequest, stream storage_v1.SpanReaderPlugin_GetTraceServer) error {
 	trace, err := s.Impl.SpanReader().GetTrace(stream.Context(), r.TraceID)
@@ -172,6 +203,79 @@ func (s *grpcServer) Capabilities(ctx context.Context, request *storage_v1.Capab
 	return &storage_v1.CapabilitiesResponse{
 		ArchiveSpanReader: s.ArchiveImpl != nil,
 		ArchiveSpanWriter: s.ArchiveImpl != nil,
+		BatchSpanWriter:   true,
+		DependencyWriter:  s.DependencyWriterImpl != nil,
+		SamplingStore:     s.SamplingStoreImpl != nil,
+	}, nil
+}
+
+// InsertThroughput saves aggregated throughput for operations
+func (s *grpcServer) InsertThroughput(ctx context.Context, r *storage_v1.InsertThroughputRequest) (*storage_v1.InsertThroughputResponse, error) {
+	if s.SamplingStoreImpl == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	err := s.SamplingStoreImpl.SamplingStore().InsertThroughput(throughputFromProto(r.Throughput))
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.InsertThroughputResponse{}, nil
+}
+
+// InsertProbabilitiesAndQPS saves the sampling probabilities and measured qps calculated by a host
+func (s *grpcServer) InsertProbabilitiesAndQPS(ctx context.Context, r *storage_v1.InsertProbabilitiesAndQPSRequest) (*storage_v1.InsertProbabilitiesAndQPSResponse, error) {
+	if s.SamplingStoreImpl == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	err := s.SamplingStoreImpl.SamplingStore().InsertProbabilitiesAndQPS(
+		r.Hostname,
+		operationValuesFromProto(r.Probabilities),
+		operationValuesFromProto(r.Qps),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.InsertProbabilitiesAndQPSResponse{}, nil
+}
+
+// GetThroughput returns aggregated throughput for operations within a time range
+func (s *grpcServer) GetThroughput(ctx context.Context, r *storage_v1.GetThroughputRequest) (*storage_v1.GetThroughputResponse, error) {
+	if s.SamplingStoreImpl == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	throughput, err := s.SamplingStoreImpl.SamplingStore().GetThroughput(r.StartTime, r.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.GetThroughputResponse{
+		Throughput: throughputToProto(throughput),
+	}, nil
+}
+
+// GetProbabilitiesAndQPS returns the sampling probabilities and measured qps per host within a time range
+func (s *grpcServer) GetProbabilitiesAndQPS(ctx context.Context, r *storage_v1.GetProbabilitiesAndQPSRequest) (*storage_v1.GetProbabilitiesAndQPSResponse, error) {
+	if s.SamplingStoreImpl == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	hosts, err := s.SamplingStoreImpl.SamplingStore().GetProbabilitiesAndQPS(r.StartTime, r.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.GetProbabilitiesAndQPSResponse{
+		Hosts: hostDataToProto(hosts),
+	}, nil
+}
+
+// GetLatestProbabilities returns the latest sampling probabilities
+func (s *grpcServer) GetLatestProbabilities(ctx context.Context, r *storage_v1.GetLatestProbabilitiesRequest) (*storage_v1.GetLatestProbabilitiesResponse, error) {
+	if s.SamplingStoreImpl == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	probabilities, err := s.SamplingStoreImpl.SamplingStore().GetLatestProbabilities()
+	if err != nil {
+		return nil, err
+	}
+	return &storage_v1.GetLatestProbabilitiesResponse{
+		Probabilities: operationValuesToProto(probabilities),
 	}, nil
 }
 
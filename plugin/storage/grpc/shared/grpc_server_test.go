@@ -16,6 +16,7 @@ package shared
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -25,11 +26,14 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	samplingModel "github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
 	grpcMocks "github.com/jaegertracing/jaeger/proto-gen/storage_v1/mocks"
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
 	dependencyStoreMocks "github.com/jaegertracing/jaeger/storage/dependencystore/mocks"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	samplingStoreMocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 	spanStoreMocks "github.com/jaegertracing/jaeger/storage/spanstore/mocks"
 )
@@ -40,6 +44,8 @@ type mockStoragePlugin struct {
 	archiveReader *spanStoreMocks.Reader
 	archiveWriter *spanStoreMocks.Writer
 	depsReader    *dependencyStoreMocks.Reader
+	depsWriter    *dependencyStoreMocks.Writer
+	samplingStore *samplingStoreMocks.Store
 }
 
 func (plugin *mockStoragePlugin) ArchiveSpanReader() spanstore.Reader {
@@ -62,6 +68,14 @@ func (plugin *mockStoragePlugin) DependencyReader() dependencystore.Reader {
 	return plugin.depsReader
 }
 
+func (plugin *mockStoragePlugin) DependencyWriter() dependencystore.Writer {
+	return plugin.depsWriter
+}
+
+func (plugin *mockStoragePlugin) SamplingStore() samplingstore.Store {
+	return plugin.samplingStore
+}
+
 type grpcServerTest struct {
 	server *grpcServer
 	impl   *mockStoragePlugin
@@ -73,6 +87,8 @@ func withGRPCServer(fn func(r *grpcServerTest)) {
 	archiveReader := new(spanStoreMocks.Reader)
 	archiveWriter := new(spanStoreMocks.Writer)
 	depReader := new(dependencyStoreMocks.Reader)
+	depWriter := new(dependencyStoreMocks.Writer)
+	samplingStore := new(samplingStoreMocks.Store)
 
 	impl := &mockStoragePlugin{
 		spanReader:    spanReader,
@@ -80,12 +96,16 @@ func withGRPCServer(fn func(r *grpcServerTest)) {
 		archiveReader: archiveReader,
 		archiveWriter: archiveWriter,
 		depsReader:    depReader,
+		depsWriter:    depWriter,
+		samplingStore: samplingStore,
 	}
 
 	r := &grpcServerTest{
 		server: &grpcServer{
-			Impl:        impl,
-			ArchiveImpl: impl,
+			Impl:                 impl,
+			ArchiveImpl:          impl,
+			DependencyWriterImpl: impl,
+			SamplingStoreImpl:    impl,
 		},
 		impl: impl,
 	}
@@ -221,6 +241,57 @@ func TestGRPCServerWriteSpan(t *testing.T) {
 	})
 }
 
+func TestGRPCServerWriteSpans(t *testing.T) {
+	withGRPCServer(func(r *grpcServerTest) {
+		r.impl.spanWriter.On("WriteSpan", context.Background(), &mockTraceSpans[0]).
+			Return(nil)
+		r.impl.spanWriter.On("WriteSpan", context.Background(), &mockTraceSpans[1]).
+			Return(errors.New("made-up error"))
+
+		s, err := r.server.WriteSpans(context.Background(), &storage_v1.WriteSpansRequest{
+			Spans: []*model.Span{&mockTraceSpans[0]},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &storage_v1.WriteSpansResponse{}, s)
+
+		_, err = r.server.WriteSpans(context.Background(), &storage_v1.WriteSpansRequest{
+			Spans: []*model.Span{&mockTraceSpans[0], &mockTraceSpans[1]},
+		})
+		assert.EqualError(t, err, "made-up error")
+	})
+}
+
+type batchSpanWriter struct {
+	*spanStoreMocks.Writer
+	batches [][]*model.Span
+}
+
+func (w *batchSpanWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	w.batches = append(w.batches, spans)
+	return nil
+}
+
+type batchStoragePlugin struct {
+	mockStoragePlugin
+	writer *batchSpanWriter
+}
+
+func (plugin *batchStoragePlugin) SpanWriter() spanstore.Writer {
+	return plugin.writer
+}
+
+func TestGRPCServerWriteSpansBatchWriter(t *testing.T) {
+	writer := &batchSpanWriter{Writer: new(spanStoreMocks.Writer)}
+	server := &grpcServer{Impl: &batchStoragePlugin{writer: writer}}
+
+	spans := []*model.Span{&mockTraceSpans[0], &mockTraceSpans[1]}
+	s, err := server.WriteSpans(context.Background(), &storage_v1.WriteSpansRequest{Spans: spans})
+	assert.NoError(t, err)
+	assert.Equal(t, &storage_v1.WriteSpansResponse{}, s)
+	assert.Equal(t, [][]*model.Span{spans}, writer.batches)
+	writer.AssertNotCalled(t, "WriteSpan", mock.Anything, mock.Anything)
+}
+
 func TestGRPCServerGetDependencies(t *testing.T) {
 	withGRPCServer(func(r *grpcServerTest) {
 		lookback := time.Duration(1 * time.Second)
@@ -370,7 +441,13 @@ func TestGRPCServerCapabilities(t *testing.T) {
 	withGRPCServer(func(r *grpcServerTest) {
 		capabilities, err := r.server.Capabilities(context.Background(), &storage_v1.CapabilitiesRequest{})
 		assert.NoError(t, err)
-		assert.Equal(t, &storage_v1.CapabilitiesResponse{ArchiveSpanReader: true, ArchiveSpanWriter: true}, capabilities)
+		assert.Equal(t, &storage_v1.CapabilitiesResponse{
+			ArchiveSpanReader: true,
+			ArchiveSpanWriter: true,
+			BatchSpanWriter:   true,
+			DependencyWriter:  true,
+			SamplingStore:     true,
+		}, capabilities)
 	})
 }
 
@@ -380,6 +457,81 @@ func TestGRPCServerCapabilities_NoArchive(t *testing.T) {
 
 		capabilities, err := r.server.Capabilities(context.Background(), &storage_v1.CapabilitiesRequest{})
 		assert.NoError(t, err)
-		assert.Equal(t, &storage_v1.CapabilitiesResponse{ArchiveSpanReader: false, ArchiveSpanWriter: false}, capabilities)
+		assert.Equal(t, &storage_v1.CapabilitiesResponse{
+			ArchiveSpanReader: false,
+			ArchiveSpanWriter: false,
+			BatchSpanWriter:   true,
+			DependencyWriter:  true,
+			SamplingStore:     true,
+		}, capabilities)
+	})
+}
+
+func TestGRPCServerCapabilities_NoDependencyWriterOrSamplingStore(t *testing.T) {
+	withGRPCServer(func(r *grpcServerTest) {
+		r.server.DependencyWriterImpl = nil
+		r.server.SamplingStoreImpl = nil
+
+		capabilities, err := r.server.Capabilities(context.Background(), &storage_v1.CapabilitiesRequest{})
+		assert.NoError(t, err)
+		assert.False(t, capabilities.DependencyWriter)
+		assert.False(t, capabilities.SamplingStore)
+
+		_, err = r.server.WriteDependencies(context.Background(), &storage_v1.WriteDependenciesRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = r.server.InsertThroughput(context.Background(), &storage_v1.InsertThroughputRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = r.server.InsertProbabilitiesAndQPS(context.Background(), &storage_v1.InsertProbabilitiesAndQPSRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = r.server.GetThroughput(context.Background(), &storage_v1.GetThroughputRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = r.server.GetProbabilitiesAndQPS(context.Background(), &storage_v1.GetProbabilitiesAndQPSRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		_, err = r.server.GetLatestProbabilities(context.Background(), &storage_v1.GetLatestProbabilitiesRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestGRPCServerWriteDependencies(t *testing.T) {
+	withGRPCServer(func(r *grpcServerTest) {
+		ts := time.Now()
+		deps := []model.DependencyLink{{Parent: "parent", Child: "child", CallCount: 1}}
+		r.impl.depsWriter.On("WriteDependencies", ts, deps).Return(nil).Once()
+		r.impl.depsWriter.On("WriteDependencies", ts, deps).Return(errors.New("made-up error"))
+
+		s, err := r.server.WriteDependencies(context.Background(), &storage_v1.WriteDependenciesRequest{
+			Timestamp:    ts,
+			Dependencies: deps,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &storage_v1.WriteDependenciesResponse{}, s)
+
+		_, err = r.server.WriteDependencies(context.Background(), &storage_v1.WriteDependenciesRequest{
+			Timestamp:    ts,
+			Dependencies: deps,
+		})
+		assert.EqualError(t, err, "made-up error")
+	})
+}
+
+func TestGRPCServerSamplingStoreErrors(t *testing.T) {
+	withGRPCServer(func(r *grpcServerTest) {
+		store := r.impl.samplingStore
+		store.On("InsertThroughput", mock.Anything).Return(errors.New("made-up error"))
+		store.On("InsertProbabilitiesAndQPS", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("made-up error"))
+		store.On("GetThroughput", mock.Anything, mock.Anything).Return(nil, errors.New("made-up error"))
+		store.On("GetProbabilitiesAndQPS", mock.Anything, mock.Anything).Return(nil, errors.New("made-up error"))
+		store.On("GetLatestProbabilities").Return(samplingModel.ServiceOperationProbabilities(nil), errors.New("made-up error"))
+
+		_, err := r.server.InsertThroughput(context.Background(), &storage_v1.InsertThroughputRequest{})
+		assert.EqualError(t, err, "made-up error")
+		_, err = r.server.InsertProbabilitiesAndQPS(context.Background(), &storage_v1.InsertProbabilitiesAndQPSRequest{})
+		assert.EqualError(t, err, "made-up error")
+		_, err = r.server.GetThroughput(context.Background(), &storage_v1.GetThroughputRequest{})
+		assert.EqualError(t, err, "made-up error")
+		_, err = r.server.GetProbabilitiesAndQPS(context.Background(), &storage_v1.GetProbabilitiesAndQPSRequest{})
+		assert.EqualError(t, err, "made-up error")
+		_, err = r.server.GetLatestProbabilities(context.Background(), &storage_v1.GetLatestProbabilitiesRequest{})
+		assert.EqualError(t, err, "made-up error")
 	})
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/go-plugin"
 
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -48,6 +49,21 @@ type ArchiveStoragePlugin interface {
 	ArchiveSpanWriter() spanstore.Writer
 }
 
+// DependencyWriterPlugin is the interface a plugin implements to store dependency links,
+// e.g. the output of the Spark dependencies job.
+type DependencyWriterPlugin interface {
+	DependencyWriter() dependencystore.Writer
+}
+
+// SamplingStorePlugin is the interface a plugin implements to back adaptive sampling.
+type SamplingStorePlugin interface {
+	SamplingStore() samplingstore.Store
+}
+
+// BatchSpanWriter writes several spans with a single call to the plugin.
+// It is only used when the plugin advertises the BatchSpanWriter capability.
+type BatchSpanWriter = spanstore.BatchWriter
+
 // PluginCapabilities allow expose plugin its capabilities.
 type PluginCapabilities interface {
 	Capabilities() (*Capabilities, error)
@@ -57,10 +73,15 @@ type PluginCapabilities interface {
 type Capabilities struct {
 	ArchiveSpanReader bool
 	ArchiveSpanWriter bool
+	BatchSpanWriter   bool
+	DependencyWriter  bool
+	SamplingStore     bool
 }
 
 // PluginServices defines services plugin can expose
 type PluginServices struct {
-	Store        StoragePlugin
-	ArchiveStore ArchiveStoragePlugin
+	Store           StoragePlugin
+	ArchiveStore    ArchiveStoragePlugin
+	DependencyStore DependencyWriterPlugin
+	SamplingStore   SamplingStorePlugin
 }
@@ -31,15 +31,31 @@ type StorageGRPCPlugin struct {
 	plugin.Plugin
 
 	// Concrete implementation, This is only used for plugins that are written in Go.
-	Impl        StoragePlugin
-	ArchiveImpl ArchiveStoragePlugin
+	Impl                 StoragePlugin
+	ArchiveImpl          ArchiveStoragePlugin
+	DependencyWriterImpl DependencyWriterPlugin
+	SamplingStoreImpl    SamplingStorePlugin
 }
 
 // GRPCServer implements plugin.GRPCPlugin. It is used by go-plugin to create a grpc plugin server.
 func (p *StorageGRPCPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	RegisterGRPCServices(s, &PluginServices{
+		Store:           p.Impl,
+		ArchiveStore:    p.ArchiveImpl,
+		DependencyStore: p.DependencyWriterImpl,
+		SamplingStore:   p.SamplingStoreImpl,
+	})
+	return nil
+}
+
+// RegisterGRPCServices registers the storage_v1 services backed by the given plugin services on s.
+// It is used to serve the storage over the network instead of as a go-plugin sub-process.
+func RegisterGRPCServices(s *grpc.Server, services *PluginServices) {
 	server := &grpcServer{
-		Impl:        p.Impl,
-		ArchiveImpl: p.ArchiveImpl,
+		Impl:                 services.Store,
+		ArchiveImpl:          services.ArchiveStore,
+		DependencyWriterImpl: services.DependencyStore,
+		SamplingStoreImpl:    services.SamplingStore,
 	}
 	storage_v1.RegisterSpanReaderPluginServer(s, server)
 	storage_v1.RegisterSpanWriterPluginServer(s, server)
@@ -47,11 +63,28 @@ func (p *StorageGRPCPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server
 	storage_v1.RegisterArchiveSpanWriterPluginServer(s, server)
 	storage_v1.RegisterPluginCapabilitiesServer(s, server)
 	storage_v1.RegisterDependenciesReaderPluginServer(s, server)
-	return nil
+	storage_v1.RegisterDependenciesWriterPluginServer(s, server)
+	storage_v1.RegisterSamplingStorePluginServer(s, server)
 }
 
 // GRPCClient implements plugin.GRPCPlugin. It is used by go-plugin to create a grpc plugin client.
 func (*StorageGRPCPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return newGRPCClient(c), nil
+}
+
+// NewGRPCClientServices returns the plugin services and capabilities backed by the storage_v1 services
+// served on the connection, e.g. by a storage server running as its own deployment.
+func NewGRPCClientServices(c *grpc.ClientConn) (*PluginServices, PluginCapabilities) {
+	client := newGRPCClient(c)
+	return &PluginServices{
+		Store:           client,
+		ArchiveStore:    client,
+		DependencyStore: client,
+		SamplingStore:   client,
+	}, client
+}
+
+func newGRPCClient(c *grpc.ClientConn) *grpcClient {
 	return &grpcClient{
 		readerClient:        storage_v1.NewSpanReaderPluginClient(c),
 		writerClient:        storage_v1.NewSpanWriterPluginClient(c),
@@ -59,5 +92,7 @@ func (*StorageGRPCPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBro
 		archiveWriterClient: storage_v1.NewArchiveSpanWriterPluginClient(c),
 		capabilitiesClient:  storage_v1.NewPluginCapabilitiesClient(c),
 		depsReaderClient:    storage_v1.NewDependenciesReaderPluginClient(c),
-	}, nil
+		depsWriterClient:    storage_v1.NewDependenciesWriterPluginClient(c),
+		samplingStoreClient: storage_v1.NewSamplingStorePluginClient(c),
+	}
 }
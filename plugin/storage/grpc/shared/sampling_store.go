@@ -0,0 +1,232 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+)
+
+var _ samplingstore.Store = (*samplingStore)(nil)
+
+// samplingStore wraps storage_v1.SamplingStorePluginClient into samplingstore.Store
+type samplingStore struct {
+	client storage_v1.SamplingStorePluginClient
+}
+
+// InsertThroughput saves aggregated throughput for operations
+func (s *samplingStore) InsertThroughput(throughput []*model.Throughput) error {
+	_, err := s.client.InsertThroughput(context.Background(), &storage_v1.InsertThroughputRequest{
+		Throughput: throughputToProto(throughput),
+	})
+	if err != nil {
+		return fmt.Errorf("plugin error: %w", err)
+	}
+
+	return nil
+}
+
+// InsertProbabilitiesAndQPS saves the sampling probabilities and measured qps calculated by a host
+func (s *samplingStore) InsertProbabilitiesAndQPS(
+	hostname string,
+	probabilities model.ServiceOperationProbabilities,
+	qps model.ServiceOperationQPS,
+) error {
+	_, err := s.client.InsertProbabilitiesAndQPS(context.Background(), &storage_v1.InsertProbabilitiesAndQPSRequest{
+		Hostname:      hostname,
+		Probabilities: operationValuesToProto(probabilities),
+		Qps:           operationValuesToProto(qps),
+	})
+	if err != nil {
+		return fmt.Errorf("plugin error: %w", err)
+	}
+
+	return nil
+}
+
+// GetThroughput returns aggregated throughput for operations within a time range
+func (s *samplingStore) GetThroughput(start, end time.Time) ([]*model.Throughput, error) {
+	resp, err := s.client.GetThroughput(context.Background(), &storage_v1.GetThroughputRequest{
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin error: %w", err)
+	}
+
+	return throughputFromProto(resp.Throughput), nil
+}
+
+// GetProbabilitiesAndQPS returns the sampling probabilities and measured qps per host within a time range
+func (s *samplingStore) GetProbabilitiesAndQPS(start, end time.Time) (map[string][]model.ServiceOperationData, error) {
+	resp, err := s.client.GetProbabilitiesAndQPS(context.Background(), &storage_v1.GetProbabilitiesAndQPSRequest{
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin error: %w", err)
+	}
+
+	return hostDataFromProto(resp.Hosts), nil
+}
+
+// GetLatestProbabilities returns the latest sampling probabilities
+func (s *samplingStore) GetLatestProbabilities() (model.ServiceOperationProbabilities, error) {
+	resp, err := s.client.GetLatestProbabilities(context.Background(), &storage_v1.GetLatestProbabilitiesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("plugin error: %w", err)
+	}
+
+	return operationValuesFromProto(resp.Probabilities), nil
+}
+
+func throughputToProto(throughput []*model.Throughput) []*storage_v1.Throughput {
+	var result []*storage_v1.Throughput
+	for _, t := range throughput {
+		probabilities := make([]string, 0, len(t.Probabilities))
+		for probability := range t.Probabilities {
+			probabilities = append(probabilities, probability)
+		}
+		sort.Strings(probabilities)
+		result = append(result, &storage_v1.Throughput{
+			Service:       t.Service,
+			Operation:     t.Operation,
+			Count:         t.Count,
+			Probabilities: probabilities,
+		})
+	}
+	return result
+}
+
+func throughputFromProto(throughput []*storage_v1.Throughput) []*model.Throughput {
+	var result []*model.Throughput
+	for _, t := range throughput {
+		probabilities := make(map[string]struct{}, len(t.Probabilities))
+		for _, probability := range t.Probabilities {
+			probabilities[probability] = struct{}{}
+		}
+		result = append(result, &model.Throughput{
+			Service:       t.Service,
+			Operation:     t.Operation,
+			Count:         t.Count,
+			Probabilities: probabilities,
+		})
+	}
+	return result
+}
+
+// operationValuesToProto flattens [service][operation] = value maps, such as probabilities or qps,
+// in a deterministic order.
+func operationValuesToProto(values map[string]map[string]float64) []*storage_v1.OperationValue {
+	services := make([]string, 0, len(values))
+	for service := range values {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	var result []*storage_v1.OperationValue
+	for _, service := range services {
+		operations := values[service]
+		names := make([]string, 0, len(operations))
+		for operation := range operations {
+			names = append(names, operation)
+		}
+		sort.Strings(names)
+		for _, operation := range names {
+			result = append(result, &storage_v1.OperationValue{
+				Service:   service,
+				Operation: operation,
+				Value:     operations[operation],
+			})
+		}
+	}
+	return result
+}
+
+func operationValuesFromProto(values []*storage_v1.OperationValue) map[string]map[string]float64 {
+	result := make(map[string]map[string]float64)
+	for _, v := range values {
+		if _, ok := result[v.Service]; !ok {
+			result[v.Service] = make(map[string]float64)
+		}
+		result[v.Service][v.Operation] = v.Value
+	}
+	return result
+}
+
+func hostDataToProto(hosts map[string][]model.ServiceOperationData) []storage_v1.HostServiceOperationData {
+	hostnames := make([]string, 0, len(hosts))
+	for hostname := range hosts {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+	var result []storage_v1.HostServiceOperationData
+	for _, hostname := range hostnames {
+		host := storage_v1.HostServiceOperationData{Hostname: hostname}
+		for _, data := range hosts[hostname] {
+			host.Data = append(host.Data, storage_v1.ServiceOperationData{
+				Operations: serviceOperationDataToProto(data),
+			})
+		}
+		result = append(result, host)
+	}
+	return result
+}
+
+func serviceOperationDataToProto(data model.ServiceOperationData) []*storage_v1.OperationProbabilityAndQPS {
+	// probabilities and qps share the keys, so they are flattened the same way
+	probabilities := make(map[string]map[string]float64, len(data))
+	for service, operations := range data {
+		probabilities[service] = make(map[string]float64, len(operations))
+		for operation, value := range operations {
+			probabilities[service][operation] = value.Probability
+		}
+	}
+	var result []*storage_v1.OperationProbabilityAndQPS
+	for _, v := range operationValuesToProto(probabilities) {
+		result = append(result, &storage_v1.OperationProbabilityAndQPS{
+			Service:     v.Service,
+			Operation:   v.Operation,
+			Probability: v.Value,
+			Qps:         data[v.Service][v.Operation].QPS,
+		})
+	}
+	return result
+}
+
+func hostDataFromProto(hosts []storage_v1.HostServiceOperationData) map[string][]model.ServiceOperationData {
+	result := make(map[string][]model.ServiceOperationData)
+	for _, host := range hosts {
+		for _, data := range host.Data {
+			serviceData := make(model.ServiceOperationData)
+			for _, v := range data.Operations {
+				if _, ok := serviceData[v.Service]; !ok {
+					serviceData[v.Service] = make(map[string]*model.ProbabilityAndQPS)
+				}
+				serviceData[v.Service][v.Operation] = &model.ProbabilityAndQPS{
+					Probability: v.Probability,
+					QPS:         v.Qps,
+				}
+			}
+			result[host.Hostname] = append(result[host.Hostname], serviceData)
+		}
+	}
+	return result
+}
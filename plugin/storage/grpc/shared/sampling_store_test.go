@@ -0,0 +1,149 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	grpcMocks "github.com/jaegertracing/jaeger/proto-gen/storage_v1/mocks"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	samplingStoreMocks "github.com/jaegertracing/jaeger/storage/samplingstore/mocks"
+)
+
+type samplingStorePlugin struct {
+	store samplingstore.Store
+}
+
+func (p *samplingStorePlugin) SamplingStore() samplingstore.Store {
+	return p.store
+}
+
+// withSamplingRoundTrip connects the client side sampling store to the server side
+// through mocked gRPC clients, so that both conversions are exercised.
+func withSamplingRoundTrip(fn func(store *samplingStoreMocks.Store, client samplingstore.Store)) {
+	store := new(samplingStoreMocks.Store)
+	server := &grpcServer{SamplingStoreImpl: &samplingStorePlugin{store: store}}
+
+	grpcClient := new(grpcMocks.SamplingStorePluginClient)
+	grpcClient.On("InsertThroughput", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, r *storage_v1.InsertThroughputRequest, _ ...grpc.CallOption) *storage_v1.InsertThroughputResponse {
+			resp, _ := server.InsertThroughput(ctx, r)
+			return resp
+		}, nil)
+	grpcClient.On("InsertProbabilitiesAndQPS", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, r *storage_v1.InsertProbabilitiesAndQPSRequest, _ ...grpc.CallOption) *storage_v1.InsertProbabilitiesAndQPSResponse {
+			resp, _ := server.InsertProbabilitiesAndQPS(ctx, r)
+			return resp
+		}, nil)
+	grpcClient.On("GetThroughput", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, r *storage_v1.GetThroughputRequest, _ ...grpc.CallOption) *storage_v1.GetThroughputResponse {
+			resp, _ := server.GetThroughput(ctx, r)
+			return resp
+		}, nil)
+	grpcClient.On("GetProbabilitiesAndQPS", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, r *storage_v1.GetProbabilitiesAndQPSRequest, _ ...grpc.CallOption) *storage_v1.GetProbabilitiesAndQPSResponse {
+			resp, _ := server.GetProbabilitiesAndQPS(ctx, r)
+			return resp
+		}, nil)
+	grpcClient.On("GetLatestProbabilities", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, r *storage_v1.GetLatestProbabilitiesRequest, _ ...grpc.CallOption) *storage_v1.GetLatestProbabilitiesResponse {
+			resp, _ := server.GetLatestProbabilities(ctx, r)
+			return resp
+		}, nil)
+
+	fn(store, &samplingStore{client: grpcClient})
+}
+
+func TestSamplingStoreThroughput(t *testing.T) {
+	withSamplingRoundTrip(func(store *samplingStoreMocks.Store, client samplingstore.Store) {
+		start := time.Unix(100, 0).UTC()
+		end := time.Unix(200, 0).UTC()
+		throughput := []*model.Throughput{
+			{Service: "svc", Operation: "op", Count: 40, Probabilities: map[string]struct{}{"0.1": {}, "0.5": {}}},
+			{Service: "svc", Operation: "op2", Count: 2, Probabilities: map[string]struct{}{}},
+		}
+		store.On("InsertThroughput", throughput).Return(nil)
+		store.On("GetThroughput", start, end).Return(throughput, nil)
+
+		require.NoError(t, client.InsertThroughput(throughput))
+		actual, err := client.GetThroughput(start, end)
+		require.NoError(t, err)
+		assert.Equal(t, throughput, actual)
+		store.AssertExpectations(t)
+	})
+}
+
+func TestSamplingStoreProbabilitiesAndQPS(t *testing.T) {
+	withSamplingRoundTrip(func(store *samplingStoreMocks.Store, client samplingstore.Store) {
+		start := time.Unix(100, 0).UTC()
+		end := time.Unix(200, 0).UTC()
+		probabilities := model.ServiceOperationProbabilities{
+			"svc":  {"op": 0.1, "op2": 0.2},
+			"svc2": {"op": 1},
+		}
+		qps := model.ServiceOperationQPS{
+			"svc": {"op": 30, "op2": 4},
+		}
+		hosts := map[string][]model.ServiceOperationData{
+			"host-a": {
+				{"svc": {"op": {Probability: 0.1, QPS: 30}}},
+				{"svc": {"op": {Probability: 0.2, QPS: 25}, "op2": {Probability: 1, QPS: 2}}},
+			},
+			"host-b": {
+				{"svc2": {"op": {Probability: 0.5, QPS: 3}}},
+			},
+		}
+		store.On("InsertProbabilitiesAndQPS", "host-a", probabilities, qps).Return(nil)
+		store.On("GetProbabilitiesAndQPS", start, end).Return(hosts, nil)
+		store.On("GetLatestProbabilities").Return(probabilities, nil)
+
+		require.NoError(t, client.InsertProbabilitiesAndQPS("host-a", probabilities, qps))
+		actualHosts, err := client.GetProbabilitiesAndQPS(start, end)
+		require.NoError(t, err)
+		assert.Equal(t, hosts, actualHosts)
+		actualProbabilities, err := client.GetLatestProbabilities()
+		require.NoError(t, err)
+		assert.Equal(t, probabilities, actualProbabilities)
+		store.AssertExpectations(t)
+	})
+}
+
+func TestSamplingStoreErrors(t *testing.T) {
+	grpcClient := new(grpcMocks.SamplingStorePluginClient)
+	for _, method := range []string{"InsertThroughput", "InsertProbabilitiesAndQPS", "GetThroughput", "GetProbabilitiesAndQPS", "GetLatestProbabilities"} {
+		grpcClient.On(method, mock.Anything, mock.Anything).Return(nil, errors.New("made-up error"))
+	}
+	client := &samplingStore{client: grpcClient}
+	expectedErr := "plugin error: made-up error"
+
+	assert.EqualError(t, client.InsertThroughput(nil), expectedErr)
+	assert.EqualError(t, client.InsertProbabilitiesAndQPS("host", nil, nil), expectedErr)
+	_, err := client.GetThroughput(time.Now(), time.Now())
+	assert.EqualError(t, err, expectedErr)
+	_, err = client.GetProbabilitiesAndQPS(time.Now(), time.Now())
+	assert.EqualError(t, err, expectedErr)
+	_, err = client.GetLatestProbabilities()
+	assert.EqualError(t, err, expectedErr)
+}
@@ -140,6 +140,35 @@ func (s *StorageIntegration) testCassandraGetDependencies(t *testing.T) {
 	assert.EqualValues(t, expected, actual)
 }
 
+// TODO: Remove once the Cassandra storage runs the whole testFindTraces.
+func (s *StorageIntegration) testCassandraFindTracesByDurationAndTags(t *testing.T) {
+	defer s.cleanUp(t)
+
+	var fixtures []*QueryFixtures
+	for _, queryTestCase := range LoadAndParseQueryTestCases(t, "fixtures/queries.json") {
+		query := queryTestCase.Query
+		if len(query.Tags) > 0 && (query.DurationMin != 0 || query.DurationMax != 0) {
+			fixtures = append(fixtures, queryTestCase)
+		}
+	}
+	require.NotEmpty(t, fixtures)
+	expectedTraces := make([][]*model.Trace, len(fixtures))
+	for i, queryTestCase := range fixtures {
+		for _, traceFixture := range queryTestCase.ExpectedFixtures {
+			trace := s.getTraceFixture(t, traceFixture)
+			require.NoError(t, s.writeTrace(t, trace), "Unexpected error when writing trace %s to storage", traceFixture)
+			expectedTraces[i] = append(expectedTraces[i], trace)
+		}
+	}
+	s.refresh(t)
+	for i, queryTestCase := range fixtures {
+		t.Run(queryTestCase.Caption, func(t *testing.T) {
+			actual := s.findTracesByQuery(t, queryTestCase.Query, expectedTraces[i])
+			CompareSliceOfTraces(t, expectedTraces[i], actual)
+		})
+	}
+}
+
 func TestCassandraStorage(t *testing.T) {
 	if os.Getenv("STORAGE") != "cassandra" {
 		t.Skip("Integration test against Cassandra skipped; set STORAGE env var to cassandra to run this")
@@ -151,4 +180,5 @@ func TestCassandraStorage(t *testing.T) {
 	// TODO: Support all other tests.
 	t.Run("GetDependencies", s1.testCassandraGetDependencies)
 	t.Run("GetDependenciesV2", s2.testCassandraGetDependencies)
+	t.Run("FindTracesByDurationAndTags", s1.testCassandraFindTracesByDurationAndTags)
 }
@@ -18,9 +18,9 @@ package integration
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
-	"strconv"
 	"testing"
 	"time"
 
@@ -31,6 +31,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/pkg/es/config"
 	eswrapper "github.com/jaegertracing/jaeger/pkg/es/wrapper"
 	"github.com/jaegertracing/jaeger/pkg/testutils"
 	"github.com/jaegertracing/jaeger/plugin/storage/es"
@@ -58,15 +59,15 @@ type ESStorageIntegration struct {
 }
 
 func (s *ESStorageIntegration) getVersion() (uint, error) {
-	pingResult, _, err := s.client.Ping(queryURL).Do(context.Background())
+	info, err := config.DetectCluster(context.Background(), s.client)
 	if err != nil {
 		return 0, err
 	}
-	esVersion, err := strconv.Atoi(string(pingResult.Version.Number[0]))
-	if err != nil {
-		return 0, err
+	// the suite runs against OpenSearch with STORAGE=opensearch, make sure it talks to the expected engine
+	if info.Distribution != os.Getenv("STORAGE") {
+		return 0, fmt.Errorf("expected %s cluster, detected %s %s", os.Getenv("STORAGE"), info.Distribution, info.Number)
 	}
-	return uint(esVersion), nil
+	return info.Version, nil
 }
 
 func (s *ESStorageIntegration) initializeES(allTagsAsFields, archive bool) error {
@@ -163,8 +164,8 @@ func healthCheck() error {
 }
 
 func testElasticsearchStorage(t *testing.T, allTagsAsFields, archive bool) {
-	if os.Getenv("STORAGE") != "elasticsearch" {
-		t.Skip("Integration test against ElasticSearch skipped; set STORAGE env var to elasticsearch to run this")
+	if storage := os.Getenv("STORAGE"); storage != config.ElasticsearchDistribution && storage != config.OpenSearchDistribution {
+		t.Skip("Integration test against ElasticSearch skipped; set STORAGE env var to elasticsearch or opensearch to run this")
 	}
 	if err := healthCheck(); err != nil {
 		t.Fatal(err)
@@ -16,6 +16,7 @@
 package integration
 
 import (
+	"net"
 	"os"
 	"testing"
 
@@ -26,6 +27,10 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/config"
 	"github.com/jaegertracing/jaeger/pkg/testutils"
 	"github.com/jaegertracing/jaeger/plugin/storage/grpc"
+	"github.com/jaegertracing/jaeger/plugin/storage/grpc/shared"
+	"github.com/jaegertracing/jaeger/plugin/storage/memory"
+	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
 const defaultPluginBinaryPath = "../../../examples/memstore-plugin/memstore-plugin"
@@ -34,17 +39,28 @@ type GRPCStorageIntegrationTestSuite struct {
 	StorageIntegration
 	logger           *zap.Logger
 	pluginBinaryPath string
+	// remote runs the storage as an in-process server reached over the network instead of the plugin binary
+	remote     bool
+	stopRemote func()
 }
 
 func (s *GRPCStorageIntegrationTestSuite) initialize() error {
 	s.logger, _ = testutils.NewLogger()
 
-	f := grpc.NewFactory()
-	v, command := config.Viperize(f.AddFlags)
-	err := command.ParseFlags([]string{
+	flags := []string{
 		"--grpc-storage-plugin.binary",
 		s.pluginBinaryPath,
-	})
+	}
+	if s.remote {
+		addr, err := s.startRemoteServer()
+		if err != nil {
+			return err
+		}
+		flags = []string{"--grpc-storage.server=" + addr}
+	}
+	f := grpc.NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	err := command.ParseFlags(flags)
 	if err != nil {
 		return err
 	}
@@ -67,6 +83,25 @@ func (s *GRPCStorageIntegrationTestSuite) initialize() error {
 	return nil
 }
 
+// startRemoteServer serves a fresh memory store, replacing the previously started server.
+func (s *GRPCStorageIntegrationTestSuite) startRemoteServer() (string, error) {
+	if s.stopRemote != nil {
+		s.stopRemote()
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	store := &memoryStoragePlugin{store: memory.NewStore(), archiveStore: memory.NewStore()}
+	server := grpc.NewRemoteServer(&shared.PluginServices{
+		Store:        store,
+		ArchiveStore: store,
+	})
+	go server.Serve(lis)
+	s.stopRemote = server.Stop
+	return lis.Addr().String(), nil
+}
+
 func (s *GRPCStorageIntegrationTestSuite) refresh() error {
 	return nil
 }
@@ -90,3 +125,40 @@ func TestGRPCStorage(t *testing.T) {
 	require.NoError(t, s.initialize())
 	s.IntegrationTestAll(t)
 }
+
+func TestGRPCRemoteStorage(t *testing.T) {
+	if os.Getenv("STORAGE") != "grpc-plugin" {
+		t.Skip("Integration test against grpc skipped; set STORAGE env var to grpc-plugin to run this")
+	}
+	s := &GRPCStorageIntegrationTestSuite{
+		remote: true,
+	}
+	require.NoError(t, s.initialize())
+	defer s.stopRemote()
+	s.IntegrationTestAll(t)
+}
+
+type memoryStoragePlugin struct {
+	store        *memory.Store
+	archiveStore *memory.Store
+}
+
+func (p *memoryStoragePlugin) DependencyReader() dependencystore.Reader {
+	return p.store
+}
+
+func (p *memoryStoragePlugin) SpanReader() spanstore.Reader {
+	return p.store
+}
+
+func (p *memoryStoragePlugin) SpanWriter() spanstore.Writer {
+	return p.store
+}
+
+func (p *memoryStoragePlugin) ArchiveSpanReader() spanstore.Reader {
+	return p.archiveStore
+}
+
+func (p *memoryStoragePlugin) ArchiveSpanWriter() spanstore.Writer {
+	return p.archiveStore
+}
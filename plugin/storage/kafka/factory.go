@@ -17,7 +17,9 @@ package kafka
 import (
 	"errors"
 	"flag"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/Shopify/sarama"
 	"github.com/spf13/viper"
@@ -69,6 +71,9 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 	logger.Info("Kafka factory",
 		zap.Any("producer builder", f.Builder),
 		zap.Any("topic", f.options.Topic))
+	if err := f.options.Writer.validate(f.options.Config.ProtocolVersion); err != nil {
+		return err
+	}
 	p, err := f.NewProducer(logger)
 	if err != nil {
 		return err
@@ -79,8 +84,12 @@ func (f *Factory) Initialize(metricsFactory metrics.Factory, logger *zap.Logger)
 		f.marshaller = newProtobufMarshaller()
 	case EncodingJSON:
 		f.marshaller = newJSONMarshaller()
+	case EncodingOTLPProto:
+		f.marshaller = newOTLPProtobufMarshaller()
+	case EncodingOTLPJSON:
+		f.marshaller = newOTLPJSONMarshaller()
 	default:
-		return errors.New("kafka encoding is not one of '" + EncodingJSON + "' or '" + EncodingProto + "'")
+		return fmt.Errorf(`kafka encoding is not one of ("%s")`, strings.Join(producerEncodings, `", "`))
 	}
 	return nil
 }
@@ -92,7 +101,7 @@ func (f *Factory) CreateSpanReader() (spanstore.Reader, error) {
 
 // CreateSpanWriter implements storage.Factory
 func (f *Factory) CreateSpanWriter() (spanstore.Writer, error) {
-	return NewSpanWriter(f.producer, f.marshaller, f.options.Topic, f.metricsFactory, f.logger), nil
+	return NewSpanWriter(f.producer, f.marshaller, f.options.Topic, f.options.Encoding, f.options.Writer, f.metricsFactory, f.logger), nil
 }
 
 // CreateDependencyReader implements storage.Factory
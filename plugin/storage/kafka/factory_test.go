@@ -83,6 +83,8 @@ func TestKafkaFactoryEncoding(t *testing.T) {
 	}{
 		{encoding: "protobuf", marshaller: new(protobufMarshaller)},
 		{encoding: "json", marshaller: new(jsonMarshaller)},
+		{encoding: "otlp-proto", marshaller: new(otlpProtobufMarshaller)},
+		{encoding: "otlp-json", marshaller: new(otlpJSONMarshaller)},
 	}
 	for _, test := range tests {
 		t.Run(test.encoding, func(t *testing.T) {
@@ -106,7 +108,19 @@ func TestKafkaFactoryMarshallerErr(t *testing.T) {
 	f.InitFromViper(v)
 
 	f.Builder = &mockProducerBuilder{t: t}
-	assert.Error(t, f.Initialize(metrics.NullFactory, zap.NewNop()))
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		`kafka encoding is not one of ("json", "protobuf", "otlp-proto", "otlp-json")`)
+}
+
+func TestKafkaFactoryWriterOptionsErr(t *testing.T) {
+	f := NewFactory()
+	v, command := config.Viperize(f.AddFlags)
+	command.ParseFlags([]string{"--kafka.producer.headers=true"})
+	f.InitFromViper(v)
+
+	f.Builder = &mockProducerBuilder{t: t}
+	assert.EqualError(t, f.Initialize(metrics.NullFactory, zap.NewNop()),
+		"record headers and trace chunks require Kafka protocol version 0.11.0 or later, got 0.8.2.0")
 }
 
 func TestKafkaFactoryDoesNotLogPassword(t *testing.T) {
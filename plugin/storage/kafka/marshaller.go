@@ -21,11 +21,14 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/converter/otlp"
 )
 
 // Marshaller encodes a span into a byte array to be sent to Kafka
 type Marshaller interface {
 	Marshal(*model.Span) ([]byte, error)
+	// MarshalChunk encodes several spans of a trace into a single message, as a model.Batch
+	MarshalChunk([]*model.Span) ([]byte, error)
 }
 
 type protobufMarshaller struct{}
@@ -39,6 +42,11 @@ func (h *protobufMarshaller) Marshal(span *model.Span) ([]byte, error) {
 	return proto.Marshal(span)
 }
 
+// MarshalChunk encodes spans as a protobuf byte array of model.Batch
+func (h *protobufMarshaller) MarshalChunk(spans []*model.Span) ([]byte, error) {
+	return proto.Marshal(&model.Batch{Spans: spans})
+}
+
 type jsonMarshaller struct {
 	pbMarshaller *jsonpb.Marshaler
 }
@@ -53,3 +61,42 @@ func (h *jsonMarshaller) Marshal(span *model.Span) ([]byte, error) {
 	err := h.pbMarshaller.Marshal(out, span)
 	return out.Bytes(), err
 }
+
+// MarshalChunk encodes spans as a json byte array of model.Batch
+func (h *jsonMarshaller) MarshalChunk(spans []*model.Span) ([]byte, error) {
+	out := new(bytes.Buffer)
+	err := h.pbMarshaller.Marshal(out, &model.Batch{Spans: spans})
+	return out.Bytes(), err
+}
+
+type otlpProtobufMarshaller struct{}
+
+func newOTLPProtobufMarshaller() *otlpProtobufMarshaller {
+	return &otlpProtobufMarshaller{}
+}
+
+// Marshal encodes a span as a protobuf byte array of an OTLP export request
+func (h *otlpProtobufMarshaller) Marshal(span *model.Span) ([]byte, error) {
+	return h.MarshalChunk([]*model.Span{span})
+}
+
+// MarshalChunk encodes spans as a protobuf byte array of an OTLP export request
+func (h *otlpProtobufMarshaller) MarshalChunk(spans []*model.Span) ([]byte, error) {
+	return proto.Marshal(otlp.FromDomain(spans))
+}
+
+type otlpJSONMarshaller struct{}
+
+func newOTLPJSONMarshaller() *otlpJSONMarshaller {
+	return &otlpJSONMarshaller{}
+}
+
+// Marshal encodes a span as a json byte array of an OTLP export request
+func (h *otlpJSONMarshaller) Marshal(span *model.Span) ([]byte, error) {
+	return h.MarshalChunk([]*model.Span{span})
+}
+
+// MarshalChunk encodes spans as a json byte array of an OTLP export request, with hex trace and span IDs
+func (h *otlpJSONMarshaller) MarshalChunk(spans []*model.Span) ([]byte, error) {
+	return otlp.MarshalJSON(otlp.FromDomain(spans))
+}
@@ -18,7 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/jaegertracing/jaeger/model"
 	"github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
 	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
 )
@@ -43,6 +45,76 @@ func testMarshallerAndUnmarshaller(t *testing.T, marshaller Marshaller, unmarsha
 	assert.Equal(t, sampleSpan, resultSpan)
 }
 
+func TestProtobufChunkMarshallerAndUnmarshaller(t *testing.T) {
+	testChunkMarshallerAndUnmarshaller(t, newProtobufMarshaller(), NewProtobufUnmarshaller())
+}
+
+func TestJSONChunkMarshallerAndUnmarshaller(t *testing.T) {
+	testChunkMarshallerAndUnmarshaller(t, newJSONMarshaller(), NewJSONUnmarshaller())
+}
+
+func testChunkMarshallerAndUnmarshaller(t *testing.T, marshaller Marshaller, unmarshaller ChunkUnmarshaller) {
+	spans := []*model.Span{sampleSpan, sampleSpan}
+	bytes, err := marshaller.MarshalChunk(spans)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, bytes)
+
+	resultSpans, err := unmarshaller.UnmarshalChunk(bytes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, spans, resultSpans)
+
+	_, err = unmarshaller.UnmarshalChunk([]byte("foo"))
+	assert.Error(t, err)
+}
+
+func TestOTLPProtobufMarshallerAndUnmarshaller(t *testing.T) {
+	testMarshallerAndUnmarshaller(t, newOTLPProtobufMarshaller(), NewOTLPProtobufUnmarshaller())
+	testBatchMarshallerAndUnmarshaller(t, newOTLPProtobufMarshaller(), NewOTLPProtobufUnmarshaller())
+}
+
+func TestOTLPJSONMarshallerAndUnmarshaller(t *testing.T) {
+	testMarshallerAndUnmarshaller(t, newOTLPJSONMarshaller(), NewOTLPJSONUnmarshaller())
+	testBatchMarshallerAndUnmarshaller(t, newOTLPJSONMarshaller(), NewOTLPJSONUnmarshaller())
+}
+
+type otlpUnmarshaller interface {
+	Unmarshaller
+	BatchUnmarshaller
+}
+
+func testBatchMarshallerAndUnmarshaller(t *testing.T, marshaller Marshaller, unmarshaller otlpUnmarshaller) {
+	spans := []*model.Span{sampleSpan, sampleSpan}
+	bytes, err := marshaller.MarshalChunk(spans)
+	require.NoError(t, err)
+
+	resultSpans, err := unmarshaller.UnmarshalBatch(bytes)
+	require.NoError(t, err)
+	assert.Equal(t, spans, resultSpans)
+
+	_, err = unmarshaller.Unmarshal(bytes)
+	assert.EqualError(t, err, "OTLP message contains 2 spans, expected 1")
+
+	_, err = unmarshaller.Unmarshal([]byte("foo"))
+	assert.Error(t, err)
+}
+
+func TestOTLPJSONMarshallerFormat(t *testing.T) {
+	bytes, err := newOTLPJSONMarshaller().Marshal(&model.Span{
+		TraceID:   model.NewTraceID(0, 1),
+		SpanID:    model.NewSpanID(2),
+		StartTime: model.EpochMicrosecondsAsTime(1),
+		Tags:      []model.KeyValue{model.String("span.kind", "client")},
+		Process:   &model.Process{ServiceName: "foo"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(bytes), `"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"foo"}}]}`)
+	assert.Contains(t, string(bytes), `"kind":3`)
+	assert.Contains(t, string(bytes), `"traceId":"00000000000000000000000000000001"`)
+	assert.Contains(t, string(bytes), `"spanId":"0000000000000002"`)
+}
+
 func TestZipkinThriftUnmarshaller(t *testing.T) {
 	operationName := "foo"
 	bytes := zipkin.SerializeThrift([]*zipkincore.Span{
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/spf13/viper"
@@ -34,6 +35,17 @@ const (
 	EncodingProto = "protobuf"
 	// EncodingZipkinThrift is used for spans encoded as Zipkin Thrift.
 	EncodingZipkinThrift = "zipkin-thrift"
+	// EncodingOTLPProto is used for spans encoded as Protobuf OTLP export requests, readable by OpenTelemetry consumers.
+	EncodingOTLPProto = "otlp-proto"
+	// EncodingOTLPJSON is used for spans encoded as JSON OTLP export requests, readable by OpenTelemetry consumers.
+	EncodingOTLPJSON = "otlp-json"
+
+	// PartitionKeyTraceID keys the messages by trace ID, so that all spans of a trace land in the same partition.
+	PartitionKeyTraceID = "trace-id"
+	// PartitionKeyService keys the messages by the service name of the span.
+	PartitionKeyService = "service"
+	// PartitionKeyTag keys the messages by the value of a span or process tag.
+	PartitionKeyTag = "tag"
 
 	configPrefix           = "kafka.producer"
 	suffixBrokers          = ".brokers"
@@ -47,6 +59,13 @@ const (
 	suffixBatchSize        = ".batch-size"
 	suffixBatchMinMessages = ".batch-min-messages"
 	suffixBatchMaxMessages = ".batch-max-messages"
+	suffixPartitionKey     = ".partition-key"
+	suffixPartitionKeyTag  = ".partition-key-tag"
+	suffixHeaders          = ".headers"
+	suffixTenantTag        = ".tenant-tag"
+	suffixChunkLinger      = ".trace-chunk-linger"
+	suffixChunkMaxSpans    = ".trace-chunk-max-spans"
+	suffixChunkMaxBytes    = ".trace-chunk-max-bytes"
 
 	defaultBroker           = "127.0.0.1:9092"
 	defaultTopic            = "jaeger-spans"
@@ -58,11 +77,19 @@ const (
 	defaultBatchSize        = 0
 	defaultBatchMinMessages = 0
 	defaultBatchMaxMessages = 0
+	defaultPartitionKey     = PartitionKeyTraceID
+	defaultChunkLinger      = 0
+	defaultChunkMaxSpans    = 100
+	// leaves room for the key and headers under the 1000000 bytes default max message size of the producer
+	defaultChunkMaxBytes = 900000
 )
 
 var (
 	// AllEncodings is a list of all supported encodings.
-	AllEncodings = []string{EncodingJSON, EncodingProto, EncodingZipkinThrift}
+	AllEncodings = []string{EncodingJSON, EncodingProto, EncodingZipkinThrift, EncodingOTLPProto, EncodingOTLPJSON}
+
+	// producerEncodings is a list of the encodings supported by the span writer.
+	producerEncodings = []string{EncodingJSON, EncodingProto, EncodingOTLPProto, EncodingOTLPJSON}
 
 	//requiredAcks is mapping of sarama supported requiredAcks
 	requiredAcks = map[string]sarama.RequiredAcks{
@@ -113,6 +140,25 @@ type Options struct {
 	Config   producer.Configuration `mapstructure:",squash"`
 	Topic    string                 `mapstructure:"topic"`
 	Encoding string                 `mapstructure:"encoding"`
+	Writer   WriterOptions          `mapstructure:",squash"`
+}
+
+// WriterOptions controls how the spans are keyed, annotated and grouped into Kafka messages.
+type WriterOptions struct {
+	// PartitionKey is one of PartitionKeyTraceID, PartitionKeyService or PartitionKeyTag, trace ID if empty
+	PartitionKey string `mapstructure:"partition_key"`
+	// PartitionKeyTag is the tag used as the key by PartitionKeyTag
+	PartitionKeyTag string `mapstructure:"partition_key_tag"`
+	// Headers enables the record headers carrying the service, tenant and encoding of the message
+	Headers bool `mapstructure:"headers"`
+	// TenantTag is the span or process tag whose value is sent in the tenant header
+	TenantTag string `mapstructure:"tenant_tag"`
+	// TraceChunkLinger is how long the spans of a trace are collected into a single message, 0 disables chunks
+	TraceChunkLinger time.Duration `mapstructure:"trace_chunk_linger"`
+	// TraceChunkMaxSpans is the maximum number of spans sent in a single message
+	TraceChunkMaxSpans int `mapstructure:"trace_chunk_max_spans"`
+	// TraceChunkMaxBytes is the maximum encoded size of a trace chunk, larger chunks are split
+	TraceChunkMaxBytes int `mapstructure:"trace_chunk_max_bytes"`
 }
 
 // AddFlags adds flags for Options
@@ -152,6 +198,41 @@ func (opt *Options) AddFlags(flagSet *flag.FlagSet) {
 		defaultBatchMaxMessages,
 		"(experimental) Maximum number of message to batch before sending records to Kafka",
 	)
+	flagSet.String(
+		configPrefix+suffixPartitionKey,
+		defaultPartitionKey,
+		fmt.Sprintf(`(experimental) The key of the messages, which selects their partition ("%s", "%s" or "%s")`, PartitionKeyTraceID, PartitionKeyService, PartitionKeyTag),
+	)
+	flagSet.String(
+		configPrefix+suffixPartitionKeyTag,
+		"",
+		"(experimental) The span or process tag whose value is the message key when the partition key is \"tag\". Spans without the tag are keyed by trace ID",
+	)
+	flagSet.Bool(
+		configPrefix+suffixHeaders,
+		false,
+		"(experimental) Add record headers with the service, tenant and encoding of the spans, so that consumers can route messages without decoding them. Requires protocol version 0.11.0 or later",
+	)
+	flagSet.String(
+		configPrefix+suffixTenantTag,
+		"",
+		"(experimental) The span or process tag whose value is sent in the tenant record header",
+	)
+	flagSet.Duration(
+		configPrefix+suffixChunkLinger,
+		defaultChunkLinger,
+		"(experimental) Time interval to collect the spans of a trace and service into a single message. Higher value reduce the number of messages but increase latency and the possibility of data loss in case of process restart. Requires protocol version 0.11.0 or later, 0 disables trace chunks",
+	)
+	flagSet.Int(
+		configPrefix+suffixChunkMaxSpans,
+		defaultChunkMaxSpans,
+		"(experimental) The maximum number of spans in a trace chunk message",
+	)
+	flagSet.Int(
+		configPrefix+suffixChunkMaxBytes,
+		defaultChunkMaxBytes,
+		"(experimental) The maximum encoded size of a trace chunk message, larger chunks are split into several messages. Must be below the max message size of the producer and the broker",
+	)
 	opt.AddOTELFlags(flagSet)
 }
 
@@ -172,7 +253,7 @@ func (opt *Options) AddOTELFlags(flagSet *flag.FlagSet) {
 	flagSet.String(
 		configPrefix+suffixEncoding,
 		defaultEncoding,
-		fmt.Sprintf(`Encoding of spans ("%s" or "%s") sent to kafka.`, EncodingJSON, EncodingProto),
+		fmt.Sprintf(`Encoding of spans ("%s") sent to kafka.`, strings.Join(producerEncodings, `", "`)),
 	)
 	auth.AddFlags(configPrefix, flagSet)
 }
@@ -212,6 +293,43 @@ func (opt *Options) InitFromViper(v *viper.Viper) {
 	}
 	opt.Topic = v.GetString(configPrefix + suffixTopic)
 	opt.Encoding = v.GetString(configPrefix + suffixEncoding)
+	opt.Writer = WriterOptions{
+		PartitionKey:       v.GetString(configPrefix + suffixPartitionKey),
+		PartitionKeyTag:    v.GetString(configPrefix + suffixPartitionKeyTag),
+		Headers:            v.GetBool(configPrefix + suffixHeaders),
+		TenantTag:          v.GetString(configPrefix + suffixTenantTag),
+		TraceChunkLinger:   v.GetDuration(configPrefix + suffixChunkLinger),
+		TraceChunkMaxSpans: v.GetInt(configPrefix + suffixChunkMaxSpans),
+		TraceChunkMaxBytes: v.GetInt(configPrefix + suffixChunkMaxBytes),
+	}
+}
+
+// validate checks that the writer options are consistent and supported by the Kafka protocol version.
+func (o WriterOptions) validate(protocolVersion string) error {
+	switch o.PartitionKey {
+	case "", PartitionKeyTraceID, PartitionKeyService:
+	case PartitionKeyTag:
+		if o.PartitionKeyTag == "" {
+			return fmt.Errorf("partition key %q requires the tag name to be set", PartitionKeyTag)
+		}
+	default:
+		return fmt.Errorf(`unknown partition key %q, use one of ("%s", "%s", "%s")`, o.PartitionKey, PartitionKeyTraceID, PartitionKeyService, PartitionKeyTag)
+	}
+	if !o.Headers && o.TraceChunkLinger <= 0 {
+		return nil
+	}
+	// record headers are only supported by the message format introduced in Kafka 0.11
+	version := sarama.V0_8_2_0
+	if protocolVersion != "" {
+		var err error
+		if version, err = sarama.ParseKafkaVersion(protocolVersion); err != nil {
+			return err
+		}
+	}
+	if !version.IsAtLeast(sarama.V0_11_0_0) {
+		return fmt.Errorf("record headers and trace chunks require Kafka protocol version 0.11.0 or later, got %s", version)
+	}
+	return nil
 }
 
 // stripWhiteSpace removes all whitespace characters from a string
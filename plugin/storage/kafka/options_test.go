@@ -57,6 +57,83 @@ func TestOptionsWithFlags(t *testing.T) {
 	assert.Equal(t, 100, opts.Config.BatchMaxMessages)
 }
 
+func TestWriterOptionsWithFlags(t *testing.T) {
+	opts := &Options{}
+	v, command := config.Viperize(opts.AddFlags)
+	command.ParseFlags([]string{
+		"--kafka.producer.partition-key=tag",
+		"--kafka.producer.partition-key-tag=customer",
+		"--kafka.producer.headers=true",
+		"--kafka.producer.tenant-tag=tenant",
+		"--kafka.producer.trace-chunk-linger=2s",
+		"--kafka.producer.trace-chunk-max-spans=50",
+		"--kafka.producer.trace-chunk-max-bytes=1000",
+	})
+	opts.InitFromViper(v)
+
+	assert.Equal(t, WriterOptions{
+		PartitionKey:       PartitionKeyTag,
+		PartitionKeyTag:    "customer",
+		Headers:            true,
+		TenantTag:          "tenant",
+		TraceChunkLinger:   2 * time.Second,
+		TraceChunkMaxSpans: 50,
+		TraceChunkMaxBytes: 1000,
+	}, opts.Writer)
+}
+
+func TestWriterOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		options         WriterOptions
+		protocolVersion string
+		err             string
+	}{
+		{name: "defaults", options: WriterOptions{}},
+		{name: "service", options: WriterOptions{PartitionKey: PartitionKeyService}},
+		{name: "tag", options: WriterOptions{PartitionKey: PartitionKeyTag, PartitionKeyTag: "foo"}},
+		{
+			name:    "tag without name",
+			options: WriterOptions{PartitionKey: PartitionKeyTag},
+			err:     `partition key "tag" requires the tag name to be set`,
+		},
+		{
+			name:    "unknown key",
+			options: WriterOptions{PartitionKey: "foo"},
+			err:     `unknown partition key "foo", use one of ("trace-id", "service", "tag")`,
+		},
+		{name: "headers", options: WriterOptions{Headers: true}, protocolVersion: "2.0.0"},
+		{name: "chunks", options: WriterOptions{TraceChunkLinger: time.Second}, protocolVersion: "0.11.0.0"},
+		{
+			name:    "headers with default version",
+			options: WriterOptions{Headers: true},
+			err:     "record headers and trace chunks require Kafka protocol version 0.11.0 or later, got 0.8.2.0",
+		},
+		{
+			name:            "chunks with old version",
+			options:         WriterOptions{TraceChunkLinger: time.Second},
+			protocolVersion: "0.10.2.0",
+			err:             "record headers and trace chunks require Kafka protocol version 0.11.0 or later, got 0.10.2.0",
+		},
+		{
+			name:            "invalid version",
+			options:         WriterOptions{Headers: true},
+			protocolVersion: "foo",
+			err:             "invalid version `foo`",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.validate(test.protocolVersion)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
 func TestFlagDefaults(t *testing.T) {
 	opts := &Options{}
 	v, command := config.Viperize(opts.AddFlags)
@@ -73,6 +150,11 @@ func TestFlagDefaults(t *testing.T) {
 	assert.Equal(t, time.Duration(0*time.Second), opts.Config.BatchLinger)
 	assert.Equal(t, 0, opts.Config.BatchMinMessages)
 	assert.Equal(t, 0, opts.Config.BatchMaxMessages)
+	assert.Equal(t, WriterOptions{
+		PartitionKey:       PartitionKeyTraceID,
+		TraceChunkMaxSpans: defaultChunkMaxSpans,
+		TraceChunkMaxBytes: defaultChunkMaxBytes,
+	}, opts.Writer)
 }
 
 func TestCompressionLevelDefaults(t *testing.T) {
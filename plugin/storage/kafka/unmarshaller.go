@@ -16,12 +16,15 @@ package kafka
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/model/converter/otlp"
 	"github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
+	otlpcollector "github.com/jaegertracing/jaeger/proto-gen/otlp/collector/trace/v1"
 )
 
 // Unmarshaller decodes a byte array to a span
@@ -29,6 +32,17 @@ type Unmarshaller interface {
 	Unmarshal([]byte) (*model.Span, error)
 }
 
+// ChunkUnmarshaller decodes a byte array written by Marshaller.MarshalChunk to the spans of a trace chunk
+type ChunkUnmarshaller interface {
+	UnmarshalChunk([]byte) ([]*model.Span, error)
+}
+
+// BatchUnmarshaller decodes a byte array which carries any number of spans, e.g. an OTLP export request.
+// Messages of such encodings are always decoded with UnmarshalBatch.
+type BatchUnmarshaller interface {
+	UnmarshalBatch([]byte) ([]*model.Span, error)
+}
+
 // ProtobufUnmarshaller implements Unmarshaller
 type ProtobufUnmarshaller struct{}
 
@@ -44,6 +58,15 @@ func (h *ProtobufUnmarshaller) Unmarshal(msg []byte) (*model.Span, error) {
 	return newSpan, err
 }
 
+// UnmarshalChunk decodes a protobuf byte array of model.Batch to spans
+func (h *ProtobufUnmarshaller) UnmarshalChunk(msg []byte) ([]*model.Span, error) {
+	batch := &model.Batch{}
+	if err := proto.Unmarshal(msg, batch); err != nil {
+		return nil, err
+	}
+	return batch.Spans, nil
+}
+
 // JSONUnmarshaller implements Unmarshaller
 type JSONUnmarshaller struct{}
 
@@ -59,6 +82,15 @@ func (h *JSONUnmarshaller) Unmarshal(msg []byte) (*model.Span, error) {
 	return newSpan, err
 }
 
+// UnmarshalChunk decodes a json byte array of model.Batch to spans
+func (h *JSONUnmarshaller) UnmarshalChunk(msg []byte) ([]*model.Span, error) {
+	batch := &model.Batch{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(msg), batch); err != nil {
+		return nil, err
+	}
+	return batch.Spans, nil
+}
+
 // ZipkinThriftUnmarshaller implements Unmarshaller
 type ZipkinThriftUnmarshaller struct{}
 
@@ -79,3 +111,57 @@ func (h *ZipkinThriftUnmarshaller) Unmarshal(msg []byte) (*model.Span, error) {
 	}
 	return mSpans[0], err
 }
+
+// OTLPProtobufUnmarshaller implements Unmarshaller and BatchUnmarshaller
+type OTLPProtobufUnmarshaller struct{}
+
+// NewOTLPProtobufUnmarshaller constructs an OTLPProtobufUnmarshaller
+func NewOTLPProtobufUnmarshaller() *OTLPProtobufUnmarshaller {
+	return &OTLPProtobufUnmarshaller{}
+}
+
+// Unmarshal decodes a protobuf byte array of an OTLP export request with a single span
+func (h *OTLPProtobufUnmarshaller) Unmarshal(msg []byte) (*model.Span, error) {
+	return singleSpan(h.UnmarshalBatch(msg))
+}
+
+// UnmarshalBatch decodes a protobuf byte array of an OTLP export request to spans
+func (h *OTLPProtobufUnmarshaller) UnmarshalBatch(msg []byte) ([]*model.Span, error) {
+	request := &otlpcollector.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(msg, request); err != nil {
+		return nil, err
+	}
+	return otlp.ToDomain(request)
+}
+
+// OTLPJSONUnmarshaller implements Unmarshaller and BatchUnmarshaller
+type OTLPJSONUnmarshaller struct{}
+
+// NewOTLPJSONUnmarshaller constructs an OTLPJSONUnmarshaller
+func NewOTLPJSONUnmarshaller() *OTLPJSONUnmarshaller {
+	return &OTLPJSONUnmarshaller{}
+}
+
+// Unmarshal decodes a json byte array of an OTLP export request with a single span
+func (h *OTLPJSONUnmarshaller) Unmarshal(msg []byte) (*model.Span, error) {
+	return singleSpan(h.UnmarshalBatch(msg))
+}
+
+// UnmarshalBatch decodes a json byte array of an OTLP export request to spans
+func (h *OTLPJSONUnmarshaller) UnmarshalBatch(msg []byte) ([]*model.Span, error) {
+	request := &otlpcollector.ExportTraceServiceRequest{}
+	if err := otlp.UnmarshalJSON(msg, request); err != nil {
+		return nil, err
+	}
+	return otlp.ToDomain(request)
+}
+
+func singleSpan(spans []*model.Span, err error) (*model.Span, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(spans) != 1 {
+		return nil, fmt.Errorf("OTLP message contains %d spans, expected 1", len(spans))
+	}
+	return spans[0], nil
+}
@@ -16,6 +16,10 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/uber/jaeger-lib/metrics"
@@ -24,6 +28,20 @@ import (
 	"github.com/jaegertracing/jaeger/model"
 )
 
+const (
+	// HeaderService is the record header carrying the service name of the spans in the message.
+	HeaderService = "jaeger-service"
+	// HeaderTenant is the record header carrying the value of the tenant tag of the spans in the message.
+	HeaderTenant = "jaeger-tenant"
+	// HeaderEncoding is the record header carrying the encoding of the message.
+	HeaderEncoding = "jaeger-encoding"
+	// HeaderSpanCount is the record header carrying the number of spans in a trace chunk.
+	// It is only set on trace chunks, i.e. messages encoded with Marshaller.MarshalChunk.
+	HeaderSpanCount = "jaeger-span-count"
+)
+
+var errWriterClosed = errors.New("kafka span writer is closed")
+
 type spanWriterMetrics struct {
 	SpansWrittenSuccess metrics.Counter
 	SpansWrittenFailure metrics.Counter
@@ -35,6 +53,29 @@ type SpanWriter struct {
 	producer   sarama.AsyncProducer
 	marshaller Marshaller
 	topic      string
+	encoding   string
+	options    WriterOptions
+	logger     *zap.Logger
+
+	chunksMu sync.Mutex
+	chunks   map[chunkKey]*traceChunk
+	// closed is set by Close, after which no message is sent, guarded by chunksMu
+	closed bool
+	// sending counts the messages being sent outside of chunksMu, Close waits for them before closing the producer
+	sending sync.WaitGroup
+}
+
+// chunkKey identifies the spans sent in the same trace chunk, which share the message key and headers
+type chunkKey struct {
+	traceID model.TraceID
+	service string
+	tenant  string
+	key     string
+}
+
+type traceChunk struct {
+	spans []*model.Span
+	timer *time.Timer
 }
 
 // NewSpanWriter initiates and returns a new kafka spanwriter
@@ -42,6 +83,8 @@ func NewSpanWriter(
 	producer sarama.AsyncProducer,
 	marshaller Marshaller,
 	topic string,
+	encoding string,
+	options WriterOptions,
 	factory metrics.Factory,
 	logger *zap.Logger,
 ) *SpanWriter {
@@ -51,14 +94,14 @@ func NewSpanWriter(
 	}
 
 	go func() {
-		for range producer.Successes() {
-			writeMetrics.SpansWrittenSuccess.Inc(1)
+		for msg := range producer.Successes() {
+			writeMetrics.SpansWrittenSuccess.Inc(spanCount(msg))
 		}
 	}()
 	go func() {
 		for e := range producer.Errors() {
 			logger.Error(e.Err.Error())
-			writeMetrics.SpansWrittenFailure.Inc(1)
+			writeMetrics.SpansWrittenFailure.Inc(spanCount(e.Msg))
 		}
 	}()
 
@@ -66,29 +109,204 @@ func NewSpanWriter(
 		producer:   producer,
 		marshaller: marshaller,
 		topic:      topic,
+		encoding:   encoding,
+		options:    options,
+		logger:     logger,
 		metrics:    writeMetrics,
+		chunks:     make(map[chunkKey]*traceChunk),
 	}
 }
 
-// WriteSpan writes the span to kafka.
+// spanCount returns the number of spans in a message, which is kept in its metadata
+func spanCount(msg *sarama.ProducerMessage) int64 {
+	if msg == nil {
+		return 1
+	}
+	if count, ok := msg.Metadata.(int); ok {
+		return int64(count)
+	}
+	return 1
+}
+
+// WriteSpan writes the span to kafka, or adds it to the chunk of its trace if trace chunks are enabled.
 func (w *SpanWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	key := chunkKey{
+		traceID: span.TraceID,
+		service: serviceName(span),
+		tenant:  w.tenant(span),
+		key:     w.partitionKey(span),
+	}
+	if w.options.TraceChunkLinger > 0 {
+		return w.addToChunk(key, span)
+	}
+
 	spanBytes, err := w.marshaller.Marshal(span)
 	if err != nil {
 		w.metrics.SpansWrittenFailure.Inc(1)
 		return err
 	}
+	if !w.startSending() {
+		return errWriterClosed
+	}
+	defer w.sending.Done()
+	w.send(key, spanBytes, 1, false)
+	return nil
+}
 
+// startSending reserves a send on the producer unless the writer is closed,
+// the caller must call w.sending.Done once the message is sent
+func (w *SpanWriter) startSending() bool {
+	w.chunksMu.Lock()
+	defer w.chunksMu.Unlock()
+	if w.closed {
+		return false
+	}
+	w.sending.Add(1)
+	return true
+}
+
+func (w *SpanWriter) send(key chunkKey, value []byte, spans int, chunk bool) {
 	// The AsyncProducer accepts messages on a channel and produces them asynchronously
 	// in the background as efficiently as possible
 	w.producer.Input() <- &sarama.ProducerMessage{
-		Topic: w.topic,
-		Key:   sarama.StringEncoder(span.TraceID.String()),
-		Value: sarama.ByteEncoder(spanBytes),
+		Topic:    w.topic,
+		Key:      sarama.StringEncoder(key.key),
+		Value:    sarama.ByteEncoder(value),
+		Headers:  w.headers(key, spans, chunk),
+		Metadata: spans,
+	}
+}
+
+func (w *SpanWriter) headers(key chunkKey, spans int, chunk bool) []sarama.RecordHeader {
+	var headers []sarama.RecordHeader
+	if w.options.Headers {
+		headers = append(headers,
+			sarama.RecordHeader{Key: []byte(HeaderService), Value: []byte(key.service)},
+			sarama.RecordHeader{Key: []byte(HeaderEncoding), Value: []byte(w.encoding)},
+		)
+		if key.tenant != "" {
+			headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderTenant), Value: []byte(key.tenant)})
+		}
+	}
+	if chunk {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(HeaderSpanCount), Value: []byte(strconv.Itoa(spans))})
+	}
+	return headers
+}
+
+// partitionKey returns the message key of the span according to the configured partition key
+func (w *SpanWriter) partitionKey(span *model.Span) string {
+	switch w.options.PartitionKey {
+	case PartitionKeyService:
+		return serviceName(span)
+	case PartitionKeyTag:
+		if value, ok := findTag(span, w.options.PartitionKeyTag); ok {
+			return value
+		}
+	}
+	return span.TraceID.String()
+}
+
+func (w *SpanWriter) tenant(span *model.Span) string {
+	if !w.options.Headers || w.options.TenantTag == "" {
+		return ""
+	}
+	value, _ := findTag(span, w.options.TenantTag)
+	return value
+}
+
+func serviceName(span *model.Span) string {
+	if span.Process == nil {
+		return ""
+	}
+	return span.Process.ServiceName
+}
+
+// findTag looks up a tag in the span tags first and then in the process tags
+func findTag(span *model.Span, key string) (string, bool) {
+	if kv, ok := model.KeyValues(span.Tags).FindByKey(key); ok {
+		return kv.AsString(), true
+	}
+	if span.Process != nil {
+		if kv, ok := model.KeyValues(span.Process.Tags).FindByKey(key); ok {
+			return kv.AsString(), true
+		}
+	}
+	return "", false
+}
+
+func (w *SpanWriter) addToChunk(key chunkKey, span *model.Span) error {
+	w.chunksMu.Lock()
+	if w.closed {
+		w.chunksMu.Unlock()
+		return errWriterClosed
+	}
+	chunk, ok := w.chunks[key]
+	if !ok {
+		chunk = &traceChunk{}
+		chunk.timer = time.AfterFunc(w.options.TraceChunkLinger, func() { w.flushChunk(key, chunk) })
+		w.chunks[key] = chunk
+	}
+	chunk.spans = append(chunk.spans, span)
+	full := w.options.TraceChunkMaxSpans > 0 && len(chunk.spans) >= w.options.TraceChunkMaxSpans
+	if full {
+		chunk.timer.Stop()
+		delete(w.chunks, key)
+		w.sending.Add(1)
+	}
+	w.chunksMu.Unlock()
+
+	if full {
+		w.sendChunk(key, chunk.spans)
+		w.sending.Done()
 	}
 	return nil
 }
 
-// Close closes SpanWriter by closing producer
+// flushChunk sends the chunk when its linger time expires, unless it has already been sent because it was full
+func (w *SpanWriter) flushChunk(key chunkKey, chunk *traceChunk) {
+	w.chunksMu.Lock()
+	if w.chunks[key] != chunk {
+		w.chunksMu.Unlock()
+		return
+	}
+	delete(w.chunks, key)
+	w.sending.Add(1)
+	w.chunksMu.Unlock()
+	w.sendChunk(key, chunk.spans)
+	w.sending.Done()
+}
+
+// sendChunk sends the spans in a single message, or splits them in halves while the encoded chunk is too large
+func (w *SpanWriter) sendChunk(key chunkKey, spans []*model.Span) {
+	chunkBytes, err := w.marshaller.MarshalChunk(spans)
+	if err != nil {
+		// the spans were accepted by WriteSpan, so the error can only be reported here
+		w.logger.Error("Failed to marshal trace chunk", zap.Stringer("trace-id", key.traceID), zap.Error(err))
+		w.metrics.SpansWrittenFailure.Inc(int64(len(spans)))
+		return
+	}
+	if len(spans) > 1 && w.options.TraceChunkMaxBytes > 0 && len(chunkBytes) > w.options.TraceChunkMaxBytes {
+		half := len(spans) / 2
+		w.sendChunk(key, spans[:half])
+		w.sendChunk(key, spans[half:])
+		return
+	}
+	w.send(key, chunkBytes, len(spans), true)
+}
+
+// Close sends the pending trace chunks, waits for the messages being sent and closes the producer.
+// The spans written after Close are rejected.
 func (w *SpanWriter) Close() error {
+	w.chunksMu.Lock()
+	w.closed = true
+	chunks := w.chunks
+	w.chunks = make(map[chunkKey]*traceChunk)
+	w.chunksMu.Unlock()
+	for key, chunk := range chunks {
+		chunk.timer.Stop()
+		w.sendChunk(key, chunk.spans)
+	}
+	w.sending.Wait()
 	return w.producer.Close()
 }
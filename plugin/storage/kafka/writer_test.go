@@ -24,6 +24,8 @@ import (
 	saramaMocks "github.com/Shopify/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-lib/metrics"
 	"github.com/uber/jaeger-lib/metrics/metricstest"
 	"go.uber.org/zap"
 
@@ -87,7 +89,7 @@ func withSpanWriter(t *testing.T, fn func(span *model.Span, w *spanWriterTest))
 		producer:       producer,
 		marshaller:     marshaller,
 		metricsFactory: serviceMetrics,
-		writer:         NewSpanWriter(producer, marshaller, "someTopic", serviceMetrics, zap.NewNop()),
+		writer:         NewSpanWriter(producer, marshaller, "someTopic", EncodingProto, WriterOptions{}, serviceMetrics, zap.NewNop()),
 	}
 
 	fn(sampleSpan, writerTest)
@@ -185,3 +187,216 @@ func TestMarshallerErr(t *testing.T) {
 			})
 	})
 }
+
+// fakeProducer keeps the produced messages in its input channel, so that tests can inspect them
+type fakeProducer struct {
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newFakeProducer() *fakeProducer {
+	return &fakeProducer{
+		input:     make(chan *sarama.ProducerMessage, 10),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+}
+
+func (p *fakeProducer) AsyncClose() {}
+
+func (p *fakeProducer) Close() error {
+	close(p.successes)
+	close(p.errors)
+	return nil
+}
+
+func (p *fakeProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
+}
+
+func (p *fakeProducer) Successes() <-chan *sarama.ProducerMessage {
+	return p.successes
+}
+
+func (p *fakeProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
+}
+
+func headersMap(msg *sarama.ProducerMessage) map[string]string {
+	headers := make(map[string]string)
+	for _, h := range msg.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	return headers
+}
+
+func receive(t *testing.T, producer *fakeProducer) *sarama.ProducerMessage {
+	select {
+	case msg := <-producer.input:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message produced")
+		return nil
+	}
+}
+
+func TestKafkaWriterPartitionKey(t *testing.T) {
+	span := &model.Span{
+		TraceID: model.NewTraceID(1, 2),
+		Tags:    model.KeyValues{model.String("span.tag", "span-value")},
+		Process: &model.Process{
+			ServiceName: "someServiceName",
+			Tags:        model.KeyValues{model.String("process.tag", "process-value")},
+		},
+	}
+	tests := []struct {
+		name     string
+		options  WriterOptions
+		expected string
+	}{
+		{name: "default", options: WriterOptions{}, expected: span.TraceID.String()},
+		{name: "trace-id", options: WriterOptions{PartitionKey: PartitionKeyTraceID}, expected: span.TraceID.String()},
+		{name: "service", options: WriterOptions{PartitionKey: PartitionKeyService}, expected: "someServiceName"},
+		{name: "span tag", options: WriterOptions{PartitionKey: PartitionKeyTag, PartitionKeyTag: "span.tag"}, expected: "span-value"},
+		{name: "process tag", options: WriterOptions{PartitionKey: PartitionKeyTag, PartitionKeyTag: "process.tag"}, expected: "process-value"},
+		{name: "missing tag", options: WriterOptions{PartitionKey: PartitionKeyTag, PartitionKeyTag: "foo"}, expected: span.TraceID.String()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			producer := newFakeProducer()
+			writer := NewSpanWriter(producer, newProtobufMarshaller(), "someTopic", EncodingProto, test.options, metrics.NullFactory, zap.NewNop())
+			require.NoError(t, writer.WriteSpan(context.Background(), span))
+
+			msg := receive(t, producer)
+			assert.Equal(t, sarama.StringEncoder(test.expected), msg.Key)
+			assert.Empty(t, msg.Headers)
+			require.NoError(t, writer.Close())
+		})
+	}
+}
+
+func TestKafkaWriterHeaders(t *testing.T) {
+	producer := newFakeProducer()
+	options := WriterOptions{Headers: true, TenantTag: "someStringTagKey"}
+	writer := NewSpanWriter(producer, newJSONMarshaller(), "someTopic", EncodingJSON, options, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, writer.WriteSpan(context.Background(), sampleSpan))
+
+	assert.Equal(t, map[string]string{
+		HeaderService:  "someServiceName",
+		HeaderEncoding: EncodingJSON,
+		HeaderTenant:   "someStringTagValue",
+	}, headersMap(receive(t, producer)))
+
+	// the tenant header is omitted for spans without the tenant tag
+	require.NoError(t, writer.WriteSpan(context.Background(), &model.Span{Process: &model.Process{ServiceName: "foo"}}))
+	assert.Equal(t, map[string]string{
+		HeaderService:  "foo",
+		HeaderEncoding: EncodingJSON,
+	}, headersMap(receive(t, producer)))
+	require.NoError(t, writer.Close())
+}
+
+func TestKafkaWriterTraceChunks(t *testing.T) {
+	producer := newFakeProducer()
+	// the linger time never expires, chunks are sent when full or on close
+	options := WriterOptions{TraceChunkLinger: time.Hour, TraceChunkMaxSpans: 2}
+	writer := NewSpanWriter(producer, newProtobufMarshaller(), "someTopic", EncodingProto, options, metrics.NullFactory, zap.NewNop())
+
+	otherTrace := &model.Span{TraceID: model.NewTraceID(0, 1), Process: &model.Process{ServiceName: "someServiceName"}}
+	for _, span := range []*model.Span{sampleSpan, otherTrace, sampleSpan} {
+		require.NoError(t, writer.WriteSpan(context.Background(), span))
+	}
+
+	msg := receive(t, producer)
+	assert.Equal(t, sarama.StringEncoder(sampleSpan.TraceID.String()), msg.Key)
+	assert.Equal(t, map[string]string{HeaderSpanCount: "2"}, headersMap(msg))
+	assert.Equal(t, 2, msg.Metadata)
+	value, err := msg.Value.Encode()
+	require.NoError(t, err)
+	spans, err := NewProtobufUnmarshaller().UnmarshalChunk(value)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Span{sampleSpan, sampleSpan}, spans)
+
+	require.NoError(t, writer.Close())
+	msg = receive(t, producer)
+	assert.Equal(t, sarama.StringEncoder(otherTrace.TraceID.String()), msg.Key)
+	assert.Equal(t, map[string]string{HeaderSpanCount: "1"}, headersMap(msg))
+}
+
+func TestKafkaWriterTraceChunkLinger(t *testing.T) {
+	producer := newFakeProducer()
+	options := WriterOptions{TraceChunkLinger: time.Millisecond, TraceChunkMaxSpans: 100}
+	writer := NewSpanWriter(producer, newProtobufMarshaller(), "someTopic", EncodingProto, options, metrics.NullFactory, zap.NewNop())
+	require.NoError(t, writer.WriteSpan(context.Background(), sampleSpan))
+
+	msg := receive(t, producer)
+	assert.Equal(t, map[string]string{HeaderSpanCount: "1"}, headersMap(msg))
+	require.NoError(t, writer.Close())
+	assert.Empty(t, producer.input)
+}
+
+func TestKafkaWriterTraceChunkMarshallerErr(t *testing.T) {
+	withSpanWriter(t, func(span *model.Span, w *spanWriterTest) {
+		marshaller := &mocks.Marshaller{}
+		marshaller.On("MarshalChunk", mock.Anything).Return(nil, errors.New("made-up error"))
+		w.writer.marshaller = marshaller
+		w.writer.options.TraceChunkLinger = time.Hour
+
+		require.NoError(t, w.writer.WriteSpan(context.Background(), span))
+		require.NoError(t, w.writer.WriteSpan(context.Background(), span))
+		w.writer.Close()
+
+		w.metricsFactory.AssertCounterMetrics(t,
+			metricstest.ExpectedMetric{
+				Name:  "kafka_spans_written",
+				Tags:  map[string]string{"status": "failure"},
+				Value: 2,
+			})
+	})
+}
+
+func TestKafkaWriterTraceChunkMaxBytes(t *testing.T) {
+	producer := newFakeProducer()
+	single, err := newProtobufMarshaller().MarshalChunk([]*model.Span{sampleSpan})
+	require.NoError(t, err)
+	// two spans fit in a chunk, four do not
+	options := WriterOptions{TraceChunkLinger: time.Hour, TraceChunkMaxSpans: 4, TraceChunkMaxBytes: 3 * len(single)}
+	writer := NewSpanWriter(producer, newProtobufMarshaller(), "someTopic", EncodingProto, options, metrics.NullFactory, zap.NewNop())
+	for i := 0; i < 4; i++ {
+		require.NoError(t, writer.WriteSpan(context.Background(), sampleSpan))
+	}
+
+	for i := 0; i < 2; i++ {
+		msg := receive(t, producer)
+		assert.Equal(t, map[string]string{HeaderSpanCount: "2"}, headersMap(msg))
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		assert.True(t, len(value) <= options.TraceChunkMaxBytes)
+	}
+	require.NoError(t, writer.Close())
+	assert.Empty(t, producer.input)
+}
+
+func TestKafkaWriterClosed(t *testing.T) {
+	for _, options := range []WriterOptions{{}, {TraceChunkLinger: time.Millisecond}} {
+		producer := newFakeProducer()
+		writer := NewSpanWriter(producer, newProtobufMarshaller(), "someTopic", EncodingProto, options, metrics.NullFactory, zap.NewNop())
+		require.NoError(t, writer.Close())
+		// the producer input is closed, sending the span would panic
+		assert.Equal(t, errWriterClosed, writer.WriteSpan(context.Background(), sampleSpan))
+		assert.Empty(t, producer.input)
+	}
+}
+
+func TestKafkaWriterCloseWaitsForChunks(t *testing.T) {
+	producer := newFakeProducer()
+	options := WriterOptions{TraceChunkLinger: time.Nanosecond}
+	writer := NewSpanWriter(producer, newProtobufMarshaller(), "someTopic", EncodingProto, options, metrics.NullFactory, zap.NewNop())
+	for i := 0; i < 5; i++ {
+		require.NoError(t, writer.WriteSpan(context.Background(), &model.Span{TraceID: model.NewTraceID(0, uint64(i))}))
+	}
+	require.NoError(t, writer.Close())
+	// all the chunks are sent before Close returns, whether they were flushed by their timer or by Close
+	assert.Len(t, producer.input, 5)
+}
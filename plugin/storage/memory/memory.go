@@ -206,6 +206,9 @@ func (m *Store) GetOperations(
 
 // FindTraces returns all traces in the query parameters are satisfied by a trace's span
 func (m *Store) FindTraces(ctx context.Context, query *spanstore.TraceQueryParameters) ([]*model.Trace, error) {
+	if len(query.TagKeys) > 0 || len(query.TagValues) > 0 {
+		return nil, spanstore.ErrTagKeysAndValuesNotSupported
+	}
 	m.RLock()
 	defer m.RUnlock()
 	var retMe []*model.Trace
@@ -282,6 +282,13 @@ func TestStoreGetEmptyTraceSet(t *testing.T) {
 	})
 }
 
+func TestStoreFindTracesTagKeysNotSupported(t *testing.T) {
+	withPopulatedMemoryStore(func(store *Store) {
+		_, err := store.FindTraces(context.Background(), &spanstore.TraceQueryParameters{TagKeys: []string{"k"}})
+		assert.Equal(t, spanstore.ErrTagKeysAndValuesNotSupported, err)
+	})
+}
+
 func TestStoreFindTracesLimitGetsMostRecent(t *testing.T) {
 	storeSize, querySize := 100, 10
 
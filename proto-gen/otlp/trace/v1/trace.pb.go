@@ -0,0 +1,642 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: opentelemetry/proto/trace/v1/trace.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	v11 "github.com/jaegertracing/jaeger/proto-gen/otlp/common/v1"
+	v1 "github.com/jaegertracing/jaeger/proto-gen/otlp/resource/v1"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// SpanKind is the type of span. Can be used to specify additional relationships between spans
+// in addition to a parent/child relationship.
+type Span_SpanKind int32
+
+const (
+	// Unspecified. Do NOT use as default.
+	Span_SPAN_KIND_UNSPECIFIED Span_SpanKind = 0
+	// Indicates that the span represents an internal operation within an application.
+	Span_SPAN_KIND_INTERNAL Span_SpanKind = 1
+	// Indicates that the span covers server-side handling of an RPC or other
+	// remote network request.
+	Span_SPAN_KIND_SERVER Span_SpanKind = 2
+	// Indicates that the span describes a request to some remote service.
+	Span_SPAN_KIND_CLIENT Span_SpanKind = 3
+	// Indicates that the span describes a producer sending a message to a broker.
+	Span_SPAN_KIND_PRODUCER Span_SpanKind = 4
+	// Indicates that the span describes consumer receiving a message from a broker.
+	Span_SPAN_KIND_CONSUMER Span_SpanKind = 5
+)
+
+var Span_SpanKind_name = map[int32]string{
+	0: "SPAN_KIND_UNSPECIFIED",
+	1: "SPAN_KIND_INTERNAL",
+	2: "SPAN_KIND_SERVER",
+	3: "SPAN_KIND_CLIENT",
+	4: "SPAN_KIND_PRODUCER",
+	5: "SPAN_KIND_CONSUMER",
+}
+
+var Span_SpanKind_value = map[string]int32{
+	"SPAN_KIND_UNSPECIFIED": 0,
+	"SPAN_KIND_INTERNAL":    1,
+	"SPAN_KIND_SERVER":      2,
+	"SPAN_KIND_CLIENT":      3,
+	"SPAN_KIND_PRODUCER":    4,
+	"SPAN_KIND_CONSUMER":    5,
+}
+
+func (x Span_SpanKind) String() string {
+	return proto.EnumName(Span_SpanKind_name, int32(x))
+}
+
+func (Span_SpanKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{2, 0}
+}
+
+// For the semantics of status codes see
+// https://github.com/open-telemetry/opentelemetry-specification/blob/master/specification/trace/api.md#set-status
+type Status_StatusCode int32
+
+const (
+	// The default status.
+	Status_STATUS_CODE_UNSET Status_StatusCode = 0
+	// The Span has been validated by an Application developers or Operator to have
+	// completed successfully.
+	Status_STATUS_CODE_OK Status_StatusCode = 1
+	// The Span contains an error.
+	Status_STATUS_CODE_ERROR Status_StatusCode = 2
+)
+
+var Status_StatusCode_name = map[int32]string{
+	0: "STATUS_CODE_UNSET",
+	1: "STATUS_CODE_OK",
+	2: "STATUS_CODE_ERROR",
+}
+
+var Status_StatusCode_value = map[string]int32{
+	"STATUS_CODE_UNSET": 0,
+	"STATUS_CODE_OK":    1,
+	"STATUS_CODE_ERROR": 2,
+}
+
+func (x Status_StatusCode) String() string {
+	return proto.EnumName(Status_StatusCode_name, int32(x))
+}
+
+func (Status_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{3, 0}
+}
+
+// A collection of InstrumentationLibrarySpans from a Resource.
+type ResourceSpans struct {
+	// The resource for the spans in this message.
+	// If this field is not set then no resource info is known.
+	Resource *v1.Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// A list of InstrumentationLibrarySpans that originate from a resource.
+	InstrumentationLibrarySpans []*InstrumentationLibrarySpans `protobuf:"bytes,2,rep,name=instrumentation_library_spans,json=instrumentationLibrarySpans,proto3" json:"instrumentation_library_spans,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}                       `json:"-"`
+	XXX_unrecognized            []byte                         `json:"-"`
+	XXX_sizecache               int32                          `json:"-"`
+}
+
+func (m *ResourceSpans) Reset()         { *m = ResourceSpans{} }
+func (m *ResourceSpans) String() string { return proto.CompactTextString(m) }
+func (*ResourceSpans) ProtoMessage()    {}
+func (*ResourceSpans) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{0}
+}
+func (m *ResourceSpans) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceSpans.Unmarshal(m, b)
+}
+func (m *ResourceSpans) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceSpans.Marshal(b, m, deterministic)
+}
+func (m *ResourceSpans) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceSpans.Merge(m, src)
+}
+func (m *ResourceSpans) XXX_Size() int {
+	return xxx_messageInfo_ResourceSpans.Size(m)
+}
+func (m *ResourceSpans) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceSpans.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceSpans proto.InternalMessageInfo
+
+func (m *ResourceSpans) GetResource() *v1.Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *ResourceSpans) GetInstrumentationLibrarySpans() []*InstrumentationLibrarySpans {
+	if m != nil {
+		return m.InstrumentationLibrarySpans
+	}
+	return nil
+}
+
+// A collection of Spans produced by an InstrumentationLibrary.
+type InstrumentationLibrarySpans struct {
+	// The instrumentation library information for the spans in this message.
+	// If this field is not set then no library info is known.
+	InstrumentationLibrary *v11.InstrumentationLibrary `protobuf:"bytes,1,opt,name=instrumentation_library,json=instrumentationLibrary,proto3" json:"instrumentation_library,omitempty"`
+	// A list of Spans that originate from an instrumentation library.
+	Spans                []*Span  `protobuf:"bytes,2,rep,name=spans,proto3" json:"spans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstrumentationLibrarySpans) Reset()         { *m = InstrumentationLibrarySpans{} }
+func (m *InstrumentationLibrarySpans) String() string { return proto.CompactTextString(m) }
+func (*InstrumentationLibrarySpans) ProtoMessage()    {}
+func (*InstrumentationLibrarySpans) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{1}
+}
+func (m *InstrumentationLibrarySpans) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstrumentationLibrarySpans.Unmarshal(m, b)
+}
+func (m *InstrumentationLibrarySpans) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstrumentationLibrarySpans.Marshal(b, m, deterministic)
+}
+func (m *InstrumentationLibrarySpans) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstrumentationLibrarySpans.Merge(m, src)
+}
+func (m *InstrumentationLibrarySpans) XXX_Size() int {
+	return xxx_messageInfo_InstrumentationLibrarySpans.Size(m)
+}
+func (m *InstrumentationLibrarySpans) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstrumentationLibrarySpans.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstrumentationLibrarySpans proto.InternalMessageInfo
+
+func (m *InstrumentationLibrarySpans) GetInstrumentationLibrary() *v11.InstrumentationLibrary {
+	if m != nil {
+		return m.InstrumentationLibrary
+	}
+	return nil
+}
+
+func (m *InstrumentationLibrarySpans) GetSpans() []*Span {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+// Span represents a single operation within a trace. Spans can be
+// nested to form a trace tree.
+type Span struct {
+	// A unique identifier for a trace. All spans from the same trace share
+	// the same `trace_id`. The ID is a 16-byte array.
+	TraceId []byte `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// A unique identifier for a span within a trace, assigned when the span
+	// is created. The ID is an 8-byte array.
+	SpanId []byte `protobuf:"bytes,2,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	// trace_state conveys information about request position in multiple distributed tracing graphs.
+	TraceState string `protobuf:"bytes,3,opt,name=trace_state,json=traceState,proto3" json:"trace_state,omitempty"`
+	// The `span_id` of this span's parent span. If this is a root span, then this
+	// field must be empty. The ID is an 8-byte array.
+	ParentSpanId []byte `protobuf:"bytes,4,opt,name=parent_span_id,json=parentSpanId,proto3" json:"parent_span_id,omitempty"`
+	// A description of the span's operation.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Distinguishes between spans generated in a particular context.
+	Kind Span_SpanKind `protobuf:"varint,6,opt,name=kind,proto3,enum=opentelemetry.proto.trace.v1.Span_SpanKind" json:"kind,omitempty"`
+	// start_time_unix_nano is the start time of the span, in nanoseconds since the UNIX epoch.
+	StartTimeUnixNano uint64 `protobuf:"fixed64,7,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
+	// end_time_unix_nano is the end time of the span, in nanoseconds since the UNIX epoch.
+	EndTimeUnixNano uint64 `protobuf:"fixed64,8,opt,name=end_time_unix_nano,json=endTimeUnixNano,proto3" json:"end_time_unix_nano,omitempty"`
+	// attributes is a collection of key/value pairs.
+	Attributes []*v11.KeyValue `protobuf:"bytes,9,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// dropped_attributes_count is the number of attributes that were discarded.
+	DroppedAttributesCount uint32 `protobuf:"varint,10,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	// events is a collection of Event items.
+	Events []*Span_Event `protobuf:"bytes,11,rep,name=events,proto3" json:"events,omitempty"`
+	// dropped_events_count is the number of dropped events.
+	DroppedEventsCount uint32 `protobuf:"varint,12,opt,name=dropped_events_count,json=droppedEventsCount,proto3" json:"dropped_events_count,omitempty"`
+	// links is a collection of Links, which are references from this span to a span
+	// in the same or different trace.
+	Links []*Span_Link `protobuf:"bytes,13,rep,name=links,proto3" json:"links,omitempty"`
+	// dropped_links_count is the number of dropped links after the maximum size was
+	// enforced.
+	DroppedLinksCount uint32 `protobuf:"varint,14,opt,name=dropped_links_count,json=droppedLinksCount,proto3" json:"dropped_links_count,omitempty"`
+	// An optional final status for this span.
+	Status               *Status  `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Span) Reset()         { *m = Span{} }
+func (m *Span) String() string { return proto.CompactTextString(m) }
+func (*Span) ProtoMessage()    {}
+func (*Span) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{2}
+}
+func (m *Span) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Span.Unmarshal(m, b)
+}
+func (m *Span) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Span.Marshal(b, m, deterministic)
+}
+func (m *Span) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Span.Merge(m, src)
+}
+func (m *Span) XXX_Size() int {
+	return xxx_messageInfo_Span.Size(m)
+}
+func (m *Span) XXX_DiscardUnknown() {
+	xxx_messageInfo_Span.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Span proto.InternalMessageInfo
+
+func (m *Span) GetTraceId() []byte {
+	if m != nil {
+		return m.TraceId
+	}
+	return nil
+}
+
+func (m *Span) GetSpanId() []byte {
+	if m != nil {
+		return m.SpanId
+	}
+	return nil
+}
+
+func (m *Span) GetTraceState() string {
+	if m != nil {
+		return m.TraceState
+	}
+	return ""
+}
+
+func (m *Span) GetParentSpanId() []byte {
+	if m != nil {
+		return m.ParentSpanId
+	}
+	return nil
+}
+
+func (m *Span) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Span) GetKind() Span_SpanKind {
+	if m != nil {
+		return m.Kind
+	}
+	return Span_SPAN_KIND_UNSPECIFIED
+}
+
+func (m *Span) GetStartTimeUnixNano() uint64 {
+	if m != nil {
+		return m.StartTimeUnixNano
+	}
+	return 0
+}
+
+func (m *Span) GetEndTimeUnixNano() uint64 {
+	if m != nil {
+		return m.EndTimeUnixNano
+	}
+	return 0
+}
+
+func (m *Span) GetAttributes() []*v11.KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Span) GetDroppedAttributesCount() uint32 {
+	if m != nil {
+		return m.DroppedAttributesCount
+	}
+	return 0
+}
+
+func (m *Span) GetEvents() []*Span_Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Span) GetDroppedEventsCount() uint32 {
+	if m != nil {
+		return m.DroppedEventsCount
+	}
+	return 0
+}
+
+func (m *Span) GetLinks() []*Span_Link {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
+func (m *Span) GetDroppedLinksCount() uint32 {
+	if m != nil {
+		return m.DroppedLinksCount
+	}
+	return 0
+}
+
+func (m *Span) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// Event is a time-stamped annotation of the span, consisting of user-supplied
+// text description and key-value pairs.
+type Span_Event struct {
+	// time_unix_nano is the time the event occurred.
+	TimeUnixNano uint64 `protobuf:"fixed64,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// name of the event.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// attributes is a collection of attribute key/value pairs on the event.
+	Attributes []*v11.KeyValue `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// dropped_attributes_count is the number of dropped attributes.
+	DroppedAttributesCount uint32   `protobuf:"varint,4,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *Span_Event) Reset()         { *m = Span_Event{} }
+func (m *Span_Event) String() string { return proto.CompactTextString(m) }
+func (*Span_Event) ProtoMessage()    {}
+func (*Span_Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{2, 0}
+}
+func (m *Span_Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Span_Event.Unmarshal(m, b)
+}
+func (m *Span_Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Span_Event.Marshal(b, m, deterministic)
+}
+func (m *Span_Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Span_Event.Merge(m, src)
+}
+func (m *Span_Event) XXX_Size() int {
+	return xxx_messageInfo_Span_Event.Size(m)
+}
+func (m *Span_Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Span_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Span_Event proto.InternalMessageInfo
+
+func (m *Span_Event) GetTimeUnixNano() uint64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *Span_Event) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Span_Event) GetAttributes() []*v11.KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Span_Event) GetDroppedAttributesCount() uint32 {
+	if m != nil {
+		return m.DroppedAttributesCount
+	}
+	return 0
+}
+
+// A pointer from the current span to another span in the same trace or in a
+// different trace.
+type Span_Link struct {
+	// A unique identifier of a trace that this linked span is part of.
+	TraceId []byte `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// A unique identifier for the linked span. The ID is an 8-byte array.
+	SpanId []byte `protobuf:"bytes,2,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	// The trace_state associated with the link.
+	TraceState string `protobuf:"bytes,3,opt,name=trace_state,json=traceState,proto3" json:"trace_state,omitempty"`
+	// attributes is a collection of attribute key/value pairs on the link.
+	Attributes []*v11.KeyValue `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// dropped_attributes_count is the number of dropped attributes.
+	DroppedAttributesCount uint32   `protobuf:"varint,5,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *Span_Link) Reset()         { *m = Span_Link{} }
+func (m *Span_Link) String() string { return proto.CompactTextString(m) }
+func (*Span_Link) ProtoMessage()    {}
+func (*Span_Link) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{2, 1}
+}
+func (m *Span_Link) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Span_Link.Unmarshal(m, b)
+}
+func (m *Span_Link) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Span_Link.Marshal(b, m, deterministic)
+}
+func (m *Span_Link) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Span_Link.Merge(m, src)
+}
+func (m *Span_Link) XXX_Size() int {
+	return xxx_messageInfo_Span_Link.Size(m)
+}
+func (m *Span_Link) XXX_DiscardUnknown() {
+	xxx_messageInfo_Span_Link.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Span_Link proto.InternalMessageInfo
+
+func (m *Span_Link) GetTraceId() []byte {
+	if m != nil {
+		return m.TraceId
+	}
+	return nil
+}
+
+func (m *Span_Link) GetSpanId() []byte {
+	if m != nil {
+		return m.SpanId
+	}
+	return nil
+}
+
+func (m *Span_Link) GetTraceState() string {
+	if m != nil {
+		return m.TraceState
+	}
+	return ""
+}
+
+func (m *Span_Link) GetAttributes() []*v11.KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Span_Link) GetDroppedAttributesCount() uint32 {
+	if m != nil {
+		return m.DroppedAttributesCount
+	}
+	return 0
+}
+
+// The Status type defines a logical error model that is suitable for different
+// programming environments, including REST APIs and RPC APIs.
+type Status struct {
+	// A developer-facing human readable error message.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The status code.
+	Code                 Status_StatusCode `protobuf:"varint,3,opt,name=code,proto3,enum=opentelemetry.proto.trace.v1.Status_StatusCode" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c407ac9c675a601, []int{3}
+}
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Status) GetCode() Status_StatusCode {
+	if m != nil {
+		return m.Code
+	}
+	return Status_STATUS_CODE_UNSET
+}
+
+func init() {
+	proto.RegisterEnum("opentelemetry.proto.trace.v1.Span_SpanKind", Span_SpanKind_name, Span_SpanKind_value)
+	proto.RegisterEnum("opentelemetry.proto.trace.v1.Status_StatusCode", Status_StatusCode_name, Status_StatusCode_value)
+	proto.RegisterType((*ResourceSpans)(nil), "opentelemetry.proto.trace.v1.ResourceSpans")
+	proto.RegisterType((*InstrumentationLibrarySpans)(nil), "opentelemetry.proto.trace.v1.InstrumentationLibrarySpans")
+	proto.RegisterType((*Span)(nil), "opentelemetry.proto.trace.v1.Span")
+	proto.RegisterType((*Span_Event)(nil), "opentelemetry.proto.trace.v1.Span.Event")
+	proto.RegisterType((*Span_Link)(nil), "opentelemetry.proto.trace.v1.Span.Link")
+	proto.RegisterType((*Status)(nil), "opentelemetry.proto.trace.v1.Status")
+}
+
+func init() {
+	proto.RegisterFile("opentelemetry/proto/trace/v1/trace.proto", fileDescriptor_5c407ac9c675a601)
+}
+
+var fileDescriptor_5c407ac9c675a601 = []byte{
+	// 843 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xc1, 0x6e, 0xdb, 0x46,
+	0x10, 0xcd, 0xca, 0x94, 0xac, 0x8c, 0x6d, 0x85, 0xde, 0x3a, 0x0e, 0xe3, 0xb4, 0x88, 0x20, 0x04,
+	0xa8, 0xda, 0xa0, 0x54, 0xed, 0xa2, 0x68, 0x0a, 0xb4, 0x68, 0x1d, 0x9a, 0x2d, 0x58, 0xbb, 0x94,
+	0xb0, 0x94, 0x72, 0xe8, 0x85, 0xa0, 0xcd, 0x85, 0xb2, 0xb5, 0xb8, 0x14, 0xc8, 0xa5, 0x11, 0x1f,
+	0xfa, 0x21, 0x05, 0xfa, 0x2b, 0xbd, 0x15, 0xe8, 0x17, 0xf4, 0xda, 0x6f, 0x09, 0x76, 0x97, 0xb4,
+	0x45, 0x43, 0x96, 0x73, 0xf1, 0xc5, 0xde, 0x7d, 0xf3, 0xde, 0xbc, 0x99, 0x9d, 0x21, 0x6c, 0xe8,
+	0xa7, 0x73, 0xca, 0x05, 0x9d, 0xd1, 0x84, 0x8a, 0xec, 0x72, 0x30, 0xcf, 0x52, 0x91, 0x0e, 0x44,
+	0x16, 0x9d, 0xd1, 0xc1, 0xc5, 0xbe, 0x3e, 0xd8, 0x0a, 0xc4, 0x1f, 0xd7, 0x98, 0x1a, 0xb4, 0x35,
+	0xe1, 0x62, 0x7f, 0xef, 0xf3, 0x65, 0x79, 0xce, 0xd2, 0x24, 0x49, 0xb9, 0x4c, 0xa4, 0x4f, 0x5a,
+	0xb4, 0x67, 0x2f, 0xe3, 0x66, 0x34, 0x4f, 0x8b, 0x4c, 0xdb, 0x56, 0x67, 0xcd, 0xef, 0xfd, 0x87,
+	0x60, 0x8b, 0x94, 0x50, 0x30, 0x8f, 0x78, 0x8e, 0x5d, 0x68, 0x57, 0x1c, 0x0b, 0x75, 0x51, 0x7f,
+	0xe3, 0xe0, 0x33, 0x7b, 0x59, 0x79, 0x57, 0x89, 0x2e, 0xf6, 0xed, 0x2a, 0x03, 0xb9, 0x92, 0xe2,
+	0x3f, 0xe0, 0x13, 0xc6, 0x73, 0x91, 0x15, 0x09, 0xe5, 0x22, 0x12, 0x2c, 0xe5, 0xe1, 0x8c, 0x9d,
+	0x66, 0x51, 0x76, 0x19, 0xe6, 0xd2, 0xc7, 0x6a, 0x74, 0xd7, 0xfa, 0x1b, 0x07, 0xdf, 0xda, 0xab,
+	0x5a, 0xb7, 0xbd, 0x7a, 0x8a, 0x13, 0x9d, 0x41, 0x15, 0x4a, 0x9e, 0xb1, 0xdb, 0x83, 0xbd, 0x7f,
+	0x11, 0x3c, 0x5b, 0x21, 0xc6, 0x1c, 0x9e, 0xdc, 0x52, 0x5e, 0xd9, 0xf4, 0xd7, 0x4b, 0x0b, 0x2b,
+	0xdf, 0xfa, 0xd6, 0xca, 0xc8, 0xee, 0xf2, 0xa2, 0xf0, 0x2b, 0x68, 0x2e, 0xb6, 0xdd, 0x5b, 0xdd,
+	0xb6, 0xac, 0x91, 0x68, 0x41, 0xef, 0x2f, 0x00, 0x43, 0xde, 0xf1, 0x53, 0x68, 0x2b, 0x42, 0xc8,
+	0x62, 0x55, 0xe3, 0x26, 0x59, 0x57, 0x77, 0x2f, 0xc6, 0x4f, 0x60, 0x5d, 0x92, 0x65, 0xa4, 0xa1,
+	0x22, 0x2d, 0x79, 0xf5, 0x62, 0xfc, 0x1c, 0x36, 0xb4, 0x26, 0x17, 0x91, 0xa0, 0xd6, 0x5a, 0x17,
+	0xf5, 0x1f, 0x12, 0x50, 0x50, 0x20, 0x11, 0xfc, 0x02, 0x3a, 0xf3, 0x28, 0xa3, 0x5c, 0x84, 0x55,
+	0x02, 0x43, 0x25, 0xd8, 0xd4, 0x68, 0xa0, 0xd3, 0x60, 0x30, 0x78, 0x94, 0x50, 0xab, 0xa9, 0xf4,
+	0xea, 0x8c, 0x7f, 0x00, 0xe3, 0x9c, 0xf1, 0xd8, 0x6a, 0x75, 0x51, 0xbf, 0x73, 0xf0, 0xf2, 0xee,
+	0x86, 0xd4, 0x8f, 0x63, 0xc6, 0x63, 0xa2, 0x84, 0x78, 0x00, 0x3b, 0xb9, 0x88, 0x32, 0x11, 0x0a,
+	0x96, 0xd0, 0xb0, 0xe0, 0xec, 0x5d, 0xc8, 0x23, 0x9e, 0x5a, 0xeb, 0x5d, 0xd4, 0x6f, 0x91, 0x6d,
+	0x15, 0x1b, 0xb3, 0x84, 0x4e, 0x38, 0x7b, 0xe7, 0x47, 0x3c, 0xc5, 0x2f, 0x01, 0x53, 0x1e, 0xdf,
+	0xa4, 0xb7, 0x15, 0xfd, 0x11, 0xe5, 0x71, 0x8d, 0xfc, 0x33, 0x40, 0x24, 0x44, 0xc6, 0x4e, 0x0b,
+	0x41, 0x73, 0xeb, 0xa1, 0x7a, 0xf5, 0x4f, 0xef, 0x98, 0xe9, 0x31, 0xbd, 0x7c, 0x13, 0xcd, 0x0a,
+	0x4a, 0x16, 0xa4, 0xf8, 0x15, 0x58, 0x71, 0x96, 0xce, 0xe7, 0x34, 0x0e, 0xaf, 0xd1, 0xf0, 0x2c,
+	0x2d, 0xb8, 0xb0, 0xa0, 0x8b, 0xfa, 0x5b, 0x64, 0xb7, 0x8c, 0x1f, 0x5e, 0x85, 0x1d, 0x19, 0xc5,
+	0x3f, 0x42, 0x8b, 0x5e, 0x50, 0x2e, 0x72, 0x6b, 0x43, 0xd9, 0xf7, 0x3f, 0xe0, 0x8d, 0x5c, 0x29,
+	0x20, 0xa5, 0x0e, 0x7f, 0x09, 0x3b, 0x95, 0xb7, 0x46, 0x4a, 0xdf, 0x4d, 0xe5, 0x8b, 0xcb, 0x98,
+	0xd2, 0x94, 0x9e, 0xdf, 0x43, 0x73, 0xc6, 0xf8, 0x79, 0x6e, 0x6d, 0xad, 0xe8, 0xb8, 0x6e, 0x79,
+	0xc2, 0xf8, 0x39, 0xd1, 0x2a, 0x6c, 0xc3, 0x47, 0x95, 0xa1, 0x02, 0x4a, 0xbf, 0x8e, 0xf2, 0xdb,
+	0x2e, 0x43, 0x52, 0x50, 0xda, 0x7d, 0x07, 0x2d, 0xb9, 0x59, 0x45, 0x6e, 0x3d, 0x52, 0x5f, 0xcd,
+	0x8b, 0x3b, 0xfc, 0x14, 0x97, 0x94, 0x9a, 0xbd, 0x7f, 0x10, 0x34, 0x55, 0xf1, 0x72, 0x0d, 0x6f,
+	0x8c, 0x15, 0xa9, 0xb1, 0x6e, 0x8a, 0xc5, 0x99, 0x56, 0x6b, 0xd8, 0x58, 0x58, 0xc3, 0xfa, 0x9c,
+	0xd7, 0xee, 0x67, 0xce, 0xc6, 0xaa, 0x39, 0xef, 0xfd, 0x8f, 0xc0, 0x90, 0x6f, 0x72, 0x3f, 0x5f,
+	0x68, 0xbd, 0x41, 0xe3, 0x7e, 0x1a, 0x6c, 0xae, 0x6a, 0xb0, 0xf7, 0x27, 0x82, 0x76, 0xf5, 0xf1,
+	0xe2, 0xa7, 0xf0, 0x38, 0x18, 0x1d, 0xfa, 0xe1, 0xb1, 0xe7, 0x1f, 0x85, 0x13, 0x3f, 0x18, 0xb9,
+	0x8e, 0xf7, 0x93, 0xe7, 0x1e, 0x99, 0x0f, 0xf0, 0x2e, 0xe0, 0xeb, 0x90, 0xe7, 0x8f, 0x5d, 0xe2,
+	0x1f, 0x9e, 0x98, 0x08, 0xef, 0x80, 0x79, 0x8d, 0x07, 0x2e, 0x79, 0xe3, 0x12, 0xb3, 0x51, 0x47,
+	0x9d, 0x13, 0xcf, 0xf5, 0xc7, 0xe6, 0x5a, 0x3d, 0xc7, 0x88, 0x0c, 0x8f, 0x26, 0x8e, 0x4b, 0x4c,
+	0xa3, 0x8e, 0x3b, 0x43, 0x3f, 0x98, 0xfc, 0xea, 0x12, 0xb3, 0xd9, 0xfb, 0x1b, 0x41, 0x4b, 0xaf,
+	0x15, 0xb6, 0x60, 0x3d, 0xa1, 0x79, 0x1e, 0x4d, 0xab, 0x0d, 0xa9, 0xae, 0xd8, 0x01, 0xe3, 0x2c,
+	0x8d, 0xf5, 0xeb, 0x76, 0x0e, 0x06, 0x1f, 0xb2, 0xa4, 0xe5, 0x2f, 0x27, 0x8d, 0x29, 0x51, 0xe2,
+	0x9e, 0x0f, 0x70, 0x8d, 0xe1, 0xc7, 0xb0, 0x1d, 0x8c, 0x0f, 0xc7, 0x93, 0x20, 0x74, 0x86, 0x47,
+	0xae, 0x7c, 0x08, 0x77, 0x6c, 0x3e, 0xc0, 0x18, 0x3a, 0x8b, 0xf0, 0xf0, 0xd8, 0x44, 0x37, 0xa9,
+	0x2e, 0x21, 0x43, 0x62, 0x36, 0x7e, 0x31, 0xda, 0xc8, 0x6c, 0xbc, 0x7e, 0x0b, 0xcf, 0x59, 0xba,
+	0xb2, 0xa0, 0xd7, 0x30, 0x96, 0xa7, 0x91, 0x04, 0x47, 0xe8, 0xb7, 0x6f, 0xa6, 0x4c, 0xbc, 0x2d,
+	0x4e, 0xe5, 0xb4, 0x07, 0xbf, 0x47, 0x74, 0x4a, 0x33, 0x49, 0x64, 0x7c, 0x5a, 0xde, 0xf4, 0xdf,
+	0xfc, 0x2f, 0xa6, 0x94, 0x0f, 0x52, 0x31, 0x9b, 0x5f, 0xfd, 0xc3, 0x71, 0xda, 0x52, 0x81, 0xaf,
+	0xde, 0x0f, 0x00, 0xf2, 0x26, 0x0d, 0x04, 0x97, 0x08, 0x00, 0x00,
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import context "context"
+import grpc "google.golang.org/grpc"
+import mock "github.com/stretchr/testify/mock"
+import storage_v1 "github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+
+// DependenciesWriterPluginClient is an autogenerated mock type for the DependenciesWriterPluginClient type
+type DependenciesWriterPluginClient struct {
+	mock.Mock
+}
+
+// WriteDependencies provides a mock function with given fields: ctx, in, opts
+func (_m *DependenciesWriterPluginClient) WriteDependencies(ctx context.Context, in *storage_v1.WriteDependenciesRequest, opts ...grpc.CallOption) (*storage_v1.WriteDependenciesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *storage_v1.WriteDependenciesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.WriteDependenciesRequest, ...grpc.CallOption) *storage_v1.WriteDependenciesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.WriteDependenciesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.WriteDependenciesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,35 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import storage_v1 "github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+
+// DependenciesWriterPluginServer is an autogenerated mock type for the DependenciesWriterPluginServer type
+type DependenciesWriterPluginServer struct {
+	mock.Mock
+}
+
+// WriteDependencies provides a mock function with given fields: _a0, _a1
+func (_m *DependenciesWriterPluginServer) WriteDependencies(_a0 context.Context, _a1 *storage_v1.WriteDependenciesRequest) (*storage_v1.WriteDependenciesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.WriteDependenciesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.WriteDependenciesRequest) *storage_v1.WriteDependenciesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.WriteDependenciesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.WriteDependenciesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,163 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import context "context"
+import grpc "google.golang.org/grpc"
+import mock "github.com/stretchr/testify/mock"
+import storage_v1 "github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+
+// SamplingStorePluginClient is an autogenerated mock type for the SamplingStorePluginClient type
+type SamplingStorePluginClient struct {
+	mock.Mock
+}
+
+// GetLatestProbabilities provides a mock function with given fields: ctx, in, opts
+func (_m *SamplingStorePluginClient) GetLatestProbabilities(ctx context.Context, in *storage_v1.GetLatestProbabilitiesRequest, opts ...grpc.CallOption) (*storage_v1.GetLatestProbabilitiesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *storage_v1.GetLatestProbabilitiesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.GetLatestProbabilitiesRequest, ...grpc.CallOption) *storage_v1.GetLatestProbabilitiesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.GetLatestProbabilitiesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.GetLatestProbabilitiesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProbabilitiesAndQPS provides a mock function with given fields: ctx, in, opts
+func (_m *SamplingStorePluginClient) GetProbabilitiesAndQPS(ctx context.Context, in *storage_v1.GetProbabilitiesAndQPSRequest, opts ...grpc.CallOption) (*storage_v1.GetProbabilitiesAndQPSResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *storage_v1.GetProbabilitiesAndQPSResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.GetProbabilitiesAndQPSRequest, ...grpc.CallOption) *storage_v1.GetProbabilitiesAndQPSResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.GetProbabilitiesAndQPSResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.GetProbabilitiesAndQPSRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThroughput provides a mock function with given fields: ctx, in, opts
+func (_m *SamplingStorePluginClient) GetThroughput(ctx context.Context, in *storage_v1.GetThroughputRequest, opts ...grpc.CallOption) (*storage_v1.GetThroughputResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *storage_v1.GetThroughputResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.GetThroughputRequest, ...grpc.CallOption) *storage_v1.GetThroughputResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.GetThroughputResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.GetThroughputRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertProbabilitiesAndQPS provides a mock function with given fields: ctx, in, opts
+func (_m *SamplingStorePluginClient) InsertProbabilitiesAndQPS(ctx context.Context, in *storage_v1.InsertProbabilitiesAndQPSRequest, opts ...grpc.CallOption) (*storage_v1.InsertProbabilitiesAndQPSResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *storage_v1.InsertProbabilitiesAndQPSResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.InsertProbabilitiesAndQPSRequest, ...grpc.CallOption) *storage_v1.InsertProbabilitiesAndQPSResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.InsertProbabilitiesAndQPSResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.InsertProbabilitiesAndQPSRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertThroughput provides a mock function with given fields: ctx, in, opts
+func (_m *SamplingStorePluginClient) InsertThroughput(ctx context.Context, in *storage_v1.InsertThroughputRequest, opts ...grpc.CallOption) (*storage_v1.InsertThroughputResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *storage_v1.InsertThroughputResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.InsertThroughputRequest, ...grpc.CallOption) *storage_v1.InsertThroughputResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.InsertThroughputResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.InsertThroughputRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,127 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mocks
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import storage_v1 "github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+
+// SamplingStorePluginServer is an autogenerated mock type for the SamplingStorePluginServer type
+type SamplingStorePluginServer struct {
+	mock.Mock
+}
+
+// GetLatestProbabilities provides a mock function with given fields: _a0, _a1
+func (_m *SamplingStorePluginServer) GetLatestProbabilities(_a0 context.Context, _a1 *storage_v1.GetLatestProbabilitiesRequest) (*storage_v1.GetLatestProbabilitiesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.GetLatestProbabilitiesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.GetLatestProbabilitiesRequest) *storage_v1.GetLatestProbabilitiesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.GetLatestProbabilitiesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.GetLatestProbabilitiesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProbabilitiesAndQPS provides a mock function with given fields: _a0, _a1
+func (_m *SamplingStorePluginServer) GetProbabilitiesAndQPS(_a0 context.Context, _a1 *storage_v1.GetProbabilitiesAndQPSRequest) (*storage_v1.GetProbabilitiesAndQPSResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.GetProbabilitiesAndQPSResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.GetProbabilitiesAndQPSRequest) *storage_v1.GetProbabilitiesAndQPSResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.GetProbabilitiesAndQPSResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.GetProbabilitiesAndQPSRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThroughput provides a mock function with given fields: _a0, _a1
+func (_m *SamplingStorePluginServer) GetThroughput(_a0 context.Context, _a1 *storage_v1.GetThroughputRequest) (*storage_v1.GetThroughputResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.GetThroughputResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.GetThroughputRequest) *storage_v1.GetThroughputResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.GetThroughputResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.GetThroughputRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertProbabilitiesAndQPS provides a mock function with given fields: _a0, _a1
+func (_m *SamplingStorePluginServer) InsertProbabilitiesAndQPS(_a0 context.Context, _a1 *storage_v1.InsertProbabilitiesAndQPSRequest) (*storage_v1.InsertProbabilitiesAndQPSResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.InsertProbabilitiesAndQPSResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.InsertProbabilitiesAndQPSRequest) *storage_v1.InsertProbabilitiesAndQPSResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.InsertProbabilitiesAndQPSResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.InsertProbabilitiesAndQPSRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertThroughput provides a mock function with given fields: _a0, _a1
+func (_m *SamplingStorePluginServer) InsertThroughput(_a0 context.Context, _a1 *storage_v1.InsertThroughputRequest) (*storage_v1.InsertThroughputResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.InsertThroughputResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.InsertThroughputRequest) *storage_v1.InsertThroughputResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.InsertThroughputResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.InsertThroughputRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
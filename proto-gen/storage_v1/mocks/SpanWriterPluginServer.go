@@ -33,3 +33,26 @@ func (_m *SpanWriterPluginServer) WriteSpan(_a0 context.Context, _a1 *storage_v1
 
 	return r0, r1
 }
+
+// WriteSpans provides a mock function with given fields: _a0, _a1
+func (_m *SpanWriterPluginServer) WriteSpans(_a0 context.Context, _a1 *storage_v1.WriteSpansRequest) (*storage_v1.WriteSpansResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *storage_v1.WriteSpansResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *storage_v1.WriteSpansRequest) *storage_v1.WriteSpansResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage_v1.WriteSpansResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *storage_v1.WriteSpansRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
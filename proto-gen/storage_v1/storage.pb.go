@@ -5,6 +5,7 @@ package storage_v1
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
@@ -221,25 +222,26 @@ func (m *WriteSpanResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_WriteSpanResponse proto.InternalMessageInfo
 
-type GetTraceRequest struct {
-	TraceID              github_com_jaegertracing_jaeger_model.TraceID `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3,customtype=github.com/jaegertracing/jaeger/model.TraceID" json:"trace_id"`
-	XXX_NoUnkeyedLiteral struct{}                                      `json:"-"`
-	XXX_unrecognized     []byte                                        `json:"-"`
-	XXX_sizecache        int32                                         `json:"-"`
+// WriteSpansRequest carries a batch of spans, written with a single round trip.
+type WriteSpansRequest struct {
+	Spans                []*model.Span `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *GetTraceRequest) Reset()         { *m = GetTraceRequest{} }
-func (m *GetTraceRequest) String() string { return proto.CompactTextString(m) }
-func (*GetTraceRequest) ProtoMessage()    {}
-func (*GetTraceRequest) Descriptor() ([]byte, []int) {
+func (m *WriteSpansRequest) Reset()         { *m = WriteSpansRequest{} }
+func (m *WriteSpansRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteSpansRequest) ProtoMessage()    {}
+func (*WriteSpansRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{4}
 }
-func (m *GetTraceRequest) XXX_Unmarshal(b []byte) error {
+func (m *WriteSpansRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetTraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *WriteSpansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetTraceRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_WriteSpansRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -249,36 +251,44 @@ func (m *GetTraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *GetTraceRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTraceRequest.Merge(m, src)
+func (m *WriteSpansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteSpansRequest.Merge(m, src)
 }
-func (m *GetTraceRequest) XXX_Size() int {
+func (m *WriteSpansRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetTraceRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTraceRequest.DiscardUnknown(m)
+func (m *WriteSpansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteSpansRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTraceRequest proto.InternalMessageInfo
+var xxx_messageInfo_WriteSpansRequest proto.InternalMessageInfo
 
-type GetServicesRequest struct {
+func (m *WriteSpansRequest) GetSpans() []*model.Span {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+// empty; extensible in the future
+type WriteSpansResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetServicesRequest) Reset()         { *m = GetServicesRequest{} }
-func (m *GetServicesRequest) String() string { return proto.CompactTextString(m) }
-func (*GetServicesRequest) ProtoMessage()    {}
-func (*GetServicesRequest) Descriptor() ([]byte, []int) {
+func (m *WriteSpansResponse) Reset()         { *m = WriteSpansResponse{} }
+func (m *WriteSpansResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteSpansResponse) ProtoMessage()    {}
+func (*WriteSpansResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{5}
 }
-func (m *GetServicesRequest) XXX_Unmarshal(b []byte) error {
+func (m *WriteSpansResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *WriteSpansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetServicesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_WriteSpansResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -288,37 +298,38 @@ func (m *GetServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetServicesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetServicesRequest.Merge(m, src)
+func (m *WriteSpansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteSpansResponse.Merge(m, src)
 }
-func (m *GetServicesRequest) XXX_Size() int {
+func (m *WriteSpansResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetServicesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetServicesRequest.DiscardUnknown(m)
+func (m *WriteSpansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteSpansResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetServicesRequest proto.InternalMessageInfo
+var xxx_messageInfo_WriteSpansResponse proto.InternalMessageInfo
 
-type GetServicesResponse struct {
-	Services             []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type WriteDependenciesRequest struct {
+	Timestamp            time.Time              `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Dependencies         []model.DependencyLink `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *GetServicesResponse) Reset()         { *m = GetServicesResponse{} }
-func (m *GetServicesResponse) String() string { return proto.CompactTextString(m) }
-func (*GetServicesResponse) ProtoMessage()    {}
-func (*GetServicesResponse) Descriptor() ([]byte, []int) {
+func (m *WriteDependenciesRequest) Reset()         { *m = WriteDependenciesRequest{} }
+func (m *WriteDependenciesRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteDependenciesRequest) ProtoMessage()    {}
+func (*WriteDependenciesRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{6}
 }
-func (m *GetServicesResponse) XXX_Unmarshal(b []byte) error {
+func (m *WriteDependenciesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetServicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *WriteDependenciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetServicesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_WriteDependenciesRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -328,45 +339,51 @@ func (m *GetServicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetServicesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetServicesResponse.Merge(m, src)
+func (m *WriteDependenciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteDependenciesRequest.Merge(m, src)
 }
-func (m *GetServicesResponse) XXX_Size() int {
+func (m *WriteDependenciesRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetServicesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetServicesResponse.DiscardUnknown(m)
+func (m *WriteDependenciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteDependenciesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetServicesResponse proto.InternalMessageInfo
+var xxx_messageInfo_WriteDependenciesRequest proto.InternalMessageInfo
 
-func (m *GetServicesResponse) GetServices() []string {
+func (m *WriteDependenciesRequest) GetTimestamp() time.Time {
 	if m != nil {
-		return m.Services
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *WriteDependenciesRequest) GetDependencies() []model.DependencyLink {
+	if m != nil {
+		return m.Dependencies
 	}
 	return nil
 }
 
-type GetOperationsRequest struct {
-	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
-	SpanKind             string   `protobuf:"bytes,2,opt,name=span_kind,json=spanKind,proto3" json:"span_kind,omitempty"`
+// empty; extensible in the future
+type WriteDependenciesResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetOperationsRequest) Reset()         { *m = GetOperationsRequest{} }
-func (m *GetOperationsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetOperationsRequest) ProtoMessage()    {}
-func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+func (m *WriteDependenciesResponse) Reset()         { *m = WriteDependenciesResponse{} }
+func (m *WriteDependenciesResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteDependenciesResponse) ProtoMessage()    {}
+func (*WriteDependenciesResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{7}
 }
-func (m *GetOperationsRequest) XXX_Unmarshal(b []byte) error {
+func (m *WriteDependenciesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *WriteDependenciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetOperationsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_WriteDependenciesResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -376,52 +393,41 @@ func (m *GetOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *GetOperationsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetOperationsRequest.Merge(m, src)
+func (m *WriteDependenciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteDependenciesResponse.Merge(m, src)
 }
-func (m *GetOperationsRequest) XXX_Size() int {
+func (m *WriteDependenciesResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetOperationsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetOperationsRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_GetOperationsRequest proto.InternalMessageInfo
-
-func (m *GetOperationsRequest) GetService() string {
-	if m != nil {
-		return m.Service
-	}
-	return ""
+func (m *WriteDependenciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteDependenciesResponse.DiscardUnknown(m)
 }
 
-func (m *GetOperationsRequest) GetSpanKind() string {
-	if m != nil {
-		return m.SpanKind
-	}
-	return ""
-}
+var xxx_messageInfo_WriteDependenciesResponse proto.InternalMessageInfo
 
-type Operation struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	SpanKind             string   `protobuf:"bytes,2,opt,name=span_kind,json=spanKind,proto3" json:"span_kind,omitempty"`
+// Throughput keeps track of the queries an operation received.
+type Throughput struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Operation            string   `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Probabilities        []string `protobuf:"bytes,4,rep,name=probabilities,proto3" json:"probabilities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Operation) Reset()         { *m = Operation{} }
-func (m *Operation) String() string { return proto.CompactTextString(m) }
-func (*Operation) ProtoMessage()    {}
-func (*Operation) Descriptor() ([]byte, []int) {
+func (m *Throughput) Reset()         { *m = Throughput{} }
+func (m *Throughput) String() string { return proto.CompactTextString(m) }
+func (*Throughput) ProtoMessage()    {}
+func (*Throughput) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{8}
 }
-func (m *Operation) XXX_Unmarshal(b []byte) error {
+func (m *Throughput) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Throughput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Operation.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Throughput.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -431,52 +437,68 @@ func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Operation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Operation.Merge(m, src)
+func (m *Throughput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Throughput.Merge(m, src)
 }
-func (m *Operation) XXX_Size() int {
+func (m *Throughput) XXX_Size() int {
 	return m.Size()
 }
-func (m *Operation) XXX_DiscardUnknown() {
-	xxx_messageInfo_Operation.DiscardUnknown(m)
+func (m *Throughput) XXX_DiscardUnknown() {
+	xxx_messageInfo_Throughput.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Operation proto.InternalMessageInfo
+var xxx_messageInfo_Throughput proto.InternalMessageInfo
 
-func (m *Operation) GetName() string {
+func (m *Throughput) GetService() string {
 	if m != nil {
-		return m.Name
+		return m.Service
 	}
 	return ""
 }
 
-func (m *Operation) GetSpanKind() string {
+func (m *Throughput) GetOperation() string {
 	if m != nil {
-		return m.SpanKind
+		return m.Operation
 	}
 	return ""
 }
 
-type GetOperationsResponse struct {
-	OperationNames       []string     `protobuf:"bytes,1,rep,name=operationNames,proto3" json:"operationNames,omitempty"`
-	Operations           []*Operation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+func (m *Throughput) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
 }
 
-func (m *GetOperationsResponse) Reset()         { *m = GetOperationsResponse{} }
-func (m *GetOperationsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetOperationsResponse) ProtoMessage()    {}
-func (*GetOperationsResponse) Descriptor() ([]byte, []int) {
+func (m *Throughput) GetProbabilities() []string {
+	if m != nil {
+		return m.Probabilities
+	}
+	return nil
+}
+
+// OperationValue holds a sampling probability or qps of a service operation.
+type OperationValue struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Operation            string   `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Value                float64  `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OperationValue) Reset()         { *m = OperationValue{} }
+func (m *OperationValue) String() string { return proto.CompactTextString(m) }
+func (*OperationValue) ProtoMessage()    {}
+func (*OperationValue) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{9}
 }
-func (m *GetOperationsResponse) XXX_Unmarshal(b []byte) error {
+func (m *OperationValue) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *OperationValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetOperationsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_OperationValue.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -486,58 +508,62 @@ func (m *GetOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *GetOperationsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetOperationsResponse.Merge(m, src)
+func (m *OperationValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationValue.Merge(m, src)
 }
-func (m *GetOperationsResponse) XXX_Size() int {
+func (m *OperationValue) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetOperationsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetOperationsResponse.DiscardUnknown(m)
+func (m *OperationValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationValue.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetOperationsResponse proto.InternalMessageInfo
+var xxx_messageInfo_OperationValue proto.InternalMessageInfo
 
-func (m *GetOperationsResponse) GetOperationNames() []string {
+func (m *OperationValue) GetService() string {
 	if m != nil {
-		return m.OperationNames
+		return m.Service
 	}
-	return nil
+	return ""
 }
 
-func (m *GetOperationsResponse) GetOperations() []*Operation {
+func (m *OperationValue) GetOperation() string {
 	if m != nil {
-		return m.Operations
+		return m.Operation
 	}
-	return nil
+	return ""
 }
 
-type TraceQueryParameters struct {
-	ServiceName          string            `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	OperationName        string            `protobuf:"bytes,2,opt,name=operation_name,json=operationName,proto3" json:"operation_name,omitempty"`
-	Tags                 map[string]string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	StartTimeMin         time.Time         `protobuf:"bytes,4,opt,name=start_time_min,json=startTimeMin,proto3,stdtime" json:"start_time_min"`
-	StartTimeMax         time.Time         `protobuf:"bytes,5,opt,name=start_time_max,json=startTimeMax,proto3,stdtime" json:"start_time_max"`
-	DurationMin          time.Duration     `protobuf:"bytes,6,opt,name=duration_min,json=durationMin,proto3,stdduration" json:"duration_min"`
-	DurationMax          time.Duration     `protobuf:"bytes,7,opt,name=duration_max,json=durationMax,proto3,stdduration" json:"duration_max"`
-	NumTraces            int32             `protobuf:"varint,8,opt,name=num_traces,json=numTraces,proto3" json:"num_traces,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *OperationValue) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
 }
 
-func (m *TraceQueryParameters) Reset()         { *m = TraceQueryParameters{} }
-func (m *TraceQueryParameters) String() string { return proto.CompactTextString(m) }
-func (*TraceQueryParameters) ProtoMessage()    {}
-func (*TraceQueryParameters) Descriptor() ([]byte, []int) {
+// OperationProbabilityAndQPS holds the sampling probability and measured qps of a service operation.
+type OperationProbabilityAndQPS struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Operation            string   `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Probability          float64  `protobuf:"fixed64,3,opt,name=probability,proto3" json:"probability,omitempty"`
+	Qps                  float64  `protobuf:"fixed64,4,opt,name=qps,proto3" json:"qps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OperationProbabilityAndQPS) Reset()         { *m = OperationProbabilityAndQPS{} }
+func (m *OperationProbabilityAndQPS) String() string { return proto.CompactTextString(m) }
+func (*OperationProbabilityAndQPS) ProtoMessage()    {}
+func (*OperationProbabilityAndQPS) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{10}
 }
-func (m *TraceQueryParameters) XXX_Unmarshal(b []byte) error {
+func (m *OperationProbabilityAndQPS) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TraceQueryParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *OperationProbabilityAndQPS) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TraceQueryParameters.Marshal(b, m, deterministic)
+		return xxx_messageInfo_OperationProbabilityAndQPS.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -547,93 +573,66 @@ func (m *TraceQueryParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *TraceQueryParameters) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TraceQueryParameters.Merge(m, src)
+func (m *OperationProbabilityAndQPS) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationProbabilityAndQPS.Merge(m, src)
 }
-func (m *TraceQueryParameters) XXX_Size() int {
+func (m *OperationProbabilityAndQPS) XXX_Size() int {
 	return m.Size()
 }
-func (m *TraceQueryParameters) XXX_DiscardUnknown() {
-	xxx_messageInfo_TraceQueryParameters.DiscardUnknown(m)
+func (m *OperationProbabilityAndQPS) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationProbabilityAndQPS.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TraceQueryParameters proto.InternalMessageInfo
+var xxx_messageInfo_OperationProbabilityAndQPS proto.InternalMessageInfo
 
-func (m *TraceQueryParameters) GetServiceName() string {
+func (m *OperationProbabilityAndQPS) GetService() string {
 	if m != nil {
-		return m.ServiceName
+		return m.Service
 	}
 	return ""
 }
 
-func (m *TraceQueryParameters) GetOperationName() string {
+func (m *OperationProbabilityAndQPS) GetOperation() string {
 	if m != nil {
-		return m.OperationName
+		return m.Operation
 	}
 	return ""
 }
 
-func (m *TraceQueryParameters) GetTags() map[string]string {
-	if m != nil {
-		return m.Tags
-	}
-	return nil
-}
-
-func (m *TraceQueryParameters) GetStartTimeMin() time.Time {
-	if m != nil {
-		return m.StartTimeMin
-	}
-	return time.Time{}
-}
-
-func (m *TraceQueryParameters) GetStartTimeMax() time.Time {
-	if m != nil {
-		return m.StartTimeMax
-	}
-	return time.Time{}
-}
-
-func (m *TraceQueryParameters) GetDurationMin() time.Duration {
-	if m != nil {
-		return m.DurationMin
-	}
-	return 0
-}
-
-func (m *TraceQueryParameters) GetDurationMax() time.Duration {
+func (m *OperationProbabilityAndQPS) GetProbability() float64 {
 	if m != nil {
-		return m.DurationMax
+		return m.Probability
 	}
 	return 0
 }
 
-func (m *TraceQueryParameters) GetNumTraces() int32 {
+func (m *OperationProbabilityAndQPS) GetQps() float64 {
 	if m != nil {
-		return m.NumTraces
+		return m.Qps
 	}
 	return 0
 }
 
-type FindTracesRequest struct {
-	Query                *TraceQueryParameters `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// ServiceOperationData holds the probabilities and qps calculated by a host at a point in time.
+type ServiceOperationData struct {
+	Operations           []*OperationProbabilityAndQPS `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
-func (m *FindTracesRequest) Reset()         { *m = FindTracesRequest{} }
-func (m *FindTracesRequest) String() string { return proto.CompactTextString(m) }
-func (*FindTracesRequest) ProtoMessage()    {}
-func (*FindTracesRequest) Descriptor() ([]byte, []int) {
+func (m *ServiceOperationData) Reset()         { *m = ServiceOperationData{} }
+func (m *ServiceOperationData) String() string { return proto.CompactTextString(m) }
+func (*ServiceOperationData) ProtoMessage()    {}
+func (*ServiceOperationData) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{11}
 }
-func (m *FindTracesRequest) XXX_Unmarshal(b []byte) error {
+func (m *ServiceOperationData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FindTracesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ServiceOperationData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FindTracesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ServiceOperationData.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -643,44 +642,45 @@ func (m *FindTracesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *FindTracesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FindTracesRequest.Merge(m, src)
+func (m *ServiceOperationData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceOperationData.Merge(m, src)
 }
-func (m *FindTracesRequest) XXX_Size() int {
+func (m *ServiceOperationData) XXX_Size() int {
 	return m.Size()
 }
-func (m *FindTracesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FindTracesRequest.DiscardUnknown(m)
+func (m *ServiceOperationData) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceOperationData.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FindTracesRequest proto.InternalMessageInfo
+var xxx_messageInfo_ServiceOperationData proto.InternalMessageInfo
 
-func (m *FindTracesRequest) GetQuery() *TraceQueryParameters {
+func (m *ServiceOperationData) GetOperations() []*OperationProbabilityAndQPS {
 	if m != nil {
-		return m.Query
+		return m.Operations
 	}
 	return nil
 }
 
-type SpansResponseChunk struct {
-	Spans                []model.Span `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type HostServiceOperationData struct {
+	Hostname             string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Data                 []ServiceOperationData `protobuf:"bytes,2,rep,name=data,proto3" json:"data"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *SpansResponseChunk) Reset()         { *m = SpansResponseChunk{} }
-func (m *SpansResponseChunk) String() string { return proto.CompactTextString(m) }
-func (*SpansResponseChunk) ProtoMessage()    {}
-func (*SpansResponseChunk) Descriptor() ([]byte, []int) {
+func (m *HostServiceOperationData) Reset()         { *m = HostServiceOperationData{} }
+func (m *HostServiceOperationData) String() string { return proto.CompactTextString(m) }
+func (*HostServiceOperationData) ProtoMessage()    {}
+func (*HostServiceOperationData) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{12}
 }
-func (m *SpansResponseChunk) XXX_Unmarshal(b []byte) error {
+func (m *HostServiceOperationData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SpansResponseChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *HostServiceOperationData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SpansResponseChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_HostServiceOperationData.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -690,44 +690,51 @@ func (m *SpansResponseChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *SpansResponseChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SpansResponseChunk.Merge(m, src)
+func (m *HostServiceOperationData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostServiceOperationData.Merge(m, src)
 }
-func (m *SpansResponseChunk) XXX_Size() int {
+func (m *HostServiceOperationData) XXX_Size() int {
 	return m.Size()
 }
-func (m *SpansResponseChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_SpansResponseChunk.DiscardUnknown(m)
+func (m *HostServiceOperationData) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostServiceOperationData.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SpansResponseChunk proto.InternalMessageInfo
+var xxx_messageInfo_HostServiceOperationData proto.InternalMessageInfo
 
-func (m *SpansResponseChunk) GetSpans() []model.Span {
+func (m *HostServiceOperationData) GetHostname() string {
 	if m != nil {
-		return m.Spans
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *HostServiceOperationData) GetData() []ServiceOperationData {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
 
-type FindTraceIDsRequest struct {
-	Query                *TraceQueryParameters `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+type InsertThroughputRequest struct {
+	Throughput           []*Throughput `protobuf:"bytes,1,rep,name=throughput,proto3" json:"throughput,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *FindTraceIDsRequest) Reset()         { *m = FindTraceIDsRequest{} }
-func (m *FindTraceIDsRequest) String() string { return proto.CompactTextString(m) }
-func (*FindTraceIDsRequest) ProtoMessage()    {}
-func (*FindTraceIDsRequest) Descriptor() ([]byte, []int) {
+func (m *InsertThroughputRequest) Reset()         { *m = InsertThroughputRequest{} }
+func (m *InsertThroughputRequest) String() string { return proto.CompactTextString(m) }
+func (*InsertThroughputRequest) ProtoMessage()    {}
+func (*InsertThroughputRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{13}
 }
-func (m *FindTraceIDsRequest) XXX_Unmarshal(b []byte) error {
+func (m *InsertThroughputRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FindTraceIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InsertThroughputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FindTraceIDsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InsertThroughputRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -737,44 +744,44 @@ func (m *FindTraceIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *FindTraceIDsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FindTraceIDsRequest.Merge(m, src)
+func (m *InsertThroughputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertThroughputRequest.Merge(m, src)
 }
-func (m *FindTraceIDsRequest) XXX_Size() int {
+func (m *InsertThroughputRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *FindTraceIDsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FindTraceIDsRequest.DiscardUnknown(m)
+func (m *InsertThroughputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertThroughputRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FindTraceIDsRequest proto.InternalMessageInfo
+var xxx_messageInfo_InsertThroughputRequest proto.InternalMessageInfo
 
-func (m *FindTraceIDsRequest) GetQuery() *TraceQueryParameters {
+func (m *InsertThroughputRequest) GetThroughput() []*Throughput {
 	if m != nil {
-		return m.Query
+		return m.Throughput
 	}
 	return nil
 }
 
-type FindTraceIDsResponse struct {
-	TraceIDs             []github_com_jaegertracing_jaeger_model.TraceID `protobuf:"bytes,1,rep,name=trace_ids,json=traceIds,proto3,customtype=github.com/jaegertracing/jaeger/model.TraceID" json:"trace_ids"`
-	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
-	XXX_unrecognized     []byte                                          `json:"-"`
-	XXX_sizecache        int32                                           `json:"-"`
+// empty; extensible in the future
+type InsertThroughputResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FindTraceIDsResponse) Reset()         { *m = FindTraceIDsResponse{} }
-func (m *FindTraceIDsResponse) String() string { return proto.CompactTextString(m) }
-func (*FindTraceIDsResponse) ProtoMessage()    {}
-func (*FindTraceIDsResponse) Descriptor() ([]byte, []int) {
+func (m *InsertThroughputResponse) Reset()         { *m = InsertThroughputResponse{} }
+func (m *InsertThroughputResponse) String() string { return proto.CompactTextString(m) }
+func (*InsertThroughputResponse) ProtoMessage()    {}
+func (*InsertThroughputResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{14}
 }
-func (m *FindTraceIDsResponse) XXX_Unmarshal(b []byte) error {
+func (m *InsertThroughputResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FindTraceIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InsertThroughputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FindTraceIDsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InsertThroughputResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -784,37 +791,39 @@ func (m *FindTraceIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *FindTraceIDsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FindTraceIDsResponse.Merge(m, src)
+func (m *InsertThroughputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertThroughputResponse.Merge(m, src)
 }
-func (m *FindTraceIDsResponse) XXX_Size() int {
+func (m *InsertThroughputResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *FindTraceIDsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_FindTraceIDsResponse.DiscardUnknown(m)
+func (m *InsertThroughputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertThroughputResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FindTraceIDsResponse proto.InternalMessageInfo
+var xxx_messageInfo_InsertThroughputResponse proto.InternalMessageInfo
 
-// empty; extensible in the future
-type CapabilitiesRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type InsertProbabilitiesAndQPSRequest struct {
+	Hostname             string            `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Probabilities        []*OperationValue `protobuf:"bytes,2,rep,name=probabilities,proto3" json:"probabilities,omitempty"`
+	Qps                  []*OperationValue `protobuf:"bytes,3,rep,name=qps,proto3" json:"qps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *CapabilitiesRequest) Reset()         { *m = CapabilitiesRequest{} }
-func (m *CapabilitiesRequest) String() string { return proto.CompactTextString(m) }
-func (*CapabilitiesRequest) ProtoMessage()    {}
-func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+func (m *InsertProbabilitiesAndQPSRequest) Reset()         { *m = InsertProbabilitiesAndQPSRequest{} }
+func (m *InsertProbabilitiesAndQPSRequest) String() string { return proto.CompactTextString(m) }
+func (*InsertProbabilitiesAndQPSRequest) ProtoMessage()    {}
+func (*InsertProbabilitiesAndQPSRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{15}
 }
-func (m *CapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+func (m *InsertProbabilitiesAndQPSRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InsertProbabilitiesAndQPSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CapabilitiesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InsertProbabilitiesAndQPSRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -824,38 +833,58 @@ func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *CapabilitiesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CapabilitiesRequest.Merge(m, src)
+func (m *InsertProbabilitiesAndQPSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertProbabilitiesAndQPSRequest.Merge(m, src)
 }
-func (m *CapabilitiesRequest) XXX_Size() int {
+func (m *InsertProbabilitiesAndQPSRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CapabilitiesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CapabilitiesRequest.DiscardUnknown(m)
+func (m *InsertProbabilitiesAndQPSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertProbabilitiesAndQPSRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CapabilitiesRequest proto.InternalMessageInfo
+var xxx_messageInfo_InsertProbabilitiesAndQPSRequest proto.InternalMessageInfo
 
-type CapabilitiesResponse struct {
-	ArchiveSpanReader    bool     `protobuf:"varint,1,opt,name=archiveSpanReader,proto3" json:"archiveSpanReader,omitempty"`
-	ArchiveSpanWriter    bool     `protobuf:"varint,2,opt,name=archiveSpanWriter,proto3" json:"archiveSpanWriter,omitempty"`
+func (m *InsertProbabilitiesAndQPSRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *InsertProbabilitiesAndQPSRequest) GetProbabilities() []*OperationValue {
+	if m != nil {
+		return m.Probabilities
+	}
+	return nil
+}
+
+func (m *InsertProbabilitiesAndQPSRequest) GetQps() []*OperationValue {
+	if m != nil {
+		return m.Qps
+	}
+	return nil
+}
+
+// empty; extensible in the future
+type InsertProbabilitiesAndQPSResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
-func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
-func (*CapabilitiesResponse) ProtoMessage()    {}
-func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+func (m *InsertProbabilitiesAndQPSResponse) Reset()         { *m = InsertProbabilitiesAndQPSResponse{} }
+func (m *InsertProbabilitiesAndQPSResponse) String() string { return proto.CompactTextString(m) }
+func (*InsertProbabilitiesAndQPSResponse) ProtoMessage()    {}
+func (*InsertProbabilitiesAndQPSResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0d2c4ccf1453ffdb, []int{16}
 }
-func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+func (m *InsertProbabilitiesAndQPSResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InsertProbabilitiesAndQPSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CapabilitiesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InsertProbabilitiesAndQPSResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -865,1689 +894,5936 @@ func (m *CapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *CapabilitiesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CapabilitiesResponse.Merge(m, src)
+func (m *InsertProbabilitiesAndQPSResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertProbabilitiesAndQPSResponse.Merge(m, src)
 }
-func (m *CapabilitiesResponse) XXX_Size() int {
+func (m *InsertProbabilitiesAndQPSResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *CapabilitiesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CapabilitiesResponse.DiscardUnknown(m)
+func (m *InsertProbabilitiesAndQPSResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertProbabilitiesAndQPSResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CapabilitiesResponse proto.InternalMessageInfo
+var xxx_messageInfo_InsertProbabilitiesAndQPSResponse proto.InternalMessageInfo
 
-func (m *CapabilitiesResponse) GetArchiveSpanReader() bool {
-	if m != nil {
-		return m.ArchiveSpanReader
-	}
-	return false
+type GetThroughputRequest struct {
+	StartTime            time.Time `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	EndTime              time.Time `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3,stdtime" json:"end_time"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *CapabilitiesResponse) GetArchiveSpanWriter() bool {
-	if m != nil {
-		return m.ArchiveSpanWriter
+func (m *GetThroughputRequest) Reset()         { *m = GetThroughputRequest{} }
+func (m *GetThroughputRequest) String() string { return proto.CompactTextString(m) }
+func (*GetThroughputRequest) ProtoMessage()    {}
+func (*GetThroughputRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{17}
+}
+func (m *GetThroughputRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetThroughputRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetThroughputRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return false
 }
-
-func init() {
-	proto.RegisterType((*GetDependenciesRequest)(nil), "jaeger.storage.v1.GetDependenciesRequest")
-	golang_proto.RegisterType((*GetDependenciesRequest)(nil), "jaeger.storage.v1.GetDependenciesRequest")
-	proto.RegisterType((*GetDependenciesResponse)(nil), "jaeger.storage.v1.GetDependenciesResponse")
-	golang_proto.RegisterType((*GetDependenciesResponse)(nil), "jaeger.storage.v1.GetDependenciesResponse")
-	proto.RegisterType((*WriteSpanRequest)(nil), "jaeger.storage.v1.WriteSpanRequest")
-	golang_proto.RegisterType((*WriteSpanRequest)(nil), "jaeger.storage.v1.WriteSpanRequest")
-	proto.RegisterType((*WriteSpanResponse)(nil), "jaeger.storage.v1.WriteSpanResponse")
-	golang_proto.RegisterType((*WriteSpanResponse)(nil), "jaeger.storage.v1.WriteSpanResponse")
-	proto.RegisterType((*GetTraceRequest)(nil), "jaeger.storage.v1.GetTraceRequest")
-	golang_proto.RegisterType((*GetTraceRequest)(nil), "jaeger.storage.v1.GetTraceRequest")
-	proto.RegisterType((*GetServicesRequest)(nil), "jaeger.storage.v1.GetServicesRequest")
-	golang_proto.RegisterType((*GetServicesRequest)(nil), "jaeger.storage.v1.GetServicesRequest")
-	proto.RegisterType((*GetServicesResponse)(nil), "jaeger.storage.v1.GetServicesResponse")
-	golang_proto.RegisterType((*GetServicesResponse)(nil), "jaeger.storage.v1.GetServicesResponse")
-	proto.RegisterType((*GetOperationsRequest)(nil), "jaeger.storage.v1.GetOperationsRequest")
-	golang_proto.RegisterType((*GetOperationsRequest)(nil), "jaeger.storage.v1.GetOperationsRequest")
-	proto.RegisterType((*Operation)(nil), "jaeger.storage.v1.Operation")
-	golang_proto.RegisterType((*Operation)(nil), "jaeger.storage.v1.Operation")
-	proto.RegisterType((*GetOperationsResponse)(nil), "jaeger.storage.v1.GetOperationsResponse")
-	golang_proto.RegisterType((*GetOperationsResponse)(nil), "jaeger.storage.v1.GetOperationsResponse")
-	proto.RegisterType((*TraceQueryParameters)(nil), "jaeger.storage.v1.TraceQueryParameters")
-	golang_proto.RegisterType((*TraceQueryParameters)(nil), "jaeger.storage.v1.TraceQueryParameters")
-	proto.RegisterMapType((map[string]string)(nil), "jaeger.storage.v1.TraceQueryParameters.TagsEntry")
-	golang_proto.RegisterMapType((map[string]string)(nil), "jaeger.storage.v1.TraceQueryParameters.TagsEntry")
-	proto.RegisterType((*FindTracesRequest)(nil), "jaeger.storage.v1.FindTracesRequest")
-	golang_proto.RegisterType((*FindTracesRequest)(nil), "jaeger.storage.v1.FindTracesRequest")
-	proto.RegisterType((*SpansResponseChunk)(nil), "jaeger.storage.v1.SpansResponseChunk")
-	golang_proto.RegisterType((*SpansResponseChunk)(nil), "jaeger.storage.v1.SpansResponseChunk")
-	proto.RegisterType((*FindTraceIDsRequest)(nil), "jaeger.storage.v1.FindTraceIDsRequest")
-	golang_proto.RegisterType((*FindTraceIDsRequest)(nil), "jaeger.storage.v1.FindTraceIDsRequest")
-	proto.RegisterType((*FindTraceIDsResponse)(nil), "jaeger.storage.v1.FindTraceIDsResponse")
-	golang_proto.RegisterType((*FindTraceIDsResponse)(nil), "jaeger.storage.v1.FindTraceIDsResponse")
-	proto.RegisterType((*CapabilitiesRequest)(nil), "jaeger.storage.v1.CapabilitiesRequest")
-	golang_proto.RegisterType((*CapabilitiesRequest)(nil), "jaeger.storage.v1.CapabilitiesRequest")
-	proto.RegisterType((*CapabilitiesResponse)(nil), "jaeger.storage.v1.CapabilitiesResponse")
-	golang_proto.RegisterType((*CapabilitiesResponse)(nil), "jaeger.storage.v1.CapabilitiesResponse")
+func (m *GetThroughputRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThroughputRequest.Merge(m, src)
 }
-
-func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
-func init() { golang_proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
-
-var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 1051 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x73, 0xdb, 0x44,
-	0x14, 0x47, 0x89, 0xd3, 0xd8, 0xcf, 0x4e, 0x49, 0xd6, 0x2e, 0x15, 0xa2, 0xb5, 0x83, 0x20, 0x7f,
-	0x60, 0x40, 0x26, 0xe6, 0x00, 0x03, 0x65, 0xa0, 0x4e, 0x52, 0x4f, 0x80, 0x42, 0x51, 0x33, 0x74,
-	0x86, 0x42, 0x3d, 0x6b, 0x6b, 0x51, 0xd4, 0x58, 0x2b, 0x55, 0x7f, 0x3c, 0xf6, 0x81, 0x1b, 0x1f,
-	0x80, 0x23, 0x27, 0xae, 0x7c, 0x0d, 0x8e, 0x3d, 0x72, 0xe6, 0x10, 0x98, 0x70, 0xe4, 0x4b, 0x30,
-	0xda, 0x5d, 0xc9, 0x92, 0xac, 0x49, 0xd2, 0x4c, 0x6e, 0xda, 0xb7, 0xbf, 0xf7, 0x7b, 0x6f, 0xdf,
-	0x5f, 0xc1, 0x8a, 0x1f, 0x38, 0x1e, 0x36, 0x89, 0xe6, 0x7a, 0x4e, 0xe0, 0xa0, 0xb5, 0xa7, 0x98,
-	0x98, 0xc4, 0xd3, 0x62, 0xe9, 0x78, 0x47, 0x69, 0x98, 0x8e, 0xe9, 0xb0, 0xdb, 0x76, 0xf4, 0xc5,
-	0x81, 0x4a, 0xcb, 0x74, 0x1c, 0x73, 0x44, 0xda, 0xec, 0x34, 0x08, 0x7f, 0x6c, 0x07, 0x96, 0x4d,
-	0xfc, 0x00, 0xdb, 0xae, 0x00, 0x34, 0xf3, 0x00, 0x23, 0xf4, 0x70, 0x60, 0x39, 0x54, 0xdc, 0x57,
-	0x6d, 0xc7, 0x20, 0x23, 0x7e, 0x50, 0x7f, 0x93, 0xe0, 0x95, 0x1e, 0x09, 0xf6, 0x88, 0x4b, 0xa8,
-	0x41, 0xe8, 0xd0, 0x22, 0xbe, 0x4e, 0x9e, 0x85, 0xc4, 0x0f, 0xd0, 0x2e, 0x80, 0x1f, 0x60, 0x2f,
-	0xe8, 0x47, 0x06, 0x64, 0x69, 0x5d, 0xda, 0xae, 0x76, 0x14, 0x8d, 0x93, 0x6b, 0x31, 0xb9, 0x76,
-	0x18, 0x5b, 0xef, 0x96, 0x9f, 0x9f, 0xb4, 0x5e, 0xfa, 0xe5, 0xef, 0x96, 0xa4, 0x57, 0x98, 0x5e,
-	0x74, 0x83, 0x3e, 0x85, 0x32, 0xa1, 0x06, 0xa7, 0x58, 0x78, 0x01, 0x8a, 0x65, 0x42, 0x8d, 0x48,
-	0xae, 0x0e, 0xe0, 0xe6, 0x9c, 0x7f, 0xbe, 0xeb, 0x50, 0x9f, 0xa0, 0x1e, 0xd4, 0x8c, 0x94, 0x5c,
-	0x96, 0xd6, 0x17, 0xb7, 0xab, 0x9d, 0xdb, 0x9a, 0x88, 0x24, 0x76, 0xad, 0xfe, 0xb8, 0xa3, 0x25,
-	0xaa, 0xd3, 0x2f, 0x2d, 0x7a, 0xdc, 0x2d, 0x45, 0x26, 0xf4, 0x8c, 0xa2, 0xfa, 0x31, 0xac, 0x3e,
-	0xf2, 0xac, 0x80, 0x3c, 0x74, 0x31, 0x8d, 0x5f, 0xbf, 0x05, 0x25, 0xdf, 0xc5, 0x54, 0xbc, 0xbb,
-	0x9e, 0x23, 0x65, 0x48, 0x06, 0x50, 0xeb, 0xb0, 0x96, 0x52, 0xe6, 0xae, 0xa9, 0x14, 0x5e, 0xee,
-	0x91, 0xe0, 0xd0, 0xc3, 0x43, 0x12, 0x13, 0x3e, 0x86, 0x72, 0x10, 0x9d, 0xfb, 0x96, 0xc1, 0x48,
-	0x6b, 0xdd, 0xcf, 0x22, 0x57, 0xfe, 0x3a, 0x69, 0xbd, 0x6b, 0x5a, 0xc1, 0x51, 0x38, 0xd0, 0x86,
-	0x8e, 0xdd, 0xe6, 0x66, 0x22, 0xa0, 0x45, 0x4d, 0x71, 0x6a, 0xf3, 0x84, 0x31, 0xb6, 0x83, 0xbd,
-	0xd3, 0x93, 0xd6, 0xb2, 0xf8, 0xd4, 0x97, 0x19, 0xe3, 0x81, 0xa1, 0x36, 0x00, 0xf5, 0x48, 0xf0,
-	0x90, 0x78, 0x63, 0x6b, 0x98, 0x64, 0x50, 0xdd, 0x81, 0x7a, 0x46, 0x2a, 0xe2, 0xa6, 0x40, 0xd9,
-	0x17, 0x32, 0x16, 0xb3, 0x8a, 0x9e, 0x9c, 0xd5, 0xfb, 0xd0, 0xe8, 0x91, 0xe0, 0x6b, 0x97, 0xf0,
-	0x92, 0x49, 0x8a, 0x41, 0x86, 0x65, 0x81, 0x61, 0xce, 0x57, 0xf4, 0xf8, 0x88, 0x5e, 0x83, 0x4a,
-	0x14, 0x87, 0xfe, 0xb1, 0x45, 0x0d, 0x96, 0xe2, 0x88, 0xce, 0xc5, 0xf4, 0x0b, 0x8b, 0x1a, 0xea,
-	0x1d, 0xa8, 0x24, 0x5c, 0x08, 0x41, 0x89, 0x62, 0x3b, 0x26, 0x60, 0xdf, 0x67, 0x6b, 0xff, 0x04,
-	0x37, 0x72, 0xce, 0x88, 0x17, 0x6c, 0xc2, 0x75, 0x27, 0x96, 0x7e, 0x85, 0xed, 0xe4, 0x1d, 0x39,
-	0x29, 0xba, 0x03, 0x90, 0x48, 0x7c, 0x79, 0x81, 0xd5, 0xc7, 0x2d, 0x6d, 0xae, 0xd3, 0xb4, 0xc4,
-	0x84, 0x9e, 0xc2, 0xab, 0xbf, 0x97, 0xa0, 0xc1, 0x22, 0xfd, 0x4d, 0x48, 0xbc, 0xe9, 0x03, 0xec,
-	0x61, 0x9b, 0x04, 0xc4, 0xf3, 0xd1, 0xeb, 0x50, 0x13, 0xaf, 0xef, 0xa7, 0x1e, 0x54, 0x15, 0xb2,
-	0xc8, 0x34, 0xda, 0x48, 0x79, 0xc8, 0x41, 0xfc, 0x71, 0x2b, 0x19, 0x0f, 0xd1, 0x3e, 0x94, 0x02,
-	0x6c, 0xfa, 0xf2, 0x22, 0x73, 0x6d, 0xa7, 0xc0, 0xb5, 0x22, 0x07, 0xb4, 0x43, 0x6c, 0xfa, 0xfb,
-	0x34, 0xf0, 0xa6, 0x3a, 0x53, 0x47, 0x9f, 0xc3, 0xf5, 0x59, 0xab, 0xf6, 0x6d, 0x8b, 0xca, 0xa5,
-	0x17, 0xe8, 0xb5, 0x5a, 0xd2, 0xae, 0xf7, 0x2d, 0x9a, 0xe7, 0xc2, 0x13, 0x79, 0xe9, 0x72, 0x5c,
-	0x78, 0x82, 0xee, 0x41, 0x2d, 0x1e, 0x3e, 0xcc, 0xab, 0x6b, 0x8c, 0xe9, 0xd5, 0x39, 0xa6, 0x3d,
-	0x01, 0xe2, 0x44, 0xbf, 0x46, 0x44, 0xd5, 0x58, 0x31, 0xf2, 0x29, 0xc3, 0x83, 0x27, 0xf2, 0xf2,
-	0x65, 0x78, 0xf0, 0x04, 0xdd, 0x06, 0xa0, 0xa1, 0xdd, 0x67, 0x5d, 0xe3, 0xcb, 0xe5, 0x75, 0x69,
-	0x7b, 0x49, 0xaf, 0xd0, 0xd0, 0x66, 0x41, 0xf6, 0x95, 0x0f, 0xa0, 0x92, 0x44, 0x16, 0xad, 0xc2,
-	0xe2, 0x31, 0x99, 0x8a, 0xdc, 0x46, 0x9f, 0xa8, 0x01, 0x4b, 0x63, 0x3c, 0x0a, 0xe3, 0x54, 0xf2,
-	0xc3, 0x47, 0x0b, 0x1f, 0x4a, 0xaa, 0x0e, 0x6b, 0xf7, 0x2c, 0x6a, 0x70, 0x9a, 0xb8, 0x65, 0x3e,
-	0x81, 0xa5, 0x67, 0x51, 0xde, 0xc4, 0x08, 0xd9, 0xba, 0x60, 0x72, 0x75, 0xae, 0xa5, 0xee, 0x03,
-	0x8a, 0x46, 0x4a, 0x52, 0xf4, 0xbb, 0x47, 0x21, 0x3d, 0x46, 0x6d, 0x58, 0x8a, 0xda, 0x23, 0x1e,
-	0x76, 0x45, 0x73, 0x49, 0x8c, 0x38, 0x8e, 0x53, 0x0f, 0xa1, 0x9e, 0xb8, 0x76, 0xb0, 0x77, 0x55,
-	0xce, 0x8d, 0xa1, 0x91, 0x65, 0x15, 0x8d, 0xf9, 0x04, 0x2a, 0xf1, 0x90, 0xe3, 0x2e, 0xd6, 0xba,
-	0x77, 0x2f, 0x3b, 0xe5, 0xca, 0x09, 0x7b, 0x59, 0x8c, 0x39, 0x5f, 0xbd, 0x01, 0xf5, 0x5d, 0xec,
-	0xe2, 0x81, 0x35, 0xb2, 0x82, 0xd9, 0xaa, 0x52, 0x3d, 0x68, 0x64, 0xc5, 0xc2, 0x9d, 0x77, 0x60,
-	0x0d, 0x7b, 0xc3, 0x23, 0x6b, 0x2c, 0xa6, 0x33, 0x36, 0x88, 0xc7, 0x5e, 0x5c, 0xd6, 0xe7, 0x2f,
-	0x72, 0x68, 0x36, 0xd4, 0x3d, 0x96, 0xeb, 0x2c, 0x9a, 0x5f, 0x74, 0x9e, 0xc2, 0xea, 0xec, 0xf4,
-	0x60, 0x14, 0x9a, 0x16, 0x45, 0xdf, 0x42, 0x25, 0xd9, 0x05, 0xe8, 0x8d, 0x82, 0x98, 0xe6, 0xd7,
-	0x8c, 0xf2, 0xe6, 0xd9, 0x20, 0xfe, 0x8e, 0xce, 0x7f, 0x8b, 0xdc, 0x18, 0x77, 0x54, 0x18, 0x7b,
-	0x04, 0xe5, 0x78, 0xc7, 0x20, 0xb5, 0x80, 0x26, 0xb7, 0x80, 0x94, 0x8d, 0x02, 0xcc, 0x7c, 0x85,
-	0xbd, 0x27, 0xa1, 0xef, 0xa1, 0x9a, 0x5a, 0x1b, 0x68, 0xa3, 0x98, 0x3b, 0xb7, 0x6c, 0x94, 0xcd,
-	0xf3, 0x60, 0x22, 0x27, 0x03, 0x58, 0xc9, 0x0c, 0x75, 0xb4, 0x55, 0xac, 0x38, 0xb7, 0x83, 0x94,
-	0xed, 0xf3, 0x81, 0xc2, 0xc6, 0x63, 0x80, 0x59, 0x3f, 0xa2, 0xa2, 0x18, 0xcf, 0xb5, 0xeb, 0xc5,
-	0xc3, 0xd3, 0x87, 0x5a, 0xba, 0xf6, 0xd1, 0xe6, 0x59, 0xf4, 0xb3, 0x96, 0x53, 0xb6, 0xce, 0xc5,
-	0x89, 0x6c, 0x4f, 0xe0, 0xe6, 0xdd, 0x7c, 0xb9, 0x89, 0x9c, 0xff, 0x20, 0xfe, 0x54, 0x52, 0xf7,
-	0x57, 0x59, 0x67, 0xd3, 0x8c, 0xe5, 0x4c, 0xb5, 0x3d, 0x61, 0x7f, 0x34, 0xe2, 0xf6, 0xea, 0x8b,
-	0xae, 0xf3, 0xb3, 0x04, 0x72, 0xf6, 0x2f, 0x2f, 0x65, 0xfc, 0x88, 0x19, 0x4f, 0x5f, 0xa3, 0xb7,
-	0x8a, 0x8d, 0x17, 0xfc, 0xc8, 0x2a, 0x6f, 0x5f, 0x04, 0x2a, 0x22, 0x10, 0x02, 0xe2, 0x36, 0xd3,
-	0xf3, 0x24, 0x4a, 0x79, 0xe6, 0x5c, 0x94, 0xf2, 0x82, 0xb9, 0x54, 0x98, 0xf2, 0xa2, 0x41, 0xd5,
-	0xbd, 0xf5, 0xfc, 0xb4, 0x29, 0xfd, 0x79, 0xda, 0x94, 0xfe, 0x39, 0x6d, 0x4a, 0x7f, 0xfc, 0xdb,
-	0x94, 0xbe, 0x03, 0xa1, 0xd2, 0x1f, 0xef, 0x0c, 0xae, 0xb1, 0x05, 0xf7, 0xfe, 0xff, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x84, 0x36, 0xed, 0x70, 0x33, 0x0c, 0x00, 0x00,
+func (m *GetThroughputRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetThroughputRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThroughputRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_GetThroughputRequest proto.InternalMessageInfo
 
-// SpanWriterPluginClient is the client API for SpanWriterPlugin service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SpanWriterPluginClient interface {
-	// spanstore/Writer
-	WriteSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error)
+func (m *GetThroughputRequest) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
 }
 
-type spanWriterPluginClient struct {
-	cc *grpc.ClientConn
+func (m *GetThroughputRequest) GetEndTime() time.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return time.Time{}
 }
 
-func NewSpanWriterPluginClient(cc *grpc.ClientConn) SpanWriterPluginClient {
-	return &spanWriterPluginClient{cc}
+type GetThroughputResponse struct {
+	Throughput           []*Throughput `protobuf:"bytes,1,rep,name=throughput,proto3" json:"throughput,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (c *spanWriterPluginClient) WriteSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error) {
-	out := new(WriteSpanResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanWriterPlugin/WriteSpan", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetThroughputResponse) Reset()         { *m = GetThroughputResponse{} }
+func (m *GetThroughputResponse) String() string { return proto.CompactTextString(m) }
+func (*GetThroughputResponse) ProtoMessage()    {}
+func (*GetThroughputResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{18}
+}
+func (m *GetThroughputResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetThroughputResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetThroughputResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *GetThroughputResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThroughputResponse.Merge(m, src)
+}
+func (m *GetThroughputResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetThroughputResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThroughputResponse.DiscardUnknown(m)
 }
 
-// SpanWriterPluginServer is the server API for SpanWriterPlugin service.
-type SpanWriterPluginServer interface {
-	// spanstore/Writer
-	WriteSpan(context.Context, *WriteSpanRequest) (*WriteSpanResponse, error)
+var xxx_messageInfo_GetThroughputResponse proto.InternalMessageInfo
+
+func (m *GetThroughputResponse) GetThroughput() []*Throughput {
+	if m != nil {
+		return m.Throughput
+	}
+	return nil
 }
 
-func RegisterSpanWriterPluginServer(s *grpc.Server, srv SpanWriterPluginServer) {
-	s.RegisterService(&_SpanWriterPlugin_serviceDesc, srv)
+type GetProbabilitiesAndQPSRequest struct {
+	StartTime            time.Time `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	EndTime              time.Time `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3,stdtime" json:"end_time"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func _SpanWriterPlugin_WriteSpan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteSpanRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SpanWriterPluginServer).WriteSpan(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.SpanWriterPlugin/WriteSpan",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SpanWriterPluginServer).WriteSpan(ctx, req.(*WriteSpanRequest))
+func (m *GetProbabilitiesAndQPSRequest) Reset()         { *m = GetProbabilitiesAndQPSRequest{} }
+func (m *GetProbabilitiesAndQPSRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProbabilitiesAndQPSRequest) ProtoMessage()    {}
+func (*GetProbabilitiesAndQPSRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{19}
+}
+func (m *GetProbabilitiesAndQPSRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetProbabilitiesAndQPSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetProbabilitiesAndQPSRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-var _SpanWriterPlugin_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "jaeger.storage.v1.SpanWriterPlugin",
-	HandlerType: (*SpanWriterPluginServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "WriteSpan",
-			Handler:    _SpanWriterPlugin_WriteSpan_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "storage.proto",
+func (m *GetProbabilitiesAndQPSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProbabilitiesAndQPSRequest.Merge(m, src)
 }
-
-// SpanReaderPluginClient is the client API for SpanReaderPlugin service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SpanReaderPluginClient interface {
-	// spanstore/Reader
-	GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (SpanReaderPlugin_GetTraceClient, error)
-	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
-	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error)
-	FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (SpanReaderPlugin_FindTracesClient, error)
-	FindTraceIDs(ctx context.Context, in *FindTraceIDsRequest, opts ...grpc.CallOption) (*FindTraceIDsResponse, error)
+func (m *GetProbabilitiesAndQPSRequest) XXX_Size() int {
+	return m.Size()
 }
-
-type spanReaderPluginClient struct {
-	cc *grpc.ClientConn
+func (m *GetProbabilitiesAndQPSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProbabilitiesAndQPSRequest.DiscardUnknown(m)
 }
 
-func NewSpanReaderPluginClient(cc *grpc.ClientConn) SpanReaderPluginClient {
-	return &spanReaderPluginClient{cc}
-}
+var xxx_messageInfo_GetProbabilitiesAndQPSRequest proto.InternalMessageInfo
 
-func (c *spanReaderPluginClient) GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (SpanReaderPlugin_GetTraceClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SpanReaderPlugin_serviceDesc.Streams[0], "/jaeger.storage.v1.SpanReaderPlugin/GetTrace", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &spanReaderPluginGetTraceClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *GetProbabilitiesAndQPSRequest) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
 	}
-	return x, nil
+	return time.Time{}
 }
 
-type SpanReaderPlugin_GetTraceClient interface {
-	Recv() (*SpansResponseChunk, error)
-	grpc.ClientStream
+func (m *GetProbabilitiesAndQPSRequest) GetEndTime() time.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return time.Time{}
 }
 
-type spanReaderPluginGetTraceClient struct {
-	grpc.ClientStream
+type GetProbabilitiesAndQPSResponse struct {
+	Hosts                []HostServiceOperationData `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
-func (x *spanReaderPluginGetTraceClient) Recv() (*SpansResponseChunk, error) {
-	m := new(SpansResponseChunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *GetProbabilitiesAndQPSResponse) Reset()         { *m = GetProbabilitiesAndQPSResponse{} }
+func (m *GetProbabilitiesAndQPSResponse) String() string { return proto.CompactTextString(m) }
+func (*GetProbabilitiesAndQPSResponse) ProtoMessage()    {}
+func (*GetProbabilitiesAndQPSResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{20}
 }
-
-func (c *spanReaderPluginClient) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
-	out := new(GetServicesResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanReaderPlugin/GetServices", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetProbabilitiesAndQPSResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *spanReaderPluginClient) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error) {
-	out := new(GetOperationsResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanReaderPlugin/GetOperations", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetProbabilitiesAndQPSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetProbabilitiesAndQPSResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
 }
-
-func (c *spanReaderPluginClient) FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (SpanReaderPlugin_FindTracesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SpanReaderPlugin_serviceDesc.Streams[1], "/jaeger.storage.v1.SpanReaderPlugin/FindTraces", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &spanReaderPluginFindTracesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *GetProbabilitiesAndQPSResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProbabilitiesAndQPSResponse.Merge(m, src)
 }
-
-type SpanReaderPlugin_FindTracesClient interface {
-	Recv() (*SpansResponseChunk, error)
-	grpc.ClientStream
+func (m *GetProbabilitiesAndQPSResponse) XXX_Size() int {
+	return m.Size()
 }
-
-type spanReaderPluginFindTracesClient struct {
-	grpc.ClientStream
+func (m *GetProbabilitiesAndQPSResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProbabilitiesAndQPSResponse.DiscardUnknown(m)
 }
 
-func (x *spanReaderPluginFindTracesClient) Recv() (*SpansResponseChunk, error) {
-	m := new(SpansResponseChunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
+var xxx_messageInfo_GetProbabilitiesAndQPSResponse proto.InternalMessageInfo
 
-func (c *spanReaderPluginClient) FindTraceIDs(ctx context.Context, in *FindTraceIDsRequest, opts ...grpc.CallOption) (*FindTraceIDsResponse, error) {
-	out := new(FindTraceIDsResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanReaderPlugin/FindTraceIDs", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetProbabilitiesAndQPSResponse) GetHosts() []HostServiceOperationData {
+	if m != nil {
+		return m.Hosts
 	}
-	return out, nil
+	return nil
 }
 
-// SpanReaderPluginServer is the server API for SpanReaderPlugin service.
-type SpanReaderPluginServer interface {
-	// spanstore/Reader
-	GetTrace(*GetTraceRequest, SpanReaderPlugin_GetTraceServer) error
-	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
-	GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error)
-	FindTraces(*FindTracesRequest, SpanReaderPlugin_FindTracesServer) error
-	FindTraceIDs(context.Context, *FindTraceIDsRequest) (*FindTraceIDsResponse, error)
+// empty; extensible in the future
+type GetLatestProbabilitiesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func RegisterSpanReaderPluginServer(s *grpc.Server, srv SpanReaderPluginServer) {
-	s.RegisterService(&_SpanReaderPlugin_serviceDesc, srv)
+func (m *GetLatestProbabilitiesRequest) Reset()         { *m = GetLatestProbabilitiesRequest{} }
+func (m *GetLatestProbabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLatestProbabilitiesRequest) ProtoMessage()    {}
+func (*GetLatestProbabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{21}
 }
-
-func _SpanReaderPlugin_GetTrace_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetTraceRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *GetLatestProbabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLatestProbabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLatestProbabilitiesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return srv.(SpanReaderPluginServer).GetTrace(m, &spanReaderPluginGetTraceServer{stream})
 }
-
-type SpanReaderPlugin_GetTraceServer interface {
-	Send(*SpansResponseChunk) error
-	grpc.ServerStream
+func (m *GetLatestProbabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLatestProbabilitiesRequest.Merge(m, src)
 }
-
-type spanReaderPluginGetTraceServer struct {
-	grpc.ServerStream
+func (m *GetLatestProbabilitiesRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *spanReaderPluginGetTraceServer) Send(m *SpansResponseChunk) error {
-	return x.ServerStream.SendMsg(m)
+func (m *GetLatestProbabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLatestProbabilitiesRequest.DiscardUnknown(m)
 }
 
-func _SpanReaderPlugin_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServicesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SpanReaderPluginServer).GetServices(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.SpanReaderPlugin/GetServices",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SpanReaderPluginServer).GetServices(ctx, req.(*GetServicesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
+var xxx_messageInfo_GetLatestProbabilitiesRequest proto.InternalMessageInfo
 
-func _SpanReaderPlugin_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetOperationsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SpanReaderPluginServer).GetOperations(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.SpanReaderPlugin/GetOperations",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SpanReaderPluginServer).GetOperations(ctx, req.(*GetOperationsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type GetLatestProbabilitiesResponse struct {
+	Probabilities        []*OperationValue `protobuf:"bytes,1,rep,name=probabilities,proto3" json:"probabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func _SpanReaderPlugin_FindTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(FindTracesRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *GetLatestProbabilitiesResponse) Reset()         { *m = GetLatestProbabilitiesResponse{} }
+func (m *GetLatestProbabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLatestProbabilitiesResponse) ProtoMessage()    {}
+func (*GetLatestProbabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{22}
+}
+func (m *GetLatestProbabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLatestProbabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLatestProbabilitiesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return srv.(SpanReaderPluginServer).FindTraces(m, &spanReaderPluginFindTracesServer{stream})
 }
-
-type SpanReaderPlugin_FindTracesServer interface {
-	Send(*SpansResponseChunk) error
-	grpc.ServerStream
+func (m *GetLatestProbabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLatestProbabilitiesResponse.Merge(m, src)
 }
-
-type spanReaderPluginFindTracesServer struct {
-	grpc.ServerStream
+func (m *GetLatestProbabilitiesResponse) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *spanReaderPluginFindTracesServer) Send(m *SpansResponseChunk) error {
-	return x.ServerStream.SendMsg(m)
+func (m *GetLatestProbabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLatestProbabilitiesResponse.DiscardUnknown(m)
 }
 
-func _SpanReaderPlugin_FindTraceIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FindTraceIDsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+var xxx_messageInfo_GetLatestProbabilitiesResponse proto.InternalMessageInfo
+
+func (m *GetLatestProbabilitiesResponse) GetProbabilities() []*OperationValue {
+	if m != nil {
+		return m.Probabilities
 	}
-	if interceptor == nil {
-		return srv.(SpanReaderPluginServer).FindTraceIDs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.SpanReaderPlugin/FindTraceIDs",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SpanReaderPluginServer).FindTraceIDs(ctx, req.(*FindTraceIDsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _SpanReaderPlugin_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "jaeger.storage.v1.SpanReaderPlugin",
-	HandlerType: (*SpanReaderPluginServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetServices",
-			Handler:    _SpanReaderPlugin_GetServices_Handler,
-		},
-		{
-			MethodName: "GetOperations",
-			Handler:    _SpanReaderPlugin_GetOperations_Handler,
-		},
-		{
-			MethodName: "FindTraceIDs",
-			Handler:    _SpanReaderPlugin_FindTraceIDs_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "GetTrace",
-			Handler:       _SpanReaderPlugin_GetTrace_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "FindTraces",
-			Handler:       _SpanReaderPlugin_FindTraces_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "storage.proto",
+	return nil
 }
 
-// ArchiveSpanWriterPluginClient is the client API for ArchiveSpanWriterPlugin service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ArchiveSpanWriterPluginClient interface {
-	// spanstore/Writer
-	WriteArchiveSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error)
+type GetTraceRequest struct {
+	TraceID              github_com_jaegertracing_jaeger_model.TraceID `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3,customtype=github.com/jaegertracing/jaeger/model.TraceID" json:"trace_id"`
+	XXX_NoUnkeyedLiteral struct{}                                      `json:"-"`
+	XXX_unrecognized     []byte                                        `json:"-"`
+	XXX_sizecache        int32                                         `json:"-"`
 }
 
-type archiveSpanWriterPluginClient struct {
-	cc *grpc.ClientConn
+func (m *GetTraceRequest) Reset()         { *m = GetTraceRequest{} }
+func (m *GetTraceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTraceRequest) ProtoMessage()    {}
+func (*GetTraceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{23}
 }
-
-func NewArchiveSpanWriterPluginClient(cc *grpc.ClientConn) ArchiveSpanWriterPluginClient {
-	return &archiveSpanWriterPluginClient{cc}
+func (m *GetTraceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *archiveSpanWriterPluginClient) WriteArchiveSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error) {
-	out := new(WriteSpanResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.ArchiveSpanWriterPlugin/WriteArchiveSpan", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetTraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTraceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
 }
-
-// ArchiveSpanWriterPluginServer is the server API for ArchiveSpanWriterPlugin service.
-type ArchiveSpanWriterPluginServer interface {
-	// spanstore/Writer
-	WriteArchiveSpan(context.Context, *WriteSpanRequest) (*WriteSpanResponse, error)
+func (m *GetTraceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTraceRequest.Merge(m, src)
 }
-
-func RegisterArchiveSpanWriterPluginServer(s *grpc.Server, srv ArchiveSpanWriterPluginServer) {
-	s.RegisterService(&_ArchiveSpanWriterPlugin_serviceDesc, srv)
+func (m *GetTraceRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func _ArchiveSpanWriterPlugin_WriteArchiveSpan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteSpanRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ArchiveSpanWriterPluginServer).WriteArchiveSpan(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.ArchiveSpanWriterPlugin/WriteArchiveSpan",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArchiveSpanWriterPluginServer).WriteArchiveSpan(ctx, req.(*WriteSpanRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *GetTraceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTraceRequest.DiscardUnknown(m)
 }
 
-var _ArchiveSpanWriterPlugin_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "jaeger.storage.v1.ArchiveSpanWriterPlugin",
-	HandlerType: (*ArchiveSpanWriterPluginServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "WriteArchiveSpan",
-			Handler:    _ArchiveSpanWriterPlugin_WriteArchiveSpan_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "storage.proto",
-}
+var xxx_messageInfo_GetTraceRequest proto.InternalMessageInfo
 
-// ArchiveSpanReaderPluginClient is the client API for ArchiveSpanReaderPlugin service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ArchiveSpanReaderPluginClient interface {
-	// spanstore/Reader
-	GetArchiveTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (ArchiveSpanReaderPlugin_GetArchiveTraceClient, error)
+type GetServicesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type archiveSpanReaderPluginClient struct {
-	cc *grpc.ClientConn
+func (m *GetServicesRequest) Reset()         { *m = GetServicesRequest{} }
+func (m *GetServicesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServicesRequest) ProtoMessage()    {}
+func (*GetServicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{24}
 }
-
-func NewArchiveSpanReaderPluginClient(cc *grpc.ClientConn) ArchiveSpanReaderPluginClient {
-	return &archiveSpanReaderPluginClient{cc}
+func (m *GetServicesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *archiveSpanReaderPluginClient) GetArchiveTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (ArchiveSpanReaderPlugin_GetArchiveTraceClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ArchiveSpanReaderPlugin_serviceDesc.Streams[0], "/jaeger.storage.v1.ArchiveSpanReaderPlugin/GetArchiveTrace", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &archiveSpanReaderPluginGetArchiveTraceClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *GetServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetServicesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
 }
-
-type ArchiveSpanReaderPlugin_GetArchiveTraceClient interface {
-	Recv() (*SpansResponseChunk, error)
-	grpc.ClientStream
+func (m *GetServicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServicesRequest.Merge(m, src)
 }
-
-type archiveSpanReaderPluginGetArchiveTraceClient struct {
-	grpc.ClientStream
+func (m *GetServicesRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *archiveSpanReaderPluginGetArchiveTraceClient) Recv() (*SpansResponseChunk, error) {
-	m := new(SpansResponseChunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *GetServicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServicesRequest.DiscardUnknown(m)
 }
 
-// ArchiveSpanReaderPluginServer is the server API for ArchiveSpanReaderPlugin service.
-type ArchiveSpanReaderPluginServer interface {
-	// spanstore/Reader
-	GetArchiveTrace(*GetTraceRequest, ArchiveSpanReaderPlugin_GetArchiveTraceServer) error
-}
+var xxx_messageInfo_GetServicesRequest proto.InternalMessageInfo
 
-func RegisterArchiveSpanReaderPluginServer(s *grpc.Server, srv ArchiveSpanReaderPluginServer) {
-	s.RegisterService(&_ArchiveSpanReaderPlugin_serviceDesc, srv)
+type GetServicesResponse struct {
+	Services             []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _ArchiveSpanReaderPlugin_GetArchiveTrace_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetTraceRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *GetServicesResponse) Reset()         { *m = GetServicesResponse{} }
+func (m *GetServicesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetServicesResponse) ProtoMessage()    {}
+func (*GetServicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{25}
+}
+func (m *GetServicesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetServicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetServicesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return srv.(ArchiveSpanReaderPluginServer).GetArchiveTrace(m, &archiveSpanReaderPluginGetArchiveTraceServer{stream})
 }
-
-type ArchiveSpanReaderPlugin_GetArchiveTraceServer interface {
-	Send(*SpansResponseChunk) error
-	grpc.ServerStream
+func (m *GetServicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServicesResponse.Merge(m, src)
+}
+func (m *GetServicesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetServicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServicesResponse.DiscardUnknown(m)
 }
 
-type archiveSpanReaderPluginGetArchiveTraceServer struct {
-	grpc.ServerStream
+var xxx_messageInfo_GetServicesResponse proto.InternalMessageInfo
+
+func (m *GetServicesResponse) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
 }
 
-func (x *archiveSpanReaderPluginGetArchiveTraceServer) Send(m *SpansResponseChunk) error {
-	return x.ServerStream.SendMsg(m)
+type GetOperationsRequest struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	SpanKind             string   `protobuf:"bytes,2,opt,name=span_kind,json=spanKind,proto3" json:"span_kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-var _ArchiveSpanReaderPlugin_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "jaeger.storage.v1.ArchiveSpanReaderPlugin",
-	HandlerType: (*ArchiveSpanReaderPluginServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "GetArchiveTrace",
-			Handler:       _ArchiveSpanReaderPlugin_GetArchiveTrace_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "storage.proto",
+func (m *GetOperationsRequest) Reset()         { *m = GetOperationsRequest{} }
+func (m *GetOperationsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOperationsRequest) ProtoMessage()    {}
+func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{26}
 }
-
-// DependenciesReaderPluginClient is the client API for DependenciesReaderPlugin service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DependenciesReaderPluginClient interface {
-	// dependencystore/Reader
-	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
+func (m *GetOperationsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-type dependenciesReaderPluginClient struct {
-	cc *grpc.ClientConn
+func (m *GetOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetOperationsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func NewDependenciesReaderPluginClient(cc *grpc.ClientConn) DependenciesReaderPluginClient {
-	return &dependenciesReaderPluginClient{cc}
+func (m *GetOperationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationsRequest.Merge(m, src)
+}
+func (m *GetOperationsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetOperationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationsRequest.DiscardUnknown(m)
 }
 
-func (c *dependenciesReaderPluginClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
-	out := new(GetDependenciesResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.DependenciesReaderPlugin/GetDependencies", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetOperationsRequest proto.InternalMessageInfo
+
+func (m *GetOperationsRequest) GetService() string {
+	if m != nil {
+		return m.Service
 	}
-	return out, nil
+	return ""
 }
 
-// DependenciesReaderPluginServer is the server API for DependenciesReaderPlugin service.
-type DependenciesReaderPluginServer interface {
-	// dependencystore/Reader
-	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
+func (m *GetOperationsRequest) GetSpanKind() string {
+	if m != nil {
+		return m.SpanKind
+	}
+	return ""
 }
 
-func RegisterDependenciesReaderPluginServer(s *grpc.Server, srv DependenciesReaderPluginServer) {
-	s.RegisterService(&_DependenciesReaderPlugin_serviceDesc, srv)
+type Operation struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SpanKind             string   `protobuf:"bytes,2,opt,name=span_kind,json=spanKind,proto3" json:"span_kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _DependenciesReaderPlugin_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDependenciesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DependenciesReaderPluginServer).GetDependencies(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.DependenciesReaderPlugin/GetDependencies",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DependenciesReaderPluginServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+func (m *Operation) Reset()         { *m = Operation{} }
+func (m *Operation) String() string { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()    {}
+func (*Operation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{27}
+}
+func (m *Operation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Operation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-var _DependenciesReaderPlugin_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "jaeger.storage.v1.DependenciesReaderPlugin",
-	HandlerType: (*DependenciesReaderPluginServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetDependencies",
-			Handler:    _DependenciesReaderPlugin_GetDependencies_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "storage.proto",
+func (m *Operation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Operation.Merge(m, src)
 }
-
-// PluginCapabilitiesClient is the client API for PluginCapabilities service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PluginCapabilitiesClient interface {
-	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+func (m *Operation) XXX_Size() int {
+	return m.Size()
 }
-
-type pluginCapabilitiesClient struct {
-	cc *grpc.ClientConn
+func (m *Operation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Operation.DiscardUnknown(m)
 }
 
-func NewPluginCapabilitiesClient(cc *grpc.ClientConn) PluginCapabilitiesClient {
-	return &pluginCapabilitiesClient{cc}
-}
+var xxx_messageInfo_Operation proto.InternalMessageInfo
 
-func (c *pluginCapabilitiesClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
-	out := new(CapabilitiesResponse)
-	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.PluginCapabilities/Capabilities", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Operation) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-// PluginCapabilitiesServer is the server API for PluginCapabilities service.
-type PluginCapabilitiesServer interface {
-	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+func (m *Operation) GetSpanKind() string {
+	if m != nil {
+		return m.SpanKind
+	}
+	return ""
 }
 
-func RegisterPluginCapabilitiesServer(s *grpc.Server, srv PluginCapabilitiesServer) {
-	s.RegisterService(&_PluginCapabilities_serviceDesc, srv)
+type GetOperationsResponse struct {
+	OperationNames       []string     `protobuf:"bytes,1,rep,name=operationNames,proto3" json:"operationNames,omitempty"`
+	Operations           []*Operation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func _PluginCapabilities_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CapabilitiesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PluginCapabilitiesServer).Capabilities(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/jaeger.storage.v1.PluginCapabilities/Capabilities",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PluginCapabilitiesServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+func (m *GetOperationsResponse) Reset()         { *m = GetOperationsResponse{} }
+func (m *GetOperationsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOperationsResponse) ProtoMessage()    {}
+func (*GetOperationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{28}
+}
+func (m *GetOperationsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetOperationsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-var _PluginCapabilities_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "jaeger.storage.v1.PluginCapabilities",
-	HandlerType: (*PluginCapabilitiesServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Capabilities",
-			Handler:    _PluginCapabilities_Capabilities_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "storage.proto",
+func (m *GetOperationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationsResponse.Merge(m, src)
+}
+func (m *GetOperationsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetOperationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationsResponse.DiscardUnknown(m)
 }
 
-func (m *GetDependenciesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetOperationsResponse proto.InternalMessageInfo
+
+func (m *GetOperationsResponse) GetOperationNames() []string {
+	if m != nil {
+		return m.OperationNames
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetDependenciesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)))
-	n1, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n1
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)))
-	n2, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.EndTime, dAtA[i:])
-	if err != nil {
-		return 0, err
+func (m *GetOperationsResponse) GetOperations() []*Operation {
+	if m != nil {
+		return m.Operations
 	}
-	i += n2
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+	return nil
 }
 
-func (m *GetDependenciesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type TraceQueryParameters struct {
+	ServiceName          string            `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	OperationName        string            `protobuf:"bytes,2,opt,name=operation_name,json=operationName,proto3" json:"operation_name,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	StartTimeMin         time.Time         `protobuf:"bytes,4,opt,name=start_time_min,json=startTimeMin,proto3,stdtime" json:"start_time_min"`
+	StartTimeMax         time.Time         `protobuf:"bytes,5,opt,name=start_time_max,json=startTimeMax,proto3,stdtime" json:"start_time_max"`
+	DurationMin          time.Duration     `protobuf:"bytes,6,opt,name=duration_min,json=durationMin,proto3,stdduration" json:"duration_min"`
+	DurationMax          time.Duration     `protobuf:"bytes,7,opt,name=duration_max,json=durationMax,proto3,stdduration" json:"duration_max"`
+	NumTraces            int32             `protobuf:"varint,8,opt,name=num_traces,json=numTraces,proto3" json:"num_traces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *GetDependenciesResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Dependencies) > 0 {
-		for _, msg := range m.Dependencies {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+func (m *TraceQueryParameters) Reset()         { *m = TraceQueryParameters{} }
+func (m *TraceQueryParameters) String() string { return proto.CompactTextString(m) }
+func (*TraceQueryParameters) ProtoMessage()    {}
+func (*TraceQueryParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{29}
+}
+func (m *TraceQueryParameters) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceQueryParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceQueryParameters.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *TraceQueryParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceQueryParameters.Merge(m, src)
+}
+func (m *TraceQueryParameters) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceQueryParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceQueryParameters.DiscardUnknown(m)
 }
 
-func (m *WriteSpanRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TraceQueryParameters proto.InternalMessageInfo
+
+func (m *TraceQueryParameters) GetServiceName() string {
+	if m != nil {
+		return m.ServiceName
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *WriteSpanRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Span != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(m.Span.Size()))
-		n3, err := m.Span.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *TraceQueryParameters) GetOperationName() string {
+	if m != nil {
+		return m.OperationName
 	}
-	return i, nil
+	return ""
 }
 
-func (m *WriteSpanResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TraceQueryParameters) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *WriteSpanResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *TraceQueryParameters) GetStartTimeMin() time.Time {
+	if m != nil {
+		return m.StartTimeMin
 	}
-	return i, nil
+	return time.Time{}
 }
 
-func (m *GetTraceRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TraceQueryParameters) GetStartTimeMax() time.Time {
+	if m != nil {
+		return m.StartTimeMax
 	}
-	return dAtA[:n], nil
+	return time.Time{}
 }
 
-func (m *GetTraceRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(m.TraceID.Size()))
-	n4, err := m.TraceID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n4
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *TraceQueryParameters) GetDurationMin() time.Duration {
+	if m != nil {
+		return m.DurationMin
 	}
-	return i, nil
+	return 0
 }
 
-func (m *GetServicesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TraceQueryParameters) GetDurationMax() time.Duration {
+	if m != nil {
+		return m.DurationMax
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetServicesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *TraceQueryParameters) GetNumTraces() int32 {
+	if m != nil {
+		return m.NumTraces
 	}
-	return i, nil
+	return 0
 }
 
-func (m *GetServicesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type FindTracesRequest struct {
+	Query                *TraceQueryParameters `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *GetServicesResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for _, s := range m.Services {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+func (m *FindTracesRequest) Reset()         { *m = FindTracesRequest{} }
+func (m *FindTracesRequest) String() string { return proto.CompactTextString(m) }
+func (*FindTracesRequest) ProtoMessage()    {}
+func (*FindTracesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{30}
+}
+func (m *FindTracesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FindTracesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FindTracesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *FindTracesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindTracesRequest.Merge(m, src)
+}
+func (m *FindTracesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FindTracesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindTracesRequest.DiscardUnknown(m)
 }
 
-func (m *GetOperationsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_FindTracesRequest proto.InternalMessageInfo
+
+func (m *FindTracesRequest) GetQuery() *TraceQueryParameters {
+	if m != nil {
+		return m.Query
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetOperationsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Service) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.Service)))
-		i += copy(dAtA[i:], m.Service)
-	}
-	if len(m.SpanKind) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.SpanKind)))
-		i += copy(dAtA[i:], m.SpanKind)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type SpansResponseChunk struct {
+	Spans                []model.Span `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *Operation) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *SpansResponseChunk) Reset()         { *m = SpansResponseChunk{} }
+func (m *SpansResponseChunk) String() string { return proto.CompactTextString(m) }
+func (*SpansResponseChunk) ProtoMessage()    {}
+func (*SpansResponseChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{31}
+}
+func (m *SpansResponseChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpansResponseChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpansResponseChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *SpansResponseChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpansResponseChunk.Merge(m, src)
+}
+func (m *SpansResponseChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpansResponseChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpansResponseChunk.DiscardUnknown(m)
 }
 
-func (m *Operation) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.SpanKind) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.SpanKind)))
-		i += copy(dAtA[i:], m.SpanKind)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_SpansResponseChunk proto.InternalMessageInfo
+
+func (m *SpansResponseChunk) GetSpans() []model.Span {
+	if m != nil {
+		return m.Spans
 	}
-	return i, nil
+	return nil
 }
 
-func (m *GetOperationsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type FindTraceIDsRequest struct {
+	Query                *TraceQueryParameters `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *GetOperationsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.OperationNames) > 0 {
-		for _, s := range m.OperationNames {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.Operations) > 0 {
-		for _, msg := range m.Operations {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+func (m *FindTraceIDsRequest) Reset()         { *m = FindTraceIDsRequest{} }
+func (m *FindTraceIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*FindTraceIDsRequest) ProtoMessage()    {}
+func (*FindTraceIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{32}
+}
+func (m *FindTraceIDsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FindTraceIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FindTraceIDsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
 }
-
-func (m *TraceQueryParameters) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *FindTraceIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindTraceIDsRequest.Merge(m, src)
+}
+func (m *FindTraceIDsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FindTraceIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindTraceIDsRequest.DiscardUnknown(m)
 }
 
-func (m *TraceQueryParameters) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ServiceName) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.ServiceName)))
-		i += copy(dAtA[i:], m.ServiceName)
-	}
-	if len(m.OperationName) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(len(m.OperationName)))
-		i += copy(dAtA[i:], m.OperationName)
-	}
-	if len(m.Tags) > 0 {
-		for k, _ := range m.Tags {
-			dAtA[i] = 0x1a
-			i++
-			v := m.Tags[k]
-			mapSize := 1 + len(k) + sovStorage(uint64(len(k))) + 1 + len(v) + sovStorage(uint64(len(v)))
-			i = encodeVarintStorage(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintStorage(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintStorage(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
-	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMin)))
-	n5, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTimeMin, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n5
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMax)))
-	n6, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTimeMax, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n6
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMin)))
-	n7, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.DurationMin, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n7
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMax)))
-	n8, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.DurationMax, dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n8
-	if m.NumTraces != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(m.NumTraces))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_FindTraceIDsRequest proto.InternalMessageInfo
+
+func (m *FindTraceIDsRequest) GetQuery() *TraceQueryParameters {
+	if m != nil {
+		return m.Query
 	}
-	return i, nil
+	return nil
 }
 
-func (m *FindTracesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type FindTraceIDsResponse struct {
+	TraceIDs             []github_com_jaegertracing_jaeger_model.TraceID `protobuf:"bytes,1,rep,name=trace_ids,json=traceIds,proto3,customtype=github.com/jaegertracing/jaeger/model.TraceID" json:"trace_ids"`
+	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
+	XXX_unrecognized     []byte                                          `json:"-"`
+	XXX_sizecache        int32                                           `json:"-"`
 }
 
-func (m *FindTracesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Query != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(m.Query.Size()))
-		n9, err := m.Query.MarshalTo(dAtA[i:])
+func (m *FindTraceIDsResponse) Reset()         { *m = FindTraceIDsResponse{} }
+func (m *FindTraceIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*FindTraceIDsResponse) ProtoMessage()    {}
+func (*FindTraceIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{33}
+}
+func (m *FindTraceIDsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FindTraceIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FindTraceIDsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n9
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
 }
-
-func (m *SpansResponseChunk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *FindTraceIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindTraceIDsResponse.Merge(m, src)
 }
-
-func (m *SpansResponseChunk) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Spans) > 0 {
-		for _, msg := range m.Spans {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func (m *FindTraceIDsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *FindTraceIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindTraceIDsResponse.DiscardUnknown(m)
 }
 
-func (m *FindTraceIDsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+var xxx_messageInfo_FindTraceIDsResponse proto.InternalMessageInfo
+
+// empty; extensible in the future
+type CapabilitiesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FindTraceIDsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Query != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintStorage(dAtA, i, uint64(m.Query.Size()))
-		n10, err := m.Query.MarshalTo(dAtA[i:])
+func (m *CapabilitiesRequest) Reset()         { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()    {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{34}
+}
+func (m *CapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CapabilitiesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n10
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *CapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesRequest.Merge(m, src)
+}
+func (m *CapabilitiesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesRequest.DiscardUnknown(m)
 }
 
-func (m *FindTraceIDsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+var xxx_messageInfo_CapabilitiesRequest proto.InternalMessageInfo
+
+type CapabilitiesResponse struct {
+	ArchiveSpanReader    bool     `protobuf:"varint,1,opt,name=archiveSpanReader,proto3" json:"archiveSpanReader,omitempty"`
+	ArchiveSpanWriter    bool     `protobuf:"varint,2,opt,name=archiveSpanWriter,proto3" json:"archiveSpanWriter,omitempty"`
+	BatchSpanWriter      bool     `protobuf:"varint,3,opt,name=batchSpanWriter,proto3" json:"batchSpanWriter,omitempty"`
+	DependencyWriter     bool     `protobuf:"varint,4,opt,name=dependencyWriter,proto3" json:"dependencyWriter,omitempty"`
+	SamplingStore        bool     `protobuf:"varint,5,opt,name=samplingStore,proto3" json:"samplingStore,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FindTraceIDsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.TraceIDs) > 0 {
-		for _, msg := range m.TraceIDs {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
+func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesResponse) ProtoMessage()    {}
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{35}
+}
+func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CapabilitiesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *CapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesResponse.Merge(m, src)
+}
+func (m *CapabilitiesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesResponse.DiscardUnknown(m)
 }
 
-func (m *CapabilitiesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CapabilitiesResponse proto.InternalMessageInfo
+
+func (m *CapabilitiesResponse) GetArchiveSpanReader() bool {
+	if m != nil {
+		return m.ArchiveSpanReader
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *CapabilitiesRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *CapabilitiesResponse) GetArchiveSpanWriter() bool {
+	if m != nil {
+		return m.ArchiveSpanWriter
 	}
-	return i, nil
+	return false
 }
 
-func (m *CapabilitiesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CapabilitiesResponse) GetBatchSpanWriter() bool {
+	if m != nil {
+		return m.BatchSpanWriter
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *CapabilitiesResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ArchiveSpanReader {
-		dAtA[i] = 0x8
-		i++
-		if m.ArchiveSpanReader {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.ArchiveSpanWriter {
-		dAtA[i] = 0x10
-		i++
-		if m.ArchiveSpanWriter {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *CapabilitiesResponse) GetDependencyWriter() bool {
+	if m != nil {
+		return m.DependencyWriter
 	}
-	return i, nil
+	return false
 }
 
-func encodeVarintStorage(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CapabilitiesResponse) GetSamplingStore() bool {
+	if m != nil {
+		return m.SamplingStore
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return false
 }
-func (m *GetDependenciesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
-	n += 1 + l + sovStorage(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)
-	n += 1 + l + sovStorage(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+func init() {
+	proto.RegisterType((*GetDependenciesRequest)(nil), "jaeger.storage.v1.GetDependenciesRequest")
+	golang_proto.RegisterType((*GetDependenciesRequest)(nil), "jaeger.storage.v1.GetDependenciesRequest")
+	proto.RegisterType((*GetDependenciesResponse)(nil), "jaeger.storage.v1.GetDependenciesResponse")
+	golang_proto.RegisterType((*GetDependenciesResponse)(nil), "jaeger.storage.v1.GetDependenciesResponse")
+	proto.RegisterType((*WriteSpanRequest)(nil), "jaeger.storage.v1.WriteSpanRequest")
+	golang_proto.RegisterType((*WriteSpanRequest)(nil), "jaeger.storage.v1.WriteSpanRequest")
+	proto.RegisterType((*WriteSpanResponse)(nil), "jaeger.storage.v1.WriteSpanResponse")
+	golang_proto.RegisterType((*WriteSpanResponse)(nil), "jaeger.storage.v1.WriteSpanResponse")
+	proto.RegisterType((*WriteSpansRequest)(nil), "jaeger.storage.v1.WriteSpansRequest")
+	golang_proto.RegisterType((*WriteSpansRequest)(nil), "jaeger.storage.v1.WriteSpansRequest")
+	proto.RegisterType((*WriteSpansResponse)(nil), "jaeger.storage.v1.WriteSpansResponse")
+	golang_proto.RegisterType((*WriteSpansResponse)(nil), "jaeger.storage.v1.WriteSpansResponse")
+	proto.RegisterType((*WriteDependenciesRequest)(nil), "jaeger.storage.v1.WriteDependenciesRequest")
+	golang_proto.RegisterType((*WriteDependenciesRequest)(nil), "jaeger.storage.v1.WriteDependenciesRequest")
+	proto.RegisterType((*WriteDependenciesResponse)(nil), "jaeger.storage.v1.WriteDependenciesResponse")
+	golang_proto.RegisterType((*WriteDependenciesResponse)(nil), "jaeger.storage.v1.WriteDependenciesResponse")
+	proto.RegisterType((*Throughput)(nil), "jaeger.storage.v1.Throughput")
+	golang_proto.RegisterType((*Throughput)(nil), "jaeger.storage.v1.Throughput")
+	proto.RegisterType((*OperationValue)(nil), "jaeger.storage.v1.OperationValue")
+	golang_proto.RegisterType((*OperationValue)(nil), "jaeger.storage.v1.OperationValue")
+	proto.RegisterType((*OperationProbabilityAndQPS)(nil), "jaeger.storage.v1.OperationProbabilityAndQPS")
+	golang_proto.RegisterType((*OperationProbabilityAndQPS)(nil), "jaeger.storage.v1.OperationProbabilityAndQPS")
+	proto.RegisterType((*ServiceOperationData)(nil), "jaeger.storage.v1.ServiceOperationData")
+	golang_proto.RegisterType((*ServiceOperationData)(nil), "jaeger.storage.v1.ServiceOperationData")
+	proto.RegisterType((*HostServiceOperationData)(nil), "jaeger.storage.v1.HostServiceOperationData")
+	golang_proto.RegisterType((*HostServiceOperationData)(nil), "jaeger.storage.v1.HostServiceOperationData")
+	proto.RegisterType((*InsertThroughputRequest)(nil), "jaeger.storage.v1.InsertThroughputRequest")
+	golang_proto.RegisterType((*InsertThroughputRequest)(nil), "jaeger.storage.v1.InsertThroughputRequest")
+	proto.RegisterType((*InsertThroughputResponse)(nil), "jaeger.storage.v1.InsertThroughputResponse")
+	golang_proto.RegisterType((*InsertThroughputResponse)(nil), "jaeger.storage.v1.InsertThroughputResponse")
+	proto.RegisterType((*InsertProbabilitiesAndQPSRequest)(nil), "jaeger.storage.v1.InsertProbabilitiesAndQPSRequest")
+	golang_proto.RegisterType((*InsertProbabilitiesAndQPSRequest)(nil), "jaeger.storage.v1.InsertProbabilitiesAndQPSRequest")
+	proto.RegisterType((*InsertProbabilitiesAndQPSResponse)(nil), "jaeger.storage.v1.InsertProbabilitiesAndQPSResponse")
+	golang_proto.RegisterType((*InsertProbabilitiesAndQPSResponse)(nil), "jaeger.storage.v1.InsertProbabilitiesAndQPSResponse")
+	proto.RegisterType((*GetThroughputRequest)(nil), "jaeger.storage.v1.GetThroughputRequest")
+	golang_proto.RegisterType((*GetThroughputRequest)(nil), "jaeger.storage.v1.GetThroughputRequest")
+	proto.RegisterType((*GetThroughputResponse)(nil), "jaeger.storage.v1.GetThroughputResponse")
+	golang_proto.RegisterType((*GetThroughputResponse)(nil), "jaeger.storage.v1.GetThroughputResponse")
+	proto.RegisterType((*GetProbabilitiesAndQPSRequest)(nil), "jaeger.storage.v1.GetProbabilitiesAndQPSRequest")
+	golang_proto.RegisterType((*GetProbabilitiesAndQPSRequest)(nil), "jaeger.storage.v1.GetProbabilitiesAndQPSRequest")
+	proto.RegisterType((*GetProbabilitiesAndQPSResponse)(nil), "jaeger.storage.v1.GetProbabilitiesAndQPSResponse")
+	golang_proto.RegisterType((*GetProbabilitiesAndQPSResponse)(nil), "jaeger.storage.v1.GetProbabilitiesAndQPSResponse")
+	proto.RegisterType((*GetLatestProbabilitiesRequest)(nil), "jaeger.storage.v1.GetLatestProbabilitiesRequest")
+	golang_proto.RegisterType((*GetLatestProbabilitiesRequest)(nil), "jaeger.storage.v1.GetLatestProbabilitiesRequest")
+	proto.RegisterType((*GetLatestProbabilitiesResponse)(nil), "jaeger.storage.v1.GetLatestProbabilitiesResponse")
+	golang_proto.RegisterType((*GetLatestProbabilitiesResponse)(nil), "jaeger.storage.v1.GetLatestProbabilitiesResponse")
+	proto.RegisterType((*GetTraceRequest)(nil), "jaeger.storage.v1.GetTraceRequest")
+	golang_proto.RegisterType((*GetTraceRequest)(nil), "jaeger.storage.v1.GetTraceRequest")
+	proto.RegisterType((*GetServicesRequest)(nil), "jaeger.storage.v1.GetServicesRequest")
+	golang_proto.RegisterType((*GetServicesRequest)(nil), "jaeger.storage.v1.GetServicesRequest")
+	proto.RegisterType((*GetServicesResponse)(nil), "jaeger.storage.v1.GetServicesResponse")
+	golang_proto.RegisterType((*GetServicesResponse)(nil), "jaeger.storage.v1.GetServicesResponse")
+	proto.RegisterType((*GetOperationsRequest)(nil), "jaeger.storage.v1.GetOperationsRequest")
+	golang_proto.RegisterType((*GetOperationsRequest)(nil), "jaeger.storage.v1.GetOperationsRequest")
+	proto.RegisterType((*Operation)(nil), "jaeger.storage.v1.Operation")
+	golang_proto.RegisterType((*Operation)(nil), "jaeger.storage.v1.Operation")
+	proto.RegisterType((*GetOperationsResponse)(nil), "jaeger.storage.v1.GetOperationsResponse")
+	golang_proto.RegisterType((*GetOperationsResponse)(nil), "jaeger.storage.v1.GetOperationsResponse")
+	proto.RegisterType((*TraceQueryParameters)(nil), "jaeger.storage.v1.TraceQueryParameters")
+	golang_proto.RegisterType((*TraceQueryParameters)(nil), "jaeger.storage.v1.TraceQueryParameters")
+	proto.RegisterMapType((map[string]string)(nil), "jaeger.storage.v1.TraceQueryParameters.TagsEntry")
+	golang_proto.RegisterMapType((map[string]string)(nil), "jaeger.storage.v1.TraceQueryParameters.TagsEntry")
+	proto.RegisterType((*FindTracesRequest)(nil), "jaeger.storage.v1.FindTracesRequest")
+	golang_proto.RegisterType((*FindTracesRequest)(nil), "jaeger.storage.v1.FindTracesRequest")
+	proto.RegisterType((*SpansResponseChunk)(nil), "jaeger.storage.v1.SpansResponseChunk")
+	golang_proto.RegisterType((*SpansResponseChunk)(nil), "jaeger.storage.v1.SpansResponseChunk")
+	proto.RegisterType((*FindTraceIDsRequest)(nil), "jaeger.storage.v1.FindTraceIDsRequest")
+	golang_proto.RegisterType((*FindTraceIDsRequest)(nil), "jaeger.storage.v1.FindTraceIDsRequest")
+	proto.RegisterType((*FindTraceIDsResponse)(nil), "jaeger.storage.v1.FindTraceIDsResponse")
+	golang_proto.RegisterType((*FindTraceIDsResponse)(nil), "jaeger.storage.v1.FindTraceIDsResponse")
+	proto.RegisterType((*CapabilitiesRequest)(nil), "jaeger.storage.v1.CapabilitiesRequest")
+	golang_proto.RegisterType((*CapabilitiesRequest)(nil), "jaeger.storage.v1.CapabilitiesRequest")
+	proto.RegisterType((*CapabilitiesResponse)(nil), "jaeger.storage.v1.CapabilitiesResponse")
+	golang_proto.RegisterType((*CapabilitiesResponse)(nil), "jaeger.storage.v1.CapabilitiesResponse")
 }
 
-func (m *GetDependenciesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Dependencies) > 0 {
-		for _, e := range m.Dependencies {
-			l = e.Size()
-			n += 1 + l + sovStorage(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
+func init() { golang_proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
+
+var fileDescriptor_0d2c4ccf1453ffdb = []byte{
+	// 1600 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x73, 0xdb, 0xd4,
+	0x17, 0xff, 0x2b, 0x76, 0x1a, 0xfb, 0x24, 0x69, 0x93, 0x1b, 0xf7, 0x5f, 0x57, 0x6d, 0x13, 0x57,
+	0x7d, 0xc4, 0x7d, 0x39, 0x8d, 0xcb, 0x0c, 0x0c, 0x94, 0x47, 0xd2, 0xb4, 0x21, 0xd0, 0x42, 0xaa,
+	0x64, 0x5a, 0xa0, 0x50, 0xcf, 0xb5, 0x75, 0xb1, 0x45, 0x62, 0xc9, 0x95, 0xae, 0x3c, 0xf1, 0xa2,
+	0x33, 0x2c, 0x18, 0x86, 0x61, 0xc5, 0x92, 0x05, 0xc3, 0x8e, 0xe9, 0x97, 0x60, 0xc1, 0x86, 0x99,
+	0x2e, 0x59, 0xb3, 0x28, 0x4c, 0x58, 0x32, 0xf0, 0x19, 0x98, 0xfb, 0x90, 0x2c, 0xc9, 0xb2, 0x9d,
+	0xa4, 0x5d, 0x74, 0xa7, 0x7b, 0xee, 0x39, 0xbf, 0xf3, 0x3e, 0xf7, 0x5e, 0xc1, 0xa4, 0x4b, 0x6d,
+	0x07, 0xd7, 0x49, 0xa9, 0xe5, 0xd8, 0xd4, 0x46, 0xd3, 0x5f, 0x60, 0x52, 0x27, 0x4e, 0xc9, 0xa7,
+	0xb6, 0x17, 0xd5, 0x5c, 0xdd, 0xae, 0xdb, 0x7c, 0x77, 0x81, 0x7d, 0x09, 0x46, 0x75, 0xae, 0x6e,
+	0xdb, 0xf5, 0x6d, 0xb2, 0xc0, 0x57, 0x55, 0xef, 0xf3, 0x05, 0x6a, 0x36, 0x89, 0x4b, 0x71, 0xb3,
+	0x25, 0x19, 0x66, 0xe3, 0x0c, 0x86, 0xe7, 0x60, 0x6a, 0xda, 0x96, 0xdc, 0x1f, 0x6f, 0xda, 0x06,
+	0xd9, 0x16, 0x0b, 0xed, 0x47, 0x05, 0xfe, 0xbf, 0x4a, 0xe8, 0x0a, 0x69, 0x11, 0xcb, 0x20, 0x56,
+	0xcd, 0x24, 0xae, 0x4e, 0x1e, 0x79, 0xc4, 0xa5, 0xe8, 0x06, 0x80, 0x4b, 0xb1, 0x43, 0x2b, 0x4c,
+	0x41, 0x5e, 0x29, 0x28, 0xc5, 0xf1, 0xb2, 0x5a, 0x12, 0xe0, 0x25, 0x1f, 0xbc, 0xb4, 0xe9, 0x6b,
+	0x5f, 0xce, 0x3c, 0x7d, 0x36, 0xf7, 0xbf, 0xef, 0xfe, 0x98, 0x53, 0xf4, 0x2c, 0x97, 0x63, 0x3b,
+	0xe8, 0x6d, 0xc8, 0x10, 0xcb, 0x10, 0x10, 0x23, 0xfb, 0x80, 0x18, 0x23, 0x96, 0xc1, 0xe8, 0x5a,
+	0x15, 0x8e, 0xf5, 0xd8, 0xe7, 0xb6, 0x6c, 0xcb, 0x25, 0x68, 0x15, 0x26, 0x8c, 0x10, 0x3d, 0xaf,
+	0x14, 0x52, 0xc5, 0xf1, 0xf2, 0xa9, 0x92, 0x8c, 0x24, 0x6e, 0x99, 0x95, 0x76, 0xb9, 0x14, 0x88,
+	0x76, 0x6e, 0x9b, 0xd6, 0xd6, 0x72, 0x9a, 0xa9, 0xd0, 0x23, 0x82, 0xda, 0x1b, 0x30, 0x75, 0xdf,
+	0x31, 0x29, 0xd9, 0x68, 0x61, 0xcb, 0xf7, 0x7e, 0x1e, 0xd2, 0x6e, 0x0b, 0x5b, 0xd2, 0xef, 0x99,
+	0x18, 0x28, 0xe7, 0xe4, 0x0c, 0xda, 0x0c, 0x4c, 0x87, 0x84, 0x85, 0x69, 0xda, 0x5b, 0x21, 0x62,
+	0x10, 0xd0, 0x0b, 0x30, 0xca, 0x24, 0x7c, 0x43, 0x13, 0x31, 0x05, 0x87, 0x96, 0x03, 0x14, 0x96,
+	0x97, 0xa8, 0x4f, 0x14, 0xc8, 0x73, 0x72, 0x52, 0xba, 0x96, 0x21, 0x1b, 0x54, 0xc2, 0xfe, 0xb2,
+	0x15, 0x88, 0xf5, 0x44, 0x74, 0xe4, 0xa0, 0x11, 0x3d, 0x01, 0xc7, 0x13, 0x0c, 0x95, 0x6e, 0x7c,
+	0xa9, 0x00, 0x6c, 0x36, 0x1c, 0xdb, 0xab, 0x37, 0x5a, 0x1e, 0x45, 0x79, 0x18, 0x73, 0x89, 0xd3,
+	0x36, 0x6b, 0xa2, 0xc8, 0xb2, 0xba, 0xbf, 0x44, 0x27, 0x21, 0x6b, 0xb7, 0x88, 0x28, 0x5e, 0x5e,
+	0x3d, 0x59, 0xbd, 0x4b, 0x40, 0x39, 0x18, 0xad, 0xd9, 0x9e, 0x45, 0xf3, 0xa9, 0x82, 0x52, 0x4c,
+	0xe9, 0x62, 0x81, 0xce, 0xc2, 0x64, 0xcb, 0xb1, 0xab, 0xb8, 0x6a, 0x6e, 0x9b, 0x94, 0xf9, 0x90,
+	0x2e, 0xa4, 0x8a, 0x59, 0x3d, 0x4a, 0xd4, 0x1e, 0xc2, 0xe1, 0x0f, 0x7d, 0xa0, 0x7b, 0x78, 0xdb,
+	0x23, 0xcf, 0x63, 0x45, 0x9b, 0x01, 0x70, 0x2b, 0x14, 0x5d, 0x2c, 0xb4, 0x6f, 0x14, 0x50, 0x03,
+	0x05, 0xeb, 0x81, 0xea, 0xce, 0x92, 0x65, 0xdc, 0x5d, 0xdf, 0x38, 0xb0, 0xb2, 0x02, 0x8c, 0x77,
+	0xfd, 0xe8, 0x48, 0x95, 0x61, 0x12, 0x9a, 0x82, 0xd4, 0xa3, 0x16, 0x73, 0x9a, 0xed, 0xb0, 0x4f,
+	0x8d, 0x40, 0x6e, 0x43, 0x80, 0x07, 0x06, 0xad, 0x60, 0x8a, 0xd1, 0x1d, 0x80, 0x00, 0xd8, 0x2f,
+	0xc9, 0x2b, 0xa5, 0x9e, 0x29, 0x54, 0xea, 0xef, 0x86, 0x1e, 0x02, 0xd0, 0x3a, 0x90, 0x7f, 0xd7,
+	0x76, 0x69, 0xa2, 0x2a, 0x15, 0x32, 0x0d, 0xdb, 0xa5, 0x16, 0x6e, 0xfa, 0xfe, 0x06, 0x6b, 0xb4,
+	0x04, 0x69, 0x03, 0x53, 0x2c, 0x4b, 0x6d, 0x3e, 0xc1, 0x80, 0x24, 0x48, 0x59, 0x74, 0x5c, 0x54,
+	0xfb, 0x08, 0x8e, 0xad, 0x59, 0x2e, 0x71, 0x68, 0xb7, 0xa8, 0xfc, 0xa6, 0x78, 0x13, 0x80, 0x06,
+	0xc4, 0xf8, 0x80, 0x08, 0xe9, 0x08, 0x49, 0x86, 0x04, 0x34, 0x15, 0xf2, 0xbd, 0xc8, 0xb2, 0x8a,
+	0x7f, 0x56, 0xa0, 0x20, 0x36, 0xd7, 0xc3, 0xa5, 0x25, 0x43, 0x23, 0xf5, 0x0f, 0xf2, 0x7c, 0x35,
+	0x5e, 0xa9, 0x22, 0x04, 0xa7, 0x07, 0xe5, 0x80, 0xd7, 0x6a, 0xac, 0x98, 0xd1, 0x35, 0x91, 0xf3,
+	0xd4, 0x5e, 0xc5, 0x79, 0x59, 0x9c, 0x81, 0xd3, 0x03, 0xac, 0x97, 0x3e, 0xfe, 0xa0, 0x40, 0x6e,
+	0x95, 0x24, 0xc4, 0xf5, 0xe5, 0x38, 0x1b, 0xee, 0xc1, 0xd1, 0x98, 0x75, 0xf2, 0x64, 0x78, 0xce,
+	0xb4, 0xff, 0xa4, 0xc0, 0xa9, 0x55, 0x32, 0x28, 0xaf, 0x2f, 0x87, 0xff, 0x26, 0xcc, 0xf6, 0x33,
+	0x33, 0x38, 0x22, 0x47, 0x59, 0xbd, 0xf9, 0xfd, 0x7d, 0x29, 0x21, 0x06, 0xfd, 0xba, 0x56, 0xb6,
+	0x98, 0x90, 0xd7, 0xe6, 0x78, 0x44, 0x6e, 0x63, 0x4a, 0xdc, 0xa8, 0x42, 0x19, 0x11, 0x69, 0x4b,
+	0x22, 0x43, 0x60, 0x4b, 0xac, 0xde, 0x95, 0x83, 0xd5, 0xbb, 0x66, 0xc1, 0x11, 0x96, 0x76, 0x07,
+	0xd7, 0x88, 0x9f, 0x8f, 0x07, 0x90, 0xa1, 0x6c, 0x5d, 0x31, 0x0d, 0x9e, 0x8d, 0x89, 0xe5, 0x77,
+	0x98, 0xf5, 0xbf, 0x3f, 0x9b, 0xbb, 0x52, 0x37, 0x69, 0xc3, 0xab, 0x96, 0x6a, 0x76, 0x73, 0x41,
+	0x28, 0x62, 0x8c, 0xa6, 0x55, 0x97, 0xab, 0x05, 0x71, 0x1b, 0xe2, 0x68, 0x6b, 0x2b, 0xbb, 0xcf,
+	0xe6, 0xc6, 0xe4, 0xa7, 0x3e, 0xc6, 0x11, 0xd7, 0x0c, 0x76, 0x18, 0xaf, 0x12, 0x3f, 0x46, 0x81,
+	0xc3, 0x8b, 0x30, 0x13, 0xa1, 0x4a, 0x2f, 0x55, 0xc8, 0xc8, 0x59, 0x2e, 0x1c, 0xcc, 0xea, 0xc1,
+	0x5a, 0xbb, 0xc3, 0xbb, 0x29, 0x70, 0x2e, 0x38, 0xba, 0xfb, 0x1f, 0x07, 0x27, 0x20, 0xcb, 0x2e,
+	0x04, 0x95, 0x2d, 0xd3, 0x32, 0xe4, 0x71, 0x90, 0x61, 0x84, 0xf7, 0x4d, 0xcb, 0xd0, 0xae, 0x43,
+	0x36, 0xc0, 0x42, 0x08, 0xd2, 0xa1, 0x29, 0xc3, 0xbf, 0x07, 0x4b, 0x3f, 0xe6, 0xcd, 0x13, 0x36,
+	0x46, 0x7a, 0x70, 0x1e, 0x0e, 0x07, 0x73, 0xfd, 0x03, 0xdc, 0x0c, 0xfc, 0x88, 0x51, 0xd1, 0xf5,
+	0xc8, 0x01, 0x22, 0x86, 0xd7, 0xc9, 0x41, 0xc9, 0x8c, 0x9c, 0x17, 0x4f, 0xd2, 0x90, 0xe3, 0x91,
+	0xbe, 0xeb, 0x11, 0xa7, 0xb3, 0x8e, 0x1d, 0xdc, 0x24, 0x94, 0x38, 0x2e, 0x3a, 0x0d, 0x13, 0xd2,
+	0xfb, 0x4a, 0xc8, 0xa1, 0x71, 0x49, 0x63, 0xaa, 0xd1, 0xb9, 0x90, 0x85, 0x82, 0x49, 0x38, 0x37,
+	0x19, 0xb1, 0x10, 0xdd, 0x84, 0x34, 0xc5, 0x75, 0x7f, 0x30, 0x2e, 0x26, 0xf5, 0x7f, 0x82, 0x01,
+	0xa5, 0x4d, 0x5c, 0x77, 0x6f, 0x5a, 0xd4, 0xe9, 0xe8, 0x5c, 0x1c, 0xbd, 0x07, 0x87, 0xbb, 0xbd,
+	0x5e, 0x69, 0x9a, 0x56, 0x3e, 0xbd, 0x8f, 0x66, 0x9d, 0x08, 0xfa, 0xfd, 0x8e, 0x69, 0xc5, 0xb1,
+	0xf0, 0x4e, 0x7e, 0xf4, 0x60, 0x58, 0x78, 0x07, 0xdd, 0x82, 0x09, 0xff, 0x66, 0xcf, 0xad, 0x3a,
+	0xc4, 0x91, 0x8e, 0xf7, 0x20, 0xad, 0x48, 0x26, 0x01, 0xf4, 0x3d, 0x03, 0x1a, 0xf7, 0x05, 0x99,
+	0x4d, 0x11, 0x1c, 0xbc, 0x93, 0x1f, 0x3b, 0x08, 0x0e, 0xde, 0x41, 0xa7, 0x00, 0x2c, 0xaf, 0x59,
+	0xe1, 0x5d, 0xe3, 0xe6, 0x33, 0x05, 0xa5, 0x38, 0xaa, 0x67, 0x2d, 0xaf, 0xc9, 0x83, 0xec, 0xaa,
+	0xaf, 0x42, 0x36, 0x88, 0x2c, 0xbb, 0xa6, 0x6c, 0x91, 0x8e, 0xcc, 0x2d, 0xfb, 0xec, 0xde, 0xa3,
+	0x44, 0x2a, 0xc5, 0xe2, 0xf5, 0x91, 0xd7, 0x14, 0x4d, 0x87, 0xe9, 0x5b, 0xa6, 0x65, 0x08, 0x98,
+	0xee, 0xc1, 0x3e, 0xfa, 0x88, 0xe5, 0x4d, 0xce, 0xde, 0xf9, 0x3d, 0x26, 0x57, 0x17, 0x52, 0xda,
+	0x4d, 0x40, 0x91, 0xab, 0xf5, 0x8d, 0x86, 0x67, 0x6d, 0xa1, 0x85, 0xe1, 0x17, 0x74, 0x7f, 0x2a,
+	0x8a, 0x6b, 0xfa, 0x26, 0xcc, 0x04, 0xa6, 0xad, 0xad, 0xbc, 0x28, 0xe3, 0xda, 0x90, 0x8b, 0xa2,
+	0xca, 0xc6, 0x7c, 0x08, 0x59, 0x7f, 0xc8, 0x09, 0x13, 0x27, 0x96, 0x97, 0x0e, 0x3a, 0xe5, 0x32,
+	0x01, 0x7a, 0x46, 0x8e, 0x39, 0x57, 0x3b, 0x0a, 0x33, 0x37, 0x70, 0xab, 0x67, 0xb2, 0xff, 0xab,
+	0x40, 0x2e, 0x4a, 0x97, 0xf6, 0x5c, 0x86, 0x69, 0xec, 0xd4, 0x1a, 0x66, 0x5b, 0xbe, 0x7d, 0xb0,
+	0x41, 0x1c, 0xee, 0x72, 0x46, 0xef, 0xdd, 0x88, 0x71, 0xf3, 0xd7, 0x81, 0x93, 0x1f, 0xe9, 0xe1,
+	0x16, 0x1b, 0xa8, 0x08, 0x47, 0xaa, 0x98, 0xd6, 0x1a, 0x21, 0xde, 0x14, 0xe7, 0x8d, 0x93, 0xd1,
+	0x45, 0x98, 0x0a, 0x9e, 0x1e, 0x1d, 0xc9, 0x9a, 0xe6, 0xac, 0x3d, 0x74, 0xf6, 0x38, 0x70, 0x71,
+	0xb3, 0xb5, 0x6d, 0x5a, 0xf5, 0x0d, 0x6a, 0x3b, 0x84, 0x77, 0x5f, 0x46, 0x8f, 0x12, 0xcb, 0xbf,
+	0x2a, 0x30, 0xd5, 0x55, 0xb0, 0xbe, 0xed, 0xd5, 0x4d, 0x0b, 0xdd, 0x83, 0x6c, 0xf0, 0x22, 0x43,
+	0x67, 0x12, 0x32, 0x1a, 0x7f, 0x41, 0xaa, 0x67, 0x07, 0x33, 0xc9, 0x20, 0x7e, 0x0c, 0x10, 0x10,
+	0x5d, 0x34, 0x50, 0xc6, 0xcf, 0x88, 0x7a, 0x6e, 0x08, 0x97, 0x80, 0x2e, 0xff, 0x9d, 0x82, 0xa9,
+	0x6e, 0x02, 0xa4, 0x1f, 0xf7, 0x21, 0xe3, 0x1f, 0x9e, 0x48, 0x4b, 0xc0, 0x89, 0x9d, 0xac, 0x89,
+	0xba, 0x7a, 0x5b, 0xe7, 0xaa, 0x82, 0x3e, 0x85, 0xf1, 0xd0, 0x79, 0x88, 0xce, 0x25, 0x63, 0xc7,
+	0x4e, 0x51, 0xf5, 0xfc, 0x30, 0x36, 0x19, 0xa6, 0x2a, 0x4c, 0x46, 0x4e, 0x2b, 0x34, 0x9f, 0x2c,
+	0xd8, 0x73, 0xb8, 0xaa, 0xc5, 0xe1, 0x8c, 0x52, 0xc7, 0x03, 0x80, 0xee, 0xa0, 0x49, 0x4c, 0x45,
+	0xcf, 0x1c, 0xda, 0x7b, 0x78, 0x2a, 0x30, 0x11, 0x6e, 0x6a, 0x74, 0x7e, 0x10, 0x7c, 0x77, 0x96,
+	0xa8, 0xf3, 0x43, 0xf9, 0x64, 0xb6, 0x77, 0xe0, 0xd8, 0x52, 0xbc, 0x8d, 0x64, 0xce, 0x3f, 0x93,
+	0xff, 0x37, 0x42, 0xfb, 0x2f, 0xb0, 0x84, 0xcb, 0x9d, 0x88, 0xe6, 0x48, 0xb5, 0x3d, 0xe4, 0x57,
+	0x35, 0xb9, 0xfb, 0xe2, 0x8b, 0xae, 0xfc, 0x95, 0x02, 0xf9, 0xe8, 0x3f, 0x86, 0x90, 0xf2, 0x06,
+	0x57, 0x1e, 0xde, 0x46, 0x17, 0x92, 0x95, 0x27, 0xfc, 0x4f, 0x51, 0x2f, 0xee, 0x85, 0x55, 0x46,
+	0xe0, 0xdb, 0x98, 0x19, 0x91, 0xe8, 0x5b, 0xf2, 0x5f, 0x50, 0xc4, 0x90, 0x4b, 0xfd, 0x22, 0x9b,
+	0x64, 0xca, 0xe5, 0xbd, 0x31, 0x4b, 0x63, 0xfe, 0x49, 0xc3, 0xcc, 0x46, 0x78, 0xa0, 0x49, 0x3b,
+	0xb6, 0x60, 0x2a, 0xfe, 0x98, 0x45, 0x49, 0x4e, 0xf6, 0x79, 0x4b, 0xab, 0x97, 0xf6, 0xc4, 0x2b,
+	0x7b, 0xe9, 0x6b, 0x05, 0x8e, 0xf7, 0x7d, 0x5f, 0xa2, 0x6b, 0x7d, 0xa1, 0xfa, 0xbf, 0xb9, 0xd4,
+	0x57, 0xf6, 0x27, 0x14, 0x19, 0x1c, 0x21, 0x97, 0xfb, 0x0c, 0x8e, 0x5e, 0x7f, 0x8b, 0xc3, 0x19,
+	0xa5, 0x8e, 0xc7, 0xfc, 0x1f, 0x6a, 0x92, 0xa3, 0x57, 0x93, 0x31, 0x06, 0x78, 0xb9, 0xb8, 0x0f,
+	0x89, 0x88, 0xfa, 0x84, 0xa7, 0x57, 0x3f, 0xf5, 0xfd, 0x9f, 0x71, 0xea, 0xe2, 0x3e, 0x24, 0x64,
+	0xbd, 0x79, 0x80, 0x44, 0x85, 0x85, 0x2f, 0x09, 0x6c, 0xde, 0x45, 0xd6, 0x49, 0xf3, 0x2e, 0xe1,
+	0xb6, 0xa1, 0xce, 0x0f, 0xe5, 0x13, 0x6a, 0x97, 0x4f, 0x3e, 0xdd, 0x9d, 0x55, 0x7e, 0xdb, 0x9d,
+	0x55, 0xfe, 0xdc, 0x9d, 0x55, 0x7e, 0xf9, 0x6b, 0x56, 0xf9, 0x04, 0xa4, 0x48, 0xa5, 0xbd, 0x58,
+	0x3d, 0xc4, 0xaf, 0xad, 0xd7, 0xfe, 0x1b, 0x00, 0xd0, 0x8f, 0xcc, 0xaf, 0x66, 0x17, 0x00, 0x00,
 }
 
-func (m *WriteSpanRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Span != nil {
-		l = m.Span.Size()
-		n += 1 + l + sovStorage(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SpanWriterPluginClient is the client API for SpanWriterPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SpanWriterPluginClient interface {
+	// spanstore/Writer
+	WriteSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error)
+	// only called when the plugin advertises the batchSpanWriter capability
+	WriteSpans(ctx context.Context, in *WriteSpansRequest, opts ...grpc.CallOption) (*WriteSpansResponse, error)
 }
 
-func (m *WriteSpanResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+type spanWriterPluginClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *GetTraceRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.TraceID.Size()
-	n += 1 + l + sovStorage(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func NewSpanWriterPluginClient(cc *grpc.ClientConn) SpanWriterPluginClient {
+	return &spanWriterPluginClient{cc}
 }
 
-func (m *GetServicesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (c *spanWriterPluginClient) WriteSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error) {
+	out := new(WriteSpanResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanWriterPlugin/WriteSpan", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *GetServicesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Services) > 0 {
-		for _, s := range m.Services {
-			l = len(s)
-			n += 1 + l + sovStorage(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (c *spanWriterPluginClient) WriteSpans(ctx context.Context, in *WriteSpansRequest, opts ...grpc.CallOption) (*WriteSpansResponse, error) {
+	out := new(WriteSpansResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanWriterPlugin/WriteSpans", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *GetOperationsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Service)
-	if l > 0 {
-		n += 1 + l + sovStorage(uint64(l))
-	}
-	l = len(m.SpanKind)
-	if l > 0 {
-		n += 1 + l + sovStorage(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+// SpanWriterPluginServer is the server API for SpanWriterPlugin service.
+type SpanWriterPluginServer interface {
+	// spanstore/Writer
+	WriteSpan(context.Context, *WriteSpanRequest) (*WriteSpanResponse, error)
+	// only called when the plugin advertises the batchSpanWriter capability
+	WriteSpans(context.Context, *WriteSpansRequest) (*WriteSpansResponse, error)
 }
 
-func (m *Operation) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovStorage(uint64(l))
-	}
-	l = len(m.SpanKind)
-	if l > 0 {
-		n += 1 + l + sovStorage(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func RegisterSpanWriterPluginServer(s *grpc.Server, srv SpanWriterPluginServer) {
+	s.RegisterService(&_SpanWriterPlugin_serviceDesc, srv)
 }
 
-func (m *GetOperationsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _SpanWriterPlugin_WriteSpan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSpanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.OperationNames) > 0 {
-		for _, s := range m.OperationNames {
-			l = len(s)
-			n += 1 + l + sovStorage(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(SpanWriterPluginServer).WriteSpan(ctx, in)
 	}
-	if len(m.Operations) > 0 {
-		for _, e := range m.Operations {
-			l = e.Size()
-			n += 1 + l + sovStorage(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SpanWriterPlugin/WriteSpan",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpanWriterPluginServer).WriteSpan(ctx, req.(*WriteSpanRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TraceQueryParameters) Size() (n int) {
-	if m == nil {
-		return 0
+func _SpanWriterPlugin_WriteSpans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSpansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.ServiceName)
-	if l > 0 {
-		n += 1 + l + sovStorage(uint64(l))
+	if interceptor == nil {
+		return srv.(SpanWriterPluginServer).WriteSpans(ctx, in)
 	}
-	l = len(m.OperationName)
-	if l > 0 {
-		n += 1 + l + sovStorage(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SpanWriterPlugin/WriteSpans",
 	}
-	if len(m.Tags) > 0 {
-		for k, v := range m.Tags {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovStorage(uint64(len(k))) + 1 + len(v) + sovStorage(uint64(len(v)))
-			n += mapEntrySize + 1 + sovStorage(uint64(mapEntrySize))
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpanWriterPluginServer).WriteSpans(ctx, req.(*WriteSpansRequest))
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMin)
-	n += 1 + l + sovStorage(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMax)
-	n += 1 + l + sovStorage(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMin)
-	n += 1 + l + sovStorage(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMax)
-	n += 1 + l + sovStorage(uint64(l))
-	if m.NumTraces != 0 {
-		n += 1 + sovStorage(uint64(m.NumTraces))
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SpanWriterPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.SpanWriterPlugin",
+	HandlerType: (*SpanWriterPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteSpan",
+			Handler:    _SpanWriterPlugin_WriteSpan_Handler,
+		},
+		{
+			MethodName: "WriteSpans",
+			Handler:    _SpanWriterPlugin_WriteSpans_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage.proto",
+}
+
+// SpanReaderPluginClient is the client API for SpanReaderPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SpanReaderPluginClient interface {
+	// spanstore/Reader
+	GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (SpanReaderPlugin_GetTraceClient, error)
+	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
+	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error)
+	FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (SpanReaderPlugin_FindTracesClient, error)
+	FindTraceIDs(ctx context.Context, in *FindTraceIDsRequest, opts ...grpc.CallOption) (*FindTraceIDsResponse, error)
+}
+
+type spanReaderPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSpanReaderPluginClient(cc *grpc.ClientConn) SpanReaderPluginClient {
+	return &spanReaderPluginClient{cc}
+}
+
+func (c *spanReaderPluginClient) GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (SpanReaderPlugin_GetTraceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SpanReaderPlugin_serviceDesc.Streams[0], "/jaeger.storage.v1.SpanReaderPlugin/GetTrace", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	x := &spanReaderPluginGetTraceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return n
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *FindTracesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type SpanReaderPlugin_GetTraceClient interface {
+	Recv() (*SpansResponseChunk, error)
+	grpc.ClientStream
+}
+
+type spanReaderPluginGetTraceClient struct {
+	grpc.ClientStream
+}
+
+func (x *spanReaderPluginGetTraceClient) Recv() (*SpansResponseChunk, error) {
+	m := new(SpansResponseChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Query != nil {
-		l = m.Query.Size()
-		n += 1 + l + sovStorage(uint64(l))
+	return m, nil
+}
+
+func (c *spanReaderPluginClient) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
+	out := new(GetServicesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanReaderPlugin/GetServices", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return out, nil
+}
+
+func (c *spanReaderPluginClient) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error) {
+	out := new(GetOperationsResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanReaderPlugin/GetOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *SpansResponseChunk) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *spanReaderPluginClient) FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (SpanReaderPlugin_FindTracesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SpanReaderPlugin_serviceDesc.Streams[1], "/jaeger.storage.v1.SpanReaderPlugin/FindTraces", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Spans) > 0 {
-		for _, e := range m.Spans {
-			l = e.Size()
-			n += 1 + l + sovStorage(uint64(l))
-		}
+	x := &spanReaderPluginFindTracesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return n
+	return x, nil
 }
 
-func (m *FindTraceIDsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type SpanReaderPlugin_FindTracesClient interface {
+	Recv() (*SpansResponseChunk, error)
+	grpc.ClientStream
+}
+
+type spanReaderPluginFindTracesClient struct {
+	grpc.ClientStream
+}
+
+func (x *spanReaderPluginFindTracesClient) Recv() (*SpansResponseChunk, error) {
+	m := new(SpansResponseChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Query != nil {
-		l = m.Query.Size()
-		n += 1 + l + sovStorage(uint64(l))
+	return m, nil
+}
+
+func (c *spanReaderPluginClient) FindTraceIDs(ctx context.Context, in *FindTraceIDsRequest, opts ...grpc.CallOption) (*FindTraceIDsResponse, error) {
+	out := new(FindTraceIDsResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SpanReaderPlugin/FindTraceIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return out, nil
+}
+
+// SpanReaderPluginServer is the server API for SpanReaderPlugin service.
+type SpanReaderPluginServer interface {
+	// spanstore/Reader
+	GetTrace(*GetTraceRequest, SpanReaderPlugin_GetTraceServer) error
+	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
+	GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error)
+	FindTraces(*FindTracesRequest, SpanReaderPlugin_FindTracesServer) error
+	FindTraceIDs(context.Context, *FindTraceIDsRequest) (*FindTraceIDsResponse, error)
+}
+
+func RegisterSpanReaderPluginServer(s *grpc.Server, srv SpanReaderPluginServer) {
+	s.RegisterService(&_SpanReaderPlugin_serviceDesc, srv)
+}
+
+func _SpanReaderPlugin_GetTrace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTraceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return n
+	return srv.(SpanReaderPluginServer).GetTrace(m, &spanReaderPluginGetTraceServer{stream})
 }
 
-func (m *FindTraceIDsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+type SpanReaderPlugin_GetTraceServer interface {
+	Send(*SpansResponseChunk) error
+	grpc.ServerStream
+}
+
+type spanReaderPluginGetTraceServer struct {
+	grpc.ServerStream
+}
+
+func (x *spanReaderPluginGetTraceServer) Send(m *SpansResponseChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SpanReaderPlugin_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.TraceIDs) > 0 {
-		for _, e := range m.TraceIDs {
-			l = e.Size()
-			n += 1 + l + sovStorage(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(SpanReaderPluginServer).GetServices(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SpanReaderPlugin/GetServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpanReaderPluginServer).GetServices(ctx, req.(*GetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpanReaderPlugin_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpanReaderPluginServer).GetOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SpanReaderPlugin/GetOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpanReaderPluginServer).GetOperations(ctx, req.(*GetOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpanReaderPlugin_FindTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindTracesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpanReaderPluginServer).FindTraces(m, &spanReaderPluginFindTracesServer{stream})
+}
+
+type SpanReaderPlugin_FindTracesServer interface {
+	Send(*SpansResponseChunk) error
+	grpc.ServerStream
+}
+
+type spanReaderPluginFindTracesServer struct {
+	grpc.ServerStream
+}
+
+func (x *spanReaderPluginFindTracesServer) Send(m *SpansResponseChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SpanReaderPlugin_FindTraceIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindTraceIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpanReaderPluginServer).FindTraceIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SpanReaderPlugin/FindTraceIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpanReaderPluginServer).FindTraceIDs(ctx, req.(*FindTraceIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SpanReaderPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.SpanReaderPlugin",
+	HandlerType: (*SpanReaderPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServices",
+			Handler:    _SpanReaderPlugin_GetServices_Handler,
+		},
+		{
+			MethodName: "GetOperations",
+			Handler:    _SpanReaderPlugin_GetOperations_Handler,
+		},
+		{
+			MethodName: "FindTraceIDs",
+			Handler:    _SpanReaderPlugin_FindTraceIDs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetTrace",
+			Handler:       _SpanReaderPlugin_GetTrace_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindTraces",
+			Handler:       _SpanReaderPlugin_FindTraces_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "storage.proto",
+}
+
+// ArchiveSpanWriterPluginClient is the client API for ArchiveSpanWriterPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ArchiveSpanWriterPluginClient interface {
+	// spanstore/Writer
+	WriteArchiveSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error)
+}
+
+type archiveSpanWriterPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewArchiveSpanWriterPluginClient(cc *grpc.ClientConn) ArchiveSpanWriterPluginClient {
+	return &archiveSpanWriterPluginClient{cc}
+}
+
+func (c *archiveSpanWriterPluginClient) WriteArchiveSpan(ctx context.Context, in *WriteSpanRequest, opts ...grpc.CallOption) (*WriteSpanResponse, error) {
+	out := new(WriteSpanResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.ArchiveSpanWriterPlugin/WriteArchiveSpan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArchiveSpanWriterPluginServer is the server API for ArchiveSpanWriterPlugin service.
+type ArchiveSpanWriterPluginServer interface {
+	// spanstore/Writer
+	WriteArchiveSpan(context.Context, *WriteSpanRequest) (*WriteSpanResponse, error)
+}
+
+func RegisterArchiveSpanWriterPluginServer(s *grpc.Server, srv ArchiveSpanWriterPluginServer) {
+	s.RegisterService(&_ArchiveSpanWriterPlugin_serviceDesc, srv)
+}
+
+func _ArchiveSpanWriterPlugin_WriteArchiveSpan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSpanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArchiveSpanWriterPluginServer).WriteArchiveSpan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.ArchiveSpanWriterPlugin/WriteArchiveSpan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArchiveSpanWriterPluginServer).WriteArchiveSpan(ctx, req.(*WriteSpanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ArchiveSpanWriterPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.ArchiveSpanWriterPlugin",
+	HandlerType: (*ArchiveSpanWriterPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteArchiveSpan",
+			Handler:    _ArchiveSpanWriterPlugin_WriteArchiveSpan_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage.proto",
+}
+
+// ArchiveSpanReaderPluginClient is the client API for ArchiveSpanReaderPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ArchiveSpanReaderPluginClient interface {
+	// spanstore/Reader
+	GetArchiveTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (ArchiveSpanReaderPlugin_GetArchiveTraceClient, error)
+}
+
+type archiveSpanReaderPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewArchiveSpanReaderPluginClient(cc *grpc.ClientConn) ArchiveSpanReaderPluginClient {
+	return &archiveSpanReaderPluginClient{cc}
+}
+
+func (c *archiveSpanReaderPluginClient) GetArchiveTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (ArchiveSpanReaderPlugin_GetArchiveTraceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ArchiveSpanReaderPlugin_serviceDesc.Streams[0], "/jaeger.storage.v1.ArchiveSpanReaderPlugin/GetArchiveTrace", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &archiveSpanReaderPluginGetArchiveTraceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArchiveSpanReaderPlugin_GetArchiveTraceClient interface {
+	Recv() (*SpansResponseChunk, error)
+	grpc.ClientStream
+}
+
+type archiveSpanReaderPluginGetArchiveTraceClient struct {
+	grpc.ClientStream
+}
+
+func (x *archiveSpanReaderPluginGetArchiveTraceClient) Recv() (*SpansResponseChunk, error) {
+	m := new(SpansResponseChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ArchiveSpanReaderPluginServer is the server API for ArchiveSpanReaderPlugin service.
+type ArchiveSpanReaderPluginServer interface {
+	// spanstore/Reader
+	GetArchiveTrace(*GetTraceRequest, ArchiveSpanReaderPlugin_GetArchiveTraceServer) error
+}
+
+func RegisterArchiveSpanReaderPluginServer(s *grpc.Server, srv ArchiveSpanReaderPluginServer) {
+	s.RegisterService(&_ArchiveSpanReaderPlugin_serviceDesc, srv)
+}
+
+func _ArchiveSpanReaderPlugin_GetArchiveTrace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTraceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArchiveSpanReaderPluginServer).GetArchiveTrace(m, &archiveSpanReaderPluginGetArchiveTraceServer{stream})
+}
+
+type ArchiveSpanReaderPlugin_GetArchiveTraceServer interface {
+	Send(*SpansResponseChunk) error
+	grpc.ServerStream
+}
+
+type archiveSpanReaderPluginGetArchiveTraceServer struct {
+	grpc.ServerStream
+}
+
+func (x *archiveSpanReaderPluginGetArchiveTraceServer) Send(m *SpansResponseChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ArchiveSpanReaderPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.ArchiveSpanReaderPlugin",
+	HandlerType: (*ArchiveSpanReaderPluginServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetArchiveTrace",
+			Handler:       _ArchiveSpanReaderPlugin_GetArchiveTrace_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "storage.proto",
+}
+
+// DependenciesReaderPluginClient is the client API for DependenciesReaderPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DependenciesReaderPluginClient interface {
+	// dependencystore/Reader
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
+}
+
+type dependenciesReaderPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDependenciesReaderPluginClient(cc *grpc.ClientConn) DependenciesReaderPluginClient {
+	return &dependenciesReaderPluginClient{cc}
+}
+
+func (c *dependenciesReaderPluginClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
+	out := new(GetDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.DependenciesReaderPlugin/GetDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DependenciesReaderPluginServer is the server API for DependenciesReaderPlugin service.
+type DependenciesReaderPluginServer interface {
+	// dependencystore/Reader
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
+}
+
+func RegisterDependenciesReaderPluginServer(s *grpc.Server, srv DependenciesReaderPluginServer) {
+	s.RegisterService(&_DependenciesReaderPlugin_serviceDesc, srv)
+}
+
+func _DependenciesReaderPlugin_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DependenciesReaderPluginServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.DependenciesReaderPlugin/GetDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DependenciesReaderPluginServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DependenciesReaderPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.DependenciesReaderPlugin",
+	HandlerType: (*DependenciesReaderPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDependencies",
+			Handler:    _DependenciesReaderPlugin_GetDependencies_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage.proto",
+}
+
+// DependenciesWriterPluginClient is the client API for DependenciesWriterPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DependenciesWriterPluginClient interface {
+	// dependencystore/Writer, only called when the plugin advertises the dependencyWriter capability
+	WriteDependencies(ctx context.Context, in *WriteDependenciesRequest, opts ...grpc.CallOption) (*WriteDependenciesResponse, error)
+}
+
+type dependenciesWriterPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDependenciesWriterPluginClient(cc *grpc.ClientConn) DependenciesWriterPluginClient {
+	return &dependenciesWriterPluginClient{cc}
+}
+
+func (c *dependenciesWriterPluginClient) WriteDependencies(ctx context.Context, in *WriteDependenciesRequest, opts ...grpc.CallOption) (*WriteDependenciesResponse, error) {
+	out := new(WriteDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.DependenciesWriterPlugin/WriteDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DependenciesWriterPluginServer is the server API for DependenciesWriterPlugin service.
+type DependenciesWriterPluginServer interface {
+	// dependencystore/Writer, only called when the plugin advertises the dependencyWriter capability
+	WriteDependencies(context.Context, *WriteDependenciesRequest) (*WriteDependenciesResponse, error)
+}
+
+func RegisterDependenciesWriterPluginServer(s *grpc.Server, srv DependenciesWriterPluginServer) {
+	s.RegisterService(&_DependenciesWriterPlugin_serviceDesc, srv)
+}
+
+func _DependenciesWriterPlugin_WriteDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DependenciesWriterPluginServer).WriteDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.DependenciesWriterPlugin/WriteDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DependenciesWriterPluginServer).WriteDependencies(ctx, req.(*WriteDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DependenciesWriterPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.DependenciesWriterPlugin",
+	HandlerType: (*DependenciesWriterPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteDependencies",
+			Handler:    _DependenciesWriterPlugin_WriteDependencies_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage.proto",
+}
+
+// SamplingStorePluginClient is the client API for SamplingStorePlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SamplingStorePluginClient interface {
+	// samplingstore/Store, only called when the plugin advertises the samplingStore capability
+	InsertThroughput(ctx context.Context, in *InsertThroughputRequest, opts ...grpc.CallOption) (*InsertThroughputResponse, error)
+	InsertProbabilitiesAndQPS(ctx context.Context, in *InsertProbabilitiesAndQPSRequest, opts ...grpc.CallOption) (*InsertProbabilitiesAndQPSResponse, error)
+	GetThroughput(ctx context.Context, in *GetThroughputRequest, opts ...grpc.CallOption) (*GetThroughputResponse, error)
+	GetProbabilitiesAndQPS(ctx context.Context, in *GetProbabilitiesAndQPSRequest, opts ...grpc.CallOption) (*GetProbabilitiesAndQPSResponse, error)
+	GetLatestProbabilities(ctx context.Context, in *GetLatestProbabilitiesRequest, opts ...grpc.CallOption) (*GetLatestProbabilitiesResponse, error)
+}
+
+type samplingStorePluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSamplingStorePluginClient(cc *grpc.ClientConn) SamplingStorePluginClient {
+	return &samplingStorePluginClient{cc}
+}
+
+func (c *samplingStorePluginClient) InsertThroughput(ctx context.Context, in *InsertThroughputRequest, opts ...grpc.CallOption) (*InsertThroughputResponse, error) {
+	out := new(InsertThroughputResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SamplingStorePlugin/InsertThroughput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *samplingStorePluginClient) InsertProbabilitiesAndQPS(ctx context.Context, in *InsertProbabilitiesAndQPSRequest, opts ...grpc.CallOption) (*InsertProbabilitiesAndQPSResponse, error) {
+	out := new(InsertProbabilitiesAndQPSResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SamplingStorePlugin/InsertProbabilitiesAndQPS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *samplingStorePluginClient) GetThroughput(ctx context.Context, in *GetThroughputRequest, opts ...grpc.CallOption) (*GetThroughputResponse, error) {
+	out := new(GetThroughputResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SamplingStorePlugin/GetThroughput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *samplingStorePluginClient) GetProbabilitiesAndQPS(ctx context.Context, in *GetProbabilitiesAndQPSRequest, opts ...grpc.CallOption) (*GetProbabilitiesAndQPSResponse, error) {
+	out := new(GetProbabilitiesAndQPSResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SamplingStorePlugin/GetProbabilitiesAndQPS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *samplingStorePluginClient) GetLatestProbabilities(ctx context.Context, in *GetLatestProbabilitiesRequest, opts ...grpc.CallOption) (*GetLatestProbabilitiesResponse, error) {
+	out := new(GetLatestProbabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.SamplingStorePlugin/GetLatestProbabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SamplingStorePluginServer is the server API for SamplingStorePlugin service.
+type SamplingStorePluginServer interface {
+	// samplingstore/Store, only called when the plugin advertises the samplingStore capability
+	InsertThroughput(context.Context, *InsertThroughputRequest) (*InsertThroughputResponse, error)
+	InsertProbabilitiesAndQPS(context.Context, *InsertProbabilitiesAndQPSRequest) (*InsertProbabilitiesAndQPSResponse, error)
+	GetThroughput(context.Context, *GetThroughputRequest) (*GetThroughputResponse, error)
+	GetProbabilitiesAndQPS(context.Context, *GetProbabilitiesAndQPSRequest) (*GetProbabilitiesAndQPSResponse, error)
+	GetLatestProbabilities(context.Context, *GetLatestProbabilitiesRequest) (*GetLatestProbabilitiesResponse, error)
+}
+
+func RegisterSamplingStorePluginServer(s *grpc.Server, srv SamplingStorePluginServer) {
+	s.RegisterService(&_SamplingStorePlugin_serviceDesc, srv)
+}
+
+func _SamplingStorePlugin_InsertThroughput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertThroughputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SamplingStorePluginServer).InsertThroughput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SamplingStorePlugin/InsertThroughput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SamplingStorePluginServer).InsertThroughput(ctx, req.(*InsertThroughputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SamplingStorePlugin_InsertProbabilitiesAndQPS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertProbabilitiesAndQPSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SamplingStorePluginServer).InsertProbabilitiesAndQPS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SamplingStorePlugin/InsertProbabilitiesAndQPS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SamplingStorePluginServer).InsertProbabilitiesAndQPS(ctx, req.(*InsertProbabilitiesAndQPSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SamplingStorePlugin_GetThroughput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThroughputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SamplingStorePluginServer).GetThroughput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SamplingStorePlugin/GetThroughput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SamplingStorePluginServer).GetThroughput(ctx, req.(*GetThroughputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SamplingStorePlugin_GetProbabilitiesAndQPS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProbabilitiesAndQPSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SamplingStorePluginServer).GetProbabilitiesAndQPS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SamplingStorePlugin/GetProbabilitiesAndQPS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SamplingStorePluginServer).GetProbabilitiesAndQPS(ctx, req.(*GetProbabilitiesAndQPSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SamplingStorePlugin_GetLatestProbabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestProbabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SamplingStorePluginServer).GetLatestProbabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.SamplingStorePlugin/GetLatestProbabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SamplingStorePluginServer).GetLatestProbabilities(ctx, req.(*GetLatestProbabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SamplingStorePlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.SamplingStorePlugin",
+	HandlerType: (*SamplingStorePluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InsertThroughput",
+			Handler:    _SamplingStorePlugin_InsertThroughput_Handler,
+		},
+		{
+			MethodName: "InsertProbabilitiesAndQPS",
+			Handler:    _SamplingStorePlugin_InsertProbabilitiesAndQPS_Handler,
+		},
+		{
+			MethodName: "GetThroughput",
+			Handler:    _SamplingStorePlugin_GetThroughput_Handler,
+		},
+		{
+			MethodName: "GetProbabilitiesAndQPS",
+			Handler:    _SamplingStorePlugin_GetProbabilitiesAndQPS_Handler,
+		},
+		{
+			MethodName: "GetLatestProbabilities",
+			Handler:    _SamplingStorePlugin_GetLatestProbabilities_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage.proto",
+}
+
+// PluginCapabilitiesClient is the client API for PluginCapabilities service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PluginCapabilitiesClient interface {
+	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+}
+
+type pluginCapabilitiesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPluginCapabilitiesClient(cc *grpc.ClientConn) PluginCapabilitiesClient {
+	return &pluginCapabilitiesClient{cc}
+}
+
+func (c *pluginCapabilitiesClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/jaeger.storage.v1.PluginCapabilities/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PluginCapabilitiesServer is the server API for PluginCapabilities service.
+type PluginCapabilitiesServer interface {
+	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+}
+
+func RegisterPluginCapabilitiesServer(s *grpc.Server, srv PluginCapabilitiesServer) {
+	s.RegisterService(&_PluginCapabilities_serviceDesc, srv)
+}
+
+func _PluginCapabilities_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginCapabilitiesServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/jaeger.storage.v1.PluginCapabilities/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginCapabilitiesServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PluginCapabilities_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v1.PluginCapabilities",
+	HandlerType: (*PluginCapabilitiesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Capabilities",
+			Handler:    _PluginCapabilities_Capabilities_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage.proto",
+}
+
+func (m *GetDependenciesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDependenciesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)))
+	n1, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)))
+	n2, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.EndTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetDependenciesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDependenciesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Dependencies) > 0 {
+		for _, msg := range m.Dependencies {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteSpanRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteSpanRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Span != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(m.Span.Size()))
+		n3, err := m.Span.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteSpanResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteSpanResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteSpansRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteSpansRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Spans) > 0 {
+		for _, msg := range m.Spans {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteSpansResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteSpansResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteDependenciesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteDependenciesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)))
+	n4, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	if len(m.Dependencies) > 0 {
+		for _, msg := range m.Dependencies {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteDependenciesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteDependenciesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Throughput) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Throughput) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if len(m.Operation) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Operation)))
+		i += copy(dAtA[i:], m.Operation)
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(m.Count))
+	}
+	if len(m.Probabilities) > 0 {
+		for _, s := range m.Probabilities {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *OperationValue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OperationValue) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if len(m.Operation) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Operation)))
+		i += copy(dAtA[i:], m.Operation)
+	}
+	if m.Value != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
+		i += 8
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *OperationProbabilityAndQPS) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OperationProbabilityAndQPS) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if len(m.Operation) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Operation)))
+		i += copy(dAtA[i:], m.Operation)
+	}
+	if m.Probability != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Probability))))
+		i += 8
+	}
+	if m.Qps != 0 {
+		dAtA[i] = 0x21
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Qps))))
+		i += 8
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ServiceOperationData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServiceOperationData) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for _, msg := range m.Operations {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *HostServiceOperationData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HostServiceOperationData) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Data) > 0 {
+		for _, msg := range m.Data {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InsertThroughputRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InsertThroughputRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Throughput) > 0 {
+		for _, msg := range m.Throughput {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InsertThroughputResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InsertThroughputResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InsertProbabilitiesAndQPSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InsertProbabilitiesAndQPSRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
+	}
+	if len(m.Probabilities) > 0 {
+		for _, msg := range m.Probabilities {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Qps) > 0 {
+		for _, msg := range m.Qps {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InsertProbabilitiesAndQPSResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InsertProbabilitiesAndQPSResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetThroughputRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThroughputRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)))
+	n5, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)))
+	n6, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.EndTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetThroughputResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThroughputResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Throughput) > 0 {
+		for _, msg := range m.Throughput {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetProbabilitiesAndQPSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetProbabilitiesAndQPSRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)))
+	n7, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)))
+	n8, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.EndTime, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n8
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetProbabilitiesAndQPSResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetProbabilitiesAndQPSResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hosts) > 0 {
+		for _, msg := range m.Hosts {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetLatestProbabilitiesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLatestProbabilitiesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetLatestProbabilitiesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLatestProbabilitiesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Probabilities) > 0 {
+		for _, msg := range m.Probabilities {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetTraceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetTraceRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(m.TraceID.Size()))
+	n9, err := m.TraceID.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetServicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetServicesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetServicesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, s := range m.Services {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetOperationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Service) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Service)))
+		i += copy(dAtA[i:], m.Service)
+	}
+	if len(m.SpanKind) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.SpanKind)))
+		i += copy(dAtA[i:], m.SpanKind)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Operation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Operation) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.SpanKind) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.SpanKind)))
+		i += copy(dAtA[i:], m.SpanKind)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetOperationsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOperationsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OperationNames) > 0 {
+		for _, s := range m.OperationNames {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Operations) > 0 {
+		for _, msg := range m.Operations {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TraceQueryParameters) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraceQueryParameters) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ServiceName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.ServiceName)))
+		i += copy(dAtA[i:], m.ServiceName)
+	}
+	if len(m.OperationName) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.OperationName)))
+		i += copy(dAtA[i:], m.OperationName)
+	}
+	if len(m.Tags) > 0 {
+		for k, _ := range m.Tags {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Tags[k]
+			mapSize := 1 + len(k) + sovStorage(uint64(len(k))) + 1 + len(v) + sovStorage(uint64(len(v)))
+			i = encodeVarintStorage(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMin)))
+	n10, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTimeMin, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n10
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMax)))
+	n11, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartTimeMax, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n11
+	dAtA[i] = 0x32
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMin)))
+	n12, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.DurationMin, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintStorage(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMax)))
+	n13, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.DurationMax, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n13
+	if m.NumTraces != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(m.NumTraces))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *FindTracesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindTracesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Query != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(m.Query.Size()))
+		n14, err := m.Query.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SpansResponseChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpansResponseChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Spans) > 0 {
+		for _, msg := range m.Spans {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *FindTraceIDsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindTraceIDsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Query != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStorage(dAtA, i, uint64(m.Query.Size()))
+		n15, err := m.Query.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *FindTraceIDsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindTraceIDsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.TraceIDs) > 0 {
+		for _, msg := range m.TraceIDs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStorage(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CapabilitiesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CapabilitiesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CapabilitiesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CapabilitiesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ArchiveSpanReader {
+		dAtA[i] = 0x8
+		i++
+		if m.ArchiveSpanReader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.ArchiveSpanWriter {
+		dAtA[i] = 0x10
+		i++
+		if m.ArchiveSpanWriter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.BatchSpanWriter {
+		dAtA[i] = 0x18
+		i++
+		if m.BatchSpanWriter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DependencyWriter {
+		dAtA[i] = 0x20
+		i++
+		if m.DependencyWriter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.SamplingStore {
+		dAtA[i] = 0x28
+		i++
+		if m.SamplingStore {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintStorage(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *GetDependenciesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovStorage(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)
+	n += 1 + l + sovStorage(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetDependenciesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Dependencies) > 0 {
+		for _, e := range m.Dependencies {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteSpanRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Span != nil {
+		l = m.Span.Size()
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteSpanResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteSpansRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Spans) > 0 {
+		for _, e := range m.Spans {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteSpansResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteDependenciesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovStorage(uint64(l))
+	if len(m.Dependencies) > 0 {
+		for _, e := range m.Dependencies {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteDependenciesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Throughput) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.Operation)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovStorage(uint64(m.Count))
+	}
+	if len(m.Probabilities) > 0 {
+		for _, s := range m.Probabilities {
+			l = len(s)
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OperationValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.Operation)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.Value != 0 {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OperationProbabilityAndQPS) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.Operation)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.Probability != 0 {
+		n += 9
+	}
+	if m.Qps != 0 {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ServiceOperationData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for _, e := range m.Operations {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *HostServiceOperationData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InsertThroughputRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Throughput) > 0 {
+		for _, e := range m.Throughput {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InsertThroughputResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InsertProbabilitiesAndQPSRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if len(m.Probabilities) > 0 {
+		for _, e := range m.Probabilities {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if len(m.Qps) > 0 {
+		for _, e := range m.Qps {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InsertProbabilitiesAndQPSResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetThroughputRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovStorage(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)
+	n += 1 + l + sovStorage(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetThroughputResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Throughput) > 0 {
+		for _, e := range m.Throughput {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetProbabilitiesAndQPSRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovStorage(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.EndTime)
+	n += 1 + l + sovStorage(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetProbabilitiesAndQPSResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hosts) > 0 {
+		for _, e := range m.Hosts {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetLatestProbabilitiesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetLatestProbabilitiesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Probabilities) > 0 {
+		for _, e := range m.Probabilities {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetTraceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.TraceID.Size()
+	n += 1 + l + sovStorage(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetServicesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, s := range m.Services {
+			l = len(s)
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetOperationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Service)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.SpanKind)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Operation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.SpanKind)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetOperationsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.OperationNames) > 0 {
+		for _, s := range m.OperationNames {
+			l = len(s)
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if len(m.Operations) > 0 {
+		for _, e := range m.Operations {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TraceQueryParameters) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ServiceName)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.OperationName)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovStorage(uint64(len(k))) + 1 + len(v) + sovStorage(uint64(len(v)))
+			n += mapEntrySize + 1 + sovStorage(uint64(mapEntrySize))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMin)
+	n += 1 + l + sovStorage(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartTimeMax)
+	n += 1 + l + sovStorage(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMin)
+	n += 1 + l + sovStorage(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.DurationMax)
+	n += 1 + l + sovStorage(uint64(l))
+	if m.NumTraces != 0 {
+		n += 1 + sovStorage(uint64(m.NumTraces))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FindTracesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SpansResponseChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Spans) > 0 {
+		for _, e := range m.Spans {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FindTraceIDsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FindTraceIDsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.TraceIDs) > 0 {
+		for _, e := range m.TraceIDs {
+			l = e.Size()
+			n += 1 + l + sovStorage(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CapabilitiesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CapabilitiesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ArchiveSpanReader {
+		n += 2
+	}
+	if m.ArchiveSpanWriter {
+		n += 2
+	}
+	if m.BatchSpanWriter {
+		n += 2
+	}
+	if m.DependencyWriter {
+		n += 2
+	}
+	if m.SamplingStore {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovStorage(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozStorage(x uint64) (n int) {
+	return sovStorage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetDependenciesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetDependenciesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetDependenciesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EndTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetDependenciesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetDependenciesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetDependenciesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dependencies = append(m.Dependencies, model.DependencyLink{})
+			if err := m.Dependencies[len(m.Dependencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteSpanRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteSpanRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteSpanRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &model.Span{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteSpanResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteSpanResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteSpanResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteSpansRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteSpansRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteSpansRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Spans = append(m.Spans, &model.Span{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteSpansResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteSpansResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteSpansResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteDependenciesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteDependenciesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteDependenciesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dependencies = append(m.Dependencies, model.DependencyLink{})
+			if err := m.Dependencies[len(m.Dependencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteDependenciesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteDependenciesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteDependenciesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Throughput) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Throughput: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Throughput: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Probabilities = append(m.Probabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OperationValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OperationValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OperationValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OperationProbabilityAndQPS) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OperationProbabilityAndQPS: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OperationProbabilityAndQPS: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Service", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Service = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probability", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Probability = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Qps", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Qps = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServiceOperationData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServiceOperationData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServiceOperationData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operations = append(m.Operations, &OperationProbabilityAndQPS{})
+			if err := m.Operations[len(m.Operations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostServiceOperationData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostServiceOperationData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostServiceOperationData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, ServiceOperationData{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InsertThroughputRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InsertThroughputRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InsertThroughputRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Throughput", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Throughput = append(m.Throughput, &Throughput{})
+			if err := m.Throughput[len(m.Throughput)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InsertThroughputResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InsertThroughputResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InsertThroughputResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InsertProbabilitiesAndQPSRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InsertProbabilitiesAndQPSRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InsertProbabilitiesAndQPSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probabilities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Probabilities = append(m.Probabilities, &OperationValue{})
+			if err := m.Probabilities[len(m.Probabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Qps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Qps = append(m.Qps, &OperationValue{})
+			if err := m.Qps[len(m.Qps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InsertProbabilitiesAndQPSResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InsertProbabilitiesAndQPSResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InsertProbabilitiesAndQPSResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetThroughputRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetThroughputRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetThroughputRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.EndTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *CapabilitiesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *CapabilitiesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ArchiveSpanReader {
-		n += 2
-	}
-	if m.ArchiveSpanWriter {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *GetThroughputResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetThroughputResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetThroughputResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Throughput", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Throughput = append(m.Throughput, &Throughput{})
+			if err := m.Throughput[len(m.Throughput)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovStorage(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozStorage(x uint64) (n int) {
-	return sovStorage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *GetDependenciesRequest) Unmarshal(dAtA []byte) error {
+func (m *GetProbabilitiesAndQPSRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2570,10 +6846,10 @@ func (m *GetDependenciesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetDependenciesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetProbabilitiesAndQPSRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetDependenciesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetProbabilitiesAndQPSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2667,7 +6943,7 @@ func (m *GetDependenciesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetDependenciesResponse) Unmarshal(dAtA []byte) error {
+func (m *GetProbabilitiesAndQPSResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2690,15 +6966,15 @@ func (m *GetDependenciesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetDependenciesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetProbabilitiesAndQPSResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetDependenciesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetProbabilitiesAndQPSResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dependencies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hosts", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2725,8 +7001,8 @@ func (m *GetDependenciesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Dependencies = append(m.Dependencies, model.DependencyLink{})
-			if err := m.Dependencies[len(m.Dependencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hosts = append(m.Hosts, HostServiceOperationData{})
+			if err := m.Hosts[len(m.Hosts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2755,7 +7031,7 @@ func (m *GetDependenciesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteSpanRequest) Unmarshal(dAtA []byte) error {
+func (m *GetLatestProbabilitiesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2778,48 +7054,12 @@ func (m *WriteSpanRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteSpanRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetLatestProbabilitiesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteSpanRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetLatestProbabilitiesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowStorage
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthStorage
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthStorage
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Span == nil {
-				m.Span = &model.Span{}
-			}
-			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorage(dAtA[iNdEx:])
@@ -2845,7 +7085,7 @@ func (m *WriteSpanRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteSpanResponse) Unmarshal(dAtA []byte) error {
+func (m *GetLatestProbabilitiesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2868,12 +7108,46 @@ func (m *WriteSpanResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteSpanResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetLatestProbabilitiesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteSpanResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetLatestProbabilitiesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probabilities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Probabilities = append(m.Probabilities, &OperationValue{})
+			if err := m.Probabilities[len(m.Probabilities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorage(dAtA[iNdEx:])
@@ -4358,6 +8632,66 @@ func (m *CapabilitiesResponse) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ArchiveSpanWriter = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchSpanWriter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BatchSpanWriter = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependencyWriter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DependencyWriter = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SamplingStore", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SamplingStore = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorage(dAtA[iNdEx:])
@@ -21,6 +21,18 @@ run_integration_test "5.6.16"
 run_integration_test "6.8.2"
 run_integration_test "7.3.0"
 
+run_opensearch_integration_test() {
+  OS_VERSION=$1
+  docker pull opensearchproject/opensearch:${OS_VERSION}
+  CID=$(docker run --rm -d -p 9200:9200 -e "discovery.type=single-node" -e "plugins.security.disabled=true" opensearchproject/opensearch:${OS_VERSION})
+  if [ "$ES_OTEL_INTEGRATION_TEST" != true ]; then
+    STORAGE=opensearch make storage-integration-test
+  fi
+  docker kill $CID
+}
+
+run_opensearch_integration_test "1.3.0"
+
 if [ "$ES_OTEL_INTEGRATION_TEST" == true ]; then
   echo "OpenTelemetry ES exporter test finished, skipping ES script tests and token propagation"
   exit 0
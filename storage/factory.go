@@ -17,11 +17,13 @@ package storage
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/uber/jaeger-lib/metrics"
 	"go.uber.org/zap"
 
 	"github.com/jaegertracing/jaeger/storage/dependencystore"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
 	"github.com/jaegertracing/jaeger/storage/spanstore"
 )
 
@@ -62,3 +64,30 @@ type ArchiveFactory interface {
 	// CreateArchiveSpanWriter creates a spanstore.Writer.
 	CreateArchiveSpanWriter() (spanstore.Writer, error)
 }
+
+var (
+	// ErrDependencyWriterNotSupported can be returned by the DependencyWriterFactory when the backend cannot store dependencies.
+	ErrDependencyWriterNotSupported = errors.New("dependency writer not supported")
+
+	// ErrSamplingStoreNotSupported can be returned by the SamplingStoreFactory when the backend cannot store sampling data.
+	ErrSamplingStoreNotSupported = errors.New("sampling store not supported")
+)
+
+// DependencyWriterFactory is an additional interface that can be implemented by a factory to store dependency links.
+type DependencyWriterFactory interface {
+	// CreateDependencyWriter creates a dependencystore.Writer.
+	CreateDependencyWriter() (dependencystore.Writer, error)
+}
+
+// SamplingStoreFactory is an additional interface that can be implemented by a factory to back adaptive sampling.
+type SamplingStoreFactory interface {
+	// CreateSamplingStore creates a samplingstore.Store.
+	CreateSamplingStore() (samplingstore.Store, error)
+}
+
+// AdminFactory is an additional interface that can be implemented by a factory to expose
+// maintenance endpoints, such as backups, on the admin server.
+type AdminFactory interface {
+	// RegisterAdminHandlers mounts the admin endpoints of the factory using the given function.
+	RegisterAdminHandlers(handle func(path string, handler http.Handler))
+}
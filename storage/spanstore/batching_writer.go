@@ -0,0 +1,142 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+var (
+	_ Writer      = (*BatchingWriter)(nil)
+	_ BatchWriter = (*BatchingWriter)(nil)
+)
+
+// BatchingWriter collects the spans written concurrently, e.g. by the collector queue workers,
+// and writes them with a single call to the underlying BatchWriter. A span is sent right away when no
+// batch is being written, otherwise it joins the next batch, which is sent once the previous one is
+// written, batchSize spans are collected or flushInterval has passed since its first span, whichever
+// comes first.
+// WriteSpan blocks until the batch is written and returns its error. When the batch fails with a permanent
+// error, its spans are written again one at a time, so that a single invalid span does not fail the others.
+type BatchingWriter struct {
+	writer        BatchWriter
+	batchSize     int
+	flushInterval time.Duration
+
+	mu       sync.Mutex
+	batch    *spanBatch
+	inFlight int
+}
+
+type spanBatch struct {
+	spans []*model.Span
+	timer *time.Timer
+	done  chan struct{}
+	errs  []error // the error of each span, set once done is closed
+}
+
+// NewBatchingWriter creates a BatchingWriter writing batches of up to batchSize spans to writer
+func NewBatchingWriter(writer BatchWriter, batchSize int, flushInterval time.Duration) *BatchingWriter {
+	return &BatchingWriter{
+		writer:        writer,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+}
+
+// WriteSpan adds the span to the current batch and waits for the batch to be written.
+func (w *BatchingWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	w.mu.Lock()
+	batch := w.batch
+	if batch == nil {
+		batch = &spanBatch{done: make(chan struct{})}
+		batch.timer = time.AfterFunc(w.flushInterval, func() { w.flush(batch) })
+		w.batch = batch
+	}
+	index := len(batch.spans)
+	batch.spans = append(batch.spans, span)
+	ready := w.inFlight == 0 || len(batch.spans) >= w.batchSize
+	if ready {
+		w.take(batch)
+	}
+	w.mu.Unlock()
+
+	if ready {
+		w.write(batch)
+	}
+	select {
+	case <-batch.done:
+		return batch.errs[index]
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WriteSpans writes the spans, which are already batched, directly to the underlying writer.
+func (w *BatchingWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	return w.writer.WriteSpans(ctx, spans)
+}
+
+// flush writes the batch when its flush interval expires, unless it has already been written.
+func (w *BatchingWriter) flush(batch *spanBatch) {
+	w.mu.Lock()
+	if w.batch != batch {
+		w.mu.Unlock()
+		return
+	}
+	w.take(batch)
+	w.mu.Unlock()
+	w.write(batch)
+}
+
+// take removes the current batch so that new spans start the next one. Must be called under lock.
+func (w *BatchingWriter) take(batch *spanBatch) {
+	batch.timer.Stop()
+	w.batch = nil
+	w.inFlight++
+}
+
+func (w *BatchingWriter) write(batch *spanBatch) {
+	// the batch is shared by several callers, none of their contexts can cancel it
+	batch.errs = make([]error, len(batch.spans))
+	err := w.writer.WriteSpans(context.Background(), batch.spans)
+	if err != nil && !IsRetryable(err) && len(batch.spans) > 1 {
+		// the batch may be rejected because of some of its spans only
+		for i, span := range batch.spans {
+			batch.errs[i] = w.writer.WriteSpans(context.Background(), []*model.Span{span})
+		}
+	} else {
+		for i := range batch.errs {
+			batch.errs[i] = err
+		}
+	}
+	close(batch.done)
+
+	w.mu.Lock()
+	w.inFlight--
+	next := w.batch
+	if w.inFlight > 0 || next == nil {
+		w.mu.Unlock()
+		return
+	}
+	// the spans which arrived during the write don't need to wait any longer
+	w.take(next)
+	w.mu.Unlock()
+	go w.write(next)
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+type fakeBatchWriter struct {
+	mu      sync.Mutex
+	batches [][]*model.Span
+	err     error
+	// when set, the first write signals started and blocks until release is closed
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingBatchWriter() *fakeBatchWriter {
+	return &fakeBatchWriter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (w *fakeBatchWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	w.mu.Lock()
+	w.batches = append(w.batches, spans)
+	first := len(w.batches) == 1
+	w.mu.Unlock()
+	if first && w.started != nil {
+		close(w.started)
+		<-w.release
+	}
+	return w.err
+}
+
+func (w *fakeBatchWriter) batchSizes() []int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var sizes []int
+	for _, batch := range w.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func writeConcurrently(ctx context.Context, w *BatchingWriter, n int) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = w.WriteSpan(ctx, &model.Span{SpanID: model.NewSpanID(uint64(i))})
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// startBlockedWrite makes the writer busy with a write which completes once fake.release is closed.
+func startBlockedWrite(t *testing.T, w *BatchingWriter, fake *fakeBatchWriter) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- w.WriteSpan(context.Background(), &model.Span{})
+	}()
+	select {
+	case <-fake.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("write did not start")
+	}
+	return errCh
+}
+
+func (w *BatchingWriter) pendingSpans() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.batch == nil {
+		return 0
+	}
+	return len(w.batch.spans)
+}
+
+func TestBatchingSpanWriterWritesImmediatelyWhenIdle(t *testing.T) {
+	fake := &fakeBatchWriter{}
+	// the flush interval never expires, spans must not wait for it
+	w := NewBatchingWriter(fake, 100, time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, w.WriteSpan(context.Background(), &model.Span{}))
+	}
+	assert.Equal(t, []int{1, 1, 1}, fake.batchSizes())
+}
+
+func TestBatchingSpanWriterFullBatches(t *testing.T) {
+	fake := newBlockingBatchWriter()
+	w := NewBatchingWriter(fake, 5, time.Hour)
+	errCh := startBlockedWrite(t, w, fake)
+	for _, err := range writeConcurrently(context.Background(), w, 10) {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []int{1, 5, 5}, fake.batchSizes())
+	close(fake.release)
+	assert.NoError(t, <-errCh)
+}
+
+func TestBatchingSpanWriterFlushInterval(t *testing.T) {
+	fake := newBlockingBatchWriter()
+	fake.err = errors.New("made-up error")
+	w := NewBatchingWriter(fake, 100, time.Millisecond)
+	errCh := startBlockedWrite(t, w, fake)
+	for _, err := range writeConcurrently(context.Background(), w, 3) {
+		assert.EqualError(t, err, "made-up error")
+	}
+	total := 0
+	for _, size := range fake.batchSizes()[1:] {
+		total += size
+	}
+	assert.Equal(t, 3, total)
+	close(fake.release)
+	assert.EqualError(t, <-errCh, "made-up error")
+}
+
+func TestBatchingSpanWriterFlushAfterWrite(t *testing.T) {
+	fake := newBlockingBatchWriter()
+	w := NewBatchingWriter(fake, 100, time.Hour)
+	errCh := startBlockedWrite(t, w, fake)
+	done := make(chan []error)
+	go func() {
+		done <- writeConcurrently(context.Background(), w, 2)
+	}()
+	for w.pendingSpans() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(fake.release)
+	assert.NoError(t, <-errCh)
+	for _, err := range <-done {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []int{1, 2}, fake.batchSizes())
+}
+
+func TestBatchingSpanWriterContextCancelled(t *testing.T) {
+	fake := newBlockingBatchWriter()
+	w := NewBatchingWriter(fake, 100, time.Hour)
+	errCh := startBlockedWrite(t, w, fake)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, w.WriteSpan(ctx, &model.Span{}))
+	assert.Equal(t, []int{1}, fake.batchSizes())
+	close(fake.release)
+	assert.NoError(t, <-errCh)
+}
+
+func TestBatchingWriterWriteSpans(t *testing.T) {
+	fake := &fakeBatchWriter{}
+	w := NewBatchingWriter(fake, 2, time.Hour)
+	assert.NoError(t, w.WriteSpans(context.Background(), []*model.Span{{}, {}, {}}))
+	assert.Equal(t, []int{3}, fake.batchSizes(), "the batches are written as is")
+}
+
+// invalidSpanWriter rejects permanently the batches holding a span without operation name
+type invalidSpanWriter struct {
+	mu      sync.Mutex
+	batches int
+	written []*model.Span
+}
+
+func (w *invalidSpanWriter) WriteSpans(ctx context.Context, spans []*model.Span) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.batches++
+	for _, span := range spans {
+		if span.OperationName == "" {
+			return PermanentError{Err: errors.New("invalid span")}
+		}
+	}
+	w.written = append(w.written, spans...)
+	return nil
+}
+
+func TestBatchingSpanWriterRetriesSpansOfRejectedBatch(t *testing.T) {
+	fake := newBlockingBatchWriter()
+	writer := &invalidSpanWriter{}
+	w := NewBatchingWriter(fake, 3, time.Hour)
+	errCh := startBlockedWrite(t, w, fake)
+	// the spans are written by the invalid span writer once the blocked write completes
+	w.writer = writer
+
+	spans := []*model.Span{{OperationName: "a"}, {}, {OperationName: "c"}}
+	errs := make([]error, len(spans))
+	var wg sync.WaitGroup
+	for i, span := range spans {
+		wg.Add(1)
+		go func(i int, span *model.Span) {
+			defer wg.Done()
+			errs[i] = w.WriteSpan(context.Background(), span)
+		}(i, span)
+	}
+	// the batch is written once full
+	wg.Wait()
+	close(fake.release)
+	assert.NoError(t, <-errCh)
+
+	assert.NoError(t, errs[0])
+	assert.EqualError(t, errs[1], "invalid span")
+	assert.False(t, IsRetryable(errs[1]))
+	assert.NoError(t, errs[2])
+	assert.ElementsMatch(t, []*model.Span{spans[0], spans[2]}, writer.written)
+	assert.Equal(t, 4, writer.batches, "the rejected batch is written again one span at a time")
+}
+
+type spanCountingWriter struct {
+	spans int
+	err   error
+}
+
+func (w *spanCountingWriter) WriteSpan(ctx context.Context, span *model.Span) error {
+	w.spans++
+	return w.err
+}
+
+func TestWriteSpans(t *testing.T) {
+	spans := []*model.Span{{}, {}}
+	fake := &fakeBatchWriter{}
+	assert.NoError(t, WriteSpans(context.Background(), NewBatchingWriter(fake, 10, time.Hour), spans))
+	assert.Equal(t, []int{2}, fake.batchSizes())
+
+	writer := &spanCountingWriter{}
+	assert.NoError(t, WriteSpans(context.Background(), writer, spans))
+	assert.Equal(t, 2, writer.spans, "the spans are written one at a time")
+
+	writer = &spanCountingWriter{err: errors.New("made-up error")}
+	assert.EqualError(t, WriteSpans(context.Background(), writer, spans), "made-up error")
+	assert.Equal(t, 1, writer.spans)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2020 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanstore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/jaegertracing/jaeger/storage/spanstore"
+)
+
+func TestIsRetryable(t *testing.T) {
+	invalid := errors.New("invalid span")
+	permanent := fmt.Errorf("failed to write span: %w", PermanentError{Err: invalid})
+	assert.Equal(t, "failed to write span: invalid span", permanent.Error())
+	assert.True(t, errors.Is(permanent, invalid))
+
+	assert.False(t, IsRetryable(permanent))
+	assert.False(t, IsRetryable(fmt.Errorf("write aborted: %w", context.Canceled)))
+	assert.False(t, IsRetryable(context.DeadlineExceeded))
+	assert.True(t, IsRetryable(errors.New("connection refused")))
+}
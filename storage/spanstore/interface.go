@@ -26,6 +26,10 @@ import (
 var (
 	// ErrTraceNotFound is returned by Reader's GetTrace if no data is found for given trace ID.
 	ErrTraceNotFound = errors.New("trace not found")
+
+	// ErrTagKeysAndValuesNotSupported is returned by Reader's FindTraces and FindTraceIDs
+	// if the query sets TagKeys or TagValues and the backend cannot search by them.
+	ErrTagKeysAndValuesNotSupported = errors.New("searching by tag keys or tag value lists is not supported by this storage backend")
 )
 
 // Writer writes spans to storage.
@@ -33,6 +37,25 @@ type Writer interface {
 	WriteSpan(ctx context.Context, span *model.Span) error
 }
 
+// BatchWriter is optionally implemented by the Writers which write several spans at once
+// more efficiently than one at a time, e.g. with a single request or transaction.
+type BatchWriter interface {
+	WriteSpans(ctx context.Context, spans []*model.Span) error
+}
+
+// WriteSpans writes the spans with a single call if writer is a BatchWriter, one at a time otherwise.
+func WriteSpans(ctx context.Context, writer Writer, spans []*model.Span) error {
+	if batchWriter, ok := writer.(BatchWriter); ok {
+		return batchWriter.WriteSpans(ctx, spans)
+	}
+	for _, span := range spans {
+		if err := writer.WriteSpan(ctx, span); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Reader finds and loads traces and other data from storage.
 type Reader interface {
 	// GetTrace retrieves the trace with a given id.
@@ -73,6 +96,16 @@ type TraceQueryParameters struct {
 	DurationMin   time.Duration
 	DurationMax   time.Duration
 	NumTraces     int
+
+	// TagKeys lists the tag keys which must be present, regardless of their value.
+	// Currently only supported by the Badger backend, which only finds the spans
+	// written after its tag key index was introduced. Other backends return
+	// ErrTagKeysAndValuesNotSupported.
+	TagKeys []string
+	// TagValues maps tag keys to lists of values, any of which must match.
+	// Currently only supported by the Badger backend, other backends return
+	// ErrTagKeysAndValuesNotSupported.
+	TagValues map[string][]string
 }
 
 // OperationQueryParameters contains parameters of query operations, empty spanKind means get operations for all kinds of span.